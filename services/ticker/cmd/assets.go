@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/spf13/cobra"
+	ticker "github.com/stellar/go/services/ticker/internal"
+	"github.com/stellar/go/services/ticker/internal/scraper"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+var ExplainLive bool
+var TOMLHistoryLimit int
+
+func init() {
+	rootCmd.AddCommand(cmdAssets)
+	cmdAssets.AddCommand(cmdAssetsExplain)
+	cmdAssets.AddCommand(cmdAssetsTOMLHistory)
+
+	cmdAssetsExplain.Flags().BoolVar(
+		&ExplainLive,
+		"live",
+		false,
+		"Evaluate the asset with its current stats on Horizon and the current listing policy, instead of showing the last stored decision",
+	)
+
+	cmdAssetsTOMLHistory.Flags().IntVar(
+		&TOMLHistoryLimit,
+		"limit",
+		10,
+		"Number of versions of the TOML file to show, newest first",
+	)
+}
+
+var cmdAssets = &cobra.Command{
+	Use:   "assets [command]",
+	Short: "Inspects the asset index",
+}
+
+var cmdAssetsExplain = &cobra.Command{
+	Use:   "explain CODE:ISSUER",
+	Short: "Explains why an asset is listed or not, according to the asset listing policy",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		code, issuer, err := scraper.ParseAssetListEntry(args[0])
+		if err == nil && code == "" {
+			err = fmt.Errorf("%q has no asset code", args[0])
+		}
+		if err != nil {
+			Logger.Fatal("could not parse asset:", err)
+		}
+
+		if ExplainLive {
+			evaluation, err := ticker.ExplainAsset(Client, Logger, code, issuer)
+			if err != nil {
+				Logger.Fatal("could not evaluate asset:", err)
+			}
+			printAssetEvaluation(evaluation, nil)
+			return
+		}
+
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		ctx := context.Background()
+		evaluation, err := session.GetAssetEvaluation(ctx, code, issuer)
+		if session.NoRows(err) {
+			Logger.Fatalf("%s:%s was never evaluated (run the asset ingestion or use --live)", code, issuer)
+		} else if err != nil {
+			Logger.Fatal("could not retrieve asset evaluation:", err)
+		}
+
+		var asset *tickerdb.Asset
+		if dbAsset, err := session.GetAsset(ctx, code, issuer); err == nil {
+			asset = &dbAsset
+		} else if !session.NoRows(err) {
+			Logger.Fatal("could not retrieve asset:", err)
+		}
+		printAssetEvaluation(evaluation, asset)
+	},
+}
+
+var cmdAssetsTOMLHistory = &cobra.Command{
+	Use:   "toml-history URL",
+	Short: "Shows the cache status and the recorded versions of a TOML file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		ctx := context.Background()
+		entry, err := session.GetTOMLCacheEntry(ctx, args[0])
+		if session.NoRows(err) {
+			Logger.Fatalf("%s was never fetched (run the asset ingestion first)", args[0])
+		} else if err != nil {
+			Logger.Fatal("could not retrieve cached TOML file:", err)
+		}
+
+		versions, err := session.RetrieveTOMLHistory(ctx, args[0], TOMLHistoryLimit)
+		if err != nil {
+			Logger.Fatal("could not retrieve TOML history:", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "TOML file:\t%s\n", entry.URL)
+		fmt.Fprintf(w, "Status code:\t%d\n", entry.StatusCode)
+		if entry.LastError != "" {
+			fmt.Fprintf(w, "Last error:\t%s\n", entry.LastError)
+		}
+		fmt.Fprintf(w, "ETag:\t%s\n", entry.ETag)
+		fmt.Fprintf(w, "Last-Modified:\t%s\n", entry.LastModified)
+		fmt.Fprintf(w, "Fetched at:\t%s\n", entry.FetchedAt.Format(time.RFC3339))
+		fmt.Fprintf(w, "Checked at:\t%s\n", entry.CheckedAt.Format(time.RFC3339))
+		fmt.Fprintf(w, "Expires at:\t%s\n", entry.ExpiresAt.Format(time.RFC3339))
+		for _, v := range versions {
+			fmt.Fprintf(w, "Version:\t%s (%d bytes, ETag %q)\n", v.FetchedAt.Format(time.RFC3339), len(v.Body), v.ETag)
+		}
+		w.Flush()
+	},
+}
+
+// printAssetEvaluation prints a decision of the asset listing policy and the
+// SEP-1 findings of the asset's TOML file, along with the validation status of
+// the asset if it's indexed.
+func printAssetEvaluation(evaluation tickerdb.AssetEvaluation, asset *tickerdb.Asset) {
+	decision := "not listed"
+	if evaluation.Listed {
+		decision = "listed"
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Asset:\t%s:%s\n", evaluation.Code, evaluation.IssuerAccount)
+	fmt.Fprintf(w, "Decision:\t%s\n", decision)
+	fmt.Fprintf(w, "Rule:\t%s\n", evaluation.Rule)
+	fmt.Fprintf(w, "Reason:\t%s\n", evaluation.Reason)
+	fmt.Fprintf(w, "Holders:\t%d\n", evaluation.NumAccounts)
+	fmt.Fprintf(w, "Supply:\t%s\n", evaluation.Amount)
+	fmt.Fprintf(w, "TOML file:\t%s\n", evaluation.TOMLURL)
+	fmt.Fprintf(w, "Home domain:\t%s\n", evaluation.HomeDomain)
+	fmt.Fprintf(w, "Evaluated at:\t%s\n", evaluation.EvaluatedAt.Format(time.RFC3339))
+
+	var findings []scraper.TOMLFinding
+	if err := json.Unmarshal([]byte(evaluation.TOMLFindings), &findings); err != nil {
+		Logger.Error("could not decode SEP-1 findings:", err)
+	}
+	for _, f := range findings {
+		fmt.Fprintf(w, "SEP-1 %s:\t%s (%s)\n", f.Severity, f.Message, f.Check)
+	}
+	if asset != nil {
+		fmt.Fprintf(w, "Indexed:\tyes (valid: %t)\n", asset.IsValid)
+		if asset.ValidationError != "" {
+			fmt.Fprintf(w, "Validation error:\t%s\n", asset.ValidationError)
+		}
+	}
+	w.Flush()
+}
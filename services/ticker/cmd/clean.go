@@ -10,17 +10,35 @@ import (
 )
 
 var DaysToKeep int
+var OrderbookDaysToKeep int
+var AssetSnapshotDaysToKeep int
 
 func init() {
 	rootCmd.AddCommand(cmdClean)
 	cmdClean.AddCommand(cmdCleanTrades)
+	cmdClean.AddCommand(cmdCleanOrderbooks)
+	cmdClean.AddCommand(cmdCleanAssetSnapshots)
 
 	cmdCleanTrades.Flags().IntVarP(
 		&DaysToKeep,
 		"keep-days",
 		"k",
 		7,
-		"Trade entries older than keep-days will be deleted",
+		"Trade (and pending trade) entries older than keep-days will be deleted",
+	)
+	cmdCleanOrderbooks.Flags().IntVarP(
+		&OrderbookDaysToKeep,
+		"keep-days",
+		"k",
+		30,
+		"Orderbook snapshots older than keep-days will be deleted",
+	)
+	cmdCleanAssetSnapshots.Flags().IntVarP(
+		&AssetSnapshotDaysToKeep,
+		"keep-days",
+		"k",
+		365,
+		"Asset snapshots older than keep-days will be deleted, except for the latest one of each asset",
 	)
 }
 
@@ -50,5 +68,56 @@ var cmdCleanTrades = &cobra.Command{
 		if err != nil {
 			Logger.Fatal("could not delete trade entries:", err)
 		}
+
+		err = session.DeleteOldPendingTrades(context.Background(), minDate)
+		if err != nil {
+			Logger.Fatal("could not delete pending trade entries:", err)
+		}
+	},
+}
+
+var cmdCleanOrderbooks = &cobra.Command{
+	Use:   "orderbooks",
+	Short: "Cleans up old orderbook snapshots from the database",
+	Run: func(cmd *cobra.Command, args []string) {
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		minDate := time.Now().AddDate(0, 0, -OrderbookDaysToKeep)
+		Logger.Infof("Deleting orderbook snapshots older than %d days", OrderbookDaysToKeep)
+		err = session.DeleteOldOrderbookSnapshots(context.Background(), minDate)
+		if err != nil {
+			Logger.Fatal("could not delete orderbook snapshots:", err)
+		}
+	},
+}
+
+var cmdCleanAssetSnapshots = &cobra.Command{
+	Use:   "asset-snapshots",
+	Short: "Cleans up old asset snapshots from the database",
+	Run: func(cmd *cobra.Command, args []string) {
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		minDate := time.Now().AddDate(0, 0, -AssetSnapshotDaysToKeep)
+		Logger.Infof("Deleting asset snapshots older than %d days", AssetSnapshotDaysToKeep)
+		err = session.DeleteOldAssetSnapshots(context.Background(), minDate)
+		if err != nil {
+			Logger.Fatal("could not delete asset snapshots:", err)
+		}
 	},
 }
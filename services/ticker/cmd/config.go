@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/network"
+	ticker "github.com/stellar/go/services/ticker/internal"
+	"github.com/stellar/go/services/ticker/internal/config"
+)
+
+// configWatcher holds the configuration read from the file given by
+// --config (or the default configuration), which the daemon reloads.
+var configWatcher *config.Watcher
+
+// loadConfig reads the configuration file, exiting if it's invalid.
+func loadConfig() {
+	w, err := config.NewWatcher(ConfigPath)
+	if err != nil {
+		Logger.Fatal("could not read config file: ", err)
+	}
+	configWatcher = w
+	ticker.SetScraperSettings(w.Config().ScraperSettings())
+}
+
+// currentConfig returns the current configuration.
+func currentConfig() *config.Config {
+	return configWatcher.Config()
+}
+
+// setting returns the value of the flag <name> of cmd if it was set on the
+// command line, or the value from the configuration otherwise.
+func setting[T any](cmd *cobra.Command, name string, flagValue, configValue T) T {
+	if cmd.Flags().Changed(name) {
+		return flagValue
+	}
+	return configValue
+}
+
+// trackedIssuers returns the issuers listed in the file given by --file, or
+// otherwise the issuers of the configuration for which include returns true.
+func trackedIssuers(include func(config.Issuer) bool) ([]string, error) {
+	if filePath != "" {
+		issuers, err := getIssuers(filePath)
+		if err != nil {
+			return nil, err
+		}
+		return removeDuplicate(issuers), nil
+	}
+	return currentConfig().IssuerAccounts(include), nil
+}
+
+// requireTrackedIssuers is like trackedIssuers, but exits if there is no
+// issuer to track.
+func requireTrackedIssuers(include func(config.Issuer) bool) []string {
+	issuers, err := trackedIssuers(include)
+	if err != nil {
+		Logger.Fatal("could not get issuers from file:", err)
+	}
+	if len(issuers) == 0 {
+		Logger.Fatal("no issuers to track: list them in the config file (--config) or in a file (--file)")
+	}
+	return issuers
+}
+
+// allIssuers includes every issuer of the configuration.
+func allIssuers(config.Issuer) bool {
+	return true
+}
+
+// networkPassphrase returns the passphrase of the configured network.
+func networkPassphrase() string {
+	if passphrase := currentConfig().Horizon.NetworkPassphrase; passphrase != "" {
+		return passphrase
+	}
+	if UseTestNet {
+		return network.TestNetworkPassphrase
+	}
+	return network.PublicNetworkPassphrase
+}
+
+// requireMinTradeSize returns the minimum trade size of the --min-trade-size
+// flag, or of the configuration file, exiting if it is negative.
+func requireMinTradeSize(cmd *cobra.Command) float64 {
+	minTradeSize := setting(cmd, "min-trade-size", MinTradeSize, currentConfig().Markets.MinTradeSize)
+	if minTradeSize < 0 {
+		Logger.Fatal("min-trade-size must not be negative")
+	}
+	return minTradeSize
+}
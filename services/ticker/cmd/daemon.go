@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/spf13/cobra"
+	ticker "github.com/stellar/go/services/ticker/internal"
+	"github.com/stellar/go/services/ticker/internal/anomaly"
+	"github.com/stellar/go/services/ticker/internal/config"
+	"github.com/stellar/go/services/ticker/internal/metrics"
+	"github.com/stellar/go/services/ticker/internal/pricing"
+	"github.com/stellar/go/services/ticker/internal/scheduler"
+	"github.com/stellar/go/services/ticker/internal/scraper"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
+)
+
+var DaemonAddress string
+var DaemonServeGraphQL bool
+var DaemonStreamTrades bool
+var DaemonAssetsOutFile string
+var DaemonMarketsOutFile string
+var DaemonCandlesOutFile string
+var DaemonMarketWindows []string
+var DaemonAggregateByCode bool
+var DaemonMinTradeSize float64
+var DaemonCandleResolution string
+var DaemonSlippageAmount float64
+var DaemonReferenceAssets []string
+var DaemonTradesDaysToKeep int
+var DaemonOrderbookDaysToKeep int
+var DaemonAssetSnapshotDaysToKeep int
+var AssetsInterval time.Duration
+var OrderbooksInterval time.Duration
+var LiquidityPoolsInterval time.Duration
+var PricesInterval time.Duration
+var TradeFlagsInterval time.Duration
+var TradesInterval time.Duration
+var AssetDataInterval time.Duration
+var MarketDataInterval time.Duration
+var CandleDataInterval time.Duration
+var CleanInterval time.Duration
+
+// minTradesRetention is the shortest period of trades kept by the cleanups,
+// which the 7-day market stats rely on.
+const minTradesRetention = 7 * 24 * time.Hour
+
+// streamRestartInterval is how often the trade stream is restarted after it
+// stops (e.g. when Horizon closes the connection).
+const streamRestartInterval = 10 * time.Second
+
+func init() {
+	rootCmd.AddCommand(cmdDaemon)
+
+	cmdDaemon.Flags().StringVar(
+		&DaemonAddress,
+		"address",
+		"0.0.0.0:8080",
+		"Address to serve the health endpoint (/health), the metrics (/metrics), the GraphQL interface and the REST API on",
+	)
+
+	cmdDaemon.Flags().BoolVar(
+		&DaemonServeGraphQL,
+		"graphql",
+		true,
+		"Serve the GraphQL interface (/graphql, /graphql/ws and /graphiql) and the REST API (/markets, /assets, /issuers, /coingecko and /cmc)",
+	)
+
+	cmdDaemon.Flags().BoolVar(
+		&DaemonStreamTrades,
+		"stream",
+		true,
+		"Continuously stream new trades from the Horizon Stream API",
+	)
+
+	cmdDaemon.Flags().StringVarP(
+		&filePath,
+		"file",
+		"f",
+		"",
+		"Filter assets and orderbooks by issuers defined in a file, one per line, instead of the issuers of the config file (all of them are refreshed if there is none)",
+	)
+
+	cmdDaemon.Flags().IntVar(
+		&BackfillHours,
+		"num-hours",
+		1*24,
+		"Number of past hours to backfill trade data",
+	)
+
+	cmdDaemon.Flags().StringVar(
+		&DaemonAssetsOutFile,
+		"assets-out-file",
+		"assets.json",
+		"Set the name of the asset data output file",
+	)
+
+	cmdDaemon.Flags().StringVar(
+		&DaemonMarketsOutFile,
+		"markets-out-file",
+		"markets.json",
+		"Set the name of the market data output file",
+	)
+
+	cmdDaemon.Flags().StringSliceVar(
+		&DaemonMarketWindows,
+		"windows",
+		[]string{},
+		"Comma-separated list of additional trailing windows to generate market stats for (e.g. 1h,24h,7d,30d)",
+	)
+
+	cmdDaemon.Flags().BoolVar(
+		&DaemonAggregateByCode,
+		"aggregate-by-code",
+		false,
+		"Merge the markets whose assets share the same codes into a single trade pair in the market data",
+	)
+
+	cmdDaemon.Flags().Float64Var(
+		&DaemonMinTradeSize,
+		"min-trade-size",
+		0,
+		"Minimum value in USD of the trades included in the VWAP, TWAP and median prices of the market data",
+	)
+
+	cmdDaemon.Flags().StringVar(
+		&DaemonCandlesOutFile,
+		"candles-out-file",
+		"candles.json",
+		"Set the name of the candle data output file",
+	)
+
+	cmdDaemon.Flags().StringVar(
+		&DaemonCandleResolution,
+		"candle-resolution",
+		"1h",
+		"Candle resolution of the candle data (1m, 5m, 15m, 1h, 4h or 1d)",
+	)
+
+	cmdDaemon.Flags().Float64Var(
+		&DaemonSlippageAmount,
+		"slippage-amount",
+		scraper.DefaultSlippageAmount,
+		"Amount of the base asset used to calculate the buy / sell slippage of each orderbook",
+	)
+
+	cmdDaemon.Flags().StringSliceVar(
+		&DaemonReferenceAssets,
+		"reference-assets",
+		pricing.DefaultReferenceAssets,
+		"Comma-separated list of fiat reference assets to derive prices from, as <currency>=<code>:<issuer> (e.g. USD=USDC:G...)",
+	)
+
+	cmdDaemon.Flags().IntVar(
+		&DaemonTradesDaysToKeep,
+		"keep-trades-days",
+		7,
+		"Trade (and pending trade) entries older than keep-trades-days are deleted by the cleanups, unless they're within the longest market window (0 keeps them all)",
+	)
+
+	cmdDaemon.Flags().IntVar(
+		&DaemonOrderbookDaysToKeep,
+		"keep-orderbook-days",
+		30,
+		"Orderbook snapshots older than keep-orderbook-days are deleted by the cleanups (0 keeps them all)",
+	)
+
+	cmdDaemon.Flags().IntVar(
+		&DaemonAssetSnapshotDaysToKeep,
+		"keep-asset-snapshot-days",
+		365,
+		"Asset snapshots older than keep-asset-snapshot-days are deleted by the cleanups, except for the latest one of each asset (0 keeps them all)",
+	)
+
+	cmdDaemon.Flags().DurationVar(&AssetsInterval, "assets-interval", time.Hour, "Interval between asset refreshes (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&OrderbooksInterval, "orderbooks-interval", 10*time.Minute, "Interval between orderbook refreshes (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&LiquidityPoolsInterval, "liquidity-pools-interval", 10*time.Minute, "Interval between liquidity pool refreshes (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&PricesInterval, "prices-interval", 5*time.Minute, "Interval between asset price refreshes (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&TradeFlagsInterval, "trade-flags-interval", time.Minute, "Interval between anomaly checks of the new trades (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&TradesInterval, "trades-interval", 6*time.Hour, "Interval between trade backfills (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&AssetDataInterval, "asset-data-interval", time.Hour, "Interval between asset data generations (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&MarketDataInterval, "market-data-interval", time.Minute, "Interval between market data generations (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&CandleDataInterval, "candle-data-interval", 5*time.Minute, "Interval between candle data generations (0 disables them)")
+	cmdDaemon.Flags().DurationVar(&CleanInterval, "clean-interval", 24*time.Hour, "Interval between deletions of old trades, orderbook snapshots and asset snapshots (0 disables them)")
+}
+
+var cmdDaemon = &cobra.Command{
+	Use:   "daemon",
+	Short: "Runs the ingestion and generation jobs on a schedule, along with the GraphQL interface, as a single process.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ds := currentDaemonSettings(cmd)
+		for _, w := range ds.windows {
+			if _, err := utils.ParseWindow(w); err != nil {
+				Logger.Fatal("could not parse windows:", err)
+			}
+		}
+
+		if _, err := pricing.ParseReferences(ds.referenceAssets); err != nil {
+			Logger.Fatal("could not parse reference-assets:", err)
+		}
+		if _, ok := tickerdb.CandleResolutions[ds.candleResolution]; !ok {
+			Logger.Fatal("invalid candle resolution:", ds.candleResolution)
+		}
+
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+		defer session.DB.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		s := scheduler.New(Logger)
+		addDaemonJobs(s, &session, cmd)
+
+		mux := http.NewServeMux()
+		if DaemonServeGraphQL {
+			mux = ticker.NewServeMux(&session, Client, Logger, ds.minTradeSize)
+		} else {
+			mux.Handle("/metrics", metrics.Handler())
+		}
+		mux.Handle("/health", s.HealthHandler())
+
+		server := &http.Server{
+			Addr:        DaemonAddress,
+			Handler:     mux,
+			ReadTimeout: 5 * time.Second,
+		}
+		go func() {
+			Logger.Infof("Starting to serve on address %s\n", DaemonAddress)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				Logger.Error("server.ListenAndServe:", err)
+			}
+		}()
+
+		s.Run(ctx)
+
+		Logger.Info("Shutting down the server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			Logger.Error("could not shut down the server:", err)
+		}
+	},
+}
+
+// daemonSettings are the settings of the daemon jobs that are read from the
+// configuration on each run, so that they follow its reloads (unless they're
+// set on the command line).
+type daemonSettings struct {
+	assetsOutFile     string
+	marketsOutFile    string
+	candlesOutFile    string
+	windows           []string
+	aggregateByCode   bool
+	minTradeSize      float64
+	candleResolution  string
+	slippageAmount    float64
+	referenceAssets   []string
+	tradesDays        int
+	orderbookDays     int
+	assetSnapshotDays int
+}
+
+// currentDaemonSettings returns the daemon settings of the current
+// configuration, overridden by the flags of cmd set on the command line.
+func currentDaemonSettings(cmd *cobra.Command) daemonSettings {
+	cfg := currentConfig()
+	return daemonSettings{
+		assetsOutFile:     setting(cmd, "assets-out-file", DaemonAssetsOutFile, cfg.Output.AssetsFile),
+		marketsOutFile:    setting(cmd, "markets-out-file", DaemonMarketsOutFile, cfg.Output.MarketsFile),
+		candlesOutFile:    setting(cmd, "candles-out-file", DaemonCandlesOutFile, cfg.Output.CandlesFile),
+		windows:           setting(cmd, "windows", DaemonMarketWindows, cfg.Markets.Windows),
+		aggregateByCode:   setting(cmd, "aggregate-by-code", DaemonAggregateByCode, cfg.Markets.AggregateByCode),
+		minTradeSize:      setting(cmd, "min-trade-size", DaemonMinTradeSize, cfg.Markets.MinTradeSize),
+		candleResolution:  setting(cmd, "candle-resolution", DaemonCandleResolution, cfg.Markets.CandleResolution),
+		slippageAmount:    setting(cmd, "slippage-amount", DaemonSlippageAmount, cfg.Markets.SlippageAmount),
+		referenceAssets:   setting(cmd, "reference-assets", DaemonReferenceAssets, cfg.Markets.ReferenceAssets),
+		tradesDays:        setting(cmd, "keep-trades-days", DaemonTradesDaysToKeep, cfg.Retention.TradesDays),
+		orderbookDays:     setting(cmd, "keep-orderbook-days", DaemonOrderbookDaysToKeep, cfg.Retention.OrderbookDays),
+		assetSnapshotDays: setting(cmd, "keep-asset-snapshot-days", DaemonAssetSnapshotDaysToKeep, cfg.Retention.AssetSnapshotsDays),
+	}
+}
+
+// addDaemonJobs schedules the same jobs that the Docker image used to run
+// through cron and supervisord, along with the reloads of the config file.
+func addDaemonJobs(s *scheduler.Scheduler, session *tickerdb.TickerSession, cmd *cobra.Command) {
+	if ConfigPath != "" {
+		s.Add(scheduler.Job{
+			Name:     "config-reload",
+			Interval: time.Duration(currentConfig().ReloadInterval),
+			Run: func(ctx context.Context) error {
+				reloadConfig()
+				return nil
+			},
+		})
+	}
+
+	s.Add(scheduler.Job{
+		Name:     "assets",
+		Interval: AssetsInterval,
+		Run: func(ctx context.Context) error {
+			issuers, err := trackedIssuers(func(i config.Issuer) bool { return !i.SkipAssets })
+			if err != nil {
+				return err
+			}
+			if len(issuers) == 0 {
+				return ticker.RefreshAssets(ctx, session, Client, Logger)
+			}
+
+			for _, issuer := range issuers {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				Logger.Infof("Refreshing assets for issuer: %s", issuer)
+				err = ticker.RefreshFilteredAssets(ctx, session, Client, Logger, issuer)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "orderbooks",
+		Interval: OrderbooksInterval,
+		Run: func(ctx context.Context) error {
+			slippageAmount := currentDaemonSettings(cmd).slippageAmount
+			issuers, err := trackedIssuers(func(i config.Issuer) bool { return !i.SkipOrderbooks })
+			if err != nil {
+				return err
+			}
+			if len(issuers) == 0 {
+				return ticker.RefreshOrderbookEntries(session, Client, Logger, slippageAmount)
+			}
+			return ticker.RefreshFilteredOrderbookEntries(session, Client, Logger, issuers, slippageAmount)
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "liquidity-pools",
+		Interval: LiquidityPoolsInterval,
+		Run: func(ctx context.Context) error {
+			return ticker.RefreshLiquidityPools(ctx, session, Client, Logger)
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "prices",
+		Interval: PricesInterval,
+		Run: func(ctx context.Context) error {
+			refs, err := pricing.ParseReferences(currentDaemonSettings(cmd).referenceAssets)
+			if err != nil {
+				return err
+			}
+			return ticker.RefreshAssetPrices(ctx, session, Logger, refs)
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "trades",
+		Interval: TradesInterval,
+		Run: func(ctx context.Context) error {
+			return ticker.BackfillTrades(ctx, session, Client, Logger, BackfillHours, 0)
+		},
+	})
+
+	if DaemonStreamTrades {
+		s.Add(scheduler.Job{
+			Name:     "trade-stream",
+			Interval: streamRestartInterval,
+			Run: func(ctx context.Context) error {
+				return ticker.StreamTrades(ctx, session, Client, Logger)
+			},
+		})
+	}
+
+	s.Add(scheduler.Job{
+		Name:     "trade-flags",
+		Interval: TradeFlagsInterval,
+		Run: func(ctx context.Context) error {
+			return ticker.FlagTrades(ctx, session, Logger, anomaly.DefaultSettings())
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "asset-data",
+		Interval: AssetDataInterval,
+		Run: func(ctx context.Context) error {
+			return ticker.GenerateAssetsFile(ctx, session, Logger, currentDaemonSettings(cmd).assetsOutFile)
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "market-data",
+		Interval: MarketDataInterval,
+		Run: func(ctx context.Context) error {
+			ds := currentDaemonSettings(cmd)
+			return ticker.GenerateMarketSummaryFile(session, Logger, ds.marketsOutFile, ds.windows, ds.aggregateByCode, ds.minTradeSize)
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "candle-data",
+		Interval: CandleDataInterval,
+		Run: func(ctx context.Context) error {
+			ds := currentDaemonSettings(cmd)
+			return ticker.GenerateCandleSummaryFile(session, Logger, ds.candlesOutFile, ds.candleResolution, 24)
+		},
+	})
+
+	s.Add(scheduler.Job{
+		Name:     "clean",
+		Interval: CleanInterval,
+		Run: func(ctx context.Context) error {
+			ds := currentDaemonSettings(cmd)
+			now := time.Now()
+			var tradesMinDate, orderbooksMinDate, assetSnapshotsMinDate time.Time
+			if ds.tradesDays > 0 {
+				tradesMinDate = now.Add(-tradesRetention(ds))
+			}
+			if ds.orderbookDays > 0 {
+				orderbooksMinDate = now.AddDate(0, 0, -ds.orderbookDays)
+			}
+			if ds.assetSnapshotDays > 0 {
+				assetSnapshotsMinDate = now.AddDate(0, 0, -ds.assetSnapshotDays)
+			}
+			return ticker.CleanDatabase(ctx, session, Logger, tradesMinDate, orderbooksMinDate, assetSnapshotsMinDate)
+		},
+	})
+}
+
+// tradesRetention returns how long the cleanups keep the trades: tradesDays,
+// extended to the 7-day market stats and the longest market window if needed.
+func tradesRetention(ds daemonSettings) time.Duration {
+	retention := time.Duration(ds.tradesDays) * 24 * time.Hour
+	if retention < minTradesRetention {
+		retention = minTradesRetention
+	}
+	for _, w := range ds.windows {
+		if d, err := utils.ParseWindow(w); err == nil && d > retention {
+			retention = d
+		}
+	}
+	return retention
+}
+
+// reloadConfig reloads the config file if it was modified, applying the new
+// scraper settings. Invalid files are logged and ignored, and changes of the
+// Horizon settings require a restart.
+func reloadConfig() {
+	previous := currentConfig()
+	reloaded, err := configWatcher.Reload()
+	if err != nil {
+		Logger.Error("could not reload config file, keeping the previous configuration: ", err)
+		return
+	}
+	if !reloaded {
+		return
+	}
+
+	cfg := currentConfig()
+	ticker.SetScraperSettings(cfg.ScraperSettings())
+	if cfg.Horizon != previous.Horizon {
+		Logger.Warn("The horizon settings of the config file are only applied on restart")
+	}
+	Logger.Infof("Reloaded config file %s\n", ConfigPath)
+}
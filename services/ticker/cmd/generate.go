@@ -7,16 +7,27 @@ import (
 	"github.com/spf13/cobra"
 	ticker "github.com/stellar/go/services/ticker/internal"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
 )
 
 var MarketsOutFile string
+var MarketWindows []string
+var AggregateByCode bool
+var MinTradeSize float64
 var AssetsOutFile string
+var CandlesOutFile string
+var CandleResolution string
+var CandleNumHours int
+var AggregatorOutDir string
 
 func init() {
 	rootCmd.AddCommand(cmdGenerate)
 	cmdGenerate.AddCommand(cmdGenerateMarketData)
 	cmdGenerate.AddCommand(cmdGeneratePartialMarketData)
 	cmdGenerate.AddCommand(cmdGenerateAssetData)
+	cmdGenerate.AddCommand(cmdGenerateCandleData)
+	cmdGenerate.AddCommand(cmdGenerateCoinGeckoData)
+	cmdGenerate.AddCommand(cmdGenerateCMCData)
 
 	cmdGenerateMarketData.Flags().StringVarP(
 		&MarketsOutFile,
@@ -26,6 +37,28 @@ func init() {
 		"Set the name of the output file",
 	)
 
+	cmdGenerateMarketData.Flags().StringSliceVarP(
+		&MarketWindows,
+		"windows",
+		"w",
+		[]string{},
+		"Comma-separated list of additional trailing windows to generate stats for (e.g. 1h,24h,7d,30d)",
+	)
+
+	cmdGenerateMarketData.Flags().BoolVar(
+		&AggregateByCode,
+		"aggregate-by-code",
+		false,
+		"Merge the markets whose assets share the same codes (e.g. all XLM_USD markets, regardless of the USD issuer) into a single trade pair",
+	)
+
+	cmdGenerateMarketData.Flags().Float64Var(
+		&MinTradeSize,
+		"min-trade-size",
+		0,
+		"Minimum value in USD of the trades included in the VWAP, TWAP and median prices",
+	)
+
 	cmdGeneratePartialMarketData.Flags().StringVarP(
 		&MarketsOutFile,
 		"out-file",
@@ -39,7 +72,14 @@ func init() {
 		"file",
 		"f",
 		"",
-		"Filter assets by issuers defined in a file",
+		"Filter assets by issuers defined in a file, one per line (instead of the issuers of the config file)",
+	)
+
+	cmdGeneratePartialMarketData.Flags().Float64Var(
+		&MinTradeSize,
+		"min-trade-size",
+		0,
+		"Minimum value in USD of the trades included in the VWAP, TWAP and median prices",
 	)
 
 	cmdGenerateAssetData.Flags().StringVarP(
@@ -49,6 +89,57 @@ func init() {
 		"assets.json",
 		"Set the name of the output file",
 	)
+
+	cmdGenerateCandleData.Flags().StringVarP(
+		&CandlesOutFile,
+		"out-file",
+		"o",
+		"candles.json",
+		"Set the name of the output file",
+	)
+
+	cmdGenerateCandleData.Flags().StringVarP(
+		&CandleResolution,
+		"resolution",
+		"r",
+		"1h",
+		"Candle resolution (1m, 5m, 15m, 1h, 4h or 1d)",
+	)
+
+	cmdGenerateCandleData.Flags().IntVar(
+		&CandleNumHours,
+		"num-hours",
+		24,
+		"Number of past hours to include in the candle data",
+	)
+
+	cmdGenerateCoinGeckoData.Flags().StringVar(
+		&AggregatorOutDir,
+		"out-dir",
+		".",
+		"Set the directory of the output files",
+	)
+
+	cmdGenerateCoinGeckoData.Flags().Float64Var(
+		&MinTradeSize,
+		"min-trade-size",
+		0,
+		"Minimum value in USD of the trades included in the price stats of the markets",
+	)
+
+	cmdGenerateCMCData.Flags().StringVar(
+		&AggregatorOutDir,
+		"out-dir",
+		".",
+		"Set the directory of the output files",
+	)
+
+	cmdGenerateCMCData.Flags().Float64Var(
+		&MinTradeSize,
+		"min-trade-size",
+		0,
+		"Minimum value in USD of the trades included in the price stats of the markets",
+	)
 }
 
 var cmdGenerate = &cobra.Command{
@@ -58,8 +149,19 @@ var cmdGenerate = &cobra.Command{
 
 var cmdGenerateMarketData = &cobra.Command{
 	Use:   "market-data",
-	Short: "Generate the aggregated market data (for 24h and 7d) and outputs to a file.",
+	Short: "Generate the aggregated market data (for 24h, 7d and any extra windows) and outputs to a file.",
 	Run: func(cmd *cobra.Command, args []string) {
+		cfg := currentConfig()
+		outFile := setting(cmd, "out-file", MarketsOutFile, cfg.Output.MarketsFile)
+		windows := setting(cmd, "windows", MarketWindows, cfg.Markets.Windows)
+		aggregateByCode := setting(cmd, "aggregate-by-code", AggregateByCode, cfg.Markets.AggregateByCode)
+		minTradeSize := requireMinTradeSize(cmd)
+		for _, w := range windows {
+			if _, err := utils.ParseWindow(w); err != nil {
+				Logger.Fatal("could not parse windows:", err)
+			}
+		}
+
 		dbInfo, err := pq.ParseURL(DatabaseURL)
 		if err != nil {
 			Logger.Fatal("could not parse db-url:", err)
@@ -70,8 +172,8 @@ var cmdGenerateMarketData = &cobra.Command{
 			Logger.Fatal("could not connect to db:", err)
 		}
 
-		Logger.Infof("Starting market data generation, outputting to: %s\n", MarketsOutFile)
-		err = ticker.GenerateMarketSummaryFile(&session, Logger, MarketsOutFile)
+		Logger.Infof("Starting market data generation, outputting to: %s\n", outFile)
+		err = ticker.GenerateMarketSummaryFile(&session, Logger, outFile, windows, aggregateByCode, minTradeSize)
 		if err != nil {
 			Logger.Fatal("could not generate market data:", err)
 		}
@@ -82,9 +184,8 @@ var cmdGeneratePartialMarketData = &cobra.Command{
 	Use:   "partial-market-data",
 	Short: "Generate the aggregated market data (for 24h and 7d) and outputs to a file for a given set of issuers.",
 	Run: func(cmd *cobra.Command, args []string) {
-		if filePath == "" {
-			Logger.Fatal("file flag is required")
-		}
+		issuers := requireTrackedIssuers(allIssuers)
+		minTradeSize := requireMinTradeSize(cmd)
 
 		dbInfo, err := pq.ParseURL(DatabaseURL)
 		if err != nil {
@@ -96,16 +197,8 @@ var cmdGeneratePartialMarketData = &cobra.Command{
 			Logger.Fatal("could not connect to db:", err)
 		}
 
-		fileContents, err := getIssuers(filePath)
-		if err != nil {
-			Logger.Fatal("could not get issuers from file:", err)
-		}
-
-		// deduplicate the file contents
-		issuers := removeDuplicate(fileContents)
-
 		Logger.Infof("Starting market data generation from filtered issuers, outputting to: %s\n", MarketsOutFile)
-		err = ticker.GeneratePartialMarketSummaryFile(&session, Logger, MarketsOutFile, issuers)
+		err = ticker.GeneratePartialMarketSummaryFile(&session, Logger, MarketsOutFile, issuers, minTradeSize)
 		if err != nil {
 			Logger.Fatal("could not generate market data:", err)
 		}
@@ -126,10 +219,90 @@ var cmdGenerateAssetData = &cobra.Command{
 			Logger.Fatal("could not connect to db:", err)
 		}
 
-		Logger.Infof("Starting asset data generation, outputting to: %s\n", AssetsOutFile)
-		err = ticker.GenerateAssetsFile(context.Background(), &session, Logger, AssetsOutFile)
+		outFile := setting(cmd, "out-file", AssetsOutFile, currentConfig().Output.AssetsFile)
+		Logger.Infof("Starting asset data generation, outputting to: %s\n", outFile)
+		err = ticker.GenerateAssetsFile(context.Background(), &session, Logger, outFile)
 		if err != nil {
 			Logger.Fatal("could not generate asset data:", err)
 		}
 	},
 }
+
+var cmdGenerateCandleData = &cobra.Command{
+	Use:   "candle-data",
+	Short: "Generate the OHLCV candles of a given resolution for all markets and outputs to a file.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := currentConfig()
+		outFile := setting(cmd, "out-file", CandlesOutFile, cfg.Output.CandlesFile)
+		resolution := setting(cmd, "resolution", CandleResolution, cfg.Markets.CandleResolution)
+		if _, ok := tickerdb.CandleResolutions[resolution]; !ok {
+			Logger.Fatal("invalid candle resolution:", resolution)
+		}
+
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		Logger.Infof("Starting candle data generation, outputting to: %s\n", outFile)
+		err = ticker.GenerateCandleSummaryFile(&session, Logger, outFile, resolution, CandleNumHours)
+		if err != nil {
+			Logger.Fatal("could not generate candle data:", err)
+		}
+	},
+}
+
+var cmdGenerateCoinGeckoData = &cobra.Command{
+	Use:   "coingecko-data",
+	Short: "Generate the market data in the CoinGecko exchange API format and outputs to pairs.json and tickers.json.",
+	Run: func(cmd *cobra.Command, args []string) {
+		minTradeSize := requireMinTradeSize(cmd)
+
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		outDir := setting(cmd, "out-dir", AggregatorOutDir, currentConfig().Output.AggregatorDir)
+		Logger.Infof("Starting CoinGecko data generation, outputting to: %s\n", outDir)
+		err = ticker.GenerateCoinGeckoFiles(context.Background(), &session, Logger, outDir, minTradeSize)
+		if err != nil {
+			Logger.Fatal("could not generate CoinGecko data:", err)
+		}
+	},
+}
+
+var cmdGenerateCMCData = &cobra.Command{
+	Use:   "cmc-data",
+	Short: "Generate the market data in the CoinMarketCap exchange API format and outputs to summary.json and ticker.json.",
+	Run: func(cmd *cobra.Command, args []string) {
+		minTradeSize := requireMinTradeSize(cmd)
+
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		outDir := setting(cmd, "out-dir", AggregatorOutDir, currentConfig().Output.AggregatorDir)
+		Logger.Infof("Starting CoinMarketCap data generation, outputting to: %s\n", outDir)
+		err = ticker.GenerateCMCFiles(context.Background(), &session, Logger, outDir, minTradeSize)
+		if err != nil {
+			Logger.Fatal("could not generate CoinMarketCap data:", err)
+		}
+	},
+}
@@ -3,16 +3,37 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/lib/pq"
 	"github.com/spf13/cobra"
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/metaarchive"
+	"github.com/stellar/go/network"
 	ticker "github.com/stellar/go/services/ticker/internal"
+	"github.com/stellar/go/services/ticker/internal/anomaly"
+	"github.com/stellar/go/services/ticker/internal/config"
+	"github.com/stellar/go/services/ticker/internal/metrics"
+	"github.com/stellar/go/services/ticker/internal/pricing"
+	"github.com/stellar/go/services/ticker/internal/scraper"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/storage"
 )
 
 var ShouldStream bool
 var BackfillHours int
+var TradesSource string
+var LedgerBackendType string
+var StartLedger uint32
+var CaptiveCoreBinaryPath string
+var CaptiveCoreConfigPath string
+var HistoryArchiveURLs []string
+var MetaArchiveURL string
+var MetricsAddress string
+var SlippageAmount float64
+var ReferenceAssets []string
 
 func init() {
 	rootCmd.AddCommand(cmdIngest)
@@ -22,6 +43,10 @@ func init() {
 	cmdIngest.AddCommand(cmdIngestFilteredTrades)
 	//cmdIngest.AddCommand(cmdIngestOrderbooks)
 	cmdIngest.AddCommand(cmdIngestFilteredOrderbooks)
+	cmdIngest.AddCommand(cmdIngestLiquidityPools)
+	cmdIngest.AddCommand(cmdIngestPrices)
+	cmdIngest.AddCommand(cmdIngestContractTokens)
+	cmdIngest.AddCommand(cmdIngestTradeFlags)
 
 	cmdIngestTrades.Flags().BoolVar(
 		&ShouldStream,
@@ -37,12 +62,68 @@ func init() {
 		"Number of past hours to backfill trade data",
 	)
 
+	cmdIngestTrades.Flags().StringVar(
+		&TradesSource,
+		"source",
+		"horizon",
+		"Source of the trade data: horizon or ledgers (transaction meta read through a ledger backend)",
+	)
+
+	cmdIngestTrades.Flags().StringVar(
+		&LedgerBackendType,
+		"ledger-backend",
+		"captive-core",
+		"Ledger backend used with --source=ledgers: captive-core or archive (a meta archive, see --meta-archive-url)",
+	)
+
+	cmdIngestTrades.Flags().Uint32Var(
+		&StartLedger,
+		"start-ledger",
+		0,
+		"First ledger to ingest with --source=ledgers (defaults to an estimate based on --num-hours)",
+	)
+
+	cmdIngestTrades.Flags().StringVar(
+		&CaptiveCoreBinaryPath,
+		"captive-core-binary-path",
+		os.Getenv("STELLAR_CORE_BINARY_PATH"),
+		"Path to the stellar-core binary used by the captive-core ledger backend",
+	)
+
+	cmdIngestTrades.Flags().StringVar(
+		&CaptiveCoreConfigPath,
+		"captive-core-config-path",
+		os.Getenv("CAPTIVE_CORE_CONFIG_PATH"),
+		"Path to the captive core TOML configuration used by the captive-core ledger backend",
+	)
+
+	cmdIngestTrades.Flags().StringSliceVar(
+		&HistoryArchiveURLs,
+		"history-archive-urls",
+		[]string{},
+		"Comma-separated list of history archive URLs (defaults to the SDF archives of the selected network)",
+	)
+
+	cmdIngestTrades.Flags().StringVar(
+		&MetaArchiveURL,
+		"meta-archive-url",
+		"",
+		"URL of the transaction meta archive used by the archive ledger backend (e.g. s3://bucket/path)",
+	)
+
+	cmdIngestTrades.Flags().StringVar(
+		&MetricsAddress,
+		"metrics-address",
+		"",
+		"Address to expose Prometheus metrics on (at /metrics), such as 0.0.0.0:2112; disabled if empty",
+	)
+
 	cmdIngestFilteredAssets.Flags().StringVarP(
 		&filePath,
 		"file",
 		"f",
 		"",
-		"Filter assets by issuers defined in a file",
+		"Filter assets by issuers defined in a file, one per line (instead of the issuers of the config file)",
 	)
 
 	cmdIngestFilteredTrades.Flags().StringVarP(
@@ -50,7 +131,7 @@ func init() {
 		"file",
 		"f",
 		"",
-		"Filter trades by issuers defined in a file",
+		"Filter trades by issuers defined in a file, one per line (instead of the issuers of the config file)",
 	)
 
 	cmdIngestFilteredOrderbooks.Flags().StringVarP(
@@ -58,7 +139,77 @@ func init() {
 		"file",
 		"f",
 		"",
-		"Filter orderbooks by issuers defined in a file",
+		"Filter orderbooks by issuers defined in a file, one per line (instead of the issuers of the config file)",
+	)
+
+	cmdIngestOrderbooks.Flags().Float64Var(
+		&SlippageAmount,
+		"slippage-amount",
+		scraper.DefaultSlippageAmount,
+		"Amount of the base asset used to calculate the buy / sell slippage of each orderbook",
+	)
+
+	cmdIngestFilteredOrderbooks.Flags().Float64Var(
+		&SlippageAmount,
+		"slippage-amount",
+		scraper.DefaultSlippageAmount,
+		"Amount of the base asset used to calculate the buy / sell slippage of each orderbook",
+	)
+
+	cmdIngestContractTokens.Flags().BoolVar(
+		&ShouldStream,
+		"stream",
+		false,
+		"Continuously ingest new ledgers as a daemon",
+	)
+
+	cmdIngestContractTokens.Flags().StringVar(
+		&LedgerBackendType,
+		"ledger-backend",
+		"captive-core",
+		"Ledger backend: captive-core or archive (a meta archive, see --meta-archive-url)",
+	)
+
+	cmdIngestContractTokens.Flags().Uint32Var(
+		&StartLedger,
+		"start-ledger",
+		0,
+		"First ledger to ingest (defaults to the ledger after the last ingested one, or to the latest ledger)",
+	)
+
+	cmdIngestContractTokens.Flags().StringVar(
+		&CaptiveCoreBinaryPath,
+		"captive-core-binary-path",
+		os.Getenv("STELLAR_CORE_BINARY_PATH"),
+		"Path to the stellar-core binary used by the captive-core ledger backend",
+	)
+
+	cmdIngestContractTokens.Flags().StringVar(
+		&CaptiveCoreConfigPath,
+		"captive-core-config-path",
+		os.Getenv("CAPTIVE_CORE_CONFIG_PATH"),
+		"Path to the captive core TOML configuration used by the captive-core ledger backend",
+	)
+
+	cmdIngestContractTokens.Flags().StringSliceVar(
+		&HistoryArchiveURLs,
+		"history-archive-urls",
+		[]string{},
+		"Comma-separated list of history archive URLs (defaults to the SDF archives of the selected network)",
+	)
+
+	cmdIngestContractTokens.Flags().StringVar(
+		&MetaArchiveURL,
+		"meta-archive-url",
+		"",
+		"URL of the transaction meta archive used by the archive ledger backend (e.g. s3://bucket/path)",
+	)
+
+	cmdIngestPrices.Flags().StringSliceVar(
+		&ReferenceAssets,
+		"reference-assets",
+		pricing.DefaultReferenceAssets,
+		"Comma-separated list of fiat reference assets to derive prices from, as <currency>=<code>:<issuer> (e.g. USD=USDC:G...)",
 	)
 }
 
@@ -95,9 +246,7 @@ var cmdIngestFilteredAssets = &cobra.Command{
 	Use:   "filtered-assets",
 	Short: "Refreshes the asset database with new data retrieved from Horizon filtered by issuers defined in a file.",
 	Run: func(cmd *cobra.Command, args []string) {
-		if filePath == "" {
-			Logger.Fatal("file flag is required")
-		}
+		issuers := requireTrackedIssuers(func(i config.Issuer) bool { return !i.SkipAssets })
 
 		Logger.Info("Refreshing the asset database")
 		dbInfo, err := pq.ParseURL(DatabaseURL)
@@ -111,18 +260,8 @@ var cmdIngestFilteredAssets = &cobra.Command{
 		}
 		defer session.DB.Close()
 
-		fileContents, err := getIssuers(filePath)
-		if err != nil {
-			Logger.Fatal("could not get issuers from file:", err)
-		}
-
-		// deduplicate the file contents
-		issuers := removeDuplicate(fileContents)
-
 		ctx := context.Background()
 
-		// for each issuer we spawn 20 threads
-
 		// loop over the issuers and refresh the assets
 		for _, issuer := range issuers {
 			Logger.Infof("Refreshing assets for issuer: %s", issuer)
@@ -136,7 +275,7 @@ var cmdIngestFilteredAssets = &cobra.Command{
 
 var cmdIngestTrades = &cobra.Command{
 	Use:   "trades",
-	Short: "Fills the trade database with data retrieved from Horizon.",
+	Short: "Fills the trade database with data retrieved from Horizon (or directly from ledgers, see --source).",
 	Run: func(cmd *cobra.Command, args []string) {
 		dbInfo, err := pq.ParseURL(DatabaseURL)
 		if err != nil {
@@ -149,7 +288,18 @@ var cmdIngestTrades = &cobra.Command{
 		}
 		defer session.DB.Close()
 
+		if MetricsAddress != "" {
+			go metrics.Serve(MetricsAddress, Logger)
+		}
+
 		ctx := context.Background()
+		if TradesSource == "ledgers" {
+			ingestLedgerTrades(ctx, &session)
+			return
+		} else if TradesSource != "horizon" {
+			Logger.Fatal("invalid trade source:", TradesSource)
+		}
+
 		numDays := float32(BackfillHours) / 24.0
 		Logger.Infof(
 			"Backfilling Trade data for the past %d hour(s) [%.2f days]\n",
@@ -175,9 +325,7 @@ var cmdIngestFilteredTrades = &cobra.Command{
 	Use:   "filtered-trades",
 	Short: "Fills the trade database with data retrieved from Horizon filtered by issuers defined in a file.",
 	Run: func(cmd *cobra.Command, args []string) {
-		if filePath == "" {
-			Logger.Fatal("file flag is required")
-		}
+		issuers := requireTrackedIssuers(func(i config.Issuer) bool { return !i.SkipTrades })
 
 		dbInfo, err := pq.ParseURL(DatabaseURL)
 		if err != nil {
@@ -198,14 +346,6 @@ var cmdIngestFilteredTrades = &cobra.Command{
 			numDays,
 		)
 
-		fileContents, err := getIssuers(filePath)
-		if err != nil {
-			Logger.Fatal("could not get issuers from file:", err)
-		}
-
-		// deduplicate the file contents
-		issuers := removeDuplicate(fileContents)
-
 		// loop over the issuers and refresh the trades
 		for _, issuer := range issuers {
 			err = ticker.BackfillFilteredTrades(ctx, &session, Client, Logger, BackfillHours, 0, issuer)
@@ -240,7 +380,8 @@ var cmdIngestOrderbooks = &cobra.Command{
 		}
 		defer session.DB.Close()
 
-		err = ticker.RefreshOrderbookEntries(&session, Client, Logger)
+		slippageAmount := setting(cmd, "slippage-amount", SlippageAmount, currentConfig().Markets.SlippageAmount)
+		err = ticker.RefreshOrderbookEntries(&session, Client, Logger, slippageAmount)
 		if err != nil {
 			Logger.Fatal("could not refresh orderbook database:", err)
 		}
@@ -251,9 +392,7 @@ var cmdIngestFilteredOrderbooks = &cobra.Command{
 	Use:   "filtered-orderbooks",
 	Short: "Refreshes the orderbook stats database with new data retrieved from Horizon filtered by issuers defined in a file.",
 	Run: func(cmd *cobra.Command, args []string) {
-		if filePath == "" {
-			Logger.Fatal("file flag is required")
-		}
+		issuers := requireTrackedIssuers(func(i config.Issuer) bool { return !i.SkipOrderbooks })
 
 		Logger.Info("Refreshing the asset database")
 		dbInfo, err := pq.ParseURL(DatabaseURL)
@@ -267,21 +406,252 @@ var cmdIngestFilteredOrderbooks = &cobra.Command{
 		}
 		defer session.DB.Close()
 
-		fileContents, err := getIssuers(filePath)
+		slippageAmount := setting(cmd, "slippage-amount", SlippageAmount, currentConfig().Markets.SlippageAmount)
+		err = ticker.RefreshFilteredOrderbookEntries(&session, Client, Logger, issuers, slippageAmount)
 		if err != nil {
-			Logger.Fatal("could not get issuers from file:", err)
+			Logger.Fatal("could not refresh orderbook database:", err)
 		}
+	},
+}
 
-		// deduplicate the file contents
-		issuers := removeDuplicate(fileContents)
+var cmdIngestLiquidityPools = &cobra.Command{
+	Use:   "liquidity-pools",
+	Short: "Refreshes the liquidity pool database with new data retrieved from Horizon.",
+	Run: func(cmd *cobra.Command, args []string) {
+		Logger.Info("Refreshing the liquidity pool database")
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
 
-		err = ticker.RefreshFilteredOrderbookEntries(&session, Client, Logger, issuers)
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
 		if err != nil {
-			Logger.Fatal("could not refresh orderbook database:", err)
+			Logger.Fatal("could not connect to db:", err)
+		}
+		defer session.DB.Close()
+
+		err = ticker.RefreshLiquidityPools(context.Background(), &session, Client, Logger)
+		if err != nil {
+			Logger.Fatal("could not refresh liquidity pool database:", err)
+		}
+	},
+}
+
+var cmdIngestPrices = &cobra.Command{
+	Use:   "prices",
+	Short: "Refreshes the fiat prices of assets, derived from the on-DEX markets against the reference assets.",
+	Run: func(cmd *cobra.Command, args []string) {
+		refs, err := pricing.ParseReferences(setting(cmd, "reference-assets", ReferenceAssets, currentConfig().Markets.ReferenceAssets))
+		if err != nil {
+			Logger.Fatal("could not parse reference-assets:", err)
+		}
+
+		Logger.Info("Refreshing the asset prices")
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+		defer session.DB.Close()
+
+		err = ticker.RefreshAssetPrices(context.Background(), &session, Logger, refs)
+		if err != nil {
+			Logger.Fatal("could not refresh asset prices:", err)
+		}
+	},
+}
+
+var cmdIngestTradeFlags = &cobra.Command{
+	Use:   "trade-flags",
+	Short: "Flags the self-trades, wash trades and price outliers among the trades which weren't checked yet.",
+	Run: func(cmd *cobra.Command, args []string) {
+		Logger.Info("Checking trades for anomalies")
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+		defer session.DB.Close()
+
+		err = ticker.FlagTrades(context.Background(), &session, Logger, anomaly.DefaultSettings())
+		if err != nil {
+			Logger.Fatal("could not flag trades:", err)
+		}
+	},
+}
+
+var cmdIngestContractTokens = &cobra.Command{
+	Use:   "contract-tokens",
+	Short: "Tracks the supply and holders of Soroban tokens from the events of the ledgers read through a ledger backend.",
+	Run: func(cmd *cobra.Command, args []string) {
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+		defer session.DB.Close()
+
+		ctx := context.Background()
+		passphrase := networkPassphrase()
+		backend, latestLedger, err := newLedgerBackend(ctx, passphrase, ledgerArchiveURLs(passphrase))
+		if err != nil {
+			Logger.Fatal("could not create ledger backend:", err)
+		}
+		defer backend.Close()
+
+		from := StartLedger
+		if from == 0 {
+			from = ticker.ContractTokensStart(ctx, &session, latestLedger)
+		}
+
+		to := latestLedger
+		if ShouldStream {
+			Logger.Info("Streaming new ledgers (this is a continuous process)")
+			to = 0
+		} else if from > to {
+			Logger.Infof("Tokens are already ingested up to ledger %d\n", to)
+			return
+		}
+
+		Logger.Infof("Ingesting token events from ledger %d using the %s backend\n", from, LedgerBackendType)
+		err = ticker.IngestContractTokens(ctx, &session, backend, Logger, passphrase, from, to)
+		if err != nil {
+			Logger.Fatal("could not refresh contract token database:", err)
 		}
 	},
 }
 
+// ledgerArchiveURLs returns the history archive URLs set through the CLI
+// flags, or the SDF ones of the given network.
+func ledgerArchiveURLs(passphrase string) []string {
+	if len(HistoryArchiveURLs) > 0 {
+		return HistoryArchiveURLs
+	}
+	if passphrase == network.TestNetworkPassphrase {
+		return network.TestNetworkhistoryArchiveURLs
+	}
+	return network.PublicNetworkhistoryArchiveURLs
+}
+
+// ingestLedgerTrades ingests trades from the transaction meta provided by the
+// configured ledger backend, as an alternative to backfilling from Horizon.
+func ingestLedgerTrades(ctx context.Context, session *tickerdb.TickerSession) {
+	passphrase := networkPassphrase()
+	backend, latestLedger, err := newLedgerBackend(ctx, passphrase, ledgerArchiveURLs(passphrase))
+	if err != nil {
+		Logger.Fatal("could not create ledger backend:", err)
+	}
+	defer backend.Close()
+
+	from := StartLedger
+	if from == 0 {
+		from = ticker.LedgerBackfillStart(ctx, session, latestLedger, BackfillHours)
+	}
+
+	to := latestLedger
+	if ShouldStream {
+		Logger.Info("Streaming new ledgers (this is a continuous process)")
+		to = 0
+	} else if from > to {
+		Logger.Infof("Trades are already ingested up to ledger %d\n", to)
+		return
+	}
+
+	Logger.Infof("Ingesting trades from ledger %d using the %s backend\n", from, LedgerBackendType)
+	err = ticker.IngestLedgerTrades(ctx, session, backend, Logger, passphrase, from, to)
+	if err != nil {
+		Logger.Fatal("could not refresh trade database:", err)
+	}
+}
+
+// newLedgerBackend creates the ledger backend selected through the CLI flags,
+// returning it along with the latest ledger it can provide.
+func newLedgerBackend(
+	ctx context.Context,
+	networkPassphrase string,
+	archiveURLs []string,
+) (backend ledgerbackend.LedgerBackend, latestLedger uint32, err error) {
+	switch LedgerBackendType {
+	case "captive-core":
+		if CaptiveCoreBinaryPath == "" || CaptiveCoreConfigPath == "" {
+			err = fmt.Errorf("--captive-core-binary-path and --captive-core-config-path are required")
+			return
+		}
+
+		var coreToml *ledgerbackend.CaptiveCoreToml
+		coreToml, err = ledgerbackend.NewCaptiveCoreTomlFromFile(
+			CaptiveCoreConfigPath,
+			ledgerbackend.CaptiveCoreTomlParams{
+				NetworkPassphrase:  networkPassphrase,
+				HistoryArchiveURLs: archiveURLs,
+			},
+		)
+		if err != nil {
+			return
+		}
+
+		// Captive core can only tell its latest ledger once a range is
+		// prepared, so the latest checkpoint in the history archive is used.
+		var archive *historyarchive.Archive
+		archive, err = historyarchive.Connect(
+			archiveURLs[0],
+			historyarchive.ArchiveOptions{NetworkPassphrase: networkPassphrase},
+		)
+		if err != nil {
+			return
+		}
+
+		var has historyarchive.HistoryArchiveState
+		has, err = archive.GetRootHAS()
+		if err != nil {
+			return
+		}
+		latestLedger = has.CurrentLedger
+
+		backend, err = ledgerbackend.NewCaptive(ledgerbackend.CaptiveCoreConfig{
+			BinaryPath:         CaptiveCoreBinaryPath,
+			NetworkPassphrase:  networkPassphrase,
+			HistoryArchiveURLs: archiveURLs,
+			Toml:               coreToml,
+			Log:                Logger.WithField("subservice", "stellar-core"),
+			Context:            ctx,
+		})
+		return
+
+	case "archive":
+		if MetaArchiveURL == "" {
+			err = fmt.Errorf("--meta-archive-url is required")
+			return
+		}
+
+		var source storage.Storage
+		source, err = storage.ConnectBackend(MetaArchiveURL, storage.ConnectOptions{Context: ctx})
+		if err != nil {
+			return
+		}
+
+		backend = ledgerbackend.NewHistoryArchiveBackend(metaarchive.NewMetaArchive(source))
+		latestLedger, err = backend.GetLatestLedgerSequence(ctx)
+		return
+
+	default:
+		err = fmt.Errorf("invalid ledger backend: %s", LedgerBackendType)
+		return
+	}
+}
+
 func getIssuers(filePath string) ([]string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -18,13 +18,21 @@ func init() {
 		"0.0.0.0:3000",
 		"Server address and port",
 	)
+
+	cmdServe.Flags().Float64Var(
+		&MinTradeSize,
+		"min-trade-size",
+		0,
+		"Default minimum value in USD of the trades included in the price stats of the REST API",
+	)
 }
 
 var cmdServe = &cobra.Command{
 	Use:   "serve",
-	Short: "Runs a GraphQL interface to get Ticker data",
+	Short: "Runs a GraphQL interface and a REST JSON API to get Ticker data",
 	Run: func(cmd *cobra.Command, args []string) {
 		Logger.Info("Starting GraphQL Server")
+		minTradeSize := requireMinTradeSize(cmd)
 		dbInfo, err := pq.ParseURL(DatabaseURL)
 		if err != nil {
 			Logger.Fatal("could not parse db-url:", err)
@@ -36,6 +44,6 @@ var cmdServe = &cobra.Command{
 		}
 		defer session.DB.Close()
 
-		ticker.StartGraphQLServer(&session, Logger, ServerAddr)
+		ticker.StartGraphQLServer(&session, Client, Logger, ServerAddr, minTradeSize)
 	},
 }
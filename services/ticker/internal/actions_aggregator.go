@@ -0,0 +1,444 @@
+package ticker
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	horizonclient "github.com/stellar/go/clients/horizonclient"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/ticker/internal/scraper"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
+	"github.com/stellar/go/support/errors"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// The CoinGecko and CoinMarketCap exchange APIs identify markets as
+// "<base>_<quote>", with the price in units of the quote asset. The ticker's
+// base asset (e.g. XLM) is used as the base, and its counter asset as the
+// quote, so the ticker's prices (in units of the base asset) are inverted.
+
+// aggregatorPriceDecimals is the number of decimal places of the prices that
+// have to be inverted.
+const aggregatorPriceDecimals = 15
+
+// maxAggregatorTrades is the maximum number of trades returned at once.
+const maxAggregatorTrades = 1000
+
+// aggregatorAsset identifies an asset of an aggregator ticker ID.
+type aggregatorAsset struct {
+	Type   string
+	Code   string
+	Issuer string
+}
+
+// aggregatorMarket holds the 24h stats of a market, in units of its quote
+// asset. Note: this struct does *not* directly map to a db entity.
+type aggregatorMarket struct {
+	TickerID           string
+	BaseID             string
+	QuoteID            string
+	LastPrice          string
+	OpenPrice          string
+	HighPrice          string
+	LowPrice           string
+	BaseVolume         string
+	QuoteVolume        string
+	HighestBid         float64
+	LowestAsk          float64
+	PriceChangePercent float64
+}
+
+// GenerateCoinGeckoPairs returns the markets traded within the last 24 hours
+// in the CoinGecko /pairs format. The price stats of the markets only include
+// the trades worth at least minTradeSize in USD.
+func GenerateCoinGeckoPairs(ctx context.Context, s *tickerdb.TickerSession, minTradeSize float64) (pairs []CoinGeckoPair, err error) {
+	markets, err := retrieveAggregatorMarkets(ctx, s, minTradeSize)
+	if err != nil {
+		return
+	}
+
+	pairs = []CoinGeckoPair{}
+	for _, m := range markets {
+		pairs = append(pairs, CoinGeckoPair{
+			TickerID: m.TickerID,
+			Base:     m.BaseID,
+			Target:   m.QuoteID,
+		})
+	}
+	return
+}
+
+// GenerateCoinGeckoTickers returns the 24h stats of the markets traded within
+// the last 24 hours in the CoinGecko /tickers format.
+func GenerateCoinGeckoTickers(ctx context.Context, s *tickerdb.TickerSession, minTradeSize float64) (tickers []CoinGeckoTicker, err error) {
+	markets, err := retrieveAggregatorMarkets(ctx, s, minTradeSize)
+	if err != nil {
+		return
+	}
+
+	tickers = []CoinGeckoTicker{}
+	for _, m := range markets {
+		tickers = append(tickers, CoinGeckoTicker{
+			TickerID:       m.TickerID,
+			BaseCurrency:   m.BaseID,
+			TargetCurrency: m.QuoteID,
+			LastPrice:      m.LastPrice,
+			BaseVolume:     m.BaseVolume,
+			TargetVolume:   m.QuoteVolume,
+			Bid:            formatFloat(m.HighestBid),
+			Ask:            formatFloat(m.LowestAsk),
+			High:           m.HighPrice,
+			Low:            m.LowPrice,
+		})
+	}
+	return
+}
+
+// GenerateCMCSummary returns the 24h stats of the markets traded within the
+// last 24 hours in the CoinMarketCap /summary format.
+func GenerateCMCSummary(ctx context.Context, s *tickerdb.TickerSession, minTradeSize float64) (summary []CMCSummary, err error) {
+	markets, err := retrieveAggregatorMarkets(ctx, s, minTradeSize)
+	if err != nil {
+		return
+	}
+
+	summary = []CMCSummary{}
+	for _, m := range markets {
+		summary = append(summary, CMCSummary{
+			TradingPairs:          m.TickerID,
+			BaseCurrency:          m.BaseID,
+			QuoteCurrency:         m.QuoteID,
+			LastPrice:             parseFloat(m.LastPrice),
+			LowestAsk:             m.LowestAsk,
+			HighestBid:            m.HighestBid,
+			BaseVolume:            parseFloat(m.BaseVolume),
+			QuoteVolume:           parseFloat(m.QuoteVolume),
+			PriceChangePercent24h: m.PriceChangePercent,
+			HighestPrice24h:       parseFloat(m.HighPrice),
+			LowestPrice24h:        parseFloat(m.LowPrice),
+		})
+	}
+	return
+}
+
+// GenerateCMCTicker returns the 24h stats of the markets traded within the
+// last 24 hours in the CoinMarketCap /ticker format.
+func GenerateCMCTicker(ctx context.Context, s *tickerdb.TickerSession, minTradeSize float64) (tickers map[string]CMCTicker, err error) {
+	markets, err := retrieveAggregatorMarkets(ctx, s, minTradeSize)
+	if err != nil {
+		return
+	}
+
+	tickers = make(map[string]CMCTicker)
+	for _, m := range markets {
+		tickers[m.TickerID] = CMCTicker{
+			BaseID:      m.BaseID,
+			QuoteID:     m.QuoteID,
+			LastPrice:   parseFloat(m.LastPrice),
+			BaseVolume:  parseFloat(m.BaseVolume),
+			QuoteVolume: parseFloat(m.QuoteVolume),
+		}
+	}
+	return
+}
+
+// GenerateCoinGeckoFiles writes the CoinGecko pairs.json and tickers.json
+// files to outDir.
+func GenerateCoinGeckoFiles(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, outDir string, minTradeSize float64) error {
+	pairs, err := GenerateCoinGeckoPairs(ctx, s, minTradeSize)
+	if err != nil {
+		return err
+	}
+	if err = writeAggregatorFile(l, pairs, filepath.Join(outDir, "pairs.json")); err != nil {
+		return err
+	}
+
+	tickers, err := GenerateCoinGeckoTickers(ctx, s, minTradeSize)
+	if err != nil {
+		return err
+	}
+	return writeAggregatorFile(l, tickers, filepath.Join(outDir, "tickers.json"))
+}
+
+// GenerateCMCFiles writes the CoinMarketCap summary.json and ticker.json
+// files to outDir.
+func GenerateCMCFiles(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, outDir string, minTradeSize float64) error {
+	summary, err := GenerateCMCSummary(ctx, s, minTradeSize)
+	if err != nil {
+		return err
+	}
+	if err = writeAggregatorFile(l, summary, filepath.Join(outDir, "summary.json")); err != nil {
+		return err
+	}
+
+	tickers, err := GenerateCMCTicker(ctx, s, minTradeSize)
+	if err != nil {
+		return err
+	}
+	return writeAggregatorFile(l, tickers, filepath.Join(outDir, "ticker.json"))
+}
+
+// FetchAggregatorOrderbook fetches the orderbook of the market with the given
+// ticker ID from Horizon, returning at most depth levels on each side (all of
+// them if depth = 0) as [price, base amount] pairs.
+func FetchAggregatorOrderbook(
+	c *horizonclient.Client,
+	l *hlog.Entry,
+	tickerID string,
+	depth int,
+) (bids [][2]string, asks [][2]string, err error) {
+	base, quote, err := parseTickerID(tickerID)
+	if err != nil {
+		return
+	}
+
+	sc := scraper.ScraperConfig{
+		Client:   c,
+		Logger:   l,
+		Settings: scraperSettings(),
+	}
+	summary, err := sc.FetchOrderbookSummary(
+		base.Type, base.Code, base.Issuer,
+		quote.Type, quote.Code, quote.Issuer,
+	)
+	if err != nil {
+		return
+	}
+
+	bids, asks = orderbookLevels(summary, depth)
+	return
+}
+
+// RetrieveAggregatorTrades returns the trades (at most limit) of the market
+// with the given ticker ID that closed within [since, until), newest first.
+func RetrieveAggregatorTrades(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	tickerID string,
+	since time.Time,
+	until time.Time,
+	limit int,
+) (trades []tickerdb.Trade, err error) {
+	base, quote, err := parseTickerID(tickerID)
+	if err != nil {
+		return
+	}
+
+	if limit <= 0 || limit > maxAggregatorTrades {
+		limit = maxAggregatorTrades
+	}
+
+	bFound, bID, err := s.GetAssetByCodeAndIssuerAccount(ctx, base.Code, base.Issuer)
+	if err != nil {
+		return
+	}
+	cFound, cID, err := s.GetAssetByCodeAndIssuerAccount(ctx, quote.Code, quote.Issuer)
+	if err != nil {
+		return
+	}
+	if !bFound || !cFound {
+		return
+	}
+
+	return s.RetrieveTrades(ctx, bID, cID, since, until, limit)
+}
+
+// dbTradeToCoinGeckoTrade converts a tickerdb.Trade to a CoinGeckoTrade.
+func dbTradeToCoinGeckoTrade(t tickerdb.Trade) CoinGeckoTrade {
+	return CoinGeckoTrade{
+		TradeID:        t.ID,
+		Price:          tradeQuotePrice(t),
+		BaseVolume:     trimDecimal(t.BaseAmount),
+		TargetVolume:   trimDecimal(t.CounterAmount),
+		TradeTimestamp: utils.TimeToUnixEpoch(t.LedgerCloseTime),
+		Type:           tradeType(t),
+	}
+}
+
+// dbTradeToCMCTrade converts a tickerdb.Trade to a CMCTrade.
+func dbTradeToCMCTrade(t tickerdb.Trade) CMCTrade {
+	return CMCTrade{
+		TradeID:     t.ID,
+		Price:       parseFloat(tradeQuotePrice(t)),
+		BaseVolume:  parseFloat(t.BaseAmount),
+		QuoteVolume: parseFloat(t.CounterAmount),
+		Timestamp:   utils.TimeToUnixEpoch(t.LedgerCloseTime),
+		Type:        tradeType(t),
+	}
+}
+
+// retrieveAggregatorMarkets retrieves the markets traded within the last 24
+// hours, converted to their aggregator representation, leaving the trades worth
+// less than minTradeSize in USD out of their price stats.
+func retrieveAggregatorMarkets(ctx context.Context, s *tickerdb.TickerSession, minTradeSize float64) (markets []aggregatorMarket, err error) {
+	dbMarkets, err := s.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, minTradeSize)
+	if err != nil {
+		return
+	}
+
+	for _, m := range dbMarkets {
+		markets = append(markets, dbPartialMarketToAggregatorMarket(m))
+	}
+	return
+}
+
+// dbPartialMarketToAggregatorMarket converts a tickerdb.PartialMarket to an
+// aggregatorMarket, inverting its prices so that they are in units of the
+// quote (i.e. counter) asset.
+func dbPartialMarketToAggregatorMarket(m tickerdb.PartialMarket) aggregatorMarket {
+	baseID := utils.GetAssetString(m.BaseAssetType, m.BaseAssetCode, m.BaseAssetIssuer)
+	quoteID := utils.GetAssetString(m.CounterAssetType, m.CounterAssetCode, m.CounterAssetIssuer)
+
+	am := aggregatorMarket{
+		TickerID:    baseID + "_" + quoteID,
+		BaseID:      baseID,
+		QuoteID:     quoteID,
+		LastPrice:   invertDecimal(m.CloseExact),
+		OpenPrice:   invertDecimal(m.OpenExact),
+		HighPrice:   invertDecimal(m.LowExact),
+		LowPrice:    invertDecimal(m.HighExact),
+		BaseVolume:  trimDecimal(m.BaseVolumeExact),
+		QuoteVolume: trimDecimal(m.CounterVolumeExact),
+		HighestBid:  m.HighestBid,
+		LowestAsk:   m.LowestAsk,
+	}
+
+	if open := parseFloat(am.OpenPrice); open != 0 {
+		am.PriceChangePercent = (parseFloat(am.LastPrice) - open) / open * 100
+	}
+	return am
+}
+
+// parseTickerID parses a ticker ID (e.g. "native_USD:GA...") into its base and
+// quote assets.
+func parseTickerID(tickerID string) (base aggregatorAsset, quote aggregatorAsset, err error) {
+	parts := strings.Split(tickerID, "_")
+	if len(parts) != 2 {
+		err = errors.Errorf("invalid ticker id %s: expected <base>_<quote>", tickerID)
+		return
+	}
+
+	if base, err = parseAggregatorAsset(parts[0]); err != nil {
+		return
+	}
+	quote, err = parseAggregatorAsset(parts[1])
+	return
+}
+
+// parseAggregatorAsset parses an asset identified as "native" or
+// "<code>:<issuer>".
+func parseAggregatorAsset(id string) (asset aggregatorAsset, err error) {
+	if id == "native" {
+		return aggregatorAsset{Type: "native", Code: "XLM", Issuer: "native"}, nil
+	}
+
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" || len(parts[0]) > 12 || parts[1] == "" {
+		err = errors.Errorf("invalid asset %s: expected native or <code>:<issuer>", id)
+		return
+	}
+
+	asset = aggregatorAsset{
+		Type:   string(horizonclient.AssetType4),
+		Code:   parts[0],
+		Issuer: parts[1],
+	}
+	if len(parts[0]) > 4 {
+		asset.Type = string(horizonclient.AssetType12)
+	}
+	return
+}
+
+// orderbookLevels converts the bids and asks of a Horizon orderbook (selling
+// the base asset for the quote asset) into [price, base amount] pairs, keeping
+// at most depth levels on each side (all of them if depth = 0).
+func orderbookLevels(summary hProtocol.OrderBookSummary, depth int) (bids [][2]string, asks [][2]string) {
+	bids = [][2]string{}
+	for _, bid := range summary.Bids {
+		if depth > 0 && len(bids) >= depth {
+			break
+		}
+		// Bid amounts are in units of the quote asset:
+		amount, ok := new(big.Rat).SetString(bid.Amount)
+		if !ok || bid.PriceR.N == 0 {
+			continue
+		}
+		amount.Mul(amount, big.NewRat(int64(bid.PriceR.D), int64(bid.PriceR.N)))
+		bids = append(bids, [2]string{bid.Price, amount.FloatString(7)})
+	}
+
+	asks = [][2]string{}
+	for _, ask := range summary.Asks {
+		if depth > 0 && len(asks) >= depth {
+			break
+		}
+		asks = append(asks, [2]string{ask.Price, ask.Amount})
+	}
+	return
+}
+
+// tradeQuotePrice returns the price of a trade in units of its counter asset.
+func tradeQuotePrice(t tickerdb.Trade) string {
+	if t.PriceN > 0 && t.PriceD > 0 {
+		return utils.RatString(big.NewRat(t.PriceD, t.PriceN), aggregatorPriceDecimals)
+	}
+	// Trades stored before the price was tracked as a rational:
+	return invertDecimal(t.Price)
+}
+
+// tradeType returns whether the base asset was bought or sold in a trade.
+func tradeType(t tickerdb.Trade) string {
+	if t.BaseIsSeller {
+		return "sell"
+	}
+	return "buy"
+}
+
+// invertDecimal returns 1 / d, or "0" if d is zero or invalid.
+func invertDecimal(d string) string {
+	r, ok := new(big.Rat).SetString(d)
+	if !ok || r.Sign() == 0 {
+		return "0"
+	}
+	return utils.RatString(r.Inv(r), aggregatorPriceDecimals)
+}
+
+// trimDecimal removes the trailing zeros of a decimal string.
+func trimDecimal(d string) string {
+	r, ok := new(big.Rat).SetString(d)
+	if !ok {
+		return "0"
+	}
+	return utils.RatString(r, aggregatorPriceDecimals)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// writeAggregatorFile writes the JSON representation of data to filename.
+func writeAggregatorFile(l *hlog.Entry, data interface{}, filename string) error {
+	jsonData, err := json.MarshalIndent(data, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	l.Info("Writing aggregator data to: ", filename)
+	numBytes, err := utils.WriteJSONToFile(jsonData, filename)
+	if err != nil {
+		return err
+	}
+	l.Infof("Wrote %d bytes to %s\n", numBytes, filename)
+	return nil
+}
@@ -0,0 +1,156 @@
+package ticker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	hlog "github.com/stellar/go/support/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aggregatorTestIssuer = "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB"
+
+func TestParseTickerID(t *testing.T) {
+	base, quote, err := parseTickerID("native_BTC:" + aggregatorTestIssuer)
+	require.NoError(t, err)
+	assert.Equal(t, aggregatorAsset{Type: "native", Code: "XLM", Issuer: "native"}, base)
+	assert.Equal(t, aggregatorAsset{Type: "credit_alphanum4", Code: "BTC", Issuer: aggregatorTestIssuer}, quote)
+
+	base, _, err = parseTickerID("LONGCODE:" + aggregatorTestIssuer + "_native")
+	require.NoError(t, err)
+	assert.Equal(t, "credit_alphanum12", base.Type)
+
+	for _, tickerID := range []string{
+		"",
+		"native",
+		"XLM_BTC",
+		"native_BTC",
+		"native_:" + aggregatorTestIssuer,
+		"native_WAYTOOLONGCODE:" + aggregatorTestIssuer,
+		"native_BTC:" + aggregatorTestIssuer + "_native",
+	} {
+		_, _, err = parseTickerID(tickerID)
+		assert.Error(t, err, tickerID)
+	}
+}
+
+func TestDBPartialMarketToAggregatorMarket(t *testing.T) {
+	m := dbPartialMarketToAggregatorMarket(tickerdb.PartialMarket{
+		BaseAssetType:      "native",
+		BaseAssetCode:      "XLM",
+		BaseAssetIssuer:    "native",
+		CounterAssetType:   "credit_alphanum4",
+		CounterAssetCode:   "BTC",
+		CounterAssetIssuer: aggregatorTestIssuer,
+		BaseVolumeExact:    "1500.5000000",
+		CounterVolumeExact: "0.0150050",
+		OpenExact:          "100000",
+		CloseExact:         "80000",
+		HighExact:          "125000",
+		LowExact:           "50000",
+		HighestBid:         0.0000125,
+		LowestAsk:          0.0000126,
+	})
+
+	assert.Equal(t, "native_BTC:"+aggregatorTestIssuer, m.TickerID)
+	assert.Equal(t, "native", m.BaseID)
+	assert.Equal(t, "BTC:"+aggregatorTestIssuer, m.QuoteID)
+	assert.Equal(t, "0.0000125", m.LastPrice)
+	assert.Equal(t, "0.00001", m.OpenPrice)
+	assert.Equal(t, "0.00002", m.HighPrice)
+	assert.Equal(t, "0.000008", m.LowPrice)
+	assert.Equal(t, "1500.5", m.BaseVolume)
+	assert.Equal(t, "0.015005", m.QuoteVolume)
+	assert.InDelta(t, 25.0, m.PriceChangePercent, 1e-9)
+
+	// Markets without prices must not produce infinite values:
+	m = dbPartialMarketToAggregatorMarket(tickerdb.PartialMarket{CloseExact: "0"})
+	assert.Equal(t, "0", m.LastPrice)
+	assert.Equal(t, "0", m.OpenPrice)
+	assert.Equal(t, 0.0, m.PriceChangePercent)
+}
+
+func TestOrderbookLevels(t *testing.T) {
+	summary := hProtocol.OrderBookSummary{
+		Bids: []hProtocol.PriceLevel{
+			{PriceR: hProtocol.Price{N: 1, D: 2}, Price: "0.5000000", Amount: "10.0000000"},
+			{PriceR: hProtocol.Price{N: 1, D: 4}, Price: "0.2500000", Amount: "1.0000000"},
+		},
+		Asks: []hProtocol.PriceLevel{
+			{PriceR: hProtocol.Price{N: 1, D: 1}, Price: "1.0000000", Amount: "3.0000000"},
+			{PriceR: hProtocol.Price{N: 2, D: 1}, Price: "2.0000000", Amount: "4.0000000"},
+		},
+	}
+
+	// Bid amounts are converted to units of the base asset:
+	bids, asks := orderbookLevels(summary, 0)
+	assert.Equal(t, [][2]string{{"0.5000000", "20.0000000"}, {"0.2500000", "4.0000000"}}, bids)
+	assert.Equal(t, [][2]string{{"1.0000000", "3.0000000"}, {"2.0000000", "4.0000000"}}, asks)
+
+	bids, asks = orderbookLevels(summary, 1)
+	assert.Equal(t, [][2]string{{"0.5000000", "20.0000000"}}, bids)
+	assert.Equal(t, [][2]string{{"1.0000000", "3.0000000"}}, asks)
+
+	bids, asks = orderbookLevels(hProtocol.OrderBookSummary{}, 0)
+	assert.Equal(t, [][2]string{}, bids)
+	assert.Equal(t, [][2]string{}, asks)
+}
+
+func TestDBTradeToAggregatorTrades(t *testing.T) {
+	closeTime := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	trade := tickerdb.Trade{
+		ID:              42,
+		BaseAmount:      "250.0000000",
+		CounterAmount:   "0.0025000",
+		Price:           "100000",
+		PriceN:          100000,
+		PriceD:          1,
+		BaseIsSeller:    true,
+		LedgerCloseTime: closeTime,
+	}
+
+	cgTrade := dbTradeToCoinGeckoTrade(trade)
+	assert.Equal(t, CoinGeckoTrade{
+		TradeID:        42,
+		Price:          "0.00001",
+		BaseVolume:     "250",
+		TargetVolume:   "0.0025",
+		TradeTimestamp: closeTime.UnixNano() / int64(time.Millisecond),
+		Type:           "sell",
+	}, cgTrade)
+
+	// Trades stored without PriceN / PriceD invert the decimal price:
+	trade.PriceN, trade.PriceD = 0, 0
+	trade.BaseIsSeller = false
+	cmcTrade := dbTradeToCMCTrade(trade)
+	assert.Equal(t, int64(42), cmcTrade.TradeID)
+	assert.Equal(t, 0.00001, cmcTrade.Price)
+	assert.Equal(t, 250.0, cmcTrade.BaseVolume)
+	assert.Equal(t, 0.0025, cmcTrade.QuoteVolume)
+	assert.Equal(t, "buy", cmcTrade.Type)
+}
+
+func TestAggregatorRESTInvalidRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	AddRESTRoutes(mux, nil, nil, hlog.New(), 0)
+
+	for _, path := range []string{
+		"/coingecko/orderbook?ticker_id=XLM_BTC",
+		"/coingecko/orderbook?ticker_id=native_BTC:" + aggregatorTestIssuer + "&depth=-1",
+		"/coingecko/historical_trades?ticker_id=native_BTC:" + aggregatorTestIssuer + "&type=both",
+		"/coingecko/historical_trades?ticker_id=native_BTC:" + aggregatorTestIssuer + "&limit=abc",
+		"/coingecko/historical_trades?ticker_id=native",
+		"/cmc/orderbook/XLM_BTC",
+		"/cmc/trades/native",
+	} {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, path)
+		assert.Contains(t, rr.Body.String(), `"error"`, path)
+	}
+}
@@ -3,6 +3,7 @@ package ticker
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,12 +17,18 @@ import (
 
 // RefreshAssets scrapes the most recent asset list and ingests then into the db.
 func RefreshAssets(ctx context.Context, s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry) (err error) {
+	settings := scraperSettings()
 	sc := scraper.ScraperConfig{
-		Client: c,
-		Logger: l,
+		Client:    c,
+		Logger:    l,
+		Ctx:       &ctx,
+		Settings:  settings,
+		TOMLCache: scraper.NewTOMLCache(settings.TOMLCacheTTL, s),
 	}
+	var evaluations assetEvaluations
+	sc.OnEvaluated = evaluations.add
 	var wg sync.WaitGroup
-	parallelism := 20
+	parallelism := sc.Settings.Parallelism
 	assetQueue := make(chan scraper.FinalAsset, parallelism)
 
 	go sc.ProcessAllAssets(0, parallelism, assetQueue)
@@ -47,22 +54,32 @@ func RefreshAssets(ctx context.Context, s *tickerdb.TickerSession, c *horizoncli
 				l.Error("Error inserting asset:", dbAsset, err)
 				continue
 			}
+			saveAssetSnapshot(ctx, s, l, finalAsset)
 			count += 1
 			l.Debugf("Assets added -- count: %d - issuer: %d, asset: %s", count, issuerID, dbAsset.Code)
 		}
 	}()
 	wg.Wait()
+
+	evaluations.save(ctx, s, l)
+	replayPendingTrades(ctx, s, l)
 	return
 }
 
 // RefreshFilteredAssets scrapes the most recent asset list and ingests then into the db.
 func RefreshFilteredAssets(ctx context.Context, s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry, issuer string) (err error) {
+	settings := scraperSettings()
 	sc := scraper.ScraperConfig{
-		Client: c,
-		Logger: l,
+		Client:    c,
+		Logger:    l,
+		Ctx:       &ctx,
+		Settings:  settings,
+		TOMLCache: scraper.NewTOMLCache(settings.TOMLCacheTTL, s),
 	}
+	var evaluations assetEvaluations
+	sc.OnEvaluated = evaluations.add
 	var wg sync.WaitGroup
-	parallelism := 20
+	parallelism := sc.Settings.Parallelism
 	assetQueue := make(chan scraper.FinalAsset, parallelism)
 
 	// retrieve asset filtered by issuer from the Horizon API.
@@ -90,14 +107,100 @@ func RefreshFilteredAssets(ctx context.Context, s *tickerdb.TickerSession, c *ho
 				l.Error("Error inserting asset:", dbAsset, err)
 				continue
 			}
+			saveAssetSnapshot(ctx, s, l, finalAsset)
 			count += 1
 			l.Debugf("Assets added -- count: %d - issuer: %d, asset: %s", count, issuerID, dbAsset.Code)
 		}
 	}()
 	wg.Wait()
+
+	evaluations.save(ctx, s, l)
+	replayPendingTrades(ctx, s, l)
 	return
 }
 
+// ExplainAsset evaluates the asset with the given code and issuer against the
+// asset policy, with its current stats on Horizon.
+func ExplainAsset(c *horizonclient.Client, l *hlog.Entry, code, issuer string) (tickerdb.AssetEvaluation, error) {
+	sc := scraper.ScraperConfig{
+		Client:   c,
+		Logger:   l,
+		Settings: scraperSettings(),
+	}
+	asset, result, err := sc.ExplainAsset(code, issuer)
+	if err != nil {
+		return tickerdb.AssetEvaluation{}, err
+	}
+	return newAssetEvaluation(asset, result, time.Now()), nil
+}
+
+// assetEvaluations collects the decisions of the asset policy during an asset
+// refresh.
+type assetEvaluations struct {
+	mu          sync.Mutex
+	evaluations []tickerdb.AssetEvaluation
+}
+
+// add records the decision of the asset policy about asset.
+func (e *assetEvaluations) add(asset scraper.PolicyAsset, result scraper.PolicyResult) {
+	evaluation := newAssetEvaluation(asset, result, time.Now())
+	e.mu.Lock()
+	e.evaluations = append(e.evaluations, evaluation)
+	e.mu.Unlock()
+}
+
+// save stores the recorded decisions in the database.
+func (e *assetEvaluations) save(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := s.BulkUpsertAssetEvaluations(ctx, e.evaluations); err != nil {
+		l.Error("Could not store asset evaluations:", err)
+		return
+	}
+	l.Infof("Stored %d asset evaluations\n", len(e.evaluations))
+}
+
+// newAssetEvaluation converts a decision of the asset policy to a
+// tickerdb.AssetEvaluation.
+func newAssetEvaluation(asset scraper.PolicyAsset, result scraper.PolicyResult, evaluatedAt time.Time) tickerdb.AssetEvaluation {
+	amount := asset.Stat.Amount
+	if _, err := strconv.ParseFloat(amount, 64); err != nil {
+		amount = "0"
+	}
+
+	findings := asset.TOMLFindings
+	if findings == nil {
+		findings = []scraper.TOMLFinding{}
+	}
+	// encoding a slice of plain structs can't fail
+	jsonFindings, _ := json.Marshal(findings)
+
+	return tickerdb.AssetEvaluation{
+		Code:          asset.Stat.Asset.Code,
+		IssuerAccount: asset.Stat.Asset.Issuer,
+		Listed:        result.Listed,
+		Rule:          result.Rule,
+		Reason:        result.Reason,
+		NumAccounts:   asset.Stat.NumAccounts,
+		Amount:        amount,
+		TOMLURL:       asset.Stat.Links.Toml.Href,
+		EvaluatedAt:   evaluatedAt,
+		HomeDomain:    asset.HomeDomain,
+		TOMLFindings:  string(jsonFindings),
+	}
+}
+
+// replayPendingTrades moves the pending trades whose assets were just added
+// to the database into the trades table.
+func replayPendingTrades(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry) {
+	numTrades, err := scraper.ReplayPendingTrades(ctx, s, l)
+	if err != nil {
+		l.Error("Could not replay pending trades:", err)
+		return
+	}
+	l.Infof("Replayed %d pending trades\n", numTrades)
+}
+
 // GenerateAssetsFile generates a file with the info about all valid scraped Assets
 func GenerateAssetsFile(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, filename string) error {
 	l.Info("Retrieving asset data from db...")
@@ -107,8 +210,20 @@ func GenerateAssetsFile(ctx context.Context, s *tickerdb.TickerSession, l *hlog.
 		return err
 	}
 
+	prices, err := retrieveAssetPrices(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := s.RetrieveLatestAssetSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+
 	for _, dbAsset := range validAssets {
 		asset := dbAssetToAsset(dbAsset)
+		setAssetPrices(&asset, prices[dbAsset.ID])
+		setAssetSupply(&asset, snapshots, dbAsset.ID)
 		assets = append(assets, asset)
 	}
 	l.Info("Asset data successfully retrieved! Writing to: ", filename)
@@ -140,6 +255,62 @@ func writeAssetSummaryToFile(assetSummary AssetSummary, filename string) (numByt
 	return
 }
 
+// saveAssetSnapshot appends the supply breakdown of a refreshed asset to the
+// asset snapshots, if it changed since the previous refresh.
+func saveAssetSnapshot(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, asset scraper.FinalAsset) {
+	snapshot := supplyBreakdownToDBAssetSnapshot(asset.Supply, asset.LastChecked)
+	if _, err := s.InsertAssetSnapshot(ctx, asset.Code, asset.Issuer, &snapshot); err != nil {
+		l.Error("Error inserting asset snapshot:", asset.Code, asset.Issuer, err)
+	}
+}
+
+// supplyBreakdownToDBAssetSnapshot converts a scraper.SupplyBreakdown to a
+// tickerdb.AssetSnapshot, whose AssetID is set when it's inserted.
+func supplyBreakdownToDBAssetSnapshot(b scraper.SupplyBreakdown, createdAt time.Time) tickerdb.AssetSnapshot {
+	return tickerdb.AssetSnapshot{
+		NumAccounts:                        b.NumAccounts,
+		NumAuthorizedToMaintainLiabilities: b.NumAuthorizedToMaintainLiabilities,
+		NumUnauthorized:                    b.NumUnauthorized,
+		NumClaimableBalances:               b.NumClaimableBalances,
+		NumLiquidityPools:                  b.NumLiquidityPools,
+		NumContracts:                       b.NumContracts,
+		Authorized:                         b.Authorized,
+		AuthorizedToMaintainLiabilities:    b.AuthorizedToMaintainLiabilities,
+		Unauthorized:                       b.Unauthorized,
+		ClaimableBalances:                  b.ClaimableBalances,
+		LiquidityPools:                     b.LiquidityPools,
+		Contracts:                          b.Contracts,
+		ArchivedContracts:                  b.ArchivedContracts,
+		CreatedAt:                          createdAt,
+	}
+}
+
+// setAssetSupply sets the supply of an asset from its latest snapshot, if
+// any.
+func setAssetSupply(a *Asset, snapshots map[int32]tickerdb.AssetSnapshot, assetID int32) {
+	snapshot, ok := snapshots[assetID]
+	if !ok {
+		return
+	}
+
+	a.Supply = &AssetSupply{
+		Total:                           snapshot.TotalSupply(),
+		Circulating:                     snapshot.CirculatingSupply(),
+		Locked:                          snapshot.LockedSupply(),
+		Authorized:                      parseFloat(snapshot.Authorized),
+		AuthorizedToMaintainLiabilities: parseFloat(snapshot.AuthorizedToMaintainLiabilities),
+		Unauthorized:                    parseFloat(snapshot.Unauthorized),
+		ClaimableBalances:               parseFloat(snapshot.ClaimableBalances),
+		LiquidityPools:                  parseFloat(snapshot.LiquidityPools),
+		Contracts:                       parseFloat(snapshot.Contracts),
+		ArchivedContracts:               parseFloat(snapshot.ArchivedContracts),
+		NumClaimableBalances:            snapshot.NumClaimableBalances,
+		NumLiquidityPools:               snapshot.NumLiquidityPools,
+		NumContracts:                    snapshot.NumContracts,
+		UpdatedAt:                       utils.TimeToRFC3339(snapshot.CreatedAt),
+	}
+}
+
 // finalAssetToDBAsset converts a scraper.TOMLAsset to a tickerdb.Asset.
 func finalAssetToDBAsset(asset scraper.FinalAsset, issuerID int32) tickerdb.Asset {
 	return tickerdb.Asset{
@@ -171,6 +342,9 @@ func finalAssetToDBAsset(asset scraper.FinalAsset, issuerID int32) tickerdb.Asse
 		CollateralAddressSignatures: strings.Join(asset.CollateralAddressSignatures, ","),
 		Countries:                   asset.Countries,
 		Status:                      asset.Status,
+		ContractID:                  asset.ContractID,
+		NumContracts:                asset.NumContracts,
+		ContractsAmount:             asset.ContractsAmount,
 	}
 }
 
@@ -210,20 +384,11 @@ func dbAssetToAsset(dbAsset tickerdb.Asset) (a Asset) {
 	a.CollateralAddressSignatures = collAddrSigns
 	a.Countries = dbAsset.Countries
 	a.Status = dbAsset.Status
+	a.ContractID = dbAsset.ContractID
+	a.NumContracts = dbAsset.NumContracts
+	a.ContractsAmount = dbAsset.ContractsAmount
 
-	i := Issuer{
-		PublicKey:        dbAsset.Issuer.PublicKey,
-		Name:             dbAsset.Issuer.Name,
-		URL:              dbAsset.Issuer.URL,
-		TOMLURL:          dbAsset.Issuer.TOMLURL,
-		FederationServer: dbAsset.Issuer.FederationServer,
-		AuthServer:       dbAsset.Issuer.AuthServer,
-		TransferServer:   dbAsset.Issuer.TransferServer,
-		WebAuthEndpoint:  dbAsset.Issuer.WebAuthEndpoint,
-		DepositServer:    dbAsset.Issuer.DepositServer,
-		OrgTwitter:       dbAsset.Issuer.OrgTwitter,
-	}
-	a.IssuerDetail = i
+	a.IssuerDetail = dbIssuerToIssuer(dbAsset.Issuer)
 
 	return
 }
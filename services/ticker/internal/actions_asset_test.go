@@ -0,0 +1,38 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAssetSupply(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := map[int32]tickerdb.AssetSnapshot{
+		1: {
+			AssetID:              1,
+			Authorized:           "1000.0000000",
+			Unauthorized:         "10.0000000",
+			ClaimableBalances:    "40.0000000",
+			LiquidityPools:       "200.0000000",
+			Contracts:            "50.0000000",
+			NumClaimableBalances: 3,
+			CreatedAt:            createdAt,
+		},
+	}
+
+	var asset Asset
+	setAssetSupply(&asset, snapshots, 2)
+	assert.Nil(t, asset.Supply)
+
+	setAssetSupply(&asset, snapshots, 1)
+	require.NotNil(t, asset.Supply)
+	assert.Equal(t, 1300.0, asset.Supply.Total)
+	assert.Equal(t, 1250.0, asset.Supply.Circulating)
+	assert.Equal(t, 50.0, asset.Supply.Locked)
+	assert.Equal(t, int32(3), asset.Supply.NumClaimableBalances)
+	assert.Equal(t, "2026-10-01T10:00:00Z", asset.Supply.UpdatedAt)
+}
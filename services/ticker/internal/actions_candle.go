@@ -0,0 +1,83 @@
+package ticker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// GenerateCandleSummaryFile generates a CandleSummary with the candles of the given
+// resolution for all valid markets in the past numHours and outputs it to <filename>.
+func GenerateCandleSummaryFile(s *tickerdb.TickerSession, l *hlog.Entry, filename string, resolution string, numHours int) error {
+	l.Info("Generating candle data...")
+	candleSummary, err := GenerateCandleSummary(s, resolution, numHours)
+	if err != nil {
+		return err
+	}
+	l.Info("Candle data successfully generated!")
+
+	jsonCandles, err := json.MarshalIndent(candleSummary, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	l.Info("Writing candle data to: ", filename)
+	numBytes, err := utils.WriteJSONToFile(jsonCandles, filename)
+	if err != nil {
+		return err
+	}
+	l.Infof("Wrote %d bytes to %s\n", numBytes, filename)
+	return nil
+}
+
+// GenerateCandleSummary outputs a CandleSummary with the candles of the given
+// resolution for all valid markets in the past numHours.
+func GenerateCandleSummary(s *tickerdb.TickerSession, resolution string, numHours int) (cs CandleSummary, err error) {
+	now := time.Now()
+	since := now.Add(time.Hour * -time.Duration(numHours))
+	ctx := context.Background()
+
+	dbCandles, err := s.RetrieveCandles(ctx, nil, resolution, since, now)
+	if err != nil {
+		return
+	}
+
+	cs = CandleSummary{
+		GeneratedAt:        utils.TimeToUnixEpoch(now),
+		GeneratedAtRFC3339: utils.TimeToRFC3339(now),
+		Resolution:         resolution,
+		Pairs:              groupCandlesByPair(dbCandles),
+	}
+	return
+}
+
+// groupCandlesByPair groups a slice of tickerdb.PairCandle (sorted by trade
+// pair name) into one PairCandles entry per trade pair.
+func groupCandlesByPair(dbCandles []tickerdb.PairCandle) (pairs []PairCandles) {
+	for _, dbCandle := range dbCandles {
+		if len(pairs) == 0 || pairs[len(pairs)-1].TradePairName != dbCandle.TradePairName {
+			pairs = append(pairs, PairCandles{TradePairName: dbCandle.TradePairName})
+		}
+
+		last := &pairs[len(pairs)-1]
+		last.Candles = append(last.Candles, dbCandleToCandle(dbCandle))
+	}
+	return
+}
+
+func dbCandleToCandle(c tickerdb.PairCandle) Candle {
+	return Candle{
+		OpenTime:      utils.TimeToUnixEpoch(c.OpenTime),
+		Open:          c.Open,
+		High:          c.High,
+		Low:           c.Low,
+		Close:         c.Close,
+		BaseVolume:    c.BaseVolume,
+		CounterVolume: c.CounterVolume,
+		TradeCount:    c.TradeCount,
+	}
+}
@@ -0,0 +1,34 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupCandlesByPair(t *testing.T) {
+	t0 := time.Unix(1556828400, 0)
+	t1 := t0.Add(time.Hour)
+	dbCandles := []tickerdb.PairCandle{
+		{TradePairName: "BTC_ETH", OpenTime: t0, Close: 1.0},
+		{TradePairName: "XLM_BTC", OpenTime: t0, Close: 2.0},
+		{TradePairName: "XLM_BTC", OpenTime: t1, Close: 3.0},
+	}
+
+	pairs := groupCandlesByPair(dbCandles)
+	require.Len(t, pairs, 2)
+
+	assert.Equal(t, "BTC_ETH", pairs[0].TradePairName)
+	require.Len(t, pairs[0].Candles, 1)
+	assert.Equal(t, 1.0, pairs[0].Candles[0].Close)
+
+	assert.Equal(t, "XLM_BTC", pairs[1].TradePairName)
+	require.Len(t, pairs[1].Candles, 2)
+	assert.Equal(t, int64(1556828400000), pairs[1].Candles[0].OpenTime)
+	assert.Equal(t, 3.0, pairs[1].Candles[1].Close)
+
+	assert.Empty(t, groupCandlesByPair(nil))
+}
@@ -0,0 +1,42 @@
+package ticker
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/errors"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// CleanDatabase deletes the trades (and pending trades) that closed before
+// tradesMinDate, the orderbook snapshots taken before orderbooksMinDate and
+// the asset snapshots taken before assetSnapshotsMinDate (except the latest
+// one of each asset), so that the database doesn't keep growing. A zero date
+// keeps every entry of the corresponding tables.
+func CleanDatabase(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, tradesMinDate, orderbooksMinDate, assetSnapshotsMinDate time.Time) error {
+	if !tradesMinDate.IsZero() {
+		l.Infof("Deleting trade entries older than %s", tradesMinDate.Format(time.RFC3339))
+		if err := s.DeleteOldTrades(ctx, tradesMinDate); err != nil {
+			return errors.Wrap(err, "could not delete trade entries")
+		}
+		if err := s.DeleteOldPendingTrades(ctx, tradesMinDate); err != nil {
+			return errors.Wrap(err, "could not delete pending trade entries")
+		}
+	}
+
+	if !orderbooksMinDate.IsZero() {
+		l.Infof("Deleting orderbook snapshots older than %s", orderbooksMinDate.Format(time.RFC3339))
+		if err := s.DeleteOldOrderbookSnapshots(ctx, orderbooksMinDate); err != nil {
+			return errors.Wrap(err, "could not delete orderbook snapshots")
+		}
+	}
+
+	if !assetSnapshotsMinDate.IsZero() {
+		l.Infof("Deleting asset snapshots older than %s", assetSnapshotsMinDate.Format(time.RFC3339))
+		if err := s.DeleteOldAssetSnapshots(ctx, assetSnapshotsMinDate); err != nil {
+			return errors.Wrap(err, "could not delete asset snapshots")
+		}
+	}
+	return nil
+}
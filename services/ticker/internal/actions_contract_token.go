@@ -0,0 +1,121 @@
+package ticker
+
+import (
+	"context"
+
+	"github.com/stellar/go/ingest/ledgerbackend"
+	"github.com/stellar/go/services/ticker/internal/scraper"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// ContractTokensJob identifies the cursor (i.e. the last processed ledger) of
+// the ingestion of Soroban tokens in the ingest_state table.
+const ContractTokensJob = "contract_tokens"
+
+// contractTokensBatchSize is the number of ledgers whose token events are
+// accumulated before persisting them when ingesting a bounded range of
+// ledgers.
+const contractTokensBatchSize = 1000
+
+// ContractTokensStart returns the first ledger to ingest in order to track
+// the Soroban tokens: the one right after the last ingested ledger, or
+// latestLedger if none was ingested yet. Since supplies and balances are
+// tracked from the token events, they are only complete for the tokens
+// created after the first ingested ledger.
+func ContractTokensStart(ctx context.Context, s *tickerdb.TickerSession, latestLedger uint32) uint32 {
+	cursor, err := s.GetIngestCursor(ctx, ContractTokensJob)
+	if err != nil || cursor == "" {
+		return latestLedger
+	}
+
+	cursorLedger, err := scraper.LedgerFromTradeID(cursor)
+	if err != nil {
+		return latestLedger
+	}
+	return cursorLedger + 1
+}
+
+// IngestContractTokens tracks the supply and holders of the Soroban tokens
+// which aren't Stellar Asset Contracts from the transfer, mint, burn and
+// clawback events of the ledgers within [from, to], as provided by backend.
+// The tokens are added to the assets table, and listed along with the classic
+// assets if they pass the asset policy. If to = 0, it keeps ingesting new
+// ledgers as they close until ctx is done.
+func IngestContractTokens(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	backend ledgerbackend.LedgerBackend,
+	l *hlog.Entry,
+	networkPassphrase string,
+	from uint32,
+	to uint32,
+) error {
+	ledgerRange := ledgerbackend.UnboundedRange(from)
+	if to != 0 {
+		ledgerRange = ledgerbackend.BoundedRange(from, to)
+	}
+
+	l.Infof("Preparing ledger range %s\n", ledgerRange)
+	if err := backend.PrepareRange(ctx, ledgerRange); err != nil {
+		return err
+	}
+
+	var changes []scraper.ContractTokenChanges
+	for seq := from; to == 0 || seq <= to; seq++ {
+		ledgerChanges, err := scraper.ExtractContractTokenChanges(ctx, backend, networkPassphrase, seq)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, ledgerChanges)
+
+		// When streaming, changes are persisted as soon as their ledger closes:
+		if to == 0 || seq == to || len(changes) >= contractTokensBatchSize {
+			updates := scraper.ContractTokenUpdates(changes)
+			if len(updates) > 0 {
+				l.Infof("Updating %d tokens up to ledger %d\n", len(updates), seq)
+			}
+			err = s.ApplyContractTokenUpdates(ctx, updates, ContractTokensJob, scraper.LedgerCursor(seq))
+			if err != nil {
+				return err
+			}
+			if err = evaluateContractTokens(ctx, s, l, updates); err != nil {
+				return err
+			}
+			changes = nil
+		}
+	}
+
+	return nil
+}
+
+// evaluateContractTokens evaluates the tokens of updates against the asset
+// policy and lists (or unlists) them accordingly, storing each decision in
+// the asset evaluations like the asset refreshes do.
+func evaluateContractTokens(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, updates []tickerdb.ContractTokenUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	sc := scraper.ScraperConfig{
+		Logger:   l,
+		Ctx:      &ctx,
+		Settings: scraperSettings(),
+	}
+	var evaluations assetEvaluations
+	for _, update := range updates {
+		token, err := s.GetContractToken(ctx, update.ContractID)
+		if err != nil {
+			return err
+		}
+
+		asset, result := sc.EvaluateContractToken(token)
+		evaluations.add(asset, result)
+		if err = s.SetContractTokenListing(ctx, token.ContractID, result.Listed, result.Reason); err != nil {
+			return err
+		}
+	}
+
+	evaluations.save(ctx, s, l)
+	return nil
+}
@@ -1,13 +1,36 @@
 package ticker
 
 import (
+	"net/http"
+	"time"
+
+	horizonclient "github.com/stellar/go/clients/horizonclient"
 	"github.com/stellar/go/services/ticker/internal/gql"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
 	hlog "github.com/stellar/go/support/log"
 )
 
-func StartGraphQLServer(s *tickerdb.TickerSession, l *hlog.Entry, port string) {
-	graphql := gql.New(s, l)
+// StartGraphQLServer serves the GraphQL interface along with the REST JSON
+// API on <address>. Orderbooks are fetched live from Horizon through c, and
+// the price stats of the REST API only include the trades worth at least
+// minTradeSize in USD by default.
+func StartGraphQLServer(s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry, address string, minTradeSize float64) {
+	server := &http.Server{
+		Addr:        address,
+		Handler:     NewServeMux(s, c, l, minTradeSize),
+		ReadTimeout: 5 * time.Second,
+	}
+	l.Infof("Starting to serve on address %s\n", address)
+
+	if err := server.ListenAndServe(); err != nil {
+		l.Error("server.ListenAndServe:", err)
+	}
+}
 
-	graphql.Serve(port)
+// NewServeMux returns the routes served by StartGraphQLServer (GraphQL, the
+// REST JSON API and the metrics), so that other routes can be added to them.
+func NewServeMux(s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry, minTradeSize float64) *http.ServeMux {
+	mux := gql.New(s, l).NewServeMux()
+	AddRESTRoutes(mux, s, c, l, minTradeSize)
+	return mux
 }
@@ -0,0 +1,74 @@
+package ticker
+
+import (
+	"context"
+	"time"
+
+	horizonclient "github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/services/ticker/internal/scraper"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/errors"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// RefreshLiquidityPools updates the reserves, implied price and TVL of the
+// liquidity pools between known assets, along with their fee income over the
+// last 24 hours.
+func RefreshLiquidityPools(ctx context.Context, s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry) error {
+	sc := scraper.ScraperConfig{
+		Client:   c,
+		Logger:   l,
+		Settings: scraperSettings(),
+	}
+
+	pools, err := sc.FetchLiquidityPools()
+	if err != nil {
+		return err
+	}
+
+	numPools := 0
+	for _, pool := range pools {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		bFound, bID, err := s.GetAssetByCodeAndIssuerAccount(ctx, pool.BaseAssetCode, pool.BaseAssetIssuer)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve base asset")
+		}
+		cFound, cID, err := s.GetAssetByCodeAndIssuerAccount(ctx, pool.CounterAssetCode, pool.CounterAssetIssuer)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve counter asset")
+		}
+		if !bFound || !cFound {
+			continue
+		}
+
+		dbPool := liquidityPoolStatsToDBLiquidityPool(pool, bID, cID)
+		err = s.InsertOrUpdateLiquidityPool(ctx, &dbPool, []string{"pool_id", "fee_income_24h"})
+		if err != nil {
+			l.Error(errors.Wrap(err, "could not insert liquidity pool into db"))
+			continue
+		}
+		numPools++
+	}
+	l.Infof("Refreshed %d liquidity pools\n", numPools)
+
+	return s.UpdateLiquidityPoolFeeIncome(ctx)
+}
+
+func liquidityPoolStatsToDBLiquidityPool(p scraper.LiquidityPoolStats, bID, cID int32) tickerdb.LiquidityPool {
+	return tickerdb.LiquidityPool{
+		PoolID:          p.PoolID,
+		BaseAssetID:     bID,
+		CounterAssetID:  cID,
+		FeeBP:           int32(p.FeeBP),
+		TotalTrustlines: int64(p.TotalTrustlines),
+		TotalShares:     p.TotalShares,
+		BaseReserve:     p.BaseReserve,
+		CounterReserve:  p.CounterReserve,
+		Price:           p.Price,
+		TVL:             p.TVL,
+		UpdatedAt:       time.Now(),
+	}
+}
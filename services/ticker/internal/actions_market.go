@@ -11,10 +11,11 @@ import (
 )
 
 // GenerateMarketSummaryFile generates a MarketSummary with the statistics for all
-// valid markets within the database and outputs it to <filename>.
-func GenerateMarketSummaryFile(s *tickerdb.TickerSession, l *hlog.Entry, filename string) error {
+// valid markets within the database and outputs it to <filename>. Additional
+// statistics are generated for each of the provided trailing windows.
+func GenerateMarketSummaryFile(s *tickerdb.TickerSession, l *hlog.Entry, filename string, windows []string, aggregateByCode bool, minTradeSize float64) error {
 	l.Info("Generating market data...")
-	marketSummary, err := GenerateMarketSummary(s)
+	marketSummary, err := GenerateMarketSummary(s, windows, tickerdb.MarketFilter{}, aggregateByCode, minTradeSize)
 	if err != nil {
 		return err
 	}
@@ -35,15 +36,24 @@ func GenerateMarketSummaryFile(s *tickerdb.TickerSession, l *hlog.Entry, filenam
 }
 
 // GenerateMarketSummary outputs a MarketSummary with the statistics for all
-// valid markets within the database.
-func GenerateMarketSummary(s *tickerdb.TickerSession) (ms MarketSummary, err error) {
+// valid markets within the database matching filter. For each of the provided trailing windows
+// (e.g. "1h" or "30d"), a stats block is added to the Windows of every market.
+// Markets are identified by their canonical IDs, unless aggregateByCode is set,
+// in which case the markets whose assets share the same codes are merged. The
+// price stats only include the trades worth at least minTradeSize in USD.
+func GenerateMarketSummary(s *tickerdb.TickerSession, windows []string, filter tickerdb.MarketFilter, aggregateByCode bool, minTradeSize float64) (ms MarketSummary, err error) {
 	var marketStatsSlice []MarketStats
 	now := time.Now()
 	nowMillis := utils.TimeToUnixEpoch(now)
 	nowRFC339 := utils.TimeToRFC3339(now)
 	ctx := context.Background()
 
-	dbMarkets, err := s.RetrieveMarketData(ctx)
+	dbMarkets, err := s.RetrieveMarketData(ctx, filter, aggregateByCode, minTradeSize)
+	if err != nil {
+		return
+	}
+
+	dbWindows, err := s.RetrieveMarketWindows(ctx, windows, filter, aggregateByCode, minTradeSize)
 	if err != nil {
 		return
 	}
@@ -52,6 +62,8 @@ func GenerateMarketSummary(s *tickerdb.TickerSession) (ms MarketSummary, err err
 		marketStats := dbMarketToMarketStats(dbMarket)
 		marketStatsSlice = append(marketStatsSlice, marketStats)
 	}
+	marketStatsSlice = addWindowOnlyMarkets(marketStatsSlice, dbWindows)
+	addMarketWindows(marketStatsSlice, windows, dbWindows)
 
 	ms = MarketSummary{
 		GeneratedAt:        nowMillis,
@@ -67,6 +79,8 @@ func dbMarketToMarketStats(m tickerdb.Market) MarketStats {
 	spread, spreadMidPoint := utils.CalcSpread(m.HighestBid, m.LowestAsk)
 	return MarketStats{
 		TradePairName:    m.TradePair,
+		MarketID:         m.MarketID,
+		MarketIDs:        tickerdb.SplitMarketIDs(m.MarketIDs),
 		BaseVolume24h:    m.BaseVolume24h,
 		CounterVolume24h: m.CounterVolume24h,
 		TradeCount24h:    m.TradeCount24h,
@@ -92,14 +106,183 @@ func dbMarketToMarketStats(m tickerdb.Market) MarketStats {
 		Spread:           spread,
 		SpreadMidPoint:   spreadMidPoint,
 		CloseTime:        closeTime,
+
+		OrderbookBaseVolume24h:    m.OrderbookBaseVolume24h,
+		OrderbookCounterVolume24h: m.OrderbookCounterVolume24h,
+		PoolBaseVolume24h:         m.PoolBaseVolume24h,
+		PoolCounterVolume24h:      m.PoolCounterVolume24h,
+		PoolBaseReserve:           m.PoolBaseReserve,
+		PoolCounterReserve:        m.PoolCounterReserve,
+		PoolPrice:                 utils.CalcPoolPrice(m.PoolBaseReserve, m.PoolCounterReserve),
+		PoolTVL:                   m.PoolTVL,
+		PoolFeeIncome24h:          m.PoolFeeIncome24h,
+
+		BaseVolumeUSD:    m.BaseVolume24hUSD,
+		CounterVolumeUSD: m.CounterVolume24hUSD,
+		PriceUSD:         m.PriceUSD,
+
+		BidDepth2Pct:   m.BidDepth2Pct,
+		BidDepth5Pct:   m.BidDepth5Pct,
+		BidDepth10Pct:  m.BidDepth10Pct,
+		AskDepth2Pct:   m.AskDepth2Pct,
+		AskDepth5Pct:   m.AskDepth5Pct,
+		AskDepth10Pct:  m.AskDepth10Pct,
+		SlippageAmount: m.SlippageAmount,
+		BuySlippage:    m.BuySlippage,
+		SellSlippage:   m.SellSlippage,
+
+		BaseVolume24hExact:    m.BaseVolume24hExact,
+		CounterVolume24hExact: m.CounterVolume24hExact,
+		Open24hExact:          m.OpenPrice24hExact,
+		Low24hExact:           m.LowestPrice24hExact,
+		High24hExact:          m.HighestPrice24hExact,
+		BaseVolume7dExact:     m.BaseVolume7dExact,
+		CounterVolume7dExact:  m.CounterVolume7dExact,
+		CloseExact:            m.LastPriceExact,
+
+		CleanBaseVolume24h:    m.CleanBaseVolume24h,
+		CleanCounterVolume24h: m.CleanCounterVolume24h,
+		FlaggedTradeCount24h:  m.FlaggedTradeCount24h,
+		CleanBaseVolume7d:     m.CleanBaseVolume7d,
+		CleanCounterVolume7d:  m.CleanCounterVolume7d,
+		VWAP24h:               m.VWAP24h,
+		TWAP24h:               m.TWAP24h,
+		MedianPrice24h:        m.MedianPrice24h,
+		VWAP7d:                m.VWAP7d,
+		TWAP7d:                m.TWAP7d,
+		MedianPrice7d:         m.MedianPrice7d,
 	}
 }
 
-// GenerateMarketSummaryFile generates a MarketSummary with the statistics for all
-// valid markets within the database and outputs it to <filename>.
-func GeneratePartialMarketSummaryFile(s *tickerdb.TickerSession, l *hlog.Entry, filename string, issuers []string) error {
+// addWindowOnlyMarkets appends to markets the ones that only traded within
+// windows longer than 7 days, which aren't returned by RetrieveMarketData.
+// Their 24h and 7d stats are empty, and their prices are the most recent
+// trade price within the windows.
+func addWindowOnlyMarkets(markets []MarketStats, dbWindows []tickerdb.MarketWindow) []MarketStats {
+	known := make(map[string]bool, len(markets))
+	for _, m := range markets {
+		known[m.TradePairName+" "+m.MarketID] = true
+	}
+
+	latest := make(map[string]tickerdb.MarketWindow)
+	var keys []string
+	for _, w := range dbWindows {
+		key := w.TradePair + " " + w.MarketID
+		if known[key] {
+			continue
+		}
+		l, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || w.CloseTime.After(l.CloseTime) {
+			latest[key] = w
+		}
+	}
+
+	for _, key := range keys {
+		w := latest[key]
+		markets = append(markets, MarketStats{
+			TradePairName: w.TradePair,
+			MarketID:      w.MarketID,
+			MarketIDs:     tickerdb.SplitMarketIDs(w.MarketIDs),
+			Open24h:       w.LastPrice,
+			Low24h:        w.LastPrice,
+			High24h:       w.LastPrice,
+			Price:         w.LastPrice,
+			Close:         w.LastPrice,
+			CloseTime:     utils.TimeToRFC3339(w.CloseTime),
+
+			BaseVolume24hExact:    "0",
+			CounterVolume24hExact: "0",
+			Open24hExact:          w.LastPriceExact,
+			Low24hExact:           w.LastPriceExact,
+			High24hExact:          w.LastPriceExact,
+			BaseVolume7dExact:     "0",
+			CounterVolume7dExact:  "0",
+			CloseExact:            w.LastPriceExact,
+		})
+	}
+	return markets
+}
+
+// addMarketWindows adds a stats block for each of the provided windows to the
+// given markets. Markets without any trade within a window get an empty block
+// whose prices fall back to the most recent trade price, mirroring the 24h stats.
+func addMarketWindows(markets []MarketStats, windows []string, dbWindows []tickerdb.MarketWindow) {
+	if len(windows) == 0 {
+		return
+	}
+
+	// markets are keyed by trade pair name and market ID, which is empty
+	// when they're aggregated by asset code:
+	windowsByPair := make(map[string]map[string]tickerdb.MarketWindow)
+	for _, w := range dbWindows {
+		key := w.TradePair + " " + w.MarketID
+		if _, ok := windowsByPair[key]; !ok {
+			windowsByPair[key] = make(map[string]tickerdb.MarketWindow)
+		}
+		windowsByPair[key][w.WindowName] = w
+	}
+
+	for i := range markets {
+		m := &markets[i]
+		m.Windows = make(map[string]WindowStats, len(windows))
+		for _, name := range windows {
+			w, ok := windowsByPair[m.TradePairName+" "+m.MarketID][name]
+			if !ok {
+				m.Windows[name] = WindowStats{
+					Open:      m.Close,
+					Low:       m.Close,
+					High:      m.Close,
+					Close:     m.Close,
+					CloseTime: m.CloseTime,
+
+					BaseVolumeExact:    "0",
+					CounterVolumeExact: "0",
+					OpenExact:          m.CloseExact,
+					LowExact:           m.CloseExact,
+					HighExact:          m.CloseExact,
+					CloseExact:         m.CloseExact,
+				}
+				continue
+			}
+
+			m.Windows[name] = WindowStats{
+				BaseVolume:    w.BaseVolume,
+				CounterVolume: w.CounterVolume,
+				TradeCount:    w.TradeCount,
+				Open:          w.OpenPrice,
+				Low:           w.LowestPrice,
+				High:          w.HighestPrice,
+				Change:        w.PriceChange,
+				Close:         w.LastPrice,
+				CloseTime:     utils.TimeToRFC3339(w.CloseTime),
+
+				BaseVolumeExact:    w.BaseVolumeExact,
+				CounterVolumeExact: w.CounterVolumeExact,
+				OpenExact:          w.OpenPriceExact,
+				LowExact:           w.LowestPriceExact,
+				HighExact:          w.HighestPriceExact,
+				CloseExact:         w.LastPriceExact,
+
+				CleanBaseVolume:    w.CleanBaseVolume,
+				CleanCounterVolume: w.CleanCounterVolume,
+				FlaggedTradeCount:  w.FlaggedTradeCount,
+				VWAP:               w.VWAP,
+				TWAP:               w.TWAP,
+				MedianPrice:        w.MedianPrice,
+			}
+		}
+	}
+}
+
+// GeneratePartialMarketSummaryFile generates a PartialMarketSummary with the
+// statistics for the valid markets of the given issuers and outputs it to
+// <filename>.
+func GeneratePartialMarketSummaryFile(s *tickerdb.TickerSession, l *hlog.Entry, filename string, issuers []string, minTradeSize float64) error {
 	l.Info("Generating partial market data...")
-	marketSummary, err := GeneratePartialMarketSummary(s, issuers)
+	marketSummary, err := GeneratePartialMarketSummary(s, issuers, minTradeSize)
 	if err != nil {
 		return err
 	}
@@ -119,9 +302,10 @@ func GeneratePartialMarketSummaryFile(s *tickerdb.TickerSession, l *hlog.Entry,
 	return nil
 }
 
-// GenerateMarketSummary outputs a MarketSummary with the statistics for all
-// valid markets within the database.
-func GeneratePartialMarketSummary(s *tickerdb.TickerSession, issuers []string) (ms PartialMarketSummary, err error) {
+// GeneratePartialMarketSummary outputs a PartialMarketSummary with the
+// statistics for the valid markets of the given issuers. The price stats only
+// include the trades worth at least minTradeSize in USD.
+func GeneratePartialMarketSummary(s *tickerdb.TickerSession, issuers []string, minTradeSize float64) (ms PartialMarketSummary, err error) {
 	var marketStatsSlice []PartialMarketStats
 	now := time.Now()
 	nowMillis := utils.TimeToUnixEpoch(now)
@@ -130,7 +314,7 @@ func GeneratePartialMarketSummary(s *tickerdb.TickerSession, issuers []string) (
 	var dbMarkets []tickerdb.PartialMarket
 
 	for _, issuer := range issuers {
-		dbPartialMarkets, err := s.RetrievePartialMarketsByIssuer(ctx, issuer, 24)
+		dbPartialMarkets, err := s.RetrievePartialMarketsByIssuer(ctx, issuer, 24, minTradeSize)
 		if err != nil {
 			return ms, err
 
@@ -156,6 +340,7 @@ func dbPartialMarketToMarketStats(m tickerdb.PartialMarket) PartialMarketStats {
 	spread, spreadMidPoint := utils.CalcSpread(m.HighestBid, m.LowestAsk)
 	return PartialMarketStats{
 		TradePairName:      m.TradePairName,
+		MarketID:           m.MarketID,
 		BaseAssetID:        m.BaseAssetID,
 		BaseAssetCode:      m.BaseAssetCode,
 		BaseAssetIssuer:    m.BaseAssetIssuer,
@@ -180,5 +365,43 @@ func dbPartialMarketToMarketStats(m tickerdb.PartialMarket) PartialMarketStats {
 		LowestAsk:          m.LowestAsk,
 		Spread:             spread,
 		SpreadMidPoint:     spreadMidPoint,
+
+		OrderbookBaseVolume:    m.OrderbookBaseVolume,
+		OrderbookCounterVolume: m.OrderbookCounterVolume,
+		PoolBaseVolume:         m.PoolBaseVolume,
+		PoolCounterVolume:      m.PoolCounterVolume,
+		PoolBaseReserve:        m.PoolBaseReserve,
+		PoolCounterReserve:     m.PoolCounterReserve,
+		PoolPrice:              utils.CalcPoolPrice(m.PoolBaseReserve, m.PoolCounterReserve),
+		PoolTVL:                m.PoolTVL,
+		PoolFeeIncome24h:       m.PoolFeeIncome24h,
+
+		BaseVolumeUSD:    m.BaseVolumeUSD,
+		CounterVolumeUSD: m.CounterVolumeUSD,
+		PriceUSD:         m.PriceUSD,
+
+		BidDepth2Pct:   m.BidDepth2Pct,
+		BidDepth5Pct:   m.BidDepth5Pct,
+		BidDepth10Pct:  m.BidDepth10Pct,
+		AskDepth2Pct:   m.AskDepth2Pct,
+		AskDepth5Pct:   m.AskDepth5Pct,
+		AskDepth10Pct:  m.AskDepth10Pct,
+		SlippageAmount: m.SlippageAmount,
+		BuySlippage:    m.BuySlippage,
+		SellSlippage:   m.SellSlippage,
+
+		BaseVolumeExact:    m.BaseVolumeExact,
+		CounterVolumeExact: m.CounterVolumeExact,
+		OpenExact:          m.OpenExact,
+		LowExact:           m.LowExact,
+		HighExact:          m.HighExact,
+		CloseExact:         m.CloseExact,
+
+		CleanBaseVolume:    m.CleanBaseVolume,
+		CleanCounterVolume: m.CleanCounterVolume,
+		FlaggedTradeCount:  m.FlaggedTradeCount,
+		VWAP:               m.VWAP,
+		TWAP:               m.TWAP,
+		MedianPrice:        m.MedianPrice,
 	}
 }
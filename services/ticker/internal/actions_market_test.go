@@ -0,0 +1,130 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMarketWindows(t *testing.T) {
+	closeTime := time.Unix(1556828400, 0)
+	markets := []MarketStats{
+		{TradePairName: "XLM_BTC", Close: 0.5, CloseTime: "2019-05-02T17:00:00Z"},
+		{TradePairName: "BTC_ETH", Close: 2.0, CloseExact: "2.0", CloseTime: "2019-04-30T10:00:00Z"},
+	}
+	dbWindows := []tickerdb.MarketWindow{
+		{
+			TradePair:     "XLM_BTC",
+			WindowName:    "1h",
+			BaseVolume:    10.0,
+			CounterVolume: 5.0,
+			TradeCount:    1,
+			OpenPrice:     0.5,
+			LowestPrice:   0.5,
+			HighestPrice:  0.5,
+			LastPrice:     0.5,
+			CloseTime:     closeTime,
+
+			BaseVolumeExact: "10.0000000",
+		},
+		{
+			TradePair:     "XLM_BTC",
+			WindowName:    "30d",
+			BaseVolume:    30.0,
+			CounterVolume: 25.0,
+			TradeCount:    3,
+			OpenPrice:     1.0,
+			LowestPrice:   0.5,
+			HighestPrice:  1.5,
+			PriceChange:   -0.5,
+			LastPrice:     0.5,
+			CloseTime:     closeTime,
+		},
+		{
+			TradePair:     "BTC_ETH",
+			WindowName:    "30d",
+			BaseVolume:    4.0,
+			CounterVolume: 8.0,
+			TradeCount:    2,
+			OpenPrice:     2.0,
+			LowestPrice:   2.0,
+			HighestPrice:  2.0,
+			LastPrice:     2.0,
+			CloseTime:     closeTime,
+		},
+	}
+
+	addMarketWindows(markets, []string{"1h", "30d"}, dbWindows)
+
+	require.Len(t, markets[0].Windows, 2)
+	assert.Equal(t, 10.0, markets[0].Windows["1h"].BaseVolume)
+	assert.Equal(t, "10.0000000", markets[0].Windows["1h"].BaseVolumeExact)
+	assert.Equal(t, int64(3), markets[0].Windows["30d"].TradeCount)
+	assert.Equal(t, 1.5, markets[0].Windows["30d"].High)
+	assert.Equal(t, -0.5, markets[0].Windows["30d"].Change)
+	assert.Equal(t, closeTime.Format(time.RFC3339), markets[0].Windows["30d"].CloseTime)
+
+	// BTC_ETH had no trades in the last hour, so its prices fall back
+	// to the most recent trade:
+	require.Len(t, markets[1].Windows, 2)
+	assert.Equal(t, WindowStats{
+		Open:      2.0,
+		Low:       2.0,
+		High:      2.0,
+		Close:     2.0,
+		CloseTime: "2019-04-30T10:00:00Z",
+
+		BaseVolumeExact:    "0",
+		CounterVolumeExact: "0",
+		OpenExact:          "2.0",
+		LowExact:           "2.0",
+		HighExact:          "2.0",
+		CloseExact:         "2.0",
+	}, markets[1].Windows["1h"])
+	assert.Equal(t, 8.0, markets[1].Windows["30d"].CounterVolume)
+
+	// No windows were requested, so no blocks are added:
+	markets = []MarketStats{{TradePairName: "XLM_BTC"}}
+	addMarketWindows(markets, nil, dbWindows)
+	assert.Nil(t, markets[0].Windows)
+}
+
+func TestAddWindowOnlyMarkets(t *testing.T) {
+	closeTime := time.Unix(1556828400, 0)
+	markets := []MarketStats{{TradePairName: "XLM_BTC", Close: 0.5}}
+	dbWindows := []tickerdb.MarketWindow{
+		{TradePair: "XLM_BTC", WindowName: "30d", LastPrice: 0.5, CloseTime: closeTime},
+		// BTC_ETH only traded more than 7 days ago:
+		{
+			TradePair:      "BTC_ETH",
+			MarketIDs:      "BTC:GA/ETH:GB,BTC:GA/ETH:GC",
+			WindowName:     "30d",
+			LastPrice:      2.0,
+			LastPriceExact: "2.0",
+			CloseTime:      closeTime,
+		},
+		{
+			TradePair:      "BTC_ETH",
+			WindowName:     "90d",
+			LastPrice:      2.0,
+			LastPriceExact: "2.0",
+			CloseTime:      closeTime,
+		},
+	}
+
+	markets = addWindowOnlyMarkets(markets, dbWindows)
+	require.Len(t, markets, 2)
+	assert.Equal(t, "BTC_ETH", markets[1].TradePairName)
+	assert.Equal(t, []string{"BTC:GA/ETH:GB", "BTC:GA/ETH:GC"}, markets[1].MarketIDs)
+	assert.Equal(t, 0.0, markets[1].BaseVolume24h)
+	assert.Equal(t, 2.0, markets[1].Close)
+	assert.Equal(t, "2.0", markets[1].CloseExact)
+	assert.Equal(t, closeTime.Format(time.RFC3339), markets[1].CloseTime)
+
+	// the windows are then added to every market:
+	addMarketWindows(markets, []string{"30d", "90d"}, dbWindows)
+	assert.Equal(t, 2.0, markets[1].Windows["90d"].Close)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	horizonclient "github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/services/ticker/internal/pubsub"
 	"github.com/stellar/go/services/ticker/internal/scraper"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
 	"github.com/stellar/go/support/errors"
@@ -12,11 +13,13 @@ import (
 )
 
 // RefreshOrderbookEntries updates the orderbook entries for the relevant markets that were active
-// in the past 7-day interval
-func RefreshOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry) error {
+// in the past 7-day interval, calculating their slippage for slippageAmount units of the base asset
+func RefreshOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry, slippageAmount float64) error {
 	sc := scraper.ScraperConfig{
-		Client: c,
-		Logger: l,
+		Client:         c,
+		Logger:         l,
+		SlippageAmount: slippageAmount,
+		Settings:       scraperSettings(),
 	}
 	ctx := context.Background()
 
@@ -40,11 +43,7 @@ func RefreshOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient.Client,
 			continue
 		}
 
-		dbOS := orderbookStatsToDBOrderbookStats(ob, mkt.BaseAssetID, mkt.CounterAssetID)
-		err = s.InsertOrUpdateOrderbookStats(ctx, &dbOS, []string{"base_asset_id", "counter_asset_id"})
-		if err != nil {
-			l.Error(errors.Wrap(err, "could not insert orderbook stats into db"))
-		}
+		persistOrderbookStats(ctx, s, l, ob, mkt)
 	}
 
 	return nil
@@ -52,10 +51,12 @@ func RefreshOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient.Client,
 
 // RefreshOrderbookEntries updates the orderbook entries for the relevant markets that were active
 // in the past 7-day interval
-func RefreshFilteredOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry, issuers []string) error {
+func RefreshFilteredOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry, issuers []string, slippageAmount float64) error {
 	sc := scraper.ScraperConfig{
-		Client: c,
-		Logger: l,
+		Client:         c,
+		Logger:         l,
+		SlippageAmount: slippageAmount,
+		Settings:       scraperSettings(),
 	}
 	ctx := context.Background()
 
@@ -80,11 +81,7 @@ func RefreshFilteredOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient
 					l.Error(errors.Wrap(err, "could not fetch orderbook for assets"))
 					continue
 				}
-				dbOS := orderbookStatsToDBOrderbookStats(ob, mkt.BaseAssetID, mkt.CounterAssetID)
-				err = s.InsertOrUpdateOrderbookStats(ctx, &dbOS, []string{"base_asset_id", "counter_asset_id"})
-				if err != nil {
-					l.Error(errors.Wrap(err, "could not insert orderbook stats into db"))
-				}
+				persistOrderbookStats(ctx, s, l, ob, mkt)
 			}
 		}
 	}
@@ -92,6 +89,32 @@ func RefreshFilteredOrderbookEntries(s *tickerdb.TickerSession, c *horizonclient
 	return nil
 }
 
+// persistOrderbookStats updates the latest orderbook stats of a market,
+// appends them to its orderbook history and notifies the GraphQL
+// subscriptions.
+func persistOrderbookStats(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, ob scraper.OrderbookStats, mkt tickerdb.PartialMarket) {
+	dbOS := orderbookStatsToDBOrderbookStats(ob, mkt.BaseAssetID, mkt.CounterAssetID)
+	err := s.InsertOrUpdateOrderbookStats(ctx, &dbOS, []string{"base_asset_id", "counter_asset_id"})
+	if err != nil {
+		l.Error(errors.Wrap(err, "could not insert orderbook stats into db"))
+		return
+	}
+
+	dbSnapshot := orderbookStatsToDBOrderbookSnapshot(dbOS)
+	err = s.InsertOrderbookSnapshot(ctx, &dbSnapshot)
+	if err != nil {
+		l.Error(errors.Wrap(err, "could not insert orderbook snapshot into db"))
+	}
+
+	pubsub.Publish(pubsub.OrderbooksTopic, pubsub.OrderbookUpdate{
+		BaseAssetCode:      mkt.BaseAssetCode,
+		BaseAssetIssuer:    mkt.BaseAssetIssuer,
+		CounterAssetCode:   mkt.CounterAssetCode,
+		CounterAssetIssuer: mkt.CounterAssetIssuer,
+		Stats:              dbOS,
+	})
+}
+
 func orderbookStatsToDBOrderbookStats(os scraper.OrderbookStats, bID, cID int32) tickerdb.OrderbookStats {
 	return tickerdb.OrderbookStats{
 		BaseAssetID:    bID,
@@ -105,5 +128,30 @@ func orderbookStatsToDBOrderbookStats(os scraper.OrderbookStats, bID, cID int32)
 		Spread:         os.Spread,
 		SpreadMidPoint: os.SpreadMidPoint,
 		UpdatedAt:      time.Now(),
+		BidDepth2Pct:   os.BidDepth2Pct,
+		BidDepth5Pct:   os.BidDepth5Pct,
+		BidDepth10Pct:  os.BidDepth10Pct,
+		AskDepth2Pct:   os.AskDepth2Pct,
+		AskDepth5Pct:   os.AskDepth5Pct,
+		AskDepth10Pct:  os.AskDepth10Pct,
+		SlippageAmount: os.SlippageAmount,
+		BuySlippage:    os.BuySlippage,
+		SellSlippage:   os.SellSlippage,
+	}
+}
+
+func orderbookStatsToDBOrderbookSnapshot(os tickerdb.OrderbookStats) tickerdb.OrderbookSnapshot {
+	return tickerdb.OrderbookSnapshot{
+		BaseAssetID:    os.BaseAssetID,
+		CounterAssetID: os.CounterAssetID,
+		NumBids:        os.NumBids,
+		BidVolume:      os.BidVolume,
+		HighestBid:     os.HighestBid,
+		NumAsks:        os.NumAsks,
+		AskVolume:      os.AskVolume,
+		LowestAsk:      os.LowestAsk,
+		Spread:         os.Spread,
+		SpreadMidPoint: os.SpreadMidPoint,
+		CreatedAt:      os.UpdatedAt,
 	}
 }
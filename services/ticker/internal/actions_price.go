@@ -0,0 +1,74 @@
+package ticker
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/pricing"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/errors"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// RefreshAssetPrices derives the fiat prices of every traded asset from the
+// on-DEX markets against the given reference assets, and stores them on the
+// database. Prices of assets that can no longer be priced are removed.
+func RefreshAssetPrices(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, refs pricing.References) error {
+	start := time.Now()
+
+	markets, err := s.RetrieveMarketPrices(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve market prices")
+	}
+
+	numPrices := 0
+	for assetID, prices := range pricing.CalcAssetPrices(markets, refs) {
+		for currency, price := range prices {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			dbPrice := tickerdb.AssetPrice{
+				AssetID:   assetID,
+				Currency:  currency,
+				Price:     price,
+				UpdatedAt: time.Now(),
+			}
+			err = s.InsertOrUpdateAssetPrice(ctx, &dbPrice, []string{"asset_id", "currency"})
+			if err != nil {
+				l.Error(errors.Wrap(err, "could not insert asset price into db"))
+				continue
+			}
+			numPrices++
+		}
+	}
+	l.Infof("Refreshed %d asset prices\n", numPrices)
+
+	return s.DeleteOldAssetPrices(ctx, start)
+}
+
+// retrieveAssetPrices retrieves the prices of all assets, keyed by asset ID
+// and currency.
+func retrieveAssetPrices(ctx context.Context, s *tickerdb.TickerSession) (map[int32]map[string]float64, error) {
+	dbPrices, err := s.RetrieveAssetPrices(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve asset prices")
+	}
+
+	prices := make(map[int32]map[string]float64)
+	for _, p := range dbPrices {
+		if prices[p.AssetID] == nil {
+			prices[p.AssetID] = make(map[string]float64)
+		}
+		prices[p.AssetID][p.Currency] = p.Price
+	}
+	return prices, nil
+}
+
+// setAssetPrices sets the prices of an asset, keyed by currency.
+func setAssetPrices(a *Asset, prices map[string]float64) {
+	a.PriceUSD = prices[pricing.USD]
+	if len(prices) > 0 {
+		a.Prices = prices
+	}
+}
@@ -2,56 +2,107 @@ package ticker
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	horizonclient "github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/ingest/ledgerbackend"
 	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/ticker/internal/pubsub"
 	"github.com/stellar/go/services/ticker/internal/scraper"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/errors"
 	hlog "github.com/stellar/go/support/log"
 )
 
+// HorizonTradesJob and LedgerTradesJob identify the cursors (i.e. the paging
+// token of the last processed trade) of each trade ingestion source in the
+// ingest_state table.
+const (
+	HorizonTradesJob = "horizon_trades"
+	LedgerTradesJob  = "ledger_trades"
+)
+
 // StreamTrades constantly streams and ingests new trades directly from horizon.
+// The stream stops with an error as soon as a trade can't be persisted, so that
+// the cursor never moves past it: it resumes from that trade once restarted.
 func StreamTrades(
 	ctx context.Context,
 	s *tickerdb.TickerSession,
 	c *horizonclient.Client,
 	l *hlog.Entry,
 ) error {
+	streamCtx, stopStream := context.WithCancel(ctx)
+	defer stopStream()
+
 	sc := scraper.ScraperConfig{
-		Client: c,
-		Logger: l,
-		Ctx:    &ctx,
+		Client:   c,
+		Logger:   l,
+		Ctx:      &streamCtx,
+		Settings: scraperSettings(),
 	}
+	var persistErr error
 	handler := func(trade hProtocol.Trade) {
-		l.Infof("New trade arrived. ID: %v; Close Time: %v\n", trade.ID, trade.LedgerCloseTime)
-		scraper.NormalizeTradeAssets(&trade)
-		bID, cID, err := scraper.FindBaseAndCounter(ctx, s, trade)
-		if err != nil {
-			l.Error(err)
+		if persistErr != nil {
 			return
 		}
-		dbTrade, err := scraper.HProtocolTradeToDBTrade(trade, bID, cID)
+		l.Infof("New trade arrived. ID: %v; Close Time: %v\n", trade.ID, trade.LedgerCloseTime)
+		scraper.NormalizeTradeAssets(&trade)
+		err := scraper.PersistTradesAndCursor(ctx, s, l, []hProtocol.Trade{trade}, HorizonTradesJob, trade.PT)
 		if err != nil {
-			l.Error(err)
+			l.Error("Could not insert trade in database: ", trade.ID, err)
+			persistErr = errors.Wrapf(err, "could not persist trade %s", trade.ID)
+			stopStream()
 			return
 		}
-
-		err = s.BulkInsertTrades(ctx, []tickerdb.Trade{dbTrade})
-		if err != nil {
-			l.Error("Could not insert trade in database: ", trade.ID)
-		}
+		publishTrade(trade)
 	}
 
-	// Ensure we start streaming from the last stored trade
-	lastTrade, err := s.GetLastTrade(ctx)
+	// Ensure we start streaming from the last processed trade. Before the
+	// cursor was tracked, the last stored trade was used instead.
+	cursor, err := s.GetIngestCursor(ctx, HorizonTradesJob)
 	if err != nil {
 		return err
 	}
 
-	cursor := lastTrade.HorizonID
-	return sc.StreamNewTrades(cursor, handler)
+	if cursor == "" {
+		lastTrade, err := s.GetLastTrade(ctx)
+		if err != nil && !s.NoRows(err) {
+			return err
+		}
+		cursor = lastTrade.HorizonID
+	}
+
+	err = sc.StreamNewTrades(cursor, handler)
+	if persistErr != nil {
+		return persistErr
+	}
+	return err
+}
+
+// publishTrade notifies the GraphQL subscriptions of a newly stored trade,
+// whose assets must already be normalized.
+func publishTrade(trade hProtocol.Trade) {
+	if pubsub.NumSubscribers(pubsub.TradesTopic) == 0 {
+		return
+	}
+
+	dbTrade, err := scraper.HProtocolTradeToDBTrade(trade, 0, 0)
+	if err != nil {
+		return
+	}
+	pubsub.Publish(pubsub.TradesTopic, pubsub.Trade{
+		HorizonID:          dbTrade.HorizonID,
+		LedgerCloseTime:    dbTrade.LedgerCloseTime,
+		BaseAssetCode:      trade.BaseAssetCode,
+		BaseAssetIssuer:    trade.BaseAssetIssuer,
+		CounterAssetCode:   trade.CounterAssetCode,
+		CounterAssetIssuer: trade.CounterAssetIssuer,
+		BaseAmount:         dbTrade.BaseAmount,
+		CounterAmount:      dbTrade.CounterAmount,
+		Price:              dbTrade.Price,
+		BaseIsSeller:       dbTrade.BaseIsSeller,
+		TradeType:          dbTrade.TradeType,
+	})
 }
 
 // BackfillTrades ingest the most recent trades (limited to numDays) directly from Horizon
@@ -65,17 +116,38 @@ func BackfillTrades(
 	limit int,
 ) error {
 	sc := scraper.ScraperConfig{
-		Client: c,
-		Logger: l,
+		Client:   c,
+		Logger:   l,
+		Settings: scraperSettings(),
 	}
 	now := time.Now()
 	since := now.Add(time.Hour * -time.Duration(numHours))
+
+	// If a previous run already ingested trades, catch up from where it stopped:
+	cursor, err := s.GetIngestCursor(ctx, HorizonTradesJob)
+	if err != nil {
+		return err
+	}
+	if cursor != "" {
+		_, err = sc.IngestTradesFromCursor(ctx, s, cursor, HorizonTradesJob, since, limit)
+		return err
+	}
+
+	// Otherwise, the newest trade becomes the cursor once the backfill is done:
+	cursor, err = sc.FetchLatestCursor()
+	if err != nil {
+		return err
+	}
+
 	trades, err := sc.FetchAllTrades(ctx, s, l, since, limit)
 	if err != nil {
 		return err
 	}
 
-	return scraper.PersistTrades(ctx, s, l, trades)
+	if cursor == "" {
+		return scraper.PersistTrades(ctx, s, l, trades)
+	}
+	return scraper.PersistTradesAndCursor(ctx, s, l, trades, HorizonTradesJob, cursor)
 }
 
 // BackfillFilteredTrades ingest the most recent trades (limited to numDays) directly from Horizon
@@ -90,8 +162,9 @@ func BackfillFilteredTrades(
 	issuer string,
 ) error {
 	sc := scraper.ScraperConfig{
-		Client: c,
-		Logger: l,
+		Client:   c,
+		Logger:   l,
+		Settings: scraperSettings(),
 	}
 	now := time.Now()
 	since := now.Add(time.Hour * -time.Duration(numHours))
@@ -100,28 +173,90 @@ func BackfillFilteredTrades(
 		return err
 	}
 
-	var dbTrades []tickerdb.Trade
+	return scraper.PersistTrades(ctx, s, l, trades)
+}
 
-	for _, trade := range trades {
-		var bID, cID int32
-		bID, cID, err = scraper.FindBaseAndCounter(ctx, s, trade)
-		if err != nil {
-			continue
-		}
+// approxLedgersPerHour is used to estimate how many ledgers cover a given
+// period, as ledgers close roughly every 5 seconds.
+const approxLedgersPerHour = 720
 
-		var dbTrade tickerdb.Trade
-		dbTrade, err = scraper.HProtocolTradeToDBTrade(trade, bID, cID)
-		if err != nil {
-			l.Error("Could not convert entry to DB Trade: ", err)
-			continue
+// ledgerTradesBatchSize is the number of trades accumulated before persisting
+// them when ingesting a bounded range of ledgers.
+const ledgerTradesBatchSize = 10000
+
+// LedgerBackfillStart estimates the first ledger to ingest in order to backfill
+// the trades of the last numHours up to latestLedger. If a previous ledger
+// ingestion already processed a later ledger, ingestion resumes right after it;
+// otherwise, if the database contains trades from a later ledger, ingestion
+// resumes from there instead.
+func LedgerBackfillStart(ctx context.Context, s *tickerdb.TickerSession, latestLedger uint32, numHours int) uint32 {
+	start := uint32(2)
+	if numLedgers := uint32(numHours) * approxLedgersPerHour; numLedgers+start < latestLedger {
+		start = latestLedger - numLedgers
+	}
+
+	cursor, err := s.GetIngestCursor(ctx, LedgerTradesJob)
+	if err == nil && cursor != "" {
+		cursorLedger, err := scraper.LedgerFromTradeID(cursor)
+		if err == nil && cursorLedger >= start {
+			start = cursorLedger + 1
 		}
-		dbTrades = append(dbTrades, dbTrade)
+		return start
 	}
 
-	l.Infof("Inserting %d entries in the database.\n", len(dbTrades))
-	err = s.BulkInsertTrades(ctx, dbTrades)
+	lastTrade, err := s.GetLastTrade(ctx)
 	if err != nil {
-		fmt.Println(err)
+		return start
+	}
+
+	lastTradeLedger, err := scraper.LedgerFromTradeID(lastTrade.HorizonID)
+	if err == nil && lastTradeLedger > start {
+		start = lastTradeLedger
+	}
+	return start
+}
+
+// IngestLedgerTrades ingests the trades of the ledgers within [from, to] directly
+// from the transaction meta provided by backend, without relying on Horizon. If
+// to = 0, it keeps ingesting new ledgers as they close until ctx is done.
+func IngestLedgerTrades(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	backend ledgerbackend.LedgerBackend,
+	l *hlog.Entry,
+	networkPassphrase string,
+	from uint32,
+	to uint32,
+) error {
+	ledgerRange := ledgerbackend.UnboundedRange(from)
+	if to != 0 {
+		ledgerRange = ledgerbackend.BoundedRange(from, to)
+	}
+
+	l.Infof("Preparing ledger range %s\n", ledgerRange)
+	if err := backend.PrepareRange(ctx, ledgerRange); err != nil {
+		return err
+	}
+
+	var trades []hProtocol.Trade
+	for seq := from; to == 0 || seq <= to; seq++ {
+		ledgerTrades, err := scraper.ExtractLedgerTrades(ctx, backend, networkPassphrase, seq)
+		if err != nil {
+			return err
+		}
+		trades = append(trades, ledgerTrades...)
+
+		// When streaming, trades are persisted as soon as their ledger closes:
+		if to == 0 || seq == to || len(trades) >= ledgerTradesBatchSize {
+			if len(trades) > 0 {
+				l.Infof("Persisting %d trades up to ledger %d\n", len(trades), seq)
+			}
+			err = scraper.PersistTradesAndCursor(ctx, s, l, trades, LedgerTradesJob, scraper.LedgerCursor(seq))
+			if err != nil {
+				return err
+			}
+			trades = nil
+		}
 	}
 
 	return nil
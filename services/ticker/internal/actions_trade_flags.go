@@ -0,0 +1,103 @@
+package ticker
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/anomaly"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/errors"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// tradeFlagsBatchSize is the number of unchecked trades checked at once.
+const tradeFlagsBatchSize = 1000
+
+// tradeFlagsHistoryLimit is the maximum number of already checked trades of
+// each market retrieved to check a batch of trades against.
+const tradeFlagsHistoryLimit = 1000
+
+// FlagTrades checks the trades ingested since the last run for anomalies
+// (self-trades, wash trades and price outliers, see anomaly.Detect), oldest
+// first, and stores their flags. Flagged trades are excluded from the clean
+// volumes of the markets.
+func FlagTrades(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry, settings anomaly.Settings) error {
+	numChecked, numFlagged := 0, 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		trades, err := s.RetrieveUncheckedTrades(ctx, tradeFlagsBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve unchecked trades")
+		}
+		if len(trades) == 0 {
+			break
+		}
+
+		history, err := retrieveFlagsHistory(ctx, s, trades, settings)
+		if err != nil {
+			return err
+		}
+
+		flags := anomaly.Detect(trades, history, settings)
+		if err = s.UpdateTradeFlags(ctx, flags); err != nil {
+			return errors.Wrap(err, "could not update trade flags")
+		}
+
+		numChecked += len(trades)
+		for _, f := range flags {
+			if f.Any() {
+				numFlagged++
+			}
+		}
+		if len(trades) < tradeFlagsBatchSize {
+			break
+		}
+	}
+
+	l.Infof("Checked %d trades for anomalies, flagged %d trades\n", numChecked, numFlagged)
+	return nil
+}
+
+// retrieveFlagsHistory retrieves the already checked trades of the markets
+// of the given trades which closed around them, within the windows used to
+// find wash trades and price outliers.
+func retrieveFlagsHistory(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	trades []tickerdb.Trade,
+	settings anomaly.Settings,
+) ([]tickerdb.Trade, error) {
+	window := settings.MedianWindow
+	if settings.ClusterWindow > window {
+		window = settings.ClusterWindow
+	}
+
+	type market struct{ base, counter int32 }
+	first := make(map[market]time.Time)
+	last := make(map[market]time.Time)
+	for _, t := range trades {
+		m := market{t.BaseAssetID, t.CounterAssetID}
+		if f, ok := first[m]; !ok || t.LedgerCloseTime.Before(f) {
+			first[m] = t.LedgerCloseTime
+		}
+		if t.LedgerCloseTime.After(last[m]) {
+			last[m] = t.LedgerCloseTime
+		}
+	}
+
+	var history []tickerdb.Trade
+	for m, f := range first {
+		// checked trades may have closed after unchecked ones (e.g. when
+		// backfilling), and belong to the loops closed by them:
+		until := last[m].Add(settings.ClusterWindow)
+		mktHistory, err := s.RetrieveCheckedTrades(ctx, m.base, m.counter, f.Add(-window), until, tradeFlagsHistoryLimit)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not retrieve checked trades")
+		}
+		history = append(history, mktHistory...)
+	}
+	return history, nil
+}
@@ -14,14 +14,15 @@ func TestProtocolTradeToDBTrade_priceMapping(t *testing.T) {
 	testCases := []struct {
 		N         int64
 		D         int64
-		WantPrice float64
+		WantPrice string
 	}{
-		{100, 200, 2},
-		{1, 2, 2},
-		{1187492342, 283724929, 0.23892779680763618},
+		{100, 200, "2"},
+		{1, 2, "2"},
+		{4, 1, "0.25"},
+		{1187492342, 283724929, "0.23892779680763617084"},
 	}
 	for _, tc := range testCases {
-		name := fmt.Sprintf("%d/%d=%f", tc.N, tc.D, tc.WantPrice)
+		name := fmt.Sprintf("%d/%d=%s", tc.N, tc.D, tc.WantPrice)
 		t.Run(name, func(t *testing.T) {
 			hpt := hProtocol.Trade{
 				BaseAmount:    "0",
@@ -34,6 +35,30 @@ func TestProtocolTradeToDBTrade_priceMapping(t *testing.T) {
 			dbTrade, err := scraper.HProtocolTradeToDBTrade(hpt, 0, 0)
 			require.NoError(t, err)
 			assert.Equal(t, tc.WantPrice, dbTrade.Price)
+
+			// The exact price is kept as a rational:
+			assert.Equal(t, dbTrade.PriceN*tc.N, dbTrade.PriceD*tc.D)
 		})
 	}
 }
+
+func TestProtocolTradeToDBTrade_exactAmounts(t *testing.T) {
+	hpt := hProtocol.Trade{
+		BaseAmount:    "922337203685.4775807",
+		CounterAmount: "0.0000001",
+		Price:         hProtocol.TradePrice{N: 1, D: 1},
+	}
+	dbTrade, err := scraper.HProtocolTradeToDBTrade(hpt, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "922337203685.4775807", dbTrade.BaseAmount)
+	assert.Equal(t, "0.0000001", dbTrade.CounterAmount)
+
+	hpt.BaseAmount = "1.00000001"
+	_, err = scraper.HProtocolTradeToDBTrade(hpt, 0, 0)
+	assert.Error(t, err)
+
+	hpt.BaseAmount = "1"
+	hpt.Price = hProtocol.TradePrice{N: 0, D: 1}
+	_, err = scraper.HProtocolTradeToDBTrade(hpt, 0, 0)
+	assert.Error(t, err)
+}
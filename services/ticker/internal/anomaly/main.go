@@ -0,0 +1,238 @@
+package anomaly
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+// Settings are the thresholds used to flag anomalous trades.
+type Settings struct {
+	// ClusterWindow is how far apart in time the trades of a circular trading
+	// loop can be, and MaxClusterSize the maximum number of accounts in it.
+	ClusterWindow  time.Duration
+	MaxClusterSize int
+
+	// A trade is a price outlier if its price is more than OutlierFactor
+	// times above or below the median price of the (at most) MedianTrades
+	// previous unflagged trades of its market within MedianWindow. Markets
+	// with fewer than MinMedianTrades such trades have no outliers.
+	MedianWindow    time.Duration
+	MedianTrades    int
+	MinMedianTrades int
+	OutlierFactor   float64
+}
+
+// DefaultSettings returns the settings used when none are configured.
+func DefaultSettings() Settings {
+	return Settings{
+		ClusterWindow:   time.Hour,
+		MaxClusterSize:  3,
+		MedianWindow:    24 * time.Hour,
+		MedianTrades:    50,
+		MinMedianTrades: 5,
+		OutlierFactor:   5,
+	}
+}
+
+// marketKey identifies the market of a trade.
+type marketKey struct {
+	base    int32
+	counter int32
+}
+
+// Detect checks the given trades for anomalies, and returns the flags of
+// each of them keyed by trade ID:
+//
+//   - self-trades are trades whose base and counter accounts are the same;
+//   - wash trades are the trades of a loop in which the base asset goes from
+//     an account back to itself through at most MaxClusterSize accounts,
+//     all within ClusterWindow (e.g. A sells to B, which sells back to A);
+//   - outliers are trades whose price deviates by more than OutlierFactor from
+//     the rolling median price of their market.
+//
+// history are the trades that were already checked, whose flags are used to
+// compute the median prices. The earlier trades of the loops closed by the
+// given trades are flagged as well: the returned flags include the history
+// trades whose flags changed.
+func Detect(trades []tickerdb.Trade, history []tickerdb.Trade, s Settings) map[int64]tickerdb.TradeFlags {
+	pending := make(map[int64]bool, len(trades))
+	markets := make(map[marketKey][]tickerdb.Trade)
+	for _, t := range trades {
+		pending[t.ID] = true
+		key := marketKey{t.BaseAssetID, t.CounterAssetID}
+		markets[key] = append(markets[key], t)
+	}
+	for _, t := range history {
+		if pending[t.ID] {
+			continue
+		}
+		key := marketKey{t.BaseAssetID, t.CounterAssetID}
+		markets[key] = append(markets[key], t)
+	}
+
+	flags := make(map[int64]tickerdb.TradeFlags, len(trades))
+	for _, mktTrades := range markets {
+		for id, f := range detectMarket(mktTrades, pending, s) {
+			flags[id] = f
+		}
+	}
+	return flags
+}
+
+// detectMarket flags the trades of a single market, returning the flags of
+// the trades to check and of the other trades whose flags changed.
+func detectMarket(trades []tickerdb.Trade, pending map[int64]bool, s Settings) map[int64]tickerdb.TradeFlags {
+	sort.Slice(trades, func(i, j int) bool {
+		if !trades[i].LedgerCloseTime.Equal(trades[j].LedgerCloseTime) {
+			return trades[i].LedgerCloseTime.Before(trades[j].LedgerCloseTime)
+		}
+		return trades[i].ID < trades[j].ID
+	})
+
+	current := make([]tickerdb.TradeFlags, len(trades))
+	for i, t := range trades {
+		if pending[t.ID] {
+			current[i] = tickerdb.TradeFlags{SelfTrade: t.BaseAccount != "" && t.BaseAccount == t.CounterAccount}
+		} else {
+			current[i] = tickerdb.TradeFlags{SelfTrade: t.IsSelfTrade, WashTrade: t.IsWashTrade, Outlier: t.IsOutlier}
+		}
+	}
+
+	for i, t := range trades {
+		if !pending[t.ID] {
+			continue
+		}
+		for _, j := range washLoop(trades, i, s) {
+			current[j].WashTrade = true
+		}
+	}
+
+	for i, t := range trades {
+		price, err := strconv.ParseFloat(t.Price, 64)
+		if !pending[t.ID] || err != nil || price <= 0 {
+			continue
+		}
+		median, ok := medianPrice(trades, current, i, s)
+		current[i].Outlier = ok && (price > median*s.OutlierFactor || price < median/s.OutlierFactor)
+	}
+
+	flags := make(map[int64]tickerdb.TradeFlags)
+	for i, t := range trades {
+		previous := tickerdb.TradeFlags{SelfTrade: t.IsSelfTrade, WashTrade: t.IsWashTrade, Outlier: t.IsOutlier}
+		if pending[t.ID] || current[i] != previous {
+			flags[t.ID] = current[i]
+		}
+	}
+	return flags
+}
+
+// transfer returns the account selling the base asset of a trade and the one
+// buying it, if both are known (liquidity pool trades have a single account).
+func transfer(t tickerdb.Trade) (from string, to string, ok bool) {
+	from, to = t.CounterAccount, t.BaseAccount
+	if t.BaseIsSeller {
+		from, to = to, from
+	}
+	return from, to, from != "" && to != "" && from != to
+}
+
+// washLoop returns the indices of the trades of a loop closed by trades[i],
+// i.e. a path through which the base asset goes from its buyer back to its
+// seller within ClusterWindow, if any. trades must be sorted by close time.
+func washLoop(trades []tickerdb.Trade, i int, s Settings) []int {
+	seller, buyer, ok := transfer(trades[i])
+	if !ok || s.MaxClusterSize < 2 {
+		return nil
+	}
+
+	// the trades within ClusterWindow of trades[i], keyed by seller:
+	t := trades[i].LedgerCloseTime
+	start := sort.Search(len(trades), func(j int) bool {
+		return !trades[j].LedgerCloseTime.Before(t.Add(-s.ClusterWindow))
+	})
+	bySeller := make(map[string][]int)
+	for j := start; j < len(trades) && !trades[j].LedgerCloseTime.After(t.Add(s.ClusterWindow)); j++ {
+		if from, _, ok := transfer(trades[j]); ok && j != i {
+			bySeller[from] = append(bySeller[from], j)
+		}
+	}
+
+	// depth-first search of a path from buyer to seller through at most
+	// MaxClusterSize accounts (including both of them), whose trades all
+	// closed within ClusterWindow:
+	visited := map[string]bool{seller: true, buyer: true}
+	var path []int
+	var search func(account string, depth int, first time.Time, last time.Time) bool
+	search = func(account string, depth int, first time.Time, last time.Time) bool {
+		for _, j := range bySeller[account] {
+			closeTime := trades[j].LedgerCloseTime
+			f, l := first, last
+			if closeTime.Before(f) {
+				f = closeTime
+			}
+			if closeTime.After(l) {
+				l = closeTime
+			}
+			if l.Sub(f) > s.ClusterWindow {
+				continue
+			}
+
+			_, to, _ := transfer(trades[j])
+			if to == seller {
+				path = append(path, j)
+				return true
+			}
+			if depth+1 >= s.MaxClusterSize || visited[to] {
+				continue
+			}
+			visited[to] = true
+			path = append(path, j)
+			if search(to, depth+1, f, l) {
+				return true
+			}
+			path = path[:len(path)-1]
+			visited[to] = false
+		}
+		return false
+	}
+
+	if !search(buyer, 1, t, t) {
+		return nil
+	}
+	return append(path, i)
+}
+
+// medianPrice returns the median price of the (at most MedianTrades) trades
+// preceding trades[i] within MedianWindow that weren't flagged, if there are
+// at least MinMedianTrades of them. trades must be sorted by close time.
+func medianPrice(trades []tickerdb.Trade, flags []tickerdb.TradeFlags, i int, s Settings) (float64, bool) {
+	since := trades[i].LedgerCloseTime.Add(-s.MedianWindow)
+
+	var prices []float64
+	for j := i - 1; j >= 0 && len(prices) < s.MedianTrades; j-- {
+		if trades[j].LedgerCloseTime.Before(since) {
+			break
+		}
+		if flags[j].Any() {
+			continue
+		}
+		price, err := strconv.ParseFloat(trades[j].Price, 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		prices = append(prices, price)
+	}
+	if len(prices) == 0 || len(prices) < s.MinMedianTrades {
+		return 0, false
+	}
+
+	sort.Float64s(prices)
+	n := len(prices)
+	if n%2 == 1 {
+		return prices[n/2], true
+	}
+	return (prices[n/2-1] + prices[n/2]) / 2, true
+}
@@ -0,0 +1,150 @@
+package anomaly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	alice = "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+	bob   = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+	carol = "GA2YS6YBWIBUMUJCNYROC5TXYTTUA4TCZF7A4MJ2O4TTGT3LFNWIOMY4"
+	dave  = "GCO2IP3MJNUOKS4PUDI4C7LGGMQDJGXG3COYX3WSB4HHNAHKYV5YL3VC"
+)
+
+var start = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+// sale returns a trade in which seller sells the base asset to buyer at the
+// given price, minutes after start.
+func sale(id int64, minutes int, seller string, buyer string, price string) tickerdb.Trade {
+	return tickerdb.Trade{
+		ID:              id,
+		LedgerCloseTime: start.Add(time.Duration(minutes) * time.Minute),
+		BaseAccount:     seller,
+		CounterAccount:  buyer,
+		BaseAssetID:     1,
+		CounterAssetID:  2,
+		BaseIsSeller:    true,
+		Price:           price,
+	}
+}
+
+func TestDetectSelfTrades(t *testing.T) {
+	trades := []tickerdb.Trade{
+		sale(1, 0, alice, alice, "1.0"),
+		sale(2, 1, alice, bob, "1.0"),
+		// liquidity pool trades have a single account:
+		sale(3, 2, "", bob, "1.0"),
+	}
+
+	flags := Detect(trades, nil, DefaultSettings())
+	assert.Equal(t, map[int64]tickerdb.TradeFlags{
+		1: {SelfTrade: true},
+		2: {},
+		3: {},
+	}, flags)
+}
+
+func TestDetectWashTrades(t *testing.T) {
+	// alice -> bob -> carol -> alice within the cluster window, while dave
+	// only buys:
+	trades := []tickerdb.Trade{
+		sale(1, 0, alice, bob, "1.0"),
+		sale(2, 5, bob, carol, "1.0"),
+		sale(3, 10, alice, dave, "1.0"),
+		sale(4, 15, carol, alice, "1.0"),
+	}
+	flags := Detect(trades, nil, DefaultSettings())
+	assert.Equal(t, map[int64]tickerdb.TradeFlags{
+		1: {WashTrade: true},
+		2: {WashTrade: true},
+		3: {},
+		4: {WashTrade: true},
+	}, flags)
+
+	// loops through more accounts than MaxClusterSize are ignored...
+	s := DefaultSettings()
+	s.MaxClusterSize = 2
+	flags = Detect(trades, nil, s)
+	assert.False(t, flags[1].WashTrade)
+	assert.False(t, flags[4].WashTrade)
+
+	// ...as well as the ones spanning more than ClusterWindow
+	s = DefaultSettings()
+	s.ClusterWindow = 10 * time.Minute
+	flags = Detect(trades, nil, s)
+	assert.False(t, flags[1].WashTrade)
+	assert.False(t, flags[4].WashTrade)
+
+	// the direction of the base asset depends on BaseIsSeller
+	buy := sale(5, 20, alice, bob, "1.0")
+	buy.BaseIsSeller = false
+	flags = Detect([]tickerdb.Trade{sale(6, 25, alice, bob, "1.0"), buy}, nil, DefaultSettings())
+	assert.True(t, flags[5].WashTrade)
+	assert.True(t, flags[6].WashTrade)
+}
+
+func TestDetectWashTradesWithHistory(t *testing.T) {
+	// the earlier leg of a loop was already checked, and is returned along
+	// with the new trade, unlike the unchanged history trades:
+	history := []tickerdb.Trade{
+		sale(1, 0, alice, bob, "1.0"),
+		sale(2, 1, carol, dave, "1.0"),
+	}
+	for i := range history {
+		history[i].FlagsChecked = true
+	}
+
+	flags := Detect([]tickerdb.Trade{sale(3, 30, bob, alice, "1.0")}, history, DefaultSettings())
+	assert.Equal(t, map[int64]tickerdb.TradeFlags{
+		1: {WashTrade: true},
+		3: {WashTrade: true},
+	}, flags)
+
+	// trades of other markets aren't part of the loop
+	other := sale(4, 30, bob, alice, "1.0")
+	other.CounterAssetID = 3
+	flags = Detect([]tickerdb.Trade{other}, history, DefaultSettings())
+	assert.Equal(t, map[int64]tickerdb.TradeFlags{4: {}}, flags)
+}
+
+func TestDetectOutliers(t *testing.T) {
+	var history []tickerdb.Trade
+	for i, price := range []string{"1.0", "1.1", "0.9", "1.0", "1.2", "50.0"} {
+		trade := sale(int64(i+1), i, alice, bob, price)
+		trade.FlagsChecked = true
+		if price == "50.0" {
+			// flagged trades are left out of the median
+			trade.IsOutlier = true
+		}
+		history = append(history, trade)
+	}
+
+	trades := []tickerdb.Trade{
+		sale(10, 10, carol, dave, "1.05"),
+		sale(11, 11, carol, dave, "6.0"),
+		sale(12, 12, carol, dave, "0.1"),
+		sale(13, 13, carol, dave, "4.9"),
+	}
+	flags := Detect(trades, history, DefaultSettings())
+	assert.Equal(t, map[int64]tickerdb.TradeFlags{
+		10: {},
+		11: {Outlier: true},
+		12: {Outlier: true},
+		13: {},
+	}, flags)
+
+	// the median only includes the trades within MedianWindow...
+	late := sale(14, 48*60, carol, dave, "6.0")
+	flags = Detect([]tickerdb.Trade{late}, history, DefaultSettings())
+	assert.False(t, flags[14].Outlier)
+
+	// ...and markets need at least MinMedianTrades unflagged trades
+	s := DefaultSettings()
+	s.MinMedianTrades = 6
+	flags = Detect([]tickerdb.Trade{sale(15, 10, carol, dave, "6.0")}, history, s)
+	assert.False(t, flags[15].Outlier)
+}
@@ -0,0 +1,400 @@
+// Package config defines the ticker configuration file, which gathers the
+// Horizon server, the tracked issuers, the asset discard thresholds, the
+// retry budget of Horizon requests, the market stats windows and the output
+// paths. It's read from TOML (through support/config) or YAML files.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/ticker/internal/pricing"
+	"github.com/stellar/go/services/ticker/internal/scraper"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
+	"github.com/stellar/go/strkey"
+	supportconfig "github.com/stellar/go/support/config"
+	"github.com/stellar/go/support/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the ticker. Omitted settings keep their
+// default value (see Default), and command line flags set explicitly take
+// precedence over the file.
+type Config struct {
+	// ReloadInterval is how often the daemon checks whether the file was
+	// modified, reloading it if so (0 disables the reloads).
+	ReloadInterval Duration `toml:"reload_interval" yaml:"reload_interval" valid:"optional"`
+
+	Horizon   Horizon   `toml:"horizon" yaml:"horizon" valid:"optional"`
+	Retry     Retry     `toml:"retry" yaml:"retry" valid:"optional"`
+	Assets    Assets    `toml:"assets" yaml:"assets" valid:"optional"`
+	Markets   Markets   `toml:"markets" yaml:"markets" valid:"optional"`
+	Output    Output    `toml:"output" yaml:"output" valid:"optional"`
+	Retention Retention `toml:"retention" yaml:"retention" valid:"optional"`
+	Issuers   []Issuer  `toml:"issuers" yaml:"issuers" valid:"optional"`
+}
+
+// Horizon is the Horizon server the data is retrieved from. The public
+// network's SDF server (or the TestNet one, with --testnet) is used if URL is
+// empty.
+type Horizon struct {
+	URL               string `toml:"url" yaml:"url" valid:"optional"`
+	NetworkPassphrase string `toml:"network_passphrase" yaml:"network_passphrase" valid:"optional"`
+}
+
+// Retry is the retry budget of the requests to Horizon: each request is
+// attempted up to Attempts times, waiting Delay before the first retry and
+// doubling it (with some jitter) on each subsequent one.
+type Retry struct {
+	Attempts int      `toml:"attempts" yaml:"attempts" valid:"optional"`
+	Delay    Duration `toml:"delay" yaml:"delay" valid:"optional"`
+}
+
+// Assets tunes the asset refreshes: how they're fetched and which ones are
+// listed on the asset index (see scraper.NewAssetPolicy).
+type Assets struct {
+	Parallelism               int      `toml:"parallelism" yaml:"parallelism" valid:"optional"`
+	PageSize                  int      `toml:"page_size" yaml:"page_size" valid:"optional"`
+	MinSupply                 float64  `toml:"min_supply" yaml:"min_supply" valid:"optional"`
+	MinAccounts               int32    `toml:"min_accounts" yaml:"min_accounts" valid:"optional"`
+	TrustedAccounts           int32    `toml:"trusted_accounts" yaml:"trusted_accounts" valid:"optional"`
+	DiscardedCodes            []string `toml:"discarded_codes" yaml:"discarded_codes" valid:"optional"`
+	RequireHTTPSTOML          bool     `toml:"require_https_toml" yaml:"require_https_toml" valid:"optional"`
+	RequireDomainVerification bool     `toml:"require_domain_verification" yaml:"require_domain_verification" valid:"optional"`
+	SkipTOMLValidation        bool     `toml:"skip_toml_validation" yaml:"skip_toml_validation" valid:"optional"`
+	TOMLCacheTTL              Duration `toml:"toml_cache_ttl" yaml:"toml_cache_ttl" valid:"optional"`
+
+	// Allow and Deny are CODE:ISSUER or ISSUER entries of assets that are
+	// always (as long as they have a supply) or never listed.
+	Allow []string `toml:"allow" yaml:"allow" valid:"optional"`
+	Deny  []string `toml:"deny" yaml:"deny" valid:"optional"`
+}
+
+// Markets tunes the market stats and prices.
+type Markets struct {
+	Windows          []string `toml:"windows" yaml:"windows" valid:"optional"`
+	AggregateByCode  bool     `toml:"aggregate_by_code" yaml:"aggregate_by_code" valid:"optional"`
+	SlippageAmount   float64  `toml:"slippage_amount" yaml:"slippage_amount" valid:"optional"`
+	MinTradeSize     float64  `toml:"min_trade_size" yaml:"min_trade_size" valid:"optional"`
+	ReferenceAssets  []string `toml:"reference_assets" yaml:"reference_assets" valid:"optional"`
+	CandleResolution string   `toml:"candle_resolution" yaml:"candle_resolution" valid:"optional"`
+}
+
+// Output are the paths of the generated files.
+type Output struct {
+	AssetsFile    string `toml:"assets_file" yaml:"assets_file" valid:"optional"`
+	MarketsFile   string `toml:"markets_file" yaml:"markets_file" valid:"optional"`
+	CandlesFile   string `toml:"candles_file" yaml:"candles_file" valid:"optional"`
+	AggregatorDir string `toml:"aggregator_dir" yaml:"aggregator_dir" valid:"optional"`
+}
+
+// Retention is the number of days of trades (along with the pending trades),
+// orderbook snapshots and asset snapshots kept by the daemon's cleanups (0
+// keeps them all).
+type Retention struct {
+	TradesDays         int `toml:"trades_days" yaml:"trades_days" valid:"optional"`
+	OrderbookDays      int `toml:"orderbook_days" yaml:"orderbook_days" valid:"optional"`
+	AssetSnapshotsDays int `toml:"asset_snapshots_days" yaml:"asset_snapshots_days" valid:"optional"`
+}
+
+// Issuer is a tracked issuer, whose assets, orderbooks and trades are
+// refreshed by the filtered ingestion commands (and by the daemon, which
+// refreshes everything if no issuer is configured).
+type Issuer struct {
+	Account string `toml:"account" yaml:"account" valid:"optional"`
+	Name    string `toml:"name" yaml:"name" valid:"optional"`
+
+	// Trusted issuers have their assets indexed regardless of the discard
+	// thresholds.
+	Trusted bool `toml:"trusted" yaml:"trusted" valid:"optional"`
+
+	SkipAssets     bool `toml:"skip_assets" yaml:"skip_assets" valid:"optional"`
+	SkipOrderbooks bool `toml:"skip_orderbooks" yaml:"skip_orderbooks" valid:"optional"`
+	SkipTrades     bool `toml:"skip_trades" yaml:"skip_trades" valid:"optional"`
+}
+
+// Duration is a time.Duration read from a string such as "5s" or "1m30s".
+type Duration time.Duration
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// ValidationError lists all the problems of an invalid configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (err *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(err.Problems, "; ")
+}
+
+// Default returns the configuration used when no file is given, which
+// matches the defaults of the command line flags.
+func Default() *Config {
+	s := scraper.DefaultSettings()
+	return &Config{
+		ReloadInterval: Duration(30 * time.Second),
+		Retry: Retry{
+			Attempts: s.Retries,
+			Delay:    Duration(s.RetryDelay),
+		},
+		Assets: Assets{
+			Parallelism:      s.Parallelism,
+			PageSize:         s.PageSize,
+			MinAccounts:      s.Discard.MinAccounts,
+			TrustedAccounts:  s.Discard.TrustedAccounts,
+			DiscardedCodes:   s.Discard.DiscardedCodes,
+			RequireHTTPSTOML: s.Discard.RequireHTTPSTOML,
+			TOMLCacheTTL:     Duration(s.TOMLCacheTTL),
+		},
+		Markets: Markets{
+			Windows:          []string{},
+			SlippageAmount:   scraper.DefaultSlippageAmount,
+			ReferenceAssets:  append([]string(nil), pricing.DefaultReferenceAssets...),
+			CandleResolution: "1h",
+		},
+		Output: Output{
+			AssetsFile:    "assets.json",
+			MarketsFile:   "markets.json",
+			CandlesFile:   "candles.json",
+			AggregatorDir: ".",
+		},
+		Retention: Retention{
+			TradesDays:         7,
+			OrderbookDays:      30,
+			AssetSnapshotsDays: 365,
+		},
+	}
+}
+
+// Read reads and validates the configuration file at path: YAML if its
+// extension is .yml or .yaml, TOML otherwise. Unknown settings are rejected.
+func Read(path string) (*Config, error) {
+	cfg := Default()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		if err := readYAML(path, cfg); err != nil {
+			return nil, err
+		}
+	default:
+		err := supportconfig.Read(path, cfg)
+		if invalid, ok := errors.Cause(err).(*supportconfig.InvalidConfigError); ok {
+			return nil, fieldsToValidationError(invalid.InvalidFields)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// readYAML decodes the YAML file at path into cfg, validating it like
+// support/config does for TOML files.
+func readYAML(path string, cfg *Config) error {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(bs))
+	dec.KnownFields(true)
+	if err = dec.Decode(cfg); err != nil && err != io.EOF {
+		return errors.Wrap(err, "decode-file failed")
+	}
+
+	if valid, err := govalidator.ValidateStruct(cfg); !valid {
+		return fieldsToValidationError(govalidator.ErrorsByField(err))
+	}
+	return nil
+}
+
+// fieldsToValidationError converts the invalid fields reported by
+// govalidator to a *ValidationError.
+func fieldsToValidationError(fields map[string]string) *ValidationError {
+	err := &ValidationError{}
+	for field, msg := range fields {
+		err.Problems = append(err.Problems, field+": "+msg)
+	}
+	sort.Strings(err.Problems)
+	return err
+}
+
+// Validate checks every setting of c, returning a *ValidationError listing
+// all the problems found.
+func (c *Config) Validate() error {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.ReloadInterval < 0 {
+		addProblem("reload_interval: must not be negative")
+	}
+
+	if c.Horizon.URL != "" {
+		if u, err := url.Parse(c.Horizon.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			addProblem("horizon.url: %q is not an HTTP(S) URL", c.Horizon.URL)
+		}
+		if c.Horizon.NetworkPassphrase == "" {
+			addProblem("horizon.network_passphrase: required along with horizon.url")
+		}
+	} else if c.Horizon.NetworkPassphrase != "" {
+		addProblem("horizon.url: required along with horizon.network_passphrase")
+	}
+
+	if c.Retry.Attempts < 1 {
+		addProblem("retry.attempts: must be at least 1")
+	}
+	if c.Retry.Delay <= 0 {
+		addProblem("retry.delay: must be positive")
+	}
+
+	if c.Assets.Parallelism < 1 {
+		addProblem("assets.parallelism: must be at least 1")
+	}
+	if c.Assets.PageSize < 1 || c.Assets.PageSize > 200 {
+		addProblem("assets.page_size: must be between 1 and 200")
+	}
+	if c.Assets.MinAccounts < 0 {
+		addProblem("assets.min_accounts: must not be negative")
+	}
+	if c.Assets.TrustedAccounts < c.Assets.MinAccounts {
+		addProblem("assets.trusted_accounts: must be at least assets.min_accounts")
+	}
+	if c.Assets.MinSupply < 0 {
+		addProblem("assets.min_supply: must not be negative")
+	}
+	if c.Assets.TOMLCacheTTL < 0 {
+		addProblem("assets.toml_cache_ttl: must not be negative")
+	}
+	for _, list := range []struct {
+		name    string
+		entries []string
+	}{
+		{"assets.allow", c.Assets.Allow},
+		{"assets.deny", c.Assets.Deny},
+	} {
+		for _, entry := range list.entries {
+			if _, _, err := scraper.ParseAssetListEntry(entry); err != nil {
+				addProblem("%s: %v", list.name, err)
+			}
+		}
+	}
+
+	for _, w := range c.Markets.Windows {
+		if _, err := utils.ParseWindow(w); err != nil {
+			addProblem("markets.windows: %v", err)
+		}
+	}
+	if c.Markets.SlippageAmount <= 0 {
+		addProblem("markets.slippage_amount: must be positive")
+	}
+	if c.Markets.MinTradeSize < 0 {
+		addProblem("markets.min_trade_size: must not be negative")
+	}
+	if _, err := pricing.ParseReferences(c.Markets.ReferenceAssets); err != nil {
+		addProblem("markets.reference_assets: %v", err)
+	}
+	if _, ok := tickerdb.CandleResolutions[c.Markets.CandleResolution]; !ok {
+		addProblem("markets.candle_resolution: invalid resolution %q", c.Markets.CandleResolution)
+	}
+
+	for _, output := range []struct{ name, path string }{
+		{"output.assets_file", c.Output.AssetsFile},
+		{"output.markets_file", c.Output.MarketsFile},
+		{"output.candles_file", c.Output.CandlesFile},
+		{"output.aggregator_dir", c.Output.AggregatorDir},
+	} {
+		if output.path == "" {
+			addProblem("%s: must not be empty", output.name)
+		}
+	}
+
+	if c.Retention.TradesDays < 0 {
+		addProblem("retention.trades_days: must not be negative")
+	}
+	if c.Retention.OrderbookDays < 0 {
+		addProblem("retention.orderbook_days: must not be negative")
+	}
+	if c.Retention.AssetSnapshotsDays < 0 {
+		addProblem("retention.asset_snapshots_days: must not be negative")
+	}
+
+	seen := make(map[string]bool, len(c.Issuers))
+	for i, issuer := range c.Issuers {
+		if !strkey.IsValidEd25519PublicKey(issuer.Account) {
+			addProblem("issuers[%d].account: %q is not a valid account ID", i, issuer.Account)
+		} else if seen[issuer.Account] {
+			addProblem("issuers[%d].account: %s is listed more than once", i, issuer.Account)
+		}
+		seen[issuer.Account] = true
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ScraperSettings returns the scraper settings of c. TOML files aren't
+// validated on the TestNet.
+func (c *Config) ScraperSettings() scraper.Settings {
+	s := scraper.Settings{
+		PageSize:     c.Assets.PageSize,
+		Retries:      c.Retry.Attempts,
+		RetryDelay:   time.Duration(c.Retry.Delay),
+		Parallelism:  c.Assets.Parallelism,
+		TOMLCacheTTL: time.Duration(c.Assets.TOMLCacheTTL),
+		Discard: scraper.DiscardRules{
+			MinSupply:                 c.Assets.MinSupply,
+			MinAccounts:               c.Assets.MinAccounts,
+			TrustedAccounts:           c.Assets.TrustedAccounts,
+			DiscardedCodes:            c.Assets.DiscardedCodes,
+			RequireHTTPSTOML:          c.Assets.RequireHTTPSTOML,
+			RequireDomainVerification: c.Assets.RequireDomainVerification,
+			SkipTOMLValidation:        c.Assets.SkipTOMLValidation || c.Horizon.NetworkPassphrase == network.TestNetworkPassphrase,
+			TrustedIssuers:            make(map[string]bool),
+			AllowList:                 c.Assets.Allow,
+			DenyList:                  c.Assets.Deny,
+		},
+	}
+	for _, issuer := range c.Issuers {
+		if issuer.Trusted {
+			s.Discard.TrustedIssuers[issuer.Account] = true
+		}
+	}
+	return s
+}
+
+// IssuerAccounts returns the accounts of the issuers for which include
+// returns true.
+func (c *Config) IssuerAccounts(include func(Issuer) bool) []string {
+	var accounts []string
+	for _, issuer := range c.Issuers {
+		if include(issuer) {
+			accounts = append(accounts, issuer.Account)
+		}
+	}
+	return accounts
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issuer1 = "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN"
+const issuer2 = "GBNZILSTVQZ4R7IKQDGHYGY2QXL5QOFJYQMXPKWRRM5PAV7Y4M67AQUA"
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadTOML(t *testing.T) {
+	path := writeFile(t, "ticker.toml", `
+reload_interval = "1m"
+
+[horizon]
+url = "https://horizon-testnet.stellar.org"
+network_passphrase = "Test SDF Network ; September 2015"
+
+[retry]
+attempts = 3
+delay = "2s"
+
+[assets]
+min_accounts = 5
+min_supply = 100
+toml_cache_ttl = "30m"
+require_https_toml = false
+allow = ["USDC:`+issuer2+`"]
+deny = ["`+issuer2+`"]
+
+[markets]
+windows = ["1h", "30d"]
+
+[[issuers]]
+account = "`+issuer1+`"
+trusted = true
+
+[[issuers]]
+account = "`+issuer2+`"
+skip_orderbooks = true
+`)
+
+	cfg, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, Duration(time.Minute), cfg.ReloadInterval)
+	assert.Equal(t, "https://horizon-testnet.stellar.org", cfg.Horizon.URL)
+	assert.Equal(t, []string{"1h", "30d"}, cfg.Markets.Windows)
+	assert.Equal(t, []string{issuer1, issuer2}, cfg.IssuerAccounts(func(Issuer) bool { return true }))
+	assert.Equal(t, []string{issuer1}, cfg.IssuerAccounts(func(i Issuer) bool { return !i.SkipOrderbooks }))
+
+	// omitted settings keep their default value:
+	assert.Equal(t, Default().Output, cfg.Output)
+	assert.Equal(t, 20, cfg.Assets.Parallelism)
+
+	s := cfg.ScraperSettings()
+	assert.Equal(t, 3, s.Retries)
+	assert.Equal(t, 2*time.Second, s.RetryDelay)
+	assert.Equal(t, int32(5), s.Discard.MinAccounts)
+	assert.False(t, s.Discard.RequireHTTPSTOML)
+	assert.True(t, s.Discard.SkipTOMLValidation)
+	assert.Equal(t, map[string]bool{issuer1: true}, s.Discard.TrustedIssuers)
+	assert.Equal(t, 100.0, s.Discard.MinSupply)
+	assert.Equal(t, 30*time.Minute, s.TOMLCacheTTL)
+	assert.Equal(t, []string{"USDC:" + issuer2}, s.Discard.AllowList)
+	assert.Equal(t, []string{issuer2}, s.Discard.DenyList)
+}
+
+func TestReadYAML(t *testing.T) {
+	path := writeFile(t, "ticker.yaml", `
+retry:
+  attempts: 3
+  delay: 2s
+output:
+  markets_file: /var/www/markets.json
+issuers:
+  - account: `+issuer1+`
+    name: Circle
+`)
+
+	cfg, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, Duration(2*time.Second), cfg.Retry.Delay)
+	assert.Equal(t, "/var/www/markets.json", cfg.Output.MarketsFile)
+	assert.Equal(t, "assets.json", cfg.Output.AssetsFile)
+	assert.Equal(t, []Issuer{{Account: issuer1, Name: "Circle"}}, cfg.Issuers)
+
+	cfg, err = Read(writeFile(t, "empty.yml", ""))
+	require.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+}
+
+func TestReadInvalid(t *testing.T) {
+	_, err := Read(writeFile(t, "unknown.toml", "[assets]\nmin_acounts = 5\n"))
+	assert.Error(t, err)
+
+	_, err = Read(writeFile(t, "unknown.yml", "assets:\n  min_acounts: 5\n"))
+	assert.Error(t, err)
+
+	_, err = Read(writeFile(t, "invalid.toml", `
+[horizon]
+url = "horizon.stellar.org"
+
+[retry]
+attempts = 0
+
+[assets]
+toml_cache_ttl = "-1h"
+allow = ["USDC:GINVALID"]
+
+[markets]
+windows = ["1w"]
+min_trade_size = -1.0
+candle_resolution = "2h"
+
+[retention]
+trades_days = -1
+
+[[issuers]]
+account = "`+issuer1+`"
+
+[[issuers]]
+account = "`+issuer1+`"
+
+[[issuers]]
+account = "GINVALID"
+`))
+	require.Error(t, err)
+	validationErr, ok := err.(*ValidationError)
+	require.True(t, ok)
+	assert.Equal(t, []string{
+		`horizon.url: "horizon.stellar.org" is not an HTTP(S) URL`,
+		"horizon.network_passphrase: required along with horizon.url",
+		"retry.attempts: must be at least 1",
+		"assets.toml_cache_ttl: must not be negative",
+		`assets.allow: "USDC:GINVALID" has an invalid issuer`,
+		`markets.windows: invalid window "1w"`,
+		"markets.min_trade_size: must not be negative",
+		`markets.candle_resolution: invalid resolution "2h"`,
+		"retention.trades_days: must not be negative",
+		"issuers[1].account: " + issuer1 + " is listed more than once",
+		`issuers[2].account: "GINVALID" is not a valid account ID`,
+	}, validationErr.Problems)
+}
+
+func TestWatcher(t *testing.T) {
+	w, err := NewWatcher("")
+	require.NoError(t, err)
+	assert.Equal(t, Default(), w.Config())
+	reloaded, err := w.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	path := writeFile(t, "ticker.toml", "[retry]\nattempts = 3\n")
+	w, err = NewWatcher(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, w.Config().Retry.Attempts)
+
+	reloaded, err = w.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	// invalid changes are reported, keeping the previous configuration:
+	require.NoError(t, os.WriteFile(path, []byte("[retry]\nattempts = 0\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	_, err = w.Reload()
+	assert.Error(t, err)
+	assert.Equal(t, 3, w.Config().Retry.Attempts)
+	reloaded, err = w.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	require.NoError(t, os.WriteFile(path, []byte("[retry]\nattempts = 4\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+	reloaded, err = w.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, 4, w.Config().Retry.Attempts)
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// Watcher holds the configuration read from a file, reloading it when the
+// file is modified.
+type Watcher struct {
+	path string
+
+	mu      sync.RWMutex
+	cfg     *Config
+	modTime time.Time
+}
+
+// NewWatcher reads the configuration file at path. The default configuration
+// is used, and never reloaded, if path is empty.
+func NewWatcher(path string) (*Watcher, error) {
+	w := &Watcher{path: path, cfg: Default()}
+	if path == "" {
+		return w, nil
+	}
+
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Config returns the current configuration, which must not be modified.
+func (w *Watcher) Config() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.cfg
+}
+
+// Reload reads the configuration file again if it was modified since it was
+// last read, reporting whether the configuration changed. An invalid file is
+// reported (once per modification) without replacing the current
+// configuration.
+func (w *Watcher) Reload() (bool, error) {
+	if w.path == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.RLock()
+	unchanged := info.ModTime().Equal(w.modTime)
+	w.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cfg, err := Read(w.path)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.modTime = info.ModTime()
+	if err != nil {
+		return false, err
+	}
+	w.cfg = cfg
+	return true, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/stellar/go/services/ticker/internal/gql/static"
+	"github.com/stellar/go/services/ticker/internal/metrics"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
 	hlog "github.com/stellar/go/support/log"
 )
@@ -39,29 +40,136 @@ type asset struct {
 	Countries                   string
 	Status                      string
 	IssuerID                    int32
+	ContractID                  string
+	NumContracts                int32
+	ContractsAmount             float64
 	OrderbookStats              orderbookStats
+	PriceUSD                    float64
+	Prices                      []*assetPrice
+
+	// id and db are used to resolve the supplyHistory field.
+	id int32
+	db *tickerdb.TickerSession
+}
+
+// assetSupplySnapshot represents the supply breakdown of an asset
+// at a given time
+type assetSupplySnapshot struct {
+	Time                               graphql.Time
+	TotalSupply                        float64
+	CirculatingSupply                  float64
+	LockedSupply                       float64
+	Authorized                         float64
+	AuthorizedToMaintainLiabilities    float64
+	Unauthorized                       float64
+	ClaimableBalances                  float64
+	LiquidityPools                     float64
+	Contracts                          float64
+	ArchivedContracts                  float64
+	NumAccounts                        int32
+	NumAuthorizedToMaintainLiabilities int32
+	NumUnauthorized                    int32
+	NumClaimableBalances               int32
+	NumLiquidityPools                  int32
+	NumContracts                       int32
+}
+
+// issuer represents the issuer of Stellar assets
+type issuer struct {
+	PublicKey        string
+	Name             string
+	URL              string
+	TOMLURL          string
+	FederationServer string
+	AuthServer       string
+	TransferServer   string
+	WebAuthEndpoint  string
+	DepositServer    string
+	OrgTwitter       string
+
+	// id and db are used to resolve the assets field.
+	id int32
+	db *tickerdb.TickerSession
+}
+
+// assetPrice represents the price of an asset in units
+// of a fiat currency
+type assetPrice struct {
+	Currency string
+	Price    float64
+}
+
+// assetListing represents the latest decision of the asset
+// listing policy about an asset
+type assetListing struct {
+	Code         string
+	Issuer       string
+	Listed       bool
+	Rule         string
+	Reason       string
+	NumAccounts  int32
+	Amount       string
+	TOMLURL      string
+	EvaluatedAt  graphql.Time
+	HomeDomain   string
+	TOMLFindings []*tomlFinding
+}
+
+// tomlFinding represents a SEP-1 compliance issue of
+// the TOML file of an asset
+type tomlFinding struct {
+	Check    string
+	Severity string
+	Message  string
 }
 
 // partialMarket represents the aggregated market data for a
 // specific pair of assets since <Since>
 type partialMarket struct {
-	TradePair            string
-	BaseAssetCode        string
-	BaseAssetIssuer      string
-	CounterAssetCode     string
-	CounterAssetIssuer   string
-	BaseVolume           float64
-	CounterVolume        float64
-	TradeCount           int32
-	Open                 float64
-	Low                  float64
-	High                 float64
-	Change               float64
-	Close                float64
-	IntervalStart        graphql.Time
-	FirstLedgerCloseTime graphql.Time
-	LastLedgerCloseTime  graphql.Time
-	OrderbookStats       orderbookStats
+	TradePair              string
+	ID                     string
+	MarketIDs              []string
+	BaseAssetCode          string
+	BaseAssetIssuer        string
+	CounterAssetCode       string
+	CounterAssetIssuer     string
+	BaseVolume             float64
+	CounterVolume          float64
+	TradeCount             int32
+	Open                   float64
+	Low                    float64
+	High                   float64
+	Change                 float64
+	Close                  float64
+	IntervalStart          graphql.Time
+	FirstLedgerCloseTime   graphql.Time
+	LastLedgerCloseTime    graphql.Time
+	OrderbookStats         orderbookStats
+	OrderbookBaseVolume    float64
+	OrderbookCounterVolume float64
+	PoolBaseVolume         float64
+	PoolCounterVolume      float64
+	PoolStats              poolStats
+	BaseVolumeUSD          float64
+	CounterVolumeUSD       float64
+	PriceUSD               float64
+	BaseVolumeExact        string
+	CounterVolumeExact     string
+	OpenExact              string
+	LowExact               string
+	HighExact              string
+	CloseExact             string
+	CleanBaseVolume        float64
+	CleanCounterVolume     float64
+	FlaggedTradeCount      int32
+	VWAP                   float64
+	TWAP                   float64
+	MedianPrice            float64
+
+	// db and aggregated are used to resolve the orderbookHistory field, which
+	// is filtered by asset code only for markets aggregated by code.
+	db         *tickerdb.TickerSession
+	aggregated bool
 }
 
 // orderbookStats represents the orderbook stats for a
@@ -75,6 +183,106 @@ type orderbookStats struct {
 	AskMin         float64
 	Spread         float64
 	SpreadMidPoint float64
+	BidDepth2Pct   float64
+	BidDepth5Pct   float64
+	BidDepth10Pct  float64
+	AskDepth2Pct   float64
+	AskDepth5Pct   float64
+	AskDepth10Pct  float64
+	SlippageAmount float64
+	BuySlippage    float64
+	SellSlippage   float64
+}
+
+// poolStats represents the liquidity of the pools of a
+// specific pair of assets (aggregated or not)
+type poolStats struct {
+	BaseReserve    float64
+	CounterReserve float64
+	Price          float64
+	TVL            float64
+	FeeIncome24h   float64
+}
+
+// liquidityPool represents a liquidity pool (AMM) between
+// two assets
+type liquidityPool struct {
+	ID                 string
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	FeeBP              int32
+	TotalTrustlines    BigInt
+	TotalShares        float64
+	PoolStats          poolStats
+	UpdatedAt          graphql.Time
+}
+
+// candle represents the OHLCV data of a trade pair
+// for a single resolution bucket
+type candle struct {
+	TradePair     string
+	Resolution    string
+	OpenTime      graphql.Time
+	Open          float64
+	High          float64
+	Low           float64
+	Close         float64
+	BaseVolume    float64
+	CounterVolume float64
+	TradeCount    BigInt
+}
+
+// orderbookSnapshot represents the orderbook stats of a trade
+// pair over a single resolution bucket
+type orderbookSnapshot struct {
+	TradePair      string
+	Resolution     string
+	StartTime      graphql.Time
+	SnapshotCount  BigInt
+	BidCount       BigInt
+	BidVolume      float64
+	BidMax         float64
+	AskCount       BigInt
+	AskVolume      float64
+	AskMin         float64
+	Spread         float64
+	SpreadMidPoint float64
+}
+
+// trade represents a single trade, as published
+// to the trades subscription
+type trade struct {
+	ID                 string
+	TradePair          string
+	MarketID           string
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	BaseAmount         float64
+	CounterAmount      float64
+	Price              float64
+	BaseAmountExact    string
+	CounterAmountExact string
+	PriceExact         string
+	BaseIsSeller       bool
+	TradeType          string
+	LedgerCloseTime    graphql.Time
+}
+
+// orderbookUpdate represents the refreshed orderbook stats
+// of a market, as published to the orderbookUpdated subscription
+type orderbookUpdate struct {
+	TradePair          string
+	MarketID           string
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	OrderbookStats     orderbookStats
+	UpdatedAt          graphql.Time
 }
 
 type resolver struct {
@@ -90,8 +298,25 @@ func New(s *tickerdb.TickerSession, l *hlog.Entry) *resolver {
 	return &resolver{db: s, logger: l}
 }
 
-// Serve creates a GraphQL interface on <address>/graphql and a GraphiQL explorer on /graphiql
+// Serve creates a GraphQL interface on <address>/graphql, serves its subscriptions over
+// WebSocket on /graphql/ws, a GraphiQL explorer on /graphiql and exposes Prometheus
+// metrics on /metrics
 func (r *resolver) Serve(address string) {
+	server := &http.Server{
+		Addr:        address,
+		Handler:     r.NewServeMux(),
+		ReadTimeout: 5 * time.Second,
+	}
+	r.logger.Infof("Starting to serve on address %s\n", address)
+
+	if err := server.ListenAndServe(); err != nil {
+		r.logger.Error("server.ListenAndServe:", err)
+	}
+}
+
+// NewServeMux sets up the routes served by Serve, so that they can also be
+// served by other commands (e.g. the daemon).
+func (r *resolver) NewServeMux() *http.ServeMux {
 	relayHandler := r.NewRelayHandler()
 	mux := http.NewServeMux()
 	mux.Handle("/graphql", http.HandlerFunc(func(wr http.ResponseWriter, re *http.Request) {
@@ -103,23 +328,18 @@ func (r *resolver) Serve(address string) {
 		r.logger.Infof("%s %s %s\n", re.RemoteAddr, re.Method, re.URL)
 		relayHandler.ServeHTTP(wr, re)
 	}))
+	mux.Handle("/graphql/ws", r.NewSubscriptionHandler())
 	mux.Handle("/graphiql", GraphiQL{})
-
-	server := &http.Server{
-		Addr:        address,
-		Handler:     mux,
-		ReadTimeout: 5 * time.Second,
-	}
-	r.logger.Infof("Starting to serve on address %s\n", address)
-
-	if err := server.ListenAndServe(); err != nil {
-		r.logger.Error("server.ListenAndServe:", err)
-	}
+	mux.Handle("/metrics", metrics.Handler())
+	return mux
 }
 
 // NewRelayHandler sets up the response handler.
 func (r *resolver) NewRelayHandler() relay.Handler {
-	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	opts := []graphql.SchemaOpt{
+		graphql.UseFieldResolvers(),
+		graphql.Tracer(metricsTracer{}),
+	}
 	r.logger.Info("Validating GraphQL schema")
 	s := graphql.MustParseSchema(static.Schema(), r, opts...)
 	r.logger.Infof("Schema Validated!")
@@ -12,3 +12,9 @@ func TestValidateSchema(t *testing.T) {
 	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
 	graphql.MustParseSchema(static.Schema(), &r, opts...)
 }
+
+func TestValidateSubscriptionSchema(t *testing.T) {
+	r := subscriptionResolver{}
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	graphql.MustParseSchema(static.SubscriptionSchema(), &r, opts...)
+}
@@ -0,0 +1,157 @@
+package gql
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultPageSize is the number of items of a page when <first> isn't
+// provided, and maxPageSize the largest <first> allowed.
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// cursorPrefix is prepended to the keys of the items before encoding them
+// into opaque cursors.
+const cursorPrefix = "cursor:"
+
+// pageInfo represents the pagination info of a
+// connection, as defined by the Relay specification
+type pageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+}
+
+// assetConnection represents a page of assets
+type assetConnection struct {
+	Edges    []*assetEdge
+	PageInfo pageInfo
+}
+
+type assetEdge struct {
+	Cursor string
+	Node   *asset
+}
+
+// issuerConnection represents a page of issuers
+type issuerConnection struct {
+	Edges    []*issuerEdge
+	PageInfo pageInfo
+}
+
+type issuerEdge struct {
+	Cursor string
+	Node   *issuer
+}
+
+// marketConnection represents a page of markets, either
+// per market (Market) or aggregated (AggregatedMarket)
+type marketConnection struct {
+	Edges    []*marketEdge
+	PageInfo pageInfo
+}
+
+type marketEdge struct {
+	Cursor string
+	Node   *partialMarket
+}
+
+// liquidityPoolConnection represents a page of liquidity pools
+type liquidityPoolConnection struct {
+	Edges    []*liquidityPoolEdge
+	PageInfo pageInfo
+}
+
+type liquidityPoolEdge struct {
+	Cursor string
+	Node   *liquidityPool
+}
+
+// tradeConnection represents a page of trades
+type tradeConnection struct {
+	Edges    []*tradeEdge
+	PageInfo pageInfo
+}
+
+type tradeEdge struct {
+	Cursor string
+	Node   *trade
+}
+
+// validateFirst validates the <first> argument of the connection fields,
+// returning the size of the requested page.
+func validateFirst(first *int32) (int, error) {
+	if first == nil {
+		return defaultPageSize, nil
+	}
+
+	if *first < 0 || *first > maxPageSize {
+		return 0, fmt.Errorf("first must be between 0 and %d", maxPageSize)
+	}
+	return int(*first), nil
+}
+
+// encodeCursor returns the opaque cursor of the item with the given key.
+func encodeCursor(key string) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + key))
+}
+
+// decodeCursor returns the key of the item of an opaque cursor.
+func decodeCursor(cursor string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(b), cursorPrefix) {
+		return "", errors.New("invalid cursor")
+	}
+	return strings.TrimPrefix(string(b), cursorPrefix), nil
+}
+
+// decodeAfter decodes the optional <after> argument of the connection fields.
+func decodeAfter(after *string) (*string, error) {
+	if after == nil {
+		return nil, nil
+	}
+
+	key, err := decodeCursor(*after)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// newPageInfo returns the pageInfo of a page whose items have the given
+// cursors. Pages following a cursor are considered to have a previous page.
+func newPageInfo(cursors []string, hasNextPage bool, after *string) pageInfo {
+	info := pageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: after != nil,
+	}
+	if len(cursors) > 0 {
+		info.StartCursor = &cursors[0]
+		info.EndCursor = &cursors[len(cursors)-1]
+	}
+	return info
+}
+
+// pageBounds returns the bounds [start, end) of the page of at most limit
+// items following the item whose key is afterKey (or starting from the first
+// item if nil), among n items sorted by key. It is used to paginate the lists
+// that are computed as a whole (e.g. the markets).
+func pageBounds(n int, key func(i int) string, afterKey *string, limit int) (start, end int) {
+	if afterKey != nil {
+		start = sort.Search(n, func(i int) bool {
+			return key(i) > *afterKey
+		})
+	}
+
+	end = start + limit
+	if end > n {
+		end = n
+	}
+	return
+}
@@ -0,0 +1,91 @@
+package gql
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursors(t *testing.T) {
+	key, err := decodeCursor(encodeCursor("XLM:native/BTC:G1"))
+	require.NoError(t, err)
+	assert.Equal(t, "XLM:native/BTC:G1", key)
+
+	_, err = decodeCursor("not base64!")
+	assert.Error(t, err)
+	_, err = decodeCursor("Zm9vYmFy") // "foobar"
+	assert.Error(t, err)
+
+	closeTime := time.Date(2026, 10, 17, 12, 30, 0, 123000, time.UTC)
+	cursor, err := parseTradeCursor(strconv.FormatInt(closeTime.UnixNano(), 10) + "_42")
+	require.NoError(t, err)
+	assert.True(t, closeTime.Equal(cursor.LedgerCloseTime))
+	assert.Equal(t, int64(42), cursor.ID)
+
+	for _, key := range []string{"", "1", "a_1", "1_a", "1_2_3"} {
+		_, err = parseTradeCursor(key)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestValidateFirst(t *testing.T) {
+	n, err := validateFirst(nil)
+	require.NoError(t, err)
+	assert.Equal(t, defaultPageSize, n)
+
+	for _, first := range []int32{0, 10, maxPageSize} {
+		n, err = validateFirst(&first)
+		require.NoError(t, err)
+		assert.Equal(t, int(first), n)
+	}
+
+	for _, first := range []int32{-1, maxPageSize + 1} {
+		_, err = validateFirst(&first)
+		assert.Error(t, err)
+	}
+}
+
+func TestNewMarketConnection(t *testing.T) {
+	markets := []*partialMarket{
+		{TradePair: "XLM_ETH", ID: "XLM:native/ETH:G1"},
+		{TradePair: "XLM_BTC", ID: "XLM:native/BTC:G2"},
+		{TradePair: "XLM_BTC", ID: "XLM:native/BTC:G1"},
+	}
+	first := int32(2)
+
+	conn, err := newMarketConnection(markets, &first, nil)
+	require.NoError(t, err)
+	require.Len(t, conn.Edges, 2)
+	assert.Equal(t, "XLM:native/BTC:G1", conn.Edges[0].Node.ID)
+	assert.Equal(t, "XLM:native/BTC:G2", conn.Edges[1].Node.ID)
+	assert.True(t, conn.PageInfo.HasNextPage)
+	assert.False(t, conn.PageInfo.HasPreviousPage)
+	assert.Equal(t, conn.Edges[0].Cursor, *conn.PageInfo.StartCursor)
+	assert.Equal(t, conn.Edges[1].Cursor, *conn.PageInfo.EndCursor)
+
+	conn, err = newMarketConnection(markets, &first, conn.PageInfo.EndCursor)
+	require.NoError(t, err)
+	require.Len(t, conn.Edges, 1)
+	assert.Equal(t, "XLM:native/ETH:G1", conn.Edges[0].Node.ID)
+	assert.False(t, conn.PageInfo.HasNextPage)
+	assert.True(t, conn.PageInfo.HasPreviousPage)
+
+	conn, err = newMarketConnection(markets, &first, conn.PageInfo.EndCursor)
+	require.NoError(t, err)
+	assert.Empty(t, conn.Edges)
+	assert.Nil(t, conn.PageInfo.StartCursor)
+
+	// markets aggregated by code are sorted by trade pair name:
+	aggregated := []*partialMarket{{TradePair: "XLM_ETH"}, {TradePair: "XLM_BTC"}}
+	conn, err = newMarketConnection(aggregated, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, conn.Edges, 2)
+	assert.Equal(t, "XLM_BTC", conn.Edges[0].Node.TradePair)
+
+	invalid := "invalid"
+	_, err = newMarketConnection(markets, nil, &invalid)
+	assert.Error(t, err)
+}
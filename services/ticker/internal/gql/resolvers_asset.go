@@ -2,8 +2,13 @@ package gql
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strconv"
+	"time"
 
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/ticker/internal/pricing"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
 )
 
@@ -17,14 +22,171 @@ func (r *resolver) Assets(ctx context.Context) (assets []*asset, err error) {
 		return
 	}
 
+	prices, err := retrieveAssetPrices(ctx, r.db)
+	if err != nil {
+		return
+	}
+
 	for _, dbAsset := range dbAssets {
-		assets = append(assets, dbAssetToAsset(dbAsset))
+		a := dbAssetToAsset(dbAsset, r.db)
+		setAssetPrices(a, prices[dbAsset.ID])
+		assets = append(assets, a)
 	}
 	return
 }
 
+// Asset resolves the asset() GraphQL query.
+func (r *resolver) Asset(ctx context.Context, args struct {
+	Code   string
+	Issuer string
+}) (*asset, error) {
+	dbAsset, err := r.db.GetAsset(ctx, args.Code, args.Issuer)
+	if r.db.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	prices, err := retrieveAssetPrices(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	a := dbAssetToAsset(dbAsset, r.db)
+	setAssetPrices(a, prices[dbAsset.ID])
+	return a, nil
+}
+
+// AssetListing resolves the assetListing() GraphQL query.
+func (r *resolver) AssetListing(ctx context.Context, args struct {
+	Code   string
+	Issuer string
+}) (*assetListing, error) {
+	evaluation, err := r.db.GetAssetEvaluation(ctx, args.Code, args.Issuer)
+	if r.db.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	findings := []*tomlFinding{}
+	if err = json.Unmarshal([]byte(evaluation.TOMLFindings), &findings); err != nil {
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	return &assetListing{
+		Code:         evaluation.Code,
+		Issuer:       evaluation.IssuerAccount,
+		Listed:       evaluation.Listed,
+		Rule:         evaluation.Rule,
+		Reason:       evaluation.Reason,
+		NumAccounts:  evaluation.NumAccounts,
+		Amount:       evaluation.Amount,
+		TOMLURL:      evaluation.TOMLURL,
+		EvaluatedAt:  graphql.Time{Time: evaluation.EvaluatedAt},
+		HomeDomain:   evaluation.HomeDomain,
+		TOMLFindings: findings,
+	}, nil
+}
+
+// AssetsConnection resolves the assetsConnection() GraphQL query.
+func (r *resolver) AssetsConnection(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*assetConnection, error) {
+	return retrieveAssetConnection(ctx, r.db, nil, args.First, args.After)
+}
+
+// retrieveAssetConnection retrieves a page of valid assets, optionally
+// filtered by issuer.
+func retrieveAssetConnection(ctx context.Context,
+	db *tickerdb.TickerSession,
+	issuerID *int32,
+	first *int32,
+	after *string,
+) (*assetConnection, error) {
+	limit, err := validateFirst(first)
+	if err != nil {
+		return nil, err
+	}
+
+	var afterID int64
+	if after != nil {
+		key, err := decodeCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		if afterID, err = strconv.ParseInt(key, 10, 32); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+
+	// one more asset is retrieved to know whether there is a next page:
+	dbAssets, err := db.RetrieveAssetsPage(ctx, issuerID, int32(afterID), limit+1)
+	if err != nil {
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	hasNextPage := len(dbAssets) > limit
+	if hasNextPage {
+		dbAssets = dbAssets[:limit]
+	}
+
+	prices, err := retrieveAssetPrices(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &assetConnection{Edges: []*assetEdge{}}
+	var cursors []string
+	for _, dbAsset := range dbAssets {
+		a := dbAssetToAsset(dbAsset, db)
+		setAssetPrices(a, prices[dbAsset.ID])
+
+		cursor := encodeCursor(strconv.Itoa(int(dbAsset.ID)))
+		cursors = append(cursors, cursor)
+		conn.Edges = append(conn.Edges, &assetEdge{Cursor: cursor, Node: a})
+	}
+	conn.PageInfo = newPageInfo(cursors, hasNextPage, after)
+	return conn, nil
+}
+
+// retrieveAssetPrices retrieves the prices of all assets, keyed by asset ID.
+func retrieveAssetPrices(ctx context.Context, db *tickerdb.TickerSession) (map[int32][]*assetPrice, error) {
+	dbPrices, err := db.RetrieveAssetPrices(ctx)
+	if err != nil {
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	prices := make(map[int32][]*assetPrice)
+	for _, p := range dbPrices {
+		prices[p.AssetID] = append(prices[p.AssetID], &assetPrice{
+			Currency: p.Currency,
+			Price:    p.Price,
+		})
+	}
+	return prices, nil
+}
+
+// setAssetPrices sets the prices of an asset.
+func setAssetPrices(a *asset, prices []*assetPrice) {
+	a.Prices = []*assetPrice{}
+	for _, p := range prices {
+		if p.Currency == pricing.USD {
+			a.PriceUSD = p.Price
+		}
+		a.Prices = append(a.Prices, p)
+	}
+}
+
 // dbAssetToAsset converts a tickerdb.Asset to an *asset
-func dbAssetToAsset(dbAsset tickerdb.Asset) *asset {
+func dbAssetToAsset(dbAsset tickerdb.Asset, db *tickerdb.TickerSession) *asset {
 	return &asset{
 		Code:                        dbAsset.Code,
 		IssuerAccount:               dbAsset.IssuerAccount,
@@ -51,5 +213,74 @@ func dbAssetToAsset(dbAsset tickerdb.Asset) *asset {
 		Countries:                   dbAsset.Countries,
 		Status:                      dbAsset.Status,
 		IssuerID:                    dbAsset.IssuerID,
+		ContractID:                  dbAsset.ContractID,
+		NumContracts:                dbAsset.NumContracts,
+		ContractsAmount:             dbAsset.ContractsAmount,
+		id:                          dbAsset.ID,
+		db:                          db,
+	}
+}
+
+// SupplyHistory resolves the supplyHistory field of the Asset GraphQL type.
+func (a *asset) SupplyHistory(ctx context.Context, args struct {
+	Resolution string
+	From       graphql.Time
+	To         *graphql.Time
+}) (snapshots []*assetSupplySnapshot, err error) {
+	if _, ok := tickerdb.CandleResolutions[args.Resolution]; !ok {
+		err = errors.New("resolution must be one of 1m, 5m, 15m, 1h, 4h or 1d")
+		return
+	}
+
+	to := time.Now()
+	if args.To != nil {
+		to = args.To.Time
+	}
+	if !args.From.Before(to) {
+		err = errors.New("from must be before to")
+		return
+	}
+
+	dbHistory, err := a.db.RetrieveAssetSupplyHistory(ctx, a.id, args.Resolution, args.From.Time, to)
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		err = errors.New("could not retrieve the requested data")
+		return
+	}
+
+	snapshots = []*assetSupplySnapshot{}
+	for _, s := range dbHistory {
+		snapshots = append(snapshots, dbAssetSnapshotToAssetSupplySnapshot(s))
+	}
+	return
+}
+
+// dbAssetSnapshotToAssetSupplySnapshot converts a tickerdb.AssetSnapshot to
+// an *assetSupplySnapshot
+func dbAssetSnapshotToAssetSupplySnapshot(s tickerdb.AssetSnapshot) *assetSupplySnapshot {
+	amount := func(a string) float64 {
+		f, _ := strconv.ParseFloat(a, 64)
+		return f
+	}
+
+	return &assetSupplySnapshot{
+		Time:                               graphql.Time{Time: s.CreatedAt},
+		TotalSupply:                        s.TotalSupply(),
+		CirculatingSupply:                  s.CirculatingSupply(),
+		LockedSupply:                       s.LockedSupply(),
+		Authorized:                         amount(s.Authorized),
+		AuthorizedToMaintainLiabilities:    amount(s.AuthorizedToMaintainLiabilities),
+		Unauthorized:                       amount(s.Unauthorized),
+		ClaimableBalances:                  amount(s.ClaimableBalances),
+		LiquidityPools:                     amount(s.LiquidityPools),
+		Contracts:                          amount(s.Contracts),
+		ArchivedContracts:                  amount(s.ArchivedContracts),
+		NumAccounts:                        s.NumAccounts,
+		NumAuthorizedToMaintainLiabilities: s.NumAuthorizedToMaintainLiabilities,
+		NumUnauthorized:                    s.NumUnauthorized,
+		NumClaimableBalances:               s.NumClaimableBalances,
+		NumLiquidityPools:                  s.NumLiquidityPools,
+		NumContracts:                       s.NumContracts,
 	}
 }
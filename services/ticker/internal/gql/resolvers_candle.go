@@ -0,0 +1,61 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+// Candles resolves the candles() GraphQL query.
+func (r *resolver) Candles(ctx context.Context, args struct {
+	PairName   string
+	Resolution string
+	From       graphql.Time
+	To         *graphql.Time
+}) (candles []*candle, err error) {
+	if _, ok := tickerdb.CandleResolutions[args.Resolution]; !ok {
+		err = errors.New("resolution must be one of 1m, 5m, 15m, 1h, 4h or 1d")
+		return
+	}
+
+	to := time.Now()
+	if args.To != nil {
+		to = args.To.Time
+	}
+	if !args.From.Before(to) {
+		err = errors.New("from must be before to")
+		return
+	}
+
+	dbCandles, err := r.db.RetrieveCandles(ctx, &args.PairName, args.Resolution, args.From.Time, to)
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		err = errors.New("could not retrieve the requested data")
+		return
+	}
+
+	for _, dbCandle := range dbCandles {
+		candles = append(candles, dbCandleToCandle(dbCandle))
+	}
+	return
+}
+
+// dbCandleToCandle converts a tickerdb.PairCandle to a *candle
+func dbCandleToCandle(dbCandle tickerdb.PairCandle) *candle {
+	return &candle{
+		TradePair:     dbCandle.TradePairName,
+		Resolution:    dbCandle.Resolution,
+		OpenTime:      graphql.Time{Time: dbCandle.OpenTime},
+		Open:          dbCandle.Open,
+		High:          dbCandle.High,
+		Low:           dbCandle.Low,
+		Close:         dbCandle.Close,
+		BaseVolume:    dbCandle.BaseVolume,
+		CounterVolume: dbCandle.CounterVolume,
+		TradeCount:    BigInt(dbCandle.TradeCount),
+	}
+}
@@ -3,12 +3,13 @@ package gql
 import (
 	"context"
 	"errors"
+	"strconv"
 
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
 )
 
 // Issuers resolves the issuers() GraphQL query.
-func (r *resolver) Issuers(ctx context.Context) (issuers []*tickerdb.Issuer, err error) {
+func (r *resolver) Issuers(ctx context.Context) (issuers []*issuer, err error) {
 	dbIssuers, err := r.db.GetAllIssuers(ctx)
 	if err != nil {
 		// obfuscating sql errors to avoid exposing underlying
@@ -16,9 +17,98 @@ func (r *resolver) Issuers(ctx context.Context) (issuers []*tickerdb.Issuer, err
 		err = errors.New("could not retrieve the requested data")
 	}
 
-	for i := range dbIssuers {
-		issuers = append(issuers, &dbIssuers[i])
+	for _, dbIssuer := range dbIssuers {
+		issuers = append(issuers, dbIssuerToIssuer(dbIssuer, r.db))
 	}
 
 	return issuers, err
 }
+
+// Issuer resolves the issuer() GraphQL query.
+func (r *resolver) Issuer(ctx context.Context, args struct {
+	PublicKey string
+}) (*issuer, error) {
+	dbIssuer, err := r.db.GetIssuerByPublicKey(ctx, args.PublicKey)
+	if r.db.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	return dbIssuerToIssuer(dbIssuer, r.db), nil
+}
+
+// IssuersConnection resolves the issuersConnection() GraphQL query.
+func (r *resolver) IssuersConnection(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*issuerConnection, error) {
+	limit, err := validateFirst(args.First)
+	if err != nil {
+		return nil, err
+	}
+
+	var afterID int64
+	if args.After != nil {
+		key, err := decodeCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		if afterID, err = strconv.ParseInt(key, 10, 32); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+
+	// one more issuer is retrieved to know whether there is a next page:
+	dbIssuers, err := r.db.RetrieveIssuersPage(ctx, int32(afterID), limit+1)
+	if err != nil {
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	hasNextPage := len(dbIssuers) > limit
+	if hasNextPage {
+		dbIssuers = dbIssuers[:limit]
+	}
+
+	conn := &issuerConnection{Edges: []*issuerEdge{}}
+	var cursors []string
+	for _, dbIssuer := range dbIssuers {
+		cursor := encodeCursor(strconv.Itoa(int(dbIssuer.ID)))
+		cursors = append(cursors, cursor)
+		conn.Edges = append(conn.Edges, &issuerEdge{
+			Cursor: cursor,
+			Node:   dbIssuerToIssuer(dbIssuer, r.db),
+		})
+	}
+	conn.PageInfo = newPageInfo(cursors, hasNextPage, args.After)
+	return conn, nil
+}
+
+// Assets resolves the assets field of the Issuer GraphQL type.
+func (i *issuer) Assets(ctx context.Context, args struct {
+	First *int32
+	After *string
+}) (*assetConnection, error) {
+	return retrieveAssetConnection(ctx, i.db, &i.id, args.First, args.After)
+}
+
+// dbIssuerToIssuer converts a tickerdb.Issuer to an *issuer
+func dbIssuerToIssuer(dbIssuer tickerdb.Issuer, db *tickerdb.TickerSession) *issuer {
+	return &issuer{
+		PublicKey:        dbIssuer.PublicKey,
+		Name:             dbIssuer.Name,
+		URL:              dbIssuer.URL,
+		TOMLURL:          dbIssuer.TOMLURL,
+		FederationServer: dbIssuer.FederationServer,
+		AuthServer:       dbIssuer.AuthServer,
+		TransferServer:   dbIssuer.TransferServer,
+		WebAuthEndpoint:  dbIssuer.WebAuthEndpoint,
+		DepositServer:    dbIssuer.DepositServer,
+		OrgTwitter:       dbIssuer.OrgTwitter,
+		id:               dbIssuer.ID,
+		db:               db,
+	}
+}
@@ -0,0 +1,110 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+// LiquidityPools resolves the liquidityPools() GraphQL query.
+func (r *resolver) LiquidityPools(ctx context.Context, args struct {
+	BaseAssetCode      *string
+	BaseAssetIssuer    *string
+	CounterAssetCode   *string
+	CounterAssetIssuer *string
+}) (pools []*liquidityPool, err error) {
+	dbPools, err := r.db.RetrieveLiquidityPools(ctx,
+		args.BaseAssetCode,
+		args.BaseAssetIssuer,
+		args.CounterAssetCode,
+		args.CounterAssetIssuer,
+	)
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		err = errors.New("could not retrieve the requested data")
+		return
+	}
+
+	for _, dbPool := range dbPools {
+		pools = append(pools, dbLiquidityPoolToLiquidityPool(dbPool))
+	}
+	return
+}
+
+// LiquidityPoolsConnection resolves the liquidityPoolsConnection() GraphQL
+// query. Pools are sorted by ID.
+func (r *resolver) LiquidityPoolsConnection(ctx context.Context, args struct {
+	BaseAssetCode      *string
+	BaseAssetIssuer    *string
+	CounterAssetCode   *string
+	CounterAssetIssuer *string
+	First              *int32
+	After              *string
+}) (*liquidityPoolConnection, error) {
+	limit, err := validateFirst(args.First)
+	if err != nil {
+		return nil, err
+	}
+	afterKey, err := decodeAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	pools, err := r.LiquidityPools(ctx, struct {
+		BaseAssetCode      *string
+		BaseAssetIssuer    *string
+		CounterAssetCode   *string
+		CounterAssetIssuer *string
+	}{
+		BaseAssetCode:      args.BaseAssetCode,
+		BaseAssetIssuer:    args.BaseAssetIssuer,
+		CounterAssetCode:   args.CounterAssetCode,
+		CounterAssetIssuer: args.CounterAssetIssuer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].ID < pools[j].ID
+	})
+	start, end := pageBounds(len(pools), func(i int) string {
+		return pools[i].ID
+	}, afterKey, limit)
+
+	conn := &liquidityPoolConnection{Edges: []*liquidityPoolEdge{}}
+	var cursors []string
+	for i := start; i < end; i++ {
+		cursor := encodeCursor(pools[i].ID)
+		cursors = append(cursors, cursor)
+		conn.Edges = append(conn.Edges, &liquidityPoolEdge{Cursor: cursor, Node: pools[i]})
+	}
+	conn.PageInfo = newPageInfo(cursors, end < len(pools), args.After)
+	return conn, nil
+}
+
+// dbLiquidityPoolToLiquidityPool converts a tickerdb.MarketLiquidityPool to a *liquidityPool
+func dbLiquidityPoolToLiquidityPool(dbPool tickerdb.MarketLiquidityPool) *liquidityPool {
+	return &liquidityPool{
+		ID:                 dbPool.PoolID,
+		BaseAssetCode:      dbPool.BaseAssetCode,
+		BaseAssetIssuer:    dbPool.BaseAssetIssuer,
+		CounterAssetCode:   dbPool.CounterAssetCode,
+		CounterAssetIssuer: dbPool.CounterAssetIssuer,
+		FeeBP:              dbPool.FeeBP,
+		TotalTrustlines:    BigInt(dbPool.TotalTrustlines),
+		TotalShares:        dbPool.TotalShares,
+		PoolStats: poolStats{
+			BaseReserve:    dbPool.BaseReserve,
+			CounterReserve: dbPool.CounterReserve,
+			Price:          dbPool.Price,
+			TVL:            dbPool.TVL,
+			FeeIncome24h:   dbPool.FeeIncome24h,
+		},
+		UpdatedAt: graphql.Time{Time: dbPool.UpdatedAt},
+	}
+}
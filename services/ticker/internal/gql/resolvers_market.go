@@ -3,6 +3,8 @@ package gql
 import (
 	"context"
 	"errors"
+	"math"
+	"sort"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
@@ -16,11 +18,16 @@ func (r *resolver) Markets(ctx context.Context, args struct {
 	CounterAssetCode   *string
 	CounterAssetIssuer *string
 	NumHoursAgo        *int32
+	MinTradeSize       *float64
 }) (partialMarkets []*partialMarket, err error) {
 	numHours, err := validateNumHoursAgo(args.NumHoursAgo)
 	if err != nil {
 		return
 	}
+	minTradeSize, err := validateMinTradeSize(args.MinTradeSize)
+	if err != nil {
+		return
+	}
 
 	dbMarkets, err := r.db.RetrievePartialMarkets(ctx,
 		args.BaseAssetCode,
@@ -28,6 +35,7 @@ func (r *resolver) Markets(ctx context.Context, args struct {
 		args.CounterAssetCode,
 		args.CounterAssetIssuer,
 		numHours,
+		minTradeSize,
 	)
 	if err != nil {
 		// obfuscating sql errors to avoid exposing underlying
@@ -37,7 +45,9 @@ func (r *resolver) Markets(ctx context.Context, args struct {
 	}
 
 	for _, dbMkt := range dbMarkets {
-		partialMarkets = append(partialMarkets, dbMarketToPartialMarket(dbMkt))
+		pm := dbMarketToPartialMarket(dbMkt)
+		pm.db = r.db
+		partialMarkets = append(partialMarkets, pm)
 	}
 	return
 }
@@ -45,17 +55,24 @@ func (r *resolver) Markets(ctx context.Context, args struct {
 // Ticker resolves the ticker() GraphQL query (TODO)
 func (r *resolver) Ticker(ctx context.Context,
 	args struct {
-		Code        *string
-		PairName    *string
-		NumHoursAgo *int32
+		Code            *string
+		PairName        *string
+		NumHoursAgo     *int32
+		AggregateByCode *bool
+		MinTradeSize    *float64
 	},
 ) (partialMarkets []*partialMarket, err error) {
 	numHours, err := validateNumHoursAgo(args.NumHoursAgo)
 	if err != nil {
 		return
 	}
+	minTradeSize, err := validateMinTradeSize(args.MinTradeSize)
+	if err != nil {
+		return
+	}
 
-	dbMarkets, err := r.db.RetrievePartialAggMarkets(ctx, args.PairName, numHours)
+	aggregateByCode := args.AggregateByCode != nil && *args.AggregateByCode
+	dbMarkets, err := r.db.RetrievePartialAggMarkets(ctx, args.PairName, numHours, aggregateByCode, minTradeSize)
 	if err != nil {
 		// obfuscating sql errors to avoid exposing underlying
 		// implementation
@@ -64,12 +81,109 @@ func (r *resolver) Ticker(ctx context.Context,
 	}
 
 	for _, dbMkt := range dbMarkets {
-		partialMarkets = append(partialMarkets, dbMarketToPartialMarket(dbMkt))
+		pm := dbMarketToPartialMarket(dbMkt)
+		pm.db = r.db
+		pm.aggregated = aggregateByCode
+		partialMarkets = append(partialMarkets, pm)
 	}
 	return
 
 }
 
+// MarketsConnection resolves the marketsConnection() GraphQL query.
+func (r *resolver) MarketsConnection(ctx context.Context, args struct {
+	BaseAssetCode      *string
+	BaseAssetIssuer    *string
+	CounterAssetCode   *string
+	CounterAssetIssuer *string
+	NumHoursAgo        *int32
+	MinTradeSize       *float64
+	First              *int32
+	After              *string
+}) (*marketConnection, error) {
+	markets, err := r.Markets(ctx, struct {
+		BaseAssetCode      *string
+		BaseAssetIssuer    *string
+		CounterAssetCode   *string
+		CounterAssetIssuer *string
+		NumHoursAgo        *int32
+		MinTradeSize       *float64
+	}{
+		BaseAssetCode:      args.BaseAssetCode,
+		BaseAssetIssuer:    args.BaseAssetIssuer,
+		CounterAssetCode:   args.CounterAssetCode,
+		CounterAssetIssuer: args.CounterAssetIssuer,
+		NumHoursAgo:        args.NumHoursAgo,
+		MinTradeSize:       args.MinTradeSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newMarketConnection(markets, args.First, args.After)
+}
+
+// TickerConnection resolves the tickerConnection() GraphQL query.
+func (r *resolver) TickerConnection(ctx context.Context, args struct {
+	PairName        *string
+	NumHoursAgo     *int32
+	AggregateByCode *bool
+	MinTradeSize    *float64
+	First           *int32
+	After           *string
+}) (*marketConnection, error) {
+	markets, err := r.Ticker(ctx, struct {
+		Code            *string
+		PairName        *string
+		NumHoursAgo     *int32
+		AggregateByCode *bool
+		MinTradeSize    *float64
+	}{
+		PairName:        args.PairName,
+		NumHoursAgo:     args.NumHoursAgo,
+		AggregateByCode: args.AggregateByCode,
+		MinTradeSize:    args.MinTradeSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newMarketConnection(markets, args.First, args.After)
+}
+
+// newMarketConnection returns a page of markets, which are sorted by ID (or
+// by trade pair name when aggregated by code). As the stats of every market
+// are computed at once, they are paginated in memory.
+func newMarketConnection(markets []*partialMarket, first *int32, after *string) (*marketConnection, error) {
+	limit, err := validateFirst(first)
+	if err != nil {
+		return nil, err
+	}
+	afterKey, err := decodeAfter(after)
+	if err != nil {
+		return nil, err
+	}
+
+	key := func(i int) string {
+		if markets[i].ID != "" {
+			return markets[i].ID
+		}
+		return markets[i].TradePair
+	}
+	sort.Slice(markets, func(i, j int) bool {
+		return key(i) < key(j)
+	})
+	start, end := pageBounds(len(markets), key, afterKey, limit)
+
+	conn := &marketConnection{Edges: []*marketEdge{}}
+	var cursors []string
+	for i := start; i < end; i++ {
+		cursor := encodeCursor(key(i))
+		cursors = append(cursors, cursor)
+		conn.Edges = append(conn.Edges, &marketEdge{Cursor: cursor, Node: markets[i]})
+	}
+	conn.PageInfo = newPageInfo(cursors, end < len(markets), after)
+	return conn, nil
+}
+
 // validateNumHoursAgo validates if the numHoursAgo parameter is within an acceptable
 // time range (at most 168 hours ago = 7 days)
 func validateNumHoursAgo(n *int32) (int, error) {
@@ -84,6 +198,20 @@ func validateNumHoursAgo(n *int32) (int, error) {
 	return 0, errors.New("numHoursAgo cannot be greater than 168 (7 days)")
 }
 
+// validateMinTradeSize validates the minTradeSize parameter, the minimum value
+// in USD of the trades included in the price stats (0 by default).
+func validateMinTradeSize(size *float64) (float64, error) {
+	if size == nil {
+		return 0, nil
+	}
+
+	if *size >= 0 && !math.IsInf(*size, 0) {
+		return *size, nil
+	}
+
+	return 0, errors.New("minTradeSize must be a non-negative number")
+}
+
 // dbMarketToPartialMarket converts a tickerdb.PartialMarket to a *partialMarket
 func dbMarketToPartialMarket(dbMarket tickerdb.PartialMarket) *partialMarket {
 	spread, spreadMidPoint := utils.CalcSpread(dbMarket.HighestBid, dbMarket.LowestAsk)
@@ -96,25 +224,62 @@ func dbMarketToPartialMarket(dbMarket tickerdb.PartialMarket) *partialMarket {
 		AskMin:         dbMarket.LowestAsk,
 		Spread:         spread,
 		SpreadMidPoint: spreadMidPoint,
+		BidDepth2Pct:   dbMarket.BidDepth2Pct,
+		BidDepth5Pct:   dbMarket.BidDepth5Pct,
+		BidDepth10Pct:  dbMarket.BidDepth10Pct,
+		AskDepth2Pct:   dbMarket.AskDepth2Pct,
+		AskDepth5Pct:   dbMarket.AskDepth5Pct,
+		AskDepth10Pct:  dbMarket.AskDepth10Pct,
+		SlippageAmount: dbMarket.SlippageAmount,
+		BuySlippage:    dbMarket.BuySlippage,
+		SellSlippage:   dbMarket.SellSlippage,
 	}
 
 	return &partialMarket{
-		TradePair:            dbMarket.TradePairName,
-		BaseAssetCode:        dbMarket.BaseAssetCode,
-		BaseAssetIssuer:      dbMarket.BaseAssetIssuer,
-		CounterAssetCode:     dbMarket.CounterAssetCode,
-		CounterAssetIssuer:   dbMarket.CounterAssetIssuer,
-		BaseVolume:           dbMarket.BaseVolume,
-		CounterVolume:        dbMarket.CounterVolume,
-		TradeCount:           dbMarket.TradeCount,
-		Open:                 dbMarket.Open,
-		Low:                  dbMarket.Low,
-		High:                 dbMarket.High,
-		Change:               dbMarket.Change,
-		Close:                dbMarket.Close,
-		IntervalStart:        graphql.Time{Time: dbMarket.IntervalStart},
-		FirstLedgerCloseTime: graphql.Time{Time: dbMarket.FirstLedgerCloseTime},
-		LastLedgerCloseTime:  graphql.Time{Time: dbMarket.LastLedgerCloseTime},
-		OrderbookStats:       os,
+		TradePair:              dbMarket.TradePairName,
+		ID:                     dbMarket.MarketID,
+		MarketIDs:              tickerdb.SplitMarketIDs(dbMarket.MarketIDs),
+		BaseAssetCode:          dbMarket.BaseAssetCode,
+		BaseAssetIssuer:        dbMarket.BaseAssetIssuer,
+		CounterAssetCode:       dbMarket.CounterAssetCode,
+		CounterAssetIssuer:     dbMarket.CounterAssetIssuer,
+		BaseVolume:             dbMarket.BaseVolume,
+		CounterVolume:          dbMarket.CounterVolume,
+		TradeCount:             dbMarket.TradeCount,
+		Open:                   dbMarket.Open,
+		Low:                    dbMarket.Low,
+		High:                   dbMarket.High,
+		Change:                 dbMarket.Change,
+		Close:                  dbMarket.Close,
+		IntervalStart:          graphql.Time{Time: dbMarket.IntervalStart},
+		FirstLedgerCloseTime:   graphql.Time{Time: dbMarket.FirstLedgerCloseTime},
+		LastLedgerCloseTime:    graphql.Time{Time: dbMarket.LastLedgerCloseTime},
+		OrderbookStats:         os,
+		OrderbookBaseVolume:    dbMarket.OrderbookBaseVolume,
+		OrderbookCounterVolume: dbMarket.OrderbookCounterVolume,
+		PoolBaseVolume:         dbMarket.PoolBaseVolume,
+		PoolCounterVolume:      dbMarket.PoolCounterVolume,
+		PoolStats: poolStats{
+			BaseReserve:    dbMarket.PoolBaseReserve,
+			CounterReserve: dbMarket.PoolCounterReserve,
+			Price:          utils.CalcPoolPrice(dbMarket.PoolBaseReserve, dbMarket.PoolCounterReserve),
+			TVL:            dbMarket.PoolTVL,
+			FeeIncome24h:   dbMarket.PoolFeeIncome24h,
+		},
+		BaseVolumeUSD:      dbMarket.BaseVolumeUSD,
+		CounterVolumeUSD:   dbMarket.CounterVolumeUSD,
+		PriceUSD:           dbMarket.PriceUSD,
+		BaseVolumeExact:    dbMarket.BaseVolumeExact,
+		CounterVolumeExact: dbMarket.CounterVolumeExact,
+		OpenExact:          dbMarket.OpenExact,
+		LowExact:           dbMarket.LowExact,
+		HighExact:          dbMarket.HighExact,
+		CloseExact:         dbMarket.CloseExact,
+		CleanBaseVolume:    dbMarket.CleanBaseVolume,
+		CleanCounterVolume: dbMarket.CleanCounterVolume,
+		FlaggedTradeCount:  dbMarket.FlaggedTradeCount,
+		VWAP:               dbMarket.VWAP,
+		TWAP:               dbMarket.TWAP,
+		MedianPrice:        dbMarket.MedianPrice,
 	}
 }
@@ -0,0 +1,90 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+// OrderbookHistory resolves the orderbookHistory field of the Market and
+// AggregatedMarket GraphQL types.
+func (m *partialMarket) OrderbookHistory(ctx context.Context, args struct {
+	Resolution string
+	From       graphql.Time
+	To         *graphql.Time
+}) (snapshots []*orderbookSnapshot, err error) {
+	if _, ok := tickerdb.CandleResolutions[args.Resolution]; !ok {
+		err = errors.New("resolution must be one of 1m, 5m, 15m, 1h, 4h or 1d")
+		return
+	}
+
+	to := time.Now()
+	if args.To != nil {
+		to = args.To.Time
+	}
+	if !args.From.Before(to) {
+		err = errors.New("from must be before to")
+		return
+	}
+
+	var bCode, bIssuer, cCode, cIssuer *string
+	if m.aggregated {
+		codes := strings.Split(m.TradePair, "_")
+		if len(codes) != 2 {
+			err = errors.New("could not retrieve the requested data")
+			return
+		}
+		bCode, cCode = &codes[0], &codes[1]
+	} else {
+		b, bi, c, ci, pErr := tickerdb.ParseMarketID(m.ID)
+		if pErr != nil {
+			err = errors.New("could not retrieve the requested data")
+			return
+		}
+		bCode, bIssuer, cCode, cIssuer = &b, &bi, &c, &ci
+	}
+
+	dbHistory, err := m.db.RetrieveOrderbookHistory(ctx,
+		bCode,
+		bIssuer,
+		cCode,
+		cIssuer,
+		args.Resolution,
+		args.From.Time,
+		to,
+	)
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		err = errors.New("could not retrieve the requested data")
+		return
+	}
+
+	for _, entry := range dbHistory {
+		snapshots = append(snapshots, dbOrderbookHistoryToOrderbookSnapshot(entry))
+	}
+	return
+}
+
+// dbOrderbookHistoryToOrderbookSnapshot converts a tickerdb.OrderbookHistoryEntry
+// to an *orderbookSnapshot
+func dbOrderbookHistoryToOrderbookSnapshot(e tickerdb.OrderbookHistoryEntry) *orderbookSnapshot {
+	return &orderbookSnapshot{
+		TradePair:      e.TradePairName,
+		Resolution:     e.Resolution,
+		StartTime:      graphql.Time{Time: e.StartTime},
+		SnapshotCount:  BigInt(e.SnapshotCount),
+		BidCount:       BigInt(e.NumBids),
+		BidVolume:      e.BidVolume,
+		BidMax:         e.HighestBid,
+		AskCount:       BigInt(e.NumAsks),
+		AskVolume:      e.AskVolume,
+		AskMin:         e.LowestAsk,
+		Spread:         e.Spread,
+		SpreadMidPoint: e.SpreadMidPoint,
+	}
+}
@@ -0,0 +1,300 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/ticker/internal/metrics"
+	"github.com/stellar/go/services/ticker/internal/pubsub"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// subscriptionBuffer is the number of events buffered for each subscription.
+// Events published while the buffer is full are dropped.
+const subscriptionBuffer = 64
+
+// subscriptionResolver resolves the root types of the schema served over
+// WebSocket (see static.SubscriptionSchema).
+type subscriptionResolver struct {
+	db     *tickerdb.TickerSession
+	logger *hlog.Entry
+}
+
+// Subscriptions resolves the subscriptions() field of the SubscriptionQuery
+// GraphQL type.
+func (r *subscriptionResolver) Subscriptions() []string {
+	return []string{"trades", "marketUpdated", "orderbookUpdated"}
+}
+
+// Trades resolves the trades() GraphQL subscription.
+func (r *subscriptionResolver) Trades(ctx context.Context, args struct {
+	Pair string
+}) (<-chan *trade, error) {
+	f, err := parsePairFilter(args.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	events := pubsub.Subscribe(ctx, pubsub.TradesTopic, subscriptionBuffer)
+	trades := make(chan *trade, subscriptionBuffer)
+	go func() {
+		defer close(trades)
+		defer observeSubscription("trades")()
+
+		for event := range events {
+			t, ok := event.(pubsub.Trade)
+			if !ok || !f.matches(t.BaseAssetCode, t.BaseAssetIssuer, t.CounterAssetCode, t.CounterAssetIssuer) {
+				continue
+			}
+
+			select {
+			case trades <- eventToTrade(t):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return trades, nil
+}
+
+// MarketUpdated resolves the marketUpdated() GraphQL subscription. The market
+// stats are retrieved again whenever the market is traded or its orderbook is
+// refreshed, coalescing the events received in the meantime.
+func (r *subscriptionResolver) MarketUpdated(ctx context.Context, args struct {
+	Pair string
+}) (<-chan *partialMarket, error) {
+	f, err := parsePairFilter(args.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeEvents := pubsub.Subscribe(ctx, pubsub.TradesTopic, subscriptionBuffer)
+	orderbookEvents := pubsub.Subscribe(ctx, pubsub.OrderbooksTopic, subscriptionBuffer)
+	markets := make(chan *partialMarket, 1)
+	go func() {
+		defer close(markets)
+		defer observeSubscription("marketUpdated")()
+
+		for {
+			var updated bool
+			select {
+			case event, ok := <-tradeEvents:
+				if !ok {
+					return
+				}
+				t, isTrade := event.(pubsub.Trade)
+				updated = isTrade && f.matches(t.BaseAssetCode, t.BaseAssetIssuer, t.CounterAssetCode, t.CounterAssetIssuer)
+			case event, ok := <-orderbookEvents:
+				if !ok {
+					return
+				}
+				ob, isOrderbook := event.(pubsub.OrderbookUpdate)
+				updated = isOrderbook && f.matches(ob.BaseAssetCode, ob.BaseAssetIssuer, ob.CounterAssetCode, ob.CounterAssetIssuer)
+			}
+			if !updated {
+				continue
+			}
+
+			// the events queued meanwhile would lead to the same stats:
+			for len(tradeEvents) > 0 || len(orderbookEvents) > 0 {
+				select {
+				case <-tradeEvents:
+				case <-orderbookEvents:
+				}
+			}
+
+			pm, err := r.retrieveMarket(ctx, f)
+			if err != nil {
+				r.logger.Error("could not retrieve updated market:", err)
+				continue
+			}
+			if pm == nil {
+				continue
+			}
+
+			select {
+			case markets <- pm:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return markets, nil
+}
+
+// OrderbookUpdated resolves the orderbookUpdated() GraphQL subscription.
+func (r *subscriptionResolver) OrderbookUpdated(ctx context.Context, args struct {
+	Pair string
+}) (<-chan *orderbookUpdate, error) {
+	f, err := parsePairFilter(args.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	events := pubsub.Subscribe(ctx, pubsub.OrderbooksTopic, subscriptionBuffer)
+	updates := make(chan *orderbookUpdate, subscriptionBuffer)
+	go func() {
+		defer close(updates)
+		defer observeSubscription("orderbookUpdated")()
+
+		for event := range events {
+			ob, ok := event.(pubsub.OrderbookUpdate)
+			if !ok || !f.matches(ob.BaseAssetCode, ob.BaseAssetIssuer, ob.CounterAssetCode, ob.CounterAssetIssuer) {
+				continue
+			}
+
+			select {
+			case updates <- eventToOrderbookUpdate(ob):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// retrieveMarket retrieves the stats of the market matched by f over the last
+// 24 hours, or nil if it wasn't traded within them.
+func (r *subscriptionResolver) retrieveMarket(ctx context.Context, f pairFilter) (*partialMarket, error) {
+	pairName := f.tradePair
+	aggregateByCode := f.marketID == ""
+	if !aggregateByCode {
+		bCode, _, cCode, _, _ := tickerdb.ParseMarketID(f.marketID)
+		pairName = bCode + "_" + cCode
+	}
+
+	dbMarkets, err := r.db.RetrievePartialAggMarkets(ctx, &pairName, 24, aggregateByCode, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbMkt := range dbMarkets {
+		if aggregateByCode || dbMkt.MarketID == f.marketID {
+			pm := dbMarketToPartialMarket(dbMkt)
+			pm.db = r.db
+			pm.aggregated = aggregateByCode
+			return pm, nil
+		}
+	}
+	return nil, nil
+}
+
+// observeSubscription counts an active subscription in
+// metrics.GraphQLSubscriptions, returning the function to call once it ends.
+func observeSubscription(name string) func() {
+	metrics.GraphQLSubscriptions.WithLabelValues(name).Inc()
+	return func() {
+		metrics.GraphQLSubscriptions.WithLabelValues(name).Dec()
+	}
+}
+
+// pairFilter matches the markets identified by the pair argument of the
+// subscriptions and of the trades query: either a single market, by its
+// canonical ID, or every market between assets with the codes of a trade pair
+// name.
+type pairFilter struct {
+	marketID  string
+	tradePair string
+}
+
+// parsePairFilter parses a pair argument.
+func parsePairFilter(pair string) (f pairFilter, err error) {
+	if _, _, _, _, pErr := tickerdb.ParseMarketID(pair); pErr == nil {
+		f.marketID = pair
+		return
+	}
+
+	codes := strings.Split(pair, "_")
+	if len(codes) != 2 || codes[0] == "" || codes[1] == "" {
+		err = errors.New("pair must be a market ID (e.g. \"XLM:native/USD:G...\") or a trade pair name (e.g. \"XLM_BTC\")")
+		return
+	}
+	f.tradePair = pair
+	return
+}
+
+// matches reports whether the market between the given assets is matched by f.
+func (f pairFilter) matches(bCode, bIssuer, cCode, cIssuer string) bool {
+	if f.marketID != "" {
+		return f.marketID == tickerdb.MarketID(bCode, bIssuer, cCode, cIssuer)
+	}
+	return f.tradePair == bCode+"_"+cCode
+}
+
+// assets returns the codes and issuers of the assets of the markets matched
+// by f, for filtering queries. Issuers are nil for trade pair names.
+func (f pairFilter) assets() (bCode, bIssuer, cCode, cIssuer *string) {
+	if f.marketID != "" {
+		b, bi, c, ci, _ := tickerdb.ParseMarketID(f.marketID)
+		return &b, &bi, &c, &ci
+	}
+
+	codes := strings.Split(f.tradePair, "_")
+	return &codes[0], nil, &codes[1], nil
+}
+
+// eventToTrade converts a pubsub.Trade to a *trade
+func eventToTrade(t pubsub.Trade) *trade {
+	baseAmount, _ := strconv.ParseFloat(t.BaseAmount, 64)
+	counterAmount, _ := strconv.ParseFloat(t.CounterAmount, 64)
+	price, _ := strconv.ParseFloat(t.Price, 64)
+
+	return &trade{
+		ID:                 t.HorizonID,
+		TradePair:          t.BaseAssetCode + "_" + t.CounterAssetCode,
+		MarketID:           tickerdb.MarketID(t.BaseAssetCode, t.BaseAssetIssuer, t.CounterAssetCode, t.CounterAssetIssuer),
+		BaseAssetCode:      t.BaseAssetCode,
+		BaseAssetIssuer:    t.BaseAssetIssuer,
+		CounterAssetCode:   t.CounterAssetCode,
+		CounterAssetIssuer: t.CounterAssetIssuer,
+		BaseAmount:         baseAmount,
+		CounterAmount:      counterAmount,
+		Price:              price,
+		BaseAmountExact:    t.BaseAmount,
+		CounterAmountExact: t.CounterAmount,
+		PriceExact:         t.Price,
+		BaseIsSeller:       t.BaseIsSeller,
+		TradeType:          t.TradeType,
+		LedgerCloseTime:    graphql.Time{Time: t.LedgerCloseTime},
+	}
+}
+
+// eventToOrderbookUpdate converts a pubsub.OrderbookUpdate to an *orderbookUpdate
+func eventToOrderbookUpdate(ob pubsub.OrderbookUpdate) *orderbookUpdate {
+	s := ob.Stats
+	spread, spreadMidPoint := utils.CalcSpread(s.HighestBid, s.LowestAsk)
+
+	return &orderbookUpdate{
+		TradePair:          ob.BaseAssetCode + "_" + ob.CounterAssetCode,
+		MarketID:           tickerdb.MarketID(ob.BaseAssetCode, ob.BaseAssetIssuer, ob.CounterAssetCode, ob.CounterAssetIssuer),
+		BaseAssetCode:      ob.BaseAssetCode,
+		BaseAssetIssuer:    ob.BaseAssetIssuer,
+		CounterAssetCode:   ob.CounterAssetCode,
+		CounterAssetIssuer: ob.CounterAssetIssuer,
+		OrderbookStats: orderbookStats{
+			BidCount:       BigInt(s.NumBids),
+			BidVolume:      s.BidVolume,
+			BidMax:         s.HighestBid,
+			AskCount:       BigInt(s.NumAsks),
+			AskVolume:      s.AskVolume,
+			AskMin:         s.LowestAsk,
+			Spread:         spread,
+			SpreadMidPoint: spreadMidPoint,
+			BidDepth2Pct:   s.BidDepth2Pct,
+			BidDepth5Pct:   s.BidDepth5Pct,
+			BidDepth10Pct:  s.BidDepth10Pct,
+			AskDepth2Pct:   s.AskDepth2Pct,
+			AskDepth5Pct:   s.AskDepth5Pct,
+			AskDepth10Pct:  s.AskDepth10Pct,
+			SlippageAmount: s.SlippageAmount,
+			BuySlippage:    s.BuySlippage,
+			SellSlippage:   s.SellSlippage,
+		},
+		UpdatedAt: graphql.Time{Time: s.UpdatedAt},
+	}
+}
@@ -0,0 +1,126 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+// Trades resolves the trades() GraphQL query.
+func (r *resolver) Trades(ctx context.Context, args struct {
+	Pair  string
+	From  *graphql.Time
+	To    *graphql.Time
+	First *int32
+	After *string
+}) (*tradeConnection, error) {
+	f, err := parsePairFilter(args.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := validateFirst(args.First)
+	if err != nil {
+		return nil, err
+	}
+
+	to := time.Now()
+	if args.To != nil {
+		to = args.To.Time
+	}
+	from := to.Add(-24 * time.Hour)
+	if args.From != nil {
+		from = args.From.Time
+	}
+	if !from.Before(to) {
+		return nil, errors.New("from must be before to")
+	}
+
+	var after *tickerdb.TradeCursor
+	if args.After != nil {
+		key, err := decodeCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		if after, err = parseTradeCursor(key); err != nil {
+			return nil, err
+		}
+	}
+
+	bCode, bIssuer, cCode, cIssuer := f.assets()
+
+	// one more trade is retrieved to know whether there is a next page:
+	dbTrades, err := r.db.RetrieveTradesPage(ctx, bCode, bIssuer, cCode, cIssuer, from, to, after, limit+1)
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		return nil, errors.New("could not retrieve the requested data")
+	}
+
+	hasNextPage := len(dbTrades) > limit
+	if hasNextPage {
+		dbTrades = dbTrades[:limit]
+	}
+
+	conn := &tradeConnection{Edges: []*tradeEdge{}}
+	var cursors []string
+	for _, dbTrade := range dbTrades {
+		cursor := encodeCursor(fmt.Sprintf("%d_%d", dbTrade.LedgerCloseTime.UnixNano(), dbTrade.ID))
+		cursors = append(cursors, cursor)
+		conn.Edges = append(conn.Edges, &tradeEdge{Cursor: cursor, Node: dbTradeToTrade(dbTrade)})
+	}
+	conn.PageInfo = newPageInfo(cursors, hasNextPage, args.After)
+	return conn, nil
+}
+
+// parseTradeCursor parses the key of a trade cursor, made of its close time
+// (in nanoseconds) and ID.
+func parseTradeCursor(key string) (*tickerdb.TradeCursor, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	closeTime, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &tickerdb.TradeCursor{LedgerCloseTime: time.Unix(0, closeTime), ID: id}, nil
+}
+
+// dbTradeToTrade converts a tickerdb.TradeWithAssets to a *trade
+func dbTradeToTrade(t tickerdb.TradeWithAssets) *trade {
+	baseAmount, _ := strconv.ParseFloat(t.BaseAmount, 64)
+	counterAmount, _ := strconv.ParseFloat(t.CounterAmount, 64)
+	price, _ := strconv.ParseFloat(t.Price, 64)
+
+	return &trade{
+		ID:                 t.HorizonID,
+		TradePair:          t.BaseAssetCode + "_" + t.CounterAssetCode,
+		MarketID:           tickerdb.MarketID(t.BaseAssetCode, t.BaseAssetIssuer, t.CounterAssetCode, t.CounterAssetIssuer),
+		BaseAssetCode:      t.BaseAssetCode,
+		BaseAssetIssuer:    t.BaseAssetIssuer,
+		CounterAssetCode:   t.CounterAssetCode,
+		CounterAssetIssuer: t.CounterAssetIssuer,
+		BaseAmount:         baseAmount,
+		CounterAmount:      counterAmount,
+		Price:              price,
+		BaseAmountExact:    t.BaseAmount,
+		CounterAmountExact: t.CounterAmount,
+		PriceExact:         t.Price,
+		BaseIsSeller:       t.BaseIsSeller,
+		TradeType:          t.TradeType,
+		LedgerCloseTime:    graphql.Time{Time: t.LedgerCloseTime},
+	}
+}
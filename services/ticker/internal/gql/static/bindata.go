@@ -1,7 +1,8 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
 // graphiql.html (1.182kB)
-// schema.gql (2.42kB)
+// schema.gql (13.655kB)
+// subscription.gql (822B)
 
 package static
 
@@ -70,7 +71,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _graphiqlHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x54\x4f\x6f\x13\x3f\x10\x3d\x6f\x3e\x85\x7f\x96\x7e\xd2\x46\x2a\x76\x52\x24\x0e\x9b\x4d\x0e\xd0\xaa\x02\x15\x4a\x81\x0b\x47\xd7\x9e\x5d\x3b\x78\xed\xed\xd8\x9b\x36\xaa\xf2\xdd\x91\xf7\x4f\x28\x7f\x2a\x21\x04\x17\xaf\x3d\x7e\xf3\xde\xf3\xcc\x68\xcb\xff\xce\xae\x5e\x7d\xfa\xfc\xfe\x9c\xe8\xd8\xd8\xcd\xac\x1c\x3e\x59\xa9\x41\xa8\xcd\x2c\xcb\x4a\x6b\xdc\x17\x82\x60\xd7\x34\xc4\xbd\x85\xa0\x01\x22\x25\x1a\xa1\x5a\x53\x1d\x63\x1b\x0a\xce\xa5\x72\xdb\xc0\xa4\xf5\x9d\xaa\xac\x40\x60\xd2\x37\x5c\x6c\xc5\x3d\xb7\xe6\x26\xf0\x1a\x45\xab\xcd\xad\xe5\x0b\xb6\x5c\xb2\xe5\xf2\x18\x60\x32\x04\xca\x7b\x99\x20\xd1\xb4\x91\x04\x94\xbf\x4d\x5b\x41\x94\x9a\x9f\xb2\x05\x7b\x3e\xec\x59\x63\x1c\xdb\x06\xba\x29\xf9\x40\xf7\xa7\xcc\x08\x42\x46\xbe\x7c\xc1\x4e\xd9\x82\x77\x8d\x1a\x02\xac\x45\xaf\x3a\x19\x8d\x77\x7f\x57\xe9\x99\xf2\xcd\x4f\x6a\x29\xf8\x2f\x14\x9f\x6e\xc6\x2f\x14\x4a\x3e\xce\x41\x79\xe3\xd5\x9e\xf4\x13\xb0\xa6\x77\x46\x45\x5d\x90\xe5\x62\xf1\xff\x8a\x68\x30\xb5\x8e\xd3\xa9\x11\x58\x1b\x57\x90\xc5\x8a\xf8\x1d\x60\x65\xfd\x5d\x41\xb4\x51\x0a\xdc\x8a\xf6\x96\x95\xd9\x11\xa3\xd6\x74\x92\xa5\x13\xeb\x23\xa2\x9d\x5e\xd1\xcd\xa5\x17\xca\xb8\x9a\x31\x56\x72\x65\x76\x8f\xde\x9b\xb6\x59\xd5\xb9\xbe\x30\xa4\x6f\xfd\xc5\xf5\x65\xde\x0a\x14\x4d\x98\x93\x87\x74\x9d\x21\xc4\x0e\xc7\xdb\x9c\x0e\xaf\xbc\xb5\xf4\x64\xbc\xce\x1a\x88\xda\xab\x82\xd0\xd6\x87\x48\x4f\x86\x60\x7a\x65\x41\xde\x7c\xbc\x7a\xc7\x42\x44\xe3\x6a\x53\xed\x27\xde\x11\x22\x11\x14\xb8\x68\x84\x0d\x05\xa1\xc6\x49\xdb\x29\x18\xf3\x0f\x73\x16\x35\xb8\xfc\xe8\x2d\x47\x08\xed\xe4\x68\xb2\x94\x62\x2c\xc2\x7d\xcc\xe7\xab\x27\xd2\x92\x8f\x63\x5a\xc4\xfd\xb4\x9d\x28\x7a\x87\xad\xc0\x00\x03\x74\xe0\xc9\x0e\x44\x8a\x28\x35\xc9\x01\xd1\xe3\xfc\xc7\xac\x04\x9d\x90\xa3\x70\x7f\x3c\xcc\xd2\xfa\x21\x4d\xdd\xd9\xd5\x5b\x86\xe0\x14\x60\xde\x23\xfa\x20\x93\x08\x22\xc2\xb9\x85\x06\x5c\xcc\x2f\xfa\xce\x5d\x5f\x9e\x90\x87\xbe\xba\x80\xc5\xb1\x09\x87\xb1\x4c\xca\xcb\x2e\x81\x59\x0d\x71\xcc\x7b\xb9\x7f\xad\xf2\x6f\x6d\x9f\x27\x5c\x5a\xbe\x1b\xb7\x64\x71\x33\x2b\xf9\xf0\x1b\xfa\x1a\x00\x00\xff\xff\xdb\x8e\x2c\x18\x9e\x04\x00\x00")
+var _graphiqlHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x52\x4b\x6f\x13\x3d\x14\x5d\x4f\x7e\x85\x3f\x4b\x9f\x34\x91\x8a\x9d\x14\x89\xc5\x64\x92\x05\xb4\xaa\x40\x85\x52\x60\xc3\xd2\xb5\x6f\x62\x07\x8f\x67\x7a\x7d\x27\x6d\x54\xe5\xbf\x23\xcf\x23\x94\x47\x25\x84\x60\x33\x63\x1f\xdf\x7b\xce\xb9\x8f\xf2\xbf\xb3\xab\x57\x9f\x3e\xbf\x3f\x67\x96\x2a\xbf\x9a\x94\xfd\x2f\x2b\x2d\x28\xb3\x9a\x64\x59\xe9\x5d\xf8\xc2\x10\xfc\x92\x47\xda\x7b\x88\x16\x80\x38\xb3\x08\xeb\x25\xb7\x44\x4d\x2c\xa4\xd4\x26\x6c\xa3\xd0\xbe\x6e\xcd\xda\x2b\x04\xa1\xeb\x4a\xaa\xad\xba\x97\xde\xdd\x44\xb9\x41\xd5\x58\x77\xeb\xe5\x4c\xcc\xe7\x62\x3e\x3f\x02\x42\xc7\xc8\x65\x27\x13\x35\xba\x86\x58\x44\xfd\xdb\xb4\x6b\x20\x6d\xe5\xa9\x98\x89\xe7\xfd\x59\x54\x2e\x88\x6d\xe4\xab\x52\xf6\x74\x7f\xca\x8c\xa0\x34\xc9\xf9\x0b\x71\x2a\x66\xb2\xad\x4c\x0f\x88\x06\x6b\xd3\x6a\x72\x75\xf8\xbb\x4a\xcf\x4c\x5d\xfd\xa4\x96\xc0\x7f\xa1\xf8\xf4\x30\x7e\xa1\x50\xca\x61\x0f\xca\x9b\xda\xec\x59\xb7\x01\x4b\x7e\xe7\x0c\xd9\x82\xcd\x67\xb3\xff\x17\xcc\x82\xdb\x58\x1a\x6f\x95\xc2\x8d\x0b\x05\x9b\x2d\x58\xbd\x03\x5c\xfb\xfa\xae\x60\xd6\x19\x03\x61\xc1\x3b\xcb\xc6\xed\x98\x33\x4b\x3e\xca\xf2\x91\xf5\x11\xd1\xce\x2e\xf8\xea\xb2\x56\xc6\x85\x8d\x10\xa2\x94\xc6\xed\x1e\xd5\x9b\x8e\xd9\xba\x0d\x5d\x63\x58\x37\xfa\x8b\xeb\xcb\xbc\x51\xa8\xaa\x38\x65\x0f\xe9\x39\x43\xa0\x16\x87\xd7\x9c\xf7\x55\xde\x7a\x7e\x32\x3c\x67\x15\x90\xad\x4d\xc1\x78\x53\x47\xe2\x27\x3d\x98\xaa\x2c\xd8\x9b\x8f\x57\xef\x44\x24\x74\x61\xe3\xd6\xfb\x91\x77\x08\xd1\x08\x06\x02\x39\xe5\x63\xc1\xb8\x0b\xda\xb7\x06\x86\xfc\xc3\x54\x90\x85\x90\x1f\xbd\xe5\x08\xb1\x19\x1d\x8d\x96\x12\x26\x08\xee\x29\x9f\x2e\x9e\x48\x4b\x3e\x8e\x69\x84\xfb\xf1\x38\x52\x74\x0e\x1b\x85\x11\xfa\xd0\x9e\x27\x3b\x30\xad\x48\x5b\x96\x03\x62\x8d\xd3\x1f\xb3\x52\xe8\x18\x39\x08\x77\xd7\xc3\x24\x7d\x3f\xa4\x55\x3c\xbb\x7a\x2b\x10\x82\x01\xcc\x13\xd6\x83\x42\x23\x28\x82\x73\x0f\x15\x04\xca\x2f\x52\x2b\xdd\xf5\xe5\x09\x7b\xe8\xba\x0b\x58\x1c\x87\x70\x18\xda\x64\x6a\xdd\xa6\x60\xb1\x01\x1a\xf2\x5e\xee\x5f\x9b\xfc\xdb\xd8\xa7\x29\x2e\x7d\xbe\x5b\xb7\x64\x71\x35\x29\xa5\xa5\xca\xaf\x26\x5f\x07\x00\xdb\x8e\x2c\x18\x9e\x04\x00\x00")
 
 func graphiqlHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -90,7 +91,7 @@ func graphiqlHtml() (*asset, error) {
 	return a, nil
 }
 
-var _schemaGql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x54\x51\x6f\xe3\x36\x0c\x7e\xb6\x7f\x05\xdb\xbd\xb4\x2f\x79\x18\xf6\x64\x6c\x03\xd2\x76\xc3\x8a\x35\xb7\xdb\xa5\x37\x0c\x28\x86\x81\xb1\x18\x87\x88\x2c\xf9\x28\x29\x6d\x70\xe8\x7f\x1f\x24\x27\xa9\x6c\xb7\xdd\x0f\xb8\x27\x5b\x24\x3f\x8a\xfc\xf4\x91\xae\xde\x50\x8b\xf0\xb5\x2c\xbe\x04\x92\x7d\x05\xc5\x9f\xf1\x5b\x3e\x97\xa5\xdf\x77\x04\xe9\x14\xdd\xdf\x81\x90\x17\xa6\x1d\x01\x6a\x0d\x3b\xd4\xac\xd0\x93\x02\x74\x8e\xbc\x03\x6b\xc0\x6f\x08\x96\x9e\xb4\x46\x01\x43\xfe\xd1\xca\x76\x56\x16\xbd\xbf\x82\x87\x79\xfc\x39\xfb\xe7\xac\x7c\x27\x19\x3b\x17\x48\xde\xc9\x76\x08\xa8\xe0\xe1\x36\xfd\x4d\xf2\x79\x41\x45\xe0\x3c\x7a\x07\x6b\xb1\x6d\xca\xa3\xd1\x79\xf8\xd1\x84\xf6\x37\x1b\xc4\xcd\x1b\xfb\x33\x6c\xe2\x5f\x44\x5e\x28\x5a\x63\xd0\x1e\x7e\x82\xef\x7f\xe8\xcd\x97\x33\xb0\x9d\x67\x6b\x50\xeb\x3d\x74\x62\x77\xac\x08\x6a\x1b\x8c\x27\x01\x34\x2a\xe2\x56\xe8\xa8\x6f\x1e\xd8\xac\x2d\xac\xad\xc0\x9a\xb5\x27\x61\xd3\xcc\xca\xa2\x45\xd9\x92\x77\x17\x65\x51\xc4\xd0\xd4\xfd\xb5\x55\x54\xc1\xd2\xc7\x90\xdc\xde\xf7\x92\x79\x0e\x77\xbd\x06\xca\x5d\x13\x5c\xd6\x62\x05\xb7\xc6\x97\xc5\x65\x05\x0f\x8b\x54\xca\x84\xf9\xa6\x11\x6a\x12\xed\x03\xd2\xac\xbc\xc1\x59\x44\x27\x7e\x5e\xa5\x07\xa1\x43\x96\x0f\xd8\x12\x5c\xd0\xac\x99\xc1\xf9\xdf\x77\x8b\x7f\xaf\xee\xaf\xcf\xc1\x0a\x20\x44\xb4\x63\xd3\x68\x82\x3a\x88\x90\xa9\xf7\x59\xe0\xf9\xe5\x90\x40\x10\x72\x41\x7b\x37\x2b\x0b\xcf\xf5\x96\x24\xf2\x78\xbc\xe0\x7f\x1b\x9e\x9f\x5a\x3b\xb5\xfe\x5c\x96\xae\xc6\x28\xa6\x2b\x6e\x62\xe0\xe1\x74\xcf\x2d\x1d\xb4\x9e\x28\x8d\x5a\xaf\x33\xc6\xcf\x8e\x9a\x9b\xd7\x89\xf9\xcc\x1e\x41\xd9\xd1\x84\xf6\x10\xe3\x52\x29\x67\x65\x81\xc1\x6f\x3e\xd1\x97\xc0\x42\xaa\x82\x2b\x6b\x35\xa1\x39\xd9\x77\xb6\xc6\x95\xa6\x81\xa3\xed\xef\xf8\x55\x5b\x4c\x09\x7a\x01\x18\x2f\x56\x6b\x52\x57\xfb\x1b\xdb\x22\x9b\x01\xc4\xd4\x1b\x3b\x55\xca\xd0\x73\x3f\x2c\x95\x5d\xb2\xce\x53\xc0\xb0\x34\xc5\xae\xd3\xb8\xbf\xa1\x9a\x5b\xd4\xae\x3a\xd0\x15\xfb\xcb\x98\x8f\x81\xe4\xea\xec\x58\x5b\xa3\x38\x8a\xc2\x65\xc6\x35\x3f\x91\xfa\x10\xda\x55\x14\xe9\x29\x51\x8b\x4f\x13\x1b\xbb\xcf\x46\x73\xcb\x7e\x58\x8d\x90\xa2\x36\x69\xed\xd6\x38\x2f\xa1\x1e\xdf\x50\x5b\xad\xd1\x93\xa0\x9e\x2b\x25\xe4\x1c\xbd\xeb\x5d\x72\x63\xd0\x07\x19\x45\x05\x13\x67\x22\xb7\xc5\x59\x08\x6e\x22\x82\xdb\x9b\xc3\xd3\x1e\xf7\x63\xaf\xaf\x28\x9a\x34\x43\x1f\x91\x25\x03\xbd\x3a\xf8\xb9\x7d\x38\xc0\xc7\x5a\x5e\x19\xfc\x91\x6b\x82\x8b\x19\xff\xb2\x3a\xc4\x27\x3a\x8a\xe7\x00\x18\x9b\x53\xa1\xd7\xbd\xce\x7a\xf2\x6d\x47\xe6\xc5\xaf\xed\xe3\xcb\x61\xc3\xcd\x26\xcb\xb8\x41\xd3\xe4\x37\x68\xeb\xb2\x23\xc7\xeb\x76\xa8\x97\x1e\xc5\x57\x69\xb4\x92\x08\xc4\xf9\x3b\x52\x0d\xc9\x75\x8c\x8f\xe6\x93\x33\x6e\x99\xb7\x7c\x56\x14\xc9\xca\xda\xed\x32\x2e\xa6\x0a\xfe\x18\x9c\x5f\xde\x60\x3c\xed\xef\xbd\xc6\xb7\xca\xd1\xd0\x0e\x5f\x4b\x28\x56\xac\x0e\x1d\x9e\xa6\x70\xc5\x6a\xcc\xc4\x8a\xd5\x02\x9f\xf2\x8d\xb4\x1d\xa3\xd0\x6d\xc7\x28\x74\xdb\x05\x67\x7c\xb9\x4e\x08\xd5\xf8\xbc\x60\xf5\xd1\x72\xb6\xef\x8e\xd5\xf6\xf2\x8e\xef\xd8\x85\x95\xe6\xfa\x77\xda\xe7\x8b\x76\xb8\x88\x82\xe8\x7c\x29\xdb\x56\x7f\xfe\x74\x97\x2f\x21\x52\x24\x18\x17\xc7\x92\x64\x37\x98\x9a\xb8\x87\x27\x46\x2f\x68\xdc\x9a\x64\xe2\x78\xa4\xd5\x3c\xf8\xcd\x2f\x46\x75\x7d\xd5\xd9\x2e\xec\xac\x63\x3f\x41\x58\x69\xee\x1f\xd9\xfb\xdc\xf8\x5c\xfe\x17\x00\x00\xff\xff\x20\x26\x65\x82\x74\x09\x00\x00")
+var _schemaGql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3b\x6b\x6f\x23\x37\x92\x9f\xd5\xbf\xa2\xec\x41\x30\x32\xa0\xd1\xce\x0c\x32\x5f\x0c\xaf\x01\x3f\x66\x2f\xc6\xd9\x89\x2f\xf6\x04\x01\x82\xc3\x82\xea\x2e\xa9\x09\xb1\xc9\x0e\xc9\x96\x46\x19\xe4\xbf\x1f\x8a\x8f\x6e\x76\xab\x2d\x7b\xf7\x6e\x73\x01\x76\xbe\x24\x16\xc9\x2a\xd6\xfb\xc5\x1e\x93\x97\x58\x31\xf8\x92\x4d\x7e\x6d\x50\xef\x4e\x61\xf2\x5f\xf4\xff\xec\xf7\x2c\xb3\xbb\x1a\xc1\xfd\xa2\xed\x57\xa0\xd1\x6a\x8e\x1b\x04\x26\x04\x6c\x98\xe0\x05\xb3\x58\x00\x33\x06\xad\x01\x25\xc1\x96\x08\x0f\x16\x85\x60\x1a\x24\xda\xad\xd2\xeb\x79\x36\xf1\xfb\xa7\xf0\xcb\x05\xfd\x71\xf4\xdf\x47\xd9\x01\x64\xdc\x98\x06\xf5\x01\x6c\xe1\xc0\x29\xfc\x72\xe3\xfe\xda\xc3\x67\x35\x2b\x10\x8c\x65\xd6\xc0\x52\xab\xca\xe1\x11\xcc\x58\x38\x93\x4d\xf5\x9d\x6a\xb4\xb9\x58\xa9\x73\x28\xe9\x2f\x82\x9c\x16\xb8\x64\x8d\xb0\xf0\x57\x78\xff\xad\x5f\x3e\x99\x83\xaa\x2d\x57\x92\x09\xb1\x83\x5a\xab\x0d\x2f\x10\x72\xd5\x48\x8b\x1a\x98\x2c\x08\x6e\xc1\x0c\x7a\xe6\x81\xcb\xa5\x82\xa5\xd2\xb0\xe4\xc2\xa2\xe6\x72\x35\x77\xd7\xd6\x9a\xe7\x91\x96\xe9\x66\xcb\xea\x19\xd8\x2d\xab\x09\x9a\xc9\x02\x2a\x2c\x38\x93\xf7\x74\xe8\x04\x94\x14\x3b\xe0\x32\x17\x4d\x81\x0e\xd8\x31\x62\x60\xab\xb4\x2d\x81\x59\x10\xc8\x8c\x25\xd0\xb3\x8a\xcb\x47\xda\x7c\xe0\xbf\xe1\x39\x7c\x7a\xb8\x4e\x79\x78\x7b\x32\xcf\x26\x15\xd3\x6b\xb4\x66\x9a\x4d\x26\x44\xa6\x93\xfc\x95\x2a\xf0\x14\x1e\x2c\x91\x97\xae\x7b\x39\x26\x3b\x81\xcf\x31\xa0\x74\x6b\x0f\x2e\x11\xef\x29\xdc\x48\x9b\x4d\x26\x29\xa5\xa7\xf0\x37\xa1\x98\xcd\x26\x27\xa7\xf0\xcb\x9d\x23\xf0\xa0\xee\x94\x3e\xa8\xba\x19\x09\x9c\xc4\x81\x2c\x2f\xc1\x33\x0c\xbc\x40\x69\xf9\x92\x63\x01\x8b\x1d\x70\x6b\x20\x67\x52\x49\x9e\x33\x01\x37\xd7\x33\x50\x1a\x2a\xd4\x2b\x2e\x57\x84\x9b\xa0\x83\xa4\x60\x5b\xaa\xa8\x4e\x03\xa6\x64\xda\x2b\xc1\xb0\x8a\x14\x5f\xa0\x01\x2e\xad\x02\x06\x86\xcb\x95\x70\xa0\x9e\xda\x9a\x71\x0d\x7c\x09\x6c\xb5\xd2\xb8\x62\x16\x2f\x77\x24\x69\xe0\x06\xac\x6e\x70\xd4\x92\x18\x81\x13\xe0\xf7\x84\x7e\x8a\xf3\xd5\x1c\x8e\x7f\xbe\xbd\xfb\xfb\xe5\xe3\xd5\x31\x11\x19\xaf\x81\xbc\xd1\x1a\x65\xbe\x4b\x0e\x1d\x9f\x10\x74\xcf\xda\x40\xa3\x69\x84\x35\x33\xb2\x4d\x60\x43\x0b\x61\x44\x7c\xe4\x74\x9e\x4d\x2c\xcf\xd7\xa8\xc9\x38\x22\x0d\x87\xb5\x38\x60\xed\x14\x2e\x95\x12\xc8\xe4\x21\x05\x5f\x44\x98\xe2\x09\x55\x97\x08\x3f\x7c\x77\x7b\xf5\x13\x69\xa8\x10\x68\x40\x2d\x81\xa5\x32\x1d\x88\xa5\xe5\x9a\xc1\x8a\x6f\x50\x12\xcf\x4a\x34\x24\x5b\x98\xbe\xab\x66\xf0\xa1\x9a\xc1\x3b\xf7\x9f\x72\x06\xdf\x96\x24\xc6\x77\xc5\xc9\xcc\x6b\x96\x80\x17\x4d\x4e\x02\x20\x7f\xd4\x16\x16\x68\xb7\x88\x12\xce\x28\x48\x9c\x3b\xc1\x9d\x59\x75\x9e\xba\x92\x54\x5b\x72\xa6\x40\xe0\x98\xbc\x8e\xb2\xc9\xa4\xa3\x23\x5d\x25\xac\xa7\xf0\xc8\x2b\xa4\x5f\x56\xf9\xbf\xbd\x6c\xae\x1c\xc2\xfd\xc8\x45\xd6\xce\x7f\x6d\x78\xc1\xed\x0e\x6a\xa5\x84\x81\xe9\xc5\xdd\x9d\x39\x69\x89\x1d\x46\xdd\x39\x21\x38\x1c\xaa\x9e\x89\x53\xd9\xa4\xbd\xf2\x9e\x6e\xfc\x43\x43\x06\x09\xe3\x36\xbd\x7e\x4f\x26\x4c\x06\xd2\x17\x3b\xe7\x86\x8e\x23\x9f\x03\x60\x7a\x2c\x99\xe5\x1b\x3c\x76\x2c\xfd\x7c\x7b\x77\x32\x23\xd8\x6d\x89\xb6\x44\x0d\xdc\x92\x0f\x76\x12\x53\x1a\xa4\xb2\x31\x21\x4d\xf3\x84\xcc\xa3\x19\xf0\x1e\x69\x47\x27\xa7\xe0\x28\x1e\xd1\x10\xb3\x68\x2c\x14\x98\x73\x43\xb6\xa7\x96\x4e\x71\x9e\x4a\xc1\x8d\x25\x7f\xac\x95\xe0\xf9\x8e\x60\xd9\x42\x35\xb6\x65\x63\x06\x7c\x8e\xf3\x01\x89\x04\x44\x79\x54\x16\xb0\x2d\x77\x91\xc0\x5b\x8f\xea\x85\x74\x86\xd3\x7b\xc2\x0b\xa2\x5a\xec\xa0\x6e\x16\x82\xe7\xb0\xc6\x5d\x9b\x45\xa7\x7e\xed\x3f\x71\x97\xe2\xf3\x71\x7d\x9f\xf1\x90\x8f\x9c\x9b\x92\x1f\x80\x2d\x99\x85\x5c\x28\x83\x45\x6b\xa0\xde\x9b\x9e\x48\xab\xb0\xc0\xa5\xd2\xe8\xfc\xec\xe4\x49\x8f\x73\x4a\x94\xb8\x25\x29\x2f\xb9\x36\x76\x0e\x67\x74\xdf\x39\xa9\x13\xb9\x13\x1c\x0b\xd1\x0c\x6e\xae\x7d\x64\x24\x18\x0a\x15\xa7\xde\x24\xfe\xf2\xe9\xe1\xfa\xf4\x3f\xe6\xf3\xf9\xf1\x09\xc5\x81\x5e\x5c\x91\x23\x31\x37\x5a\x0e\x77\xa9\xc4\xe6\x25\x1a\xc0\x0d\x55\x3e\xe1\x9e\xc8\x5f\x48\x10\x5b\x6e\x4b\xb0\x14\x58\x9c\x55\xba\xa0\xea\xc4\x13\x83\xc4\x78\x28\xe8\x45\x82\x89\x63\xae\x8d\xb1\x4b\xdb\xa9\xd5\x85\x09\x17\x57\xaf\x94\x94\x98\x93\x7f\x7b\xc7\xa8\xd9\x8a\x4b\x57\x29\x6d\x50\x93\xfd\x99\x68\x80\x54\xbe\x71\x34\xc0\x16\x6a\x83\x33\x52\x5d\xa3\x65\x92\xe8\xce\xdc\x75\xe7\xc0\x2d\x56\x26\x95\xf9\x87\xb7\x33\x2a\x2f\x2a\x65\x2c\xbc\x7f\xfb\xf6\x04\x96\x4a\x08\xb5\x4d\x41\x1d\x71\xe7\x94\x8c\x8c\xd2\xf3\x28\x84\xce\x15\x0d\x50\xbe\x34\x4a\x13\x61\xe4\xa9\x1a\x19\xd1\x3c\x4b\x33\xec\x62\xe7\xb4\xa5\xf4\x50\x19\xde\x14\x7c\xbc\x73\x87\xa2\x0b\x98\x8e\xfb\x69\x27\xac\x19\xf4\x64\x15\x3d\x20\x95\x54\xb0\xef\x97\xc2\x7b\x8b\xef\x21\x08\x44\x27\x08\xfe\xc8\xb8\x38\x96\x84\x47\x33\xed\x73\x36\xe4\x73\x6f\x8f\x33\x9f\xfc\xfb\x8c\xfd\x6b\xca\x80\xe7\x88\x1b\x96\x08\x3d\x32\xfb\x59\xe9\xff\x4b\x0f\xcf\x70\xd0\xcb\x5d\x29\xf9\xbf\x67\x99\xc9\x19\xf5\x41\x97\x7c\x45\xfc\x87\x5f\x2e\x08\xf8\xc6\xca\x5d\x48\x8d\x55\x2f\xc0\x47\xd3\xbd\xc8\x1d\xc9\xc9\x3a\x01\x25\x3f\x65\x53\x85\x33\xc6\x49\xf8\x28\x9b\xb0\xc6\x96\x3f\xe2\xaf\x0d\xd7\x58\xb4\xca\x69\xd7\x37\x2a\x67\x0b\xd1\x69\x8d\x36\x2a\x7f\x87\x33\xa6\xa3\xe0\x74\x57\x4a\x5a\xad\x84\xc0\xe2\x72\x77\xad\x2a\xc6\x65\x0f\x44\xe6\xa5\xda\x97\x63\x7f\xe7\xb1\x4f\x2a\x37\xee\xfc\x85\x3b\xd0\x27\xad\xe0\xa6\x16\x6c\x77\x8d\x39\xaf\x98\x30\xa7\x41\x5c\xc4\x5f\xbf\xca\x2a\xd0\xe4\xc9\xcf\x5c\xc9\x82\x93\xa8\x4d\xb2\xb8\xe4\x9f\xb1\xf8\xbe\xa9\x16\xa8\x13\x44\x15\xfb\xbc\xb7\xc6\xcd\x27\x29\x78\xc5\x6d\x9f\x1a\x8d\x05\x56\xae\x98\xba\x91\xc6\xea\x26\x1f\xde\x90\x2b\x21\x98\x45\xcd\xc4\x45\x51\x68\x34\x06\x0f\xee\x3e\xf0\x95\x64\xb6\xd1\x83\x53\x8d\xa4\xe2\x26\x5d\xa3\x8e\xa7\x49\x17\xbc\x11\xdc\x5c\x07\xd5\x52\x18\x4d\xdb\x61\x27\x4f\x70\x9a\x62\xb9\xed\x97\x20\xd3\xd0\x37\xe5\xc9\x2e\x83\x07\xa5\xd5\x82\x5c\xf5\x15\x58\xb5\x46\x39\x73\x40\x64\x87\xc7\xf1\xe0\xf1\x89\x6f\x1f\x08\x95\x74\x12\xa3\x33\x71\xd7\x40\xa9\x44\x91\x24\x05\x7f\x1b\x13\x4a\xae\x62\x3a\x44\xf0\x16\x45\x7f\xee\xdc\x79\xaa\x9e\x03\x82\x9b\xeb\x84\x3f\xd9\x54\x91\xfa\xd6\x7c\xdb\x9b\x2e\xfa\x66\x49\x34\xbb\x46\xba\x4d\x75\xfe\xea\x02\x35\xdf\x60\xd1\x35\xf9\x4a\xbe\xb9\xfe\xf8\x33\x68\x5c\x22\xf5\x4c\x18\x33\x0f\x21\x98\x3a\x0c\xd4\x2a\xff\x15\xde\x52\xfd\x25\xa1\x91\x6b\xa9\xb6\x92\x2a\xfc\xb8\xd9\xb9\x82\x5b\x69\x87\x16\xae\x45\x8f\xb5\xa9\x69\xea\x5a\xec\x60\xa1\x91\xad\x0b\xb5\x95\xa0\x36\xa8\xc1\xf2\x0a\x67\x1d\x2d\x46\xb2\xda\x94\xca\x1a\xb0\x6c\x8d\x92\xe6\x19\xae\x4f\x75\xa4\x13\x16\x8d\x4b\x8d\xa6\x7c\x69\x1b\x32\x83\x35\x62\x1d\xc4\xef\x7a\x62\x42\x12\x6f\x81\x1a\xf5\x0b\xfb\xa1\x79\x36\xf1\x0c\x7c\xc7\x8d\x55\x7a\x37\xfd\xa7\x3a\x18\x27\x95\x07\x87\xe7\x21\xd0\x40\xe2\xf9\x3d\xcb\xbc\xa1\x06\x19\xa9\x65\x5b\xf9\x52\x89\x71\x46\x42\x3a\xf7\xa3\x91\x9c\xeb\xbc\x11\x8c\x4a\xdc\x78\x9a\x1b\x28\x51\x50\x01\x91\xbd\x02\x0a\x66\x4a\xf3\xdf\xa8\x30\x0e\x71\x6e\xb6\xd7\x1d\x91\xb4\x5a\xb3\xa1\x4e\x8f\x8b\x50\xa4\xab\x7c\x8d\x45\xf6\x6a\x1f\x75\x8b\xcd\x97\xaf\x4c\xa3\x7c\x6d\x61\xd9\xd0\xa0\xa7\xbb\x73\x06\xb9\x60\xbc\xa2\x80\x99\xd1\xa4\x47\x30\x99\x53\x6d\x45\xdd\xb5\xce\x4b\x67\x77\xed\xc5\x73\x48\x82\x31\xb4\xd8\x31\xc1\x97\xbd\x6a\xef\x9d\x27\xe1\xbf\x2f\x41\x4a\x06\x24\xa1\x56\xe8\x56\x59\x26\xfc\x99\xce\x32\x13\xc1\x0d\xb7\x3c\xd7\xc3\xd5\x8e\x8a\xb1\xb5\x47\x75\xc7\xb8\xb4\x8c\xcb\x5b\xce\x16\x5c\x70\xeb\x42\x53\x3c\xd8\xc8\x31\xf0\x56\x38\x97\x41\x32\xdd\x56\x3f\x6f\x27\x20\x51\x5a\xdd\x52\x94\xe4\xd5\xfe\xd6\x48\x76\xa3\xa5\xe7\xa8\x6e\x4f\x7e\xea\x91\xdd\x2e\x5f\xed\xd3\xdd\xee\xf5\x72\x79\xba\x31\x0c\x54\x71\x32\xda\x45\x06\x52\x5c\x1c\xd4\x24\x1e\xe4\x62\x48\xcb\x53\xeb\x1b\x2f\xe9\x1c\xf7\xda\xc6\x8a\x15\xe8\x1d\xe3\x4c\x37\x02\xcf\x5d\xcb\x7b\xa6\x91\x19\x25\xcf\x67\x5d\x00\xa6\x4c\x12\x8c\x8f\xc8\x83\x92\x51\x53\x89\x12\x70\xc3\x44\x43\xb5\x56\x6a\x7f\xa1\x6b\x7c\xb2\x0a\x49\x16\x88\xbe\x41\xb6\x6c\x44\x0a\xe1\x69\x49\x16\x46\x74\x18\x0b\x8e\xf6\x8c\x55\x95\xf8\xf4\xe3\x6d\xb2\xd2\xd2\x79\x61\xa3\x1f\xc4\xec\x57\xaa\x0a\xa1\x70\x25\x49\x94\x1d\xe5\x49\x62\x20\x38\x57\x97\xc0\x1e\x3e\xde\xbf\x79\x47\x80\xb9\xaa\x6a\xc1\xc9\x48\x7d\x8b\xdf\x4f\x22\xaf\x0d\x3c\xfe\x70\x77\x4b\xf3\x10\x84\xa9\x13\xd4\x06\xb5\x1b\x20\x52\x5e\xa0\x1b\x63\x0d\xd4\x52\x48\x34\xff\x8d\x4b\xca\x85\x94\x20\x08\x3e\xfc\x6c\x43\x20\xf3\xf7\xef\x5d\x4e\x77\xb3\xee\xc6\x39\xa0\xd6\x4a\x1b\xa8\x35\x6e\x50\x5a\xa2\x2b\x7b\x35\x60\xd1\x19\x80\x4f\x2c\x0b\x24\x5e\x23\x81\x33\xd8\x32\xd7\xe1\x19\x28\x94\x7c\x6d\x83\x62\x13\x7a\x9c\x5e\x4b\xcc\xd7\x1d\xf5\x24\x92\x63\x77\xab\x9b\x33\x1e\x07\x14\xc7\x94\x16\xa8\xe1\xe5\x36\xb5\xe0\x0a\x8d\x61\xab\x44\xcb\xd1\xf6\x7d\xc1\x4e\xf8\x5d\x63\x7d\xdf\xeb\x78\xe9\x8e\x74\xec\x1a\x39\x09\xad\x74\xd7\x7a\xef\x37\xeb\xb3\x38\x1a\xa7\xf3\x37\xd7\xad\xb2\x42\x26\x77\x03\x13\x3f\x22\xa7\x96\x9b\x17\xdd\xa5\x61\xe4\x7d\x73\x4d\x3a\x09\x8b\x2e\x61\x8f\x36\x0b\x47\xd9\x53\xcd\xc2\x51\xd6\xeb\x08\x06\x40\x4f\x37\x0b\x01\xe3\x4f\x4a\x34\x55\x6c\x81\x3a\x80\xe1\xb2\x93\xda\x15\xed\x45\xd7\x50\x35\xca\x6e\x5f\xa8\x6d\xf7\xa3\xe4\xab\xb2\xfb\x95\x97\x4c\xae\xd2\x1b\x68\x00\xd3\xfd\xe4\x74\xdd\x86\x89\x07\x1a\x72\xb6\x99\xc4\xf5\x31\xb7\x58\xac\x50\x5f\xd1\xf9\xc7\x34\xcd\x08\xf6\xf4\x9e\xd2\x05\xea\x85\x52\xeb\x07\x0a\x2c\xa7\xf0\x43\xef\x37\xc9\xf7\x15\x6c\x1c\x73\x06\x4c\x2d\xb8\xa5\x14\xbb\x41\xd9\x20\x4c\x5b\x58\x50\xcb\x25\x0d\x09\x36\x66\x3e\x4c\xe1\x7d\x8d\x77\x9b\x3d\xbd\xbf\x36\xfe\xf0\x3c\xa1\xe7\x72\x44\xd8\xed\xe6\xd5\xb8\xd4\x09\xc9\x18\x1c\xad\x1f\x00\x09\xac\xdf\xc7\x3f\xfb\x5c\x73\x49\x2f\x30\x5d\xe4\xa1\x1a\xd3\x45\xfe\xc8\x43\x37\x7f\x9d\xc5\x9a\x95\xe0\x9f\x2d\x5b\x61\xba\x5f\xa8\x76\x26\xd6\xab\x56\x7b\x56\xb6\x5f\xc7\xa6\x2b\x29\xe9\xf8\x99\x5c\x29\x16\x95\x61\xbe\xb7\x14\x6c\xb5\xa2\xaa\xcb\x80\x41\xb1\x7c\xe3\x97\x29\xd8\x98\xb2\x7d\xf5\x30\x14\x3c\x02\x97\x8d\x15\x1c\xb5\x19\x6b\x1e\x22\x2e\x0f\x43\xcd\x00\x75\x9e\x63\x2a\x70\x1b\x4f\xe8\x20\x60\x79\x1c\xfa\x4c\xcc\x0b\x9e\x9d\x37\xfe\x7e\x5e\xe1\x9b\x2d\xf2\x55\x49\xa3\x28\xb6\x41\xcd\x56\xe1\x1d\xce\xb4\x04\xfa\x77\xb7\xe4\x09\x87\x18\x99\x0d\xc4\xd1\xa7\xbd\x85\x55\x12\x0d\x2c\x50\xa8\x6d\xbc\xbe\xe2\x92\x57\x4d\x15\x70\x19\xfe\x1b\xce\xb3\x09\x3d\xf6\x75\x1c\xd8\xde\xaf\xe4\xd9\xaf\x5d\x24\x64\xad\xf9\x86\x2c\xfe\x92\xbe\x22\xb4\x0f\x04\xfe\x6c\x07\xb1\xd2\xaa\xa9\xfd\x80\x6e\xa4\x57\x20\x14\xa3\xed\x42\x4b\xd6\xff\xaa\x63\xe8\x02\x47\xe0\x20\xb6\x53\xf8\x99\x9a\x54\x2a\x8a\x2a\x26\x40\x63\xad\xd1\xa0\xb4\x6e\x7e\xd8\xc6\xff\x68\xb1\xa4\x85\xa8\x4c\x9a\x73\xf6\x5c\xe2\x23\x61\x4a\xe8\xe9\x79\xc5\x70\x93\xe2\xed\x70\x4d\xa8\xed\x70\x89\x62\xef\x70\xcd\x8d\xbc\x07\x8b\x6d\x51\x38\x98\x69\xfd\x9f\xa7\x48\x17\x2f\x69\x4a\xb1\xeb\x3d\x3a\x3a\xad\x52\x0d\x77\x32\x3b\x90\x3f\x09\xf6\x9f\x4c\xa1\x7b\x0e\xfb\x35\xb1\x7d\x4d\x6c\x5f\x13\xdb\xd7\xc4\xf6\x35\xb1\xfd\x5b\x24\x36\xff\xcd\xc2\x13\xe9\x6c\x5c\x6c\x44\x49\x3f\xe6\xf6\x52\x41\x3f\xfa\xf7\x12\xc3\x20\xf4\x77\x92\x48\x8e\x3c\x9f\x7f\xe2\xb8\x3f\xb2\xd0\x0f\xf1\xf0\x25\x83\xc9\x82\x17\x83\xc3\xb4\x34\x44\xba\xe0\xc5\x1d\xfb\xdc\xfd\x66\x66\x3d\x84\x62\x66\x3d\x84\x62\x66\x7d\xc7\x13\x7e\x4d\xad\x91\x25\x43\x34\xff\xfb\x8e\x17\xf7\x8a\x0f\xc6\xdd\x0b\x5e\x38\x73\x67\x66\xdd\x5a\xc8\x94\xd3\xc8\x9a\xdb\xd6\x74\x82\x15\xf8\x08\x7c\xe2\x46\x40\x5c\xc2\xfb\x6f\x9c\xc1\x7f\xf8\xc6\x21\x78\xf7\xf6\x9b\x78\xbc\xe2\xc1\xc2\xba\x78\x66\x04\xaf\x6b\xaa\x8f\x09\xf7\x37\x27\x74\x72\xd1\xec\x28\x6c\xfe\x85\x70\x18\x14\x82\x7e\x9c\xc5\x73\x7e\x30\x7f\xde\x27\xa3\xcb\x02\x14\xd7\xf9\x32\xbe\x0f\x74\x4e\x9f\x33\x37\x62\xe5\x42\xd0\x5e\x45\xae\xb7\xe0\xc5\x35\xd6\xb6\x7c\x7f\x9f\x77\x9c\xb7\xab\x1f\x46\x57\xdf\xbd\xed\x2d\x33\xb3\x1e\x41\x11\x57\x3f\x8c\xae\x0e\x50\xf4\xf9\xea\xd6\x17\xcd\xee\x21\x6c\x25\x87\x51\x88\xbd\xd5\x76\xa8\xb7\x17\xe2\x68\xd0\x13\xaa\xb9\xa0\x19\x96\x06\x27\xff\x2d\xd2\x3c\x4c\x8b\x67\x59\x97\xbb\x48\x39\xde\x34\xfc\xab\x7a\x08\xf5\x45\x88\x9a\x25\x06\xd8\xd7\xa6\x8b\x99\xf3\xa1\x81\x87\x8d\x7f\xc8\x5d\xdd\x67\x51\x3d\x7f\x8d\xf8\x47\x5c\xe4\xcf\xe6\x35\xad\x22\xf6\x2a\x2d\xaa\x81\x7a\xfa\xf0\xef\x0f\x9c\xc6\x82\x18\x5c\x82\xde\x07\xb8\x0c\x18\xdc\x27\x7f\xde\xc4\x99\x19\x14\x6b\x94\xda\x7a\x0f\x0d\x8f\x3f\xdd\xb6\xee\xb4\x44\xcc\x5e\x51\x71\x4d\x73\xca\x56\x5b\x34\x5d\xe9\xbe\x80\x21\x8d\x1e\x74\xe4\xa0\xca\xb6\x26\x23\x15\x92\x8b\xfd\x88\x06\xf5\x26\x11\x54\x80\xda\x5b\xef\x4f\x9d\x27\x76\x23\xba\x1f\x4b\xc4\x1b\x47\xde\xfb\x6f\xbb\xd0\x1b\xc3\x63\x6f\x00\x0e\x5f\x06\xbd\xc1\x1f\x37\x46\x5b\x22\x5e\xde\xc7\xee\xc1\x3d\x81\x3c\xea\xc6\x58\xc1\x25\xa6\x0f\xc4\x6e\xe7\x81\x3e\xd4\x34\xcf\x55\xb5\x93\xa6\x2e\xfa\x23\xe5\xc8\xb3\x9f\xe1\x11\xb3\xfb\x9f\x42\xed\xbd\x41\x37\x5a\x1c\x1c\x5d\x2f\xb1\x40\xed\xd2\xfb\x03\xa9\x2a\xe5\x89\x35\xb6\xdc\x5b\xb4\x9a\x49\xb3\x44\xbd\xb7\xb1\xc5\xc5\x45\x63\xcb\x8f\xb2\xa8\x7d\x6a\x68\x77\x0a\xac\x95\xe1\x76\x0f\x42\xe9\xd5\xe3\x96\xdb\xe4\xfb\x84\xa4\x8a\x1c\x7c\x33\x18\xcd\xce\x4d\xa4\x75\xfb\xad\xcd\x3f\xf6\x85\x4d\x18\x73\x87\xaf\x54\x5d\x8c\x99\xb7\x05\xcc\x88\x8d\xa7\xbd\x42\xf0\x97\x6c\x58\x22\xf9\xd7\x81\xa4\x16\x72\xf1\x6f\x8d\xb5\x75\x35\xbb\x63\x2b\x86\x3a\x57\x33\xef\x19\xe9\x58\xac\x8b\x4d\xed\x98\xcd\xfe\xcb\x6d\x99\xd8\x1e\x66\x97\x08\x30\x58\x1e\xf8\x6d\x07\xb9\x57\xa5\xa5\xf0\xc3\x4d\x87\x65\xb8\x48\xb8\x6e\xcc\x03\x0a\x81\x3a\x79\xc1\x21\x03\x39\x6e\x13\x97\x7f\x0f\x68\xc3\xe7\xdf\x29\x6e\xd2\xb3\x80\x93\xe9\xe0\x8b\x0e\x31\xde\x54\xbf\x24\x1b\xce\x48\x97\x6a\x09\x67\xad\x4f\x9e\x0f\xb3\xd7\x27\xb7\x03\x5f\xfe\x84\xfa\x6c\x19\x0b\x41\xa6\xcb\xb8\x07\x22\x4d\xfb\xd5\x1e\x25\x7e\xf7\x09\xae\xcb\x46\x79\xeb\x4e\xb3\xfe\x27\x77\xf0\x23\x0a\x46\x4f\x7d\xa6\xc6\x9c\x2f\x79\xee\x62\xca\x3c\x7c\x7e\xe7\xfd\x4b\xd5\xec\xd7\x06\x07\x4e\x71\xcf\x56\x78\x43\xf8\xbf\x64\x93\x92\x99\xef\xf1\xb3\xa5\xa5\xf4\xd1\xae\x64\xe6\x5e\xe3\x86\xab\xc6\x0c\xb7\x5c\xfe\xbf\x72\x77\x44\x8e\xb3\x09\xca\x62\xb0\x14\x43\xe7\x20\x24\x90\xba\xc8\x2a\xda\x6f\x26\x3e\x16\x2b\xa4\x19\xdf\xa4\x0e\x54\x9d\xb6\xf4\x75\xf1\xb7\x3d\x19\x9e\x50\x93\x8b\xe8\x05\xd1\x69\xc6\x9d\x19\x86\xec\xf1\x8b\xfd\xde\x8b\x6e\xee\x8e\x1e\xb8\x3a\xfc\x13\x93\x16\x68\xf8\x9d\x5a\x7a\xb7\xdf\x7b\xd1\xdd\xdd\xd1\x03\x77\xfb\x43\x1d\xd0\xd3\x5f\xcb\xa5\x54\x0c\x4f\xbd\x88\x9e\x31\xa0\x03\x94\x0d\x8f\x3f\x51\x42\x8c\x13\xd8\x3b\xf2\x22\xea\xf6\x20\x0e\x90\xd6\x3b\xdb\xa1\x08\x13\x96\x31\x7a\xdc\xd6\x8b\xe8\x68\x4f\x1e\xb8\xff\x51\xb3\x02\x8f\xb2\xdf\xb3\xff\x19\x00\x2f\x2d\x6f\x89\x57\x35\x00\x00")
 
 func schemaGqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -106,7 +107,27 @@ func schemaGql() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "schema.gql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf7, 0x3c, 0xa2, 0xb0, 0x7, 0x29, 0xef, 0xb6, 0x66, 0x0, 0x23, 0x1e, 0xf8, 0xf4, 0x6a, 0x79, 0x4f, 0xa5, 0xd8, 0xec, 0x24, 0x85, 0xfe, 0xa, 0xfd, 0xe9, 0xc6, 0x27, 0x6c, 0xf1, 0xd8, 0x46}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5b, 0xdf, 0xf9, 0x40, 0x55, 0xad, 0x3e, 0x9a, 0xb6, 0x43, 0x9e, 0xab, 0x8a, 0x2e, 0xa5, 0xa7, 0x3f, 0xd0, 0x31, 0x2d, 0x39, 0x28, 0xe2, 0xde, 0xb0, 0xbb, 0x5, 0x51, 0xde, 0x23, 0x1c, 0x91}}
+	return a, nil
+}
+
+var _subscriptionGql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x53\x5f\x6b\xdb\x40\x0c\x7f\xce\x7d\x8a\x5f\x92\x97\x04\x82\x03\x63\x4f\x61\x0c\xb6\x15\xc6\x60\x65\x8c\xb4\x6c\x30\xc6\x90\x6d\xd5\x77\x34\xb9\x4b\xa5\x4b\x4c\x18\xfd\xee\x43\x76\x9b\x3a\x29\x7b\xb4\x25\xfd\xfe\x49\x37\x45\xf6\x0c\xdd\x97\x5a\x49\xd8\xe5\x90\xa2\x82\x84\xa1\x2c\x07\xae\x91\x0e\x2c\xf8\xc1\xe5\x3a\x55\xf7\x9c\x91\x22\x96\x8d\xd0\xce\x3f\x6c\x96\xad\x2e\x50\x1e\x41\x6e\x0a\xad\x3c\x6f\x09\xe9\xce\xc0\x82\x20\xb5\x11\xad\x4f\xca\x78\xd8\xb3\x1c\x21\x29\x65\x04\xc5\x7a\x40\xf3\xdd\x2a\x05\xde\xed\x28\xc8\x7b\x37\xb5\x32\x87\xec\x59\x40\xa8\x28\xa6\x18\x2a\xda\x60\x4b\x62\xbc\x5f\xae\x30\xe3\xa2\x29\x30\xf9\xf9\xf5\x7a\x15\x29\x87\x03\x2f\x6f\xd7\x57\xab\xcf\x45\x51\x4c\xe6\x48\xd2\xe9\xc8\x42\x35\xc3\x10\x11\x69\xcb\x83\x99\x3f\x1f\x6f\x3e\x4d\xe6\x0b\xb4\x3e\x54\x1e\x5b\xca\x95\x67\x05\x1f\x4c\x5d\xcf\xe1\xa6\x28\x39\xb7\xcc\x11\xa4\xca\x59\xd1\x86\xec\x91\x3b\x1b\x55\xaa\x59\x0b\x97\x8f\x3b\x3e\x33\x81\xbf\x6e\xd4\x27\xd8\x51\xab\x45\xd0\x3b\x5a\x80\xd4\x0a\xc7\x2e\xcd\x10\x1b\xd6\xcc\x75\xe1\x46\x7d\xe3\xcc\x9a\x56\x58\x67\x09\xb1\x19\xcf\x57\xb8\xb1\xdf\x63\xf7\x0c\xa7\x99\xf2\x00\xad\xdf\x83\x15\x36\xa4\x19\x6f\xde\xc2\xa7\xbd\x28\x66\xd4\x34\xc2\x0d\x65\xae\x6d\x17\x26\xd3\x10\x5a\xcf\xf1\x79\x32\x28\xe8\x32\x98\x2e\x08\x8e\x66\x1f\xa1\xdb\x4c\xd7\x50\x23\x89\x8d\x07\xa3\x96\x9a\xa5\x4c\xe9\xde\xaa\xc2\x77\xc2\xea\x3b\xfd\x7d\x5a\xb7\xbb\xda\x48\x5f\xd9\xf8\x70\xd2\x73\xdd\xf5\xbd\x38\x7a\x01\xbc\xf0\x36\xd0\x72\xca\x6b\x48\x78\x1a\xfc\x1f\xe7\xb7\xf3\x86\xb1\x7b\x74\xaf\x37\xd5\x9d\x5b\xbf\x2e\x3b\x0d\x7d\x3a\x56\xd0\x81\xc2\x86\xca\xcd\xc5\x1b\x28\xdc\xe8\xec\x7b\x85\x5f\x4f\x84\xbf\xc7\xee\xd1\xfd\x1b\x00\x1e\x63\xde\x2f\x36\x03\x00\x00")
+
+func subscriptionGqlBytes() ([]byte, error) {
+	return bindataRead(
+		_subscriptionGql,
+		"subscription.gql",
+	)
+}
+
+func subscriptionGql() (*asset, error) {
+	bytes, err := subscriptionGqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "subscription.gql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x24, 0x37, 0xcf, 0x83, 0x68, 0x7c, 0xcd, 0x56, 0x2f, 0x1a, 0x9b, 0x95, 0x20, 0x3b, 0xbc, 0xfd, 0xe2, 0x5b, 0x6, 0xab, 0xd4, 0x52, 0xa6, 0x78, 0xca, 0xd7, 0xe3, 0xf7, 0xc8, 0x9b, 0x24, 0x81}}
 	return a, nil
 }
 
@@ -201,8 +222,9 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"graphiql.html": graphiqlHtml,
-	"schema.gql":    schemaGql,
+	"graphiql.html":    graphiqlHtml,
+	"schema.gql":       schemaGql,
+	"subscription.gql": subscriptionGql,
 }
 
 // AssetDir returns the file names below a certain
@@ -248,8 +270,9 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"graphiql.html": {graphiqlHtml, map[string]*bintree{}},
-	"schema.gql":    {schemaGql, map[string]*bintree{}},
+	"graphiql.html":    &bintree{graphiqlHtml, map[string]*bintree{}},
+	"schema.gql":       &bintree{schemaGql, map[string]*bintree{}},
+	"subscription.gql": &bintree{subscriptionGql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
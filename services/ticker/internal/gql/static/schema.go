@@ -2,6 +2,7 @@ package static
 
 import (
 	"bytes"
+	"regexp"
 	"strings"
 )
 
@@ -26,3 +27,21 @@ func Schema() string {
 
 	return buf.String()
 }
+
+// schemaDefinition matches the schema definition (i.e. the root operation
+// types) of the .gql files.
+var schemaDefinition = regexp.MustCompile(`(?s)\bschema\s*\{.*?\}`)
+
+// subscriptionSchemaDefinition roots the schema served over WebSocket.
+const subscriptionSchemaDefinition = `schema {
+	query: SubscriptionQuery
+	subscription: Subscription
+}`
+
+// SubscriptionSchema returns the schema served over WebSocket, which shares
+// the types of Schema but is rooted at the Subscription type. graphql-go
+// resolves every root type with the same resolver, so keeping subscriptions
+// in a schema of their own lets their fields share names with queries.
+func SubscriptionSchema() string {
+	return schemaDefinition.ReplaceAllLiteralString(Schema(), subscriptionSchemaDefinition)
+}
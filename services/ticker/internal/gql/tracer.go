@@ -0,0 +1,51 @@
+package gql
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+	"github.com/stellar/go/services/ticker/internal/metrics"
+)
+
+// metricsTracer observes the latency of the query resolvers (i.e. the fields
+// of the Query type) in metrics.ResolverDuration.
+type metricsTracer struct{}
+
+var _ trace.Tracer = metricsTracer{}
+
+func (metricsTracer) TraceQuery(
+	ctx context.Context,
+	queryString string,
+	operationName string,
+	variables map[string]interface{},
+	varTypes map[string]*introspection.Type,
+) (context.Context, trace.TraceQueryFinishFunc) {
+	return ctx, func([]*errors.QueryError) {}
+}
+
+func (metricsTracer) TraceField(
+	ctx context.Context,
+	label string,
+	typeName string,
+	fieldName string,
+	trivial bool,
+	args map[string]interface{},
+) (context.Context, trace.TraceFieldFinishFunc) {
+	if typeName != "Query" {
+		return ctx, func(*errors.QueryError) {}
+	}
+
+	start := time.Now()
+	return ctx, func(err *errors.QueryError) {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+		metrics.ResolverDuration.
+			WithLabelValues(fieldName, status).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,269 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stellar/go/services/ticker/internal/gql/static"
+	hlog "github.com/stellar/go/support/log"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocket subprotocols supported by the subscriptions endpoint: the
+// graphql-transport-ws protocol of the graphql-ws library, and the legacy
+// graphql-ws protocol of subscriptions-transport-ws.
+const (
+	transportWSProtocol = "graphql-transport-ws"
+	legacyWSProtocol    = "graphql-ws"
+)
+
+// wsInitTimeout is how long clients have to send their connection_init
+// message, and wsKeepAlive how often keep-alive messages are sent to the
+// clients of the legacy protocol.
+const (
+	wsInitTimeout = 10 * time.Second
+	wsKeepAlive   = 20 * time.Second
+)
+
+// wsMessage is a message of either subprotocol.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsSubscribePayload is the payload of the subscribe (or start) messages.
+type wsSubscribePayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewSubscriptionHandler sets up the WebSocket handler serving the GraphQL
+// subscriptions.
+func (r *resolver) NewSubscriptionHandler() http.Handler {
+	opts := []graphql.SchemaOpt{
+		graphql.UseFieldResolvers(),
+	}
+	s := graphql.MustParseSchema(
+		static.SubscriptionSchema(),
+		&subscriptionResolver{db: r.db, logger: r.logger},
+		opts...,
+	)
+
+	return websocket.Server{
+		Handshake: selectWSProtocol,
+		Handler: func(ws *websocket.Conn) {
+			c := wsConn{
+				ws:     ws,
+				schema: s,
+				logger: r.logger,
+				legacy: ws.Config().Protocol[0] == legacyWSProtocol,
+				subs:   make(map[string]context.CancelFunc),
+			}
+			c.serve()
+		},
+	}
+}
+
+// selectWSProtocol picks the first subprotocol requested by the client that
+// is supported, rejecting the connection if there is none.
+func selectWSProtocol(config *websocket.Config, req *http.Request) error {
+	for _, p := range config.Protocol {
+		if p == transportWSProtocol || p == legacyWSProtocol {
+			config.Protocol = []string{p}
+			return nil
+		}
+	}
+	return errors.New("unsupported subprotocol, expected graphql-transport-ws or graphql-ws")
+}
+
+// wsConn serves the subscriptions of a single WebSocket connection.
+type wsConn struct {
+	ws     *websocket.Conn
+	schema *graphql.Schema
+	logger *hlog.Entry
+	legacy bool
+
+	mu   sync.Mutex
+	subs map[string]context.CancelFunc
+}
+
+// serve reads the messages of the client until the connection is closed,
+// terminated, or a message breaks the protocol.
+func (c *wsConn) serve() {
+	ctx, cancel := context.WithCancel(c.ws.Request().Context())
+	defer cancel()
+	defer c.ws.Close()
+
+	// the read timeout of the HTTP server doesn't apply to WebSockets, but
+	// the connection must be initialized in time:
+	if err := c.ws.SetDeadline(time.Now().Add(wsInitTimeout)); err != nil {
+		return
+	}
+
+	initialized := false
+	for {
+		var msg wsMessage
+		if err := websocket.JSON.Receive(c.ws, &msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			if initialized {
+				return
+			}
+			initialized = true
+			if err := c.ws.SetDeadline(time.Time{}); err != nil {
+				return
+			}
+			c.send(wsMessage{Type: "connection_ack"})
+			if c.legacy {
+				c.send(wsMessage{Type: "ka"})
+				go c.keepAlive(ctx)
+			}
+		case "ping":
+			c.send(wsMessage{Type: "pong", Payload: msg.Payload})
+		case "pong":
+		case "subscribe", "start":
+			if !initialized || msg.ID == "" {
+				return
+			}
+			c.subscribe(ctx, msg)
+		case "complete", "stop":
+			c.unsubscribe(msg.ID)
+		case "connection_terminate":
+			return
+		default:
+			c.logger.Infof("Closing WebSocket of %s: unexpected message type %q\n", c.ws.Request().RemoteAddr, msg.Type)
+			return
+		}
+	}
+}
+
+// subscribe starts the subscription requested by msg, sending its responses
+// to the client until it completes.
+func (c *wsConn) subscribe(ctx context.Context, msg wsMessage) {
+	var payload wsSubscribePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		c.sendError(msg.ID, errors.New("invalid subscription payload"))
+		return
+	}
+
+	c.mu.Lock()
+	if _, ok := c.subs[msg.ID]; ok {
+		c.mu.Unlock()
+		c.sendError(msg.ID, errors.New("subscription "+msg.ID+" already exists"))
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.subs[msg.ID] = cancel
+	c.mu.Unlock()
+
+	responses, err := c.schema.Subscribe(ctx, payload.Query, payload.OperationName, payload.Variables)
+	if err != nil {
+		c.unsubscribe(msg.ID)
+		c.sendError(msg.ID, err)
+		return
+	}
+
+	nextType := "next"
+	if c.legacy {
+		nextType = "data"
+	}
+
+	go func() {
+		defer c.unsubscribe(msg.ID)
+
+		first := true
+		for resp := range responses {
+			r, ok := resp.(*graphql.Response)
+			if !ok {
+				continue
+			}
+
+			// requests that can't be executed fail without any data:
+			if first && r.Data == nil && len(r.Errors) > 0 {
+				c.sendQueryErrors(msg.ID, r)
+				return
+			}
+			first = false
+
+			data, err := json.Marshal(r)
+			if err != nil {
+				continue
+			}
+			c.send(wsMessage{ID: msg.ID, Type: nextType, Payload: data})
+		}
+
+		// subscriptions completed by the client aren't acknowledged:
+		if ctx.Err() == nil {
+			c.send(wsMessage{ID: msg.ID, Type: "complete"})
+		}
+	}()
+}
+
+// unsubscribe stops the subscription with the given ID, if any.
+func (c *wsConn) unsubscribe(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cancel, ok := c.subs[id]; ok {
+		cancel()
+		delete(c.subs, id)
+	}
+}
+
+// keepAlive sends a keep-alive message to the client of the legacy protocol
+// every wsKeepAlive, until ctx is done.
+func (c *wsConn) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(wsKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.send(wsMessage{Type: "ka"})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// send sends msg to the client. Errors are only logged, as they also make
+// the next read fail, which closes the connection.
+func (c *wsConn) send(msg wsMessage) {
+	if err := websocket.JSON.Send(c.ws, msg); err != nil {
+		c.logger.Debugf("Could not send WebSocket message to %s: %v\n", c.ws.Request().RemoteAddr, err)
+	}
+}
+
+// sendError sends an error message for the subscription with the given ID.
+func (c *wsConn) sendError(id string, err error) {
+	c.sendQueryErrors(id, &graphql.Response{
+		Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("%s", err)},
+	})
+}
+
+// sendQueryErrors sends the errors of r as an error message for the
+// subscription with the given ID: a list of errors for graphql-transport-ws,
+// or the first one for the legacy protocol.
+func (c *wsConn) sendQueryErrors(id string, r *graphql.Response) {
+	var payload interface{} = r.Errors
+	if c.legacy && len(r.Errors) > 0 {
+		payload = r.Errors[0]
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	c.send(wsMessage{ID: id, Type: "error", Payload: data})
+}
@@ -0,0 +1,163 @@
+package gql
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/pubsub"
+	hlog "github.com/stellar/go/support/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func dialSubscriptions(t *testing.T, url, protocol string) *websocket.Conn {
+	config, err := websocket.NewConfig(url, "http://localhost/")
+	require.NoError(t, err)
+	config.Protocol = []string{protocol}
+	ws, err := websocket.DialConfig(config)
+	require.NoError(t, err)
+	require.NoError(t, ws.SetDeadline(time.Now().Add(5*time.Second)))
+	return ws
+}
+
+func receiveMessage(t *testing.T, ws *websocket.Conn) wsMessage {
+	var msg wsMessage
+	require.NoError(t, websocket.JSON.Receive(ws, &msg))
+	return msg
+}
+
+func TestTradesSubscription(t *testing.T) {
+	r := &resolver{logger: hlog.New()}
+	server := httptest.NewServer(r.NewSubscriptionHandler())
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ws := dialSubscriptions(t, url, transportWSProtocol)
+	defer ws.Close()
+
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{Type: "connection_init"}))
+	assert.Equal(t, "connection_ack", receiveMessage(t, ws).Type)
+
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{Type: "ping"}))
+	assert.Equal(t, "pong", receiveMessage(t, ws).Type)
+
+	// invalid pairs are rejected with an error message:
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{
+		ID:      "1",
+		Type:    "subscribe",
+		Payload: json.RawMessage(`{"query": "subscription { trades(pair: \"XLM\") { id } }"}`),
+	}))
+	msg := receiveMessage(t, ws)
+	assert.Equal(t, "error", msg.Type)
+	assert.Equal(t, "1", msg.ID)
+	assert.Contains(t, string(msg.Payload), "pair must be a market ID")
+
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{
+		ID:      "2",
+		Type:    "subscribe",
+		Payload: json.RawMessage(`{"query": "subscription { trades(pair: \"XLM_BTC\") { id, marketID, price, priceExact } }"}`),
+	}))
+	require.Eventually(t, func() bool {
+		return pubsub.NumSubscribers(pubsub.TradesTopic) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// only the trades of the subscribed pair are sent:
+	pubsub.Publish(pubsub.TradesTopic, pubsub.Trade{
+		HorizonID:        "1",
+		BaseAssetCode:    "XLM",
+		BaseAssetIssuer:  "native",
+		CounterAssetCode: "ETH",
+	})
+	pubsub.Publish(pubsub.TradesTopic, pubsub.Trade{
+		HorizonID:          "2",
+		BaseAssetCode:      "XLM",
+		BaseAssetIssuer:    "native",
+		CounterAssetCode:   "BTC",
+		CounterAssetIssuer: "GBTC",
+		Price:              "0.5",
+	})
+
+	msg = receiveMessage(t, ws)
+	assert.Equal(t, "next", msg.Type)
+	assert.Equal(t, "2", msg.ID)
+	assert.JSONEq(t, `{
+		"data": {
+			"trades": {
+				"id": "2",
+				"marketID": "XLM:native/BTC:GBTC",
+				"price": 0.5,
+				"priceExact": "0.5"
+			}
+		}
+	}`, string(msg.Payload))
+
+	// completing the subscription unsubscribes from the trades:
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{ID: "2", Type: "complete"}))
+	require.Eventually(t, func() bool {
+		return pubsub.NumSubscribers(pubsub.TradesTopic) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLegacySubscriptionProtocol(t *testing.T) {
+	r := &resolver{logger: hlog.New()}
+	server := httptest.NewServer(r.NewSubscriptionHandler())
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ws := dialSubscriptions(t, url, legacyWSProtocol)
+	defer ws.Close()
+
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{Type: "connection_init"}))
+	assert.Equal(t, "connection_ack", receiveMessage(t, ws).Type)
+	assert.Equal(t, "ka", receiveMessage(t, ws).Type)
+
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{
+		ID:      "1",
+		Type:    "start",
+		Payload: json.RawMessage(`{"query": "subscription { orderbookUpdated(pair: \"XLM:native/BTC:GBTC\") { tradePair, orderbookStats { bidCount } } }"}`),
+	}))
+	require.Eventually(t, func() bool {
+		return pubsub.NumSubscribers(pubsub.OrderbooksTopic) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	update := pubsub.OrderbookUpdate{
+		BaseAssetCode:      "XLM",
+		BaseAssetIssuer:    "native",
+		CounterAssetCode:   "BTC",
+		CounterAssetIssuer: "GBTC",
+	}
+	update.Stats.NumBids = 3
+	pubsub.Publish(pubsub.OrderbooksTopic, update)
+
+	msg := receiveMessage(t, ws)
+	assert.Equal(t, "data", msg.Type)
+	assert.JSONEq(t, `{
+		"data": {
+			"orderbookUpdated": {
+				"tradePair": "XLM_BTC",
+				"orderbookStats": {"bidCount": 3}
+			}
+		}
+	}`, string(msg.Payload))
+
+	require.NoError(t, websocket.JSON.Send(ws, wsMessage{ID: "1", Type: "stop"}))
+	require.Eventually(t, func() bool {
+		return pubsub.NumSubscribers(pubsub.OrderbooksTopic) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestUnsupportedSubscriptionProtocol(t *testing.T) {
+	r := &resolver{logger: hlog.New()}
+	server := httptest.NewServer(r.NewSubscriptionHandler())
+	defer server.Close()
+
+	config, err := websocket.NewConfig("ws"+strings.TrimPrefix(server.URL, "http"), "http://localhost/")
+	require.NoError(t, err)
+	config.Protocol = []string{"unknown"}
+	_, err = websocket.DialConfig(config)
+	assert.Error(t, err)
+}
@@ -13,34 +13,132 @@ type MarketSummary struct {
 }
 
 // MarketStats represents the statistics of a specific market (identified by
-// a trade pair).
+// a trade pair and, unless markets are aggregated by asset code, by its
+// canonical ID), along with the IDs of the markets it includes.
 type MarketStats struct {
-	TradePairName    string  `json:"name"`
-	BaseVolume24h    float64 `json:"base_volume"`
-	CounterVolume24h float64 `json:"counter_volume"`
-	TradeCount24h    int64   `json:"trade_count"`
-	Open24h          float64 `json:"open"`
-	Low24h           float64 `json:"low"`
-	High24h          float64 `json:"high"`
-	Change24h        float64 `json:"change"`
-	BaseVolume7d     float64 `json:"base_volume_7d"`
-	CounterVolume7d  float64 `json:"counter_volume_7d"`
-	TradeCount7d     int64   `json:"trade_count_7d"`
-	Open7d           float64 `json:"open_7d"`
-	Low7d            float64 `json:"low_7d"`
-	High7d           float64 `json:"high_7d"`
-	Change7d         float64 `json:"change_7d"`
-	Price            float64 `json:"price"`
-	Close            float64 `json:"close"`
-	CloseTime        string  `json:"close_time"`
-	BidCount         int     `json:"bid_count"`
-	BidVolume        float64 `json:"bid_volume"`
-	BidMax           float64 `json:"bid_max"`
-	AskCount         int     `json:"ask_count"`
-	AskVolume        float64 `json:"ask_volume"`
-	AskMin           float64 `json:"ask_min"`
-	Spread           float64 `json:"spread"`
-	SpreadMidPoint   float64 `json:"spread_mid_point"`
+	TradePairName    string   `json:"name"`
+	MarketID         string   `json:"market_id,omitempty"`
+	MarketIDs        []string `json:"market_ids"`
+	BaseVolume24h    float64  `json:"base_volume"`
+	CounterVolume24h float64  `json:"counter_volume"`
+	TradeCount24h    int64    `json:"trade_count"`
+	Open24h          float64  `json:"open"`
+	Low24h           float64  `json:"low"`
+	High24h          float64  `json:"high"`
+	Change24h        float64  `json:"change"`
+	BaseVolume7d     float64  `json:"base_volume_7d"`
+	CounterVolume7d  float64  `json:"counter_volume_7d"`
+	TradeCount7d     int64    `json:"trade_count_7d"`
+	Open7d           float64  `json:"open_7d"`
+	Low7d            float64  `json:"low_7d"`
+	High7d           float64  `json:"high_7d"`
+	Change7d         float64  `json:"change_7d"`
+	Price            float64  `json:"price"`
+	Close            float64  `json:"close"`
+	CloseTime        string   `json:"close_time"`
+	BidCount         int      `json:"bid_count"`
+	BidVolume        float64  `json:"bid_volume"`
+	BidMax           float64  `json:"bid_max"`
+	AskCount         int      `json:"ask_count"`
+	AskVolume        float64  `json:"ask_volume"`
+	AskMin           float64  `json:"ask_min"`
+	Spread           float64  `json:"spread"`
+	SpreadMidPoint   float64  `json:"spread_mid_point"`
+
+	// 24h volumes split by venue (orderbook offers vs. liquidity pools), and
+	// the liquidity of the market's pools, whose implied price is in the same
+	// units as Close, and whose TVL and fee income are in counter units:
+	OrderbookBaseVolume24h    float64 `json:"orderbook_base_volume"`
+	OrderbookCounterVolume24h float64 `json:"orderbook_counter_volume"`
+	PoolBaseVolume24h         float64 `json:"pool_base_volume"`
+	PoolCounterVolume24h      float64 `json:"pool_counter_volume"`
+	PoolBaseReserve           float64 `json:"pool_base_reserve"`
+	PoolCounterReserve        float64 `json:"pool_counter_reserve"`
+	PoolPrice                 float64 `json:"pool_price"`
+	PoolTVL                   float64 `json:"pool_tvl"`
+	PoolFeeIncome24h          float64 `json:"pool_fee_income"`
+
+	// 24h volumes in USD, and the USD price of the base asset, derived from
+	// the on-DEX reference markets (0 when unknown):
+	BaseVolumeUSD    float64 `json:"base_volume_usd"`
+	CounterVolumeUSD float64 `json:"counter_volume_usd"`
+	PriceUSD         float64 `json:"price_usd"`
+
+	// Orderbook depth within 2%, 5% and 10% of the mid price (in counter
+	// units), and the slippage (in %) of buying / selling SlippageAmount units
+	// of base against the orderbook:
+	BidDepth2Pct   float64 `json:"bid_depth_2pct"`
+	BidDepth5Pct   float64 `json:"bid_depth_5pct"`
+	BidDepth10Pct  float64 `json:"bid_depth_10pct"`
+	AskDepth2Pct   float64 `json:"ask_depth_2pct"`
+	AskDepth5Pct   float64 `json:"ask_depth_5pct"`
+	AskDepth10Pct  float64 `json:"ask_depth_10pct"`
+	SlippageAmount float64 `json:"slippage_amount"`
+	BuySlippage    float64 `json:"buy_slippage"`
+	SellSlippage   float64 `json:"sell_slippage"`
+
+	// Exact decimal representations of the volumes and prices above:
+	BaseVolume24hExact    string `json:"base_volume_exact"`
+	CounterVolume24hExact string `json:"counter_volume_exact"`
+	Open24hExact          string `json:"open_exact"`
+	Low24hExact           string `json:"low_exact"`
+	High24hExact          string `json:"high_exact"`
+	BaseVolume7dExact     string `json:"base_volume_7d_exact"`
+	CounterVolume7dExact  string `json:"counter_volume_7d_exact"`
+	CloseExact            string `json:"close_exact"`
+
+	// Volumes excluding the trades flagged as self-trades, wash trades or
+	// price outliers, and the number of flagged trades within 24h:
+	CleanBaseVolume24h    float64 `json:"clean_base_volume"`
+	CleanCounterVolume24h float64 `json:"clean_counter_volume"`
+	FlaggedTradeCount24h  int64   `json:"flagged_trade_count"`
+	CleanBaseVolume7d     float64 `json:"clean_base_volume_7d"`
+	CleanCounterVolume7d  float64 `json:"clean_counter_volume_7d"`
+
+	// Volume- and time-weighted average prices and median trade prices,
+	// excluding flagged trades and the ones below the minimum trade size:
+	VWAP24h        float64 `json:"vwap"`
+	TWAP24h        float64 `json:"twap"`
+	MedianPrice24h float64 `json:"median_price"`
+	VWAP7d         float64 `json:"vwap_7d"`
+	TWAP7d         float64 `json:"twap_7d"`
+	MedianPrice7d  float64 `json:"median_price_7d"`
+
+	Windows map[string]WindowStats `json:"windows,omitempty"`
+}
+
+// WindowStats represents the statistics of a specific market within a
+// trailing window of time (e.g. the last 30 days).
+type WindowStats struct {
+	BaseVolume    float64 `json:"base_volume"`
+	CounterVolume float64 `json:"counter_volume"`
+	TradeCount    int64   `json:"trade_count"`
+	Open          float64 `json:"open"`
+	Low           float64 `json:"low"`
+	High          float64 `json:"high"`
+	Change        float64 `json:"change"`
+	Close         float64 `json:"close"`
+	CloseTime     string  `json:"close_time"`
+
+	// Exact decimal representations of the volumes and prices above:
+	BaseVolumeExact    string `json:"base_volume_exact"`
+	CounterVolumeExact string `json:"counter_volume_exact"`
+	OpenExact          string `json:"open_exact"`
+	LowExact           string `json:"low_exact"`
+	HighExact          string `json:"high_exact"`
+	CloseExact         string `json:"close_exact"`
+
+	// Volumes excluding the trades flagged as anomalous, and the number of
+	// flagged trades:
+	CleanBaseVolume    float64 `json:"clean_base_volume"`
+	CleanCounterVolume float64 `json:"clean_counter_volume"`
+	FlaggedTradeCount  int64   `json:"flagged_trade_count"`
+
+	// Volume- and time-weighted average prices and median trade price,
+	// excluding flagged trades and the ones below the minimum trade size:
+	VWAP        float64 `json:"vwap"`
+	TWAP        float64 `json:"twap"`
+	MedianPrice float64 `json:"median_price"`
 }
 
 // PartialMarketSummary represents a summary of statistics of all valid markets
@@ -55,6 +153,7 @@ type PartialMarketSummary struct {
 // a trade pair).
 type PartialMarketStats struct {
 	TradePairName      string  `json:"name"`
+	MarketID           string  `json:"market_id"`
 	BaseAssetID        int32   `json:"base_asset_id"`
 	BaseAssetCode      string  `json:"base_asset_code"`
 	BaseAssetIssuer    string  `json:"base_asset_issuer"`
@@ -79,6 +178,86 @@ type PartialMarketStats struct {
 	LowestAsk          float64 `json:"lowest_ask"`
 	Spread             float64 `json:"spread"`
 	SpreadMidPoint     float64 `json:"spread_mid_point"`
+
+	// Volumes split by venue (orderbook offers vs. liquidity pools), and the
+	// liquidity of the market's pools, whose implied price is in the same
+	// units as Close, and whose TVL and 24h fee income are in counter units:
+	OrderbookBaseVolume    float64 `json:"orderbook_base_volume"`
+	OrderbookCounterVolume float64 `json:"orderbook_counter_volume"`
+	PoolBaseVolume         float64 `json:"pool_base_volume"`
+	PoolCounterVolume      float64 `json:"pool_counter_volume"`
+	PoolBaseReserve        float64 `json:"pool_base_reserve"`
+	PoolCounterReserve     float64 `json:"pool_counter_reserve"`
+	PoolPrice              float64 `json:"pool_price"`
+	PoolTVL                float64 `json:"pool_tvl"`
+	PoolFeeIncome24h       float64 `json:"pool_fee_income"`
+
+	// Volumes in USD, and the USD price of the base asset, derived from the
+	// on-DEX reference markets (0 when unknown):
+	BaseVolumeUSD    float64 `json:"base_volume_usd"`
+	CounterVolumeUSD float64 `json:"counter_volume_usd"`
+	PriceUSD         float64 `json:"price_usd"`
+
+	// Orderbook depth within 2%, 5% and 10% of the mid price (in counter
+	// units), and the slippage (in %) of buying / selling SlippageAmount units
+	// of base against the orderbook:
+	BidDepth2Pct   float64 `json:"bid_depth_2pct"`
+	BidDepth5Pct   float64 `json:"bid_depth_5pct"`
+	BidDepth10Pct  float64 `json:"bid_depth_10pct"`
+	AskDepth2Pct   float64 `json:"ask_depth_2pct"`
+	AskDepth5Pct   float64 `json:"ask_depth_5pct"`
+	AskDepth10Pct  float64 `json:"ask_depth_10pct"`
+	SlippageAmount float64 `json:"slippage_amount"`
+	BuySlippage    float64 `json:"buy_slippage"`
+	SellSlippage   float64 `json:"sell_slippage"`
+
+	// Exact decimal representations of the volumes and prices above:
+	BaseVolumeExact    string `json:"base_volume_exact"`
+	CounterVolumeExact string `json:"counter_volume_exact"`
+	OpenExact          string `json:"open_exact"`
+	LowExact           string `json:"low_exact"`
+	HighExact          string `json:"high_exact"`
+	CloseExact         string `json:"close_exact"`
+
+	// Volumes excluding the trades flagged as anomalous, and the number of
+	// flagged trades:
+	CleanBaseVolume    float64 `json:"clean_base_volume"`
+	CleanCounterVolume float64 `json:"clean_counter_volume"`
+	FlaggedTradeCount  int32   `json:"flagged_trade_count"`
+
+	// Volume- and time-weighted average prices and median trade price,
+	// excluding flagged trades and the ones below the minimum trade size:
+	VWAP        float64 `json:"vwap"`
+	TWAP        float64 `json:"twap"`
+	MedianPrice float64 `json:"median_price"`
+}
+
+// CandleSummary represents the OHLCV candles of all valid markets
+// for a given resolution and period of time.
+type CandleSummary struct {
+	GeneratedAt        int64         `json:"generated_at"`
+	GeneratedAtRFC3339 string        `json:"generated_at_rfc3339"`
+	Resolution         string        `json:"resolution"`
+	Pairs              []PairCandles `json:"pairs"`
+}
+
+// PairCandles represents the candles of a specific market (identified
+// by a trade pair).
+type PairCandles struct {
+	TradePairName string   `json:"name"`
+	Candles       []Candle `json:"candles"`
+}
+
+// Candle represents the OHLCV data of a market for a single bucket.
+type Candle struct {
+	OpenTime      int64   `json:"open_time"`
+	Open          float64 `json:"open"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	Close         float64 `json:"close"`
+	BaseVolume    float64 `json:"base_volume"`
+	CounterVolume float64 `json:"counter_volume"`
+	TradeCount    int64   `json:"trade_count"`
 }
 
 // Asset Summary represents the collection of valid assets.
@@ -94,6 +273,36 @@ type Asset struct {
 
 	IssuerDetail       Issuer `json:"issuer_detail"`
 	LastValidTimestamp string `json:"last_valid"`
+
+	// Prices of the asset derived from the on-DEX reference markets, keyed
+	// by fiat currency (PriceUSD is 0 when unknown):
+	PriceUSD float64            `json:"price_usd"`
+	Prices   map[string]float64 `json:"prices,omitempty"`
+
+	// Supply is the breakdown of the supply of the asset on its latest
+	// refresh (nil if unknown).
+	Supply *AssetSupply `json:"supply,omitempty"`
+}
+
+// AssetSupply represents the supply of an asset, split between its
+// circulating supply (held by authorized accounts, liquidity pools and
+// contracts) and its locked supply (held by accounts that aren't fully
+// authorized, claimable balances and archived contracts).
+type AssetSupply struct {
+	Total                           float64 `json:"total"`
+	Circulating                     float64 `json:"circulating"`
+	Locked                          float64 `json:"locked"`
+	Authorized                      float64 `json:"authorized"`
+	AuthorizedToMaintainLiabilities float64 `json:"authorized_to_maintain_liabilities"`
+	Unauthorized                    float64 `json:"unauthorized"`
+	ClaimableBalances               float64 `json:"claimable_balances"`
+	LiquidityPools                  float64 `json:"liquidity_pools"`
+	Contracts                       float64 `json:"contracts"`
+	ArchivedContracts               float64 `json:"archived_contracts"`
+	NumClaimableBalances            int32   `json:"num_claimable_balances"`
+	NumLiquidityPools               int32   `json:"num_liquidity_pools"`
+	NumContracts                    int32   `json:"num_contracts"`
+	UpdatedAt                       string  `json:"updated_at"`
 }
 
 // Issuer represents the aggregated data for a given issuer.
@@ -109,3 +318,102 @@ type Issuer struct {
 	DepositServer    string `json:"deposit_server"`
 	OrgTwitter       string `json:"org_twitter"`
 }
+
+// CoinGeckoPair represents a market in the format of the /pairs endpoint of
+// the CoinGecko exchange API. Assets are identified as "native" or
+// "<code>:<issuer>", and ticker IDs as "<base>_<target>".
+type CoinGeckoPair struct {
+	TickerID string `json:"ticker_id"`
+	Base     string `json:"base"`
+	Target   string `json:"target"`
+}
+
+// CoinGeckoTicker represents the 24h stats of a market in the format of the
+// /tickers endpoint of the CoinGecko exchange API. Prices are in units of
+// the target asset.
+type CoinGeckoTicker struct {
+	TickerID       string `json:"ticker_id"`
+	BaseCurrency   string `json:"base_currency"`
+	TargetCurrency string `json:"target_currency"`
+	LastPrice      string `json:"last_price"`
+	BaseVolume     string `json:"base_volume"`
+	TargetVolume   string `json:"target_volume"`
+	Bid            string `json:"bid"`
+	Ask            string `json:"ask"`
+	High           string `json:"high"`
+	Low            string `json:"low"`
+}
+
+// CoinGeckoOrderbook represents the orderbook of a market in the format of
+// the /orderbook endpoint of the CoinGecko exchange API. Each level is a
+// [price, base amount] pair.
+type CoinGeckoOrderbook struct {
+	TickerID  string      `json:"ticker_id"`
+	Timestamp int64       `json:"timestamp"`
+	Bids      [][2]string `json:"bids"`
+	Asks      [][2]string `json:"asks"`
+}
+
+// CoinGeckoTrades represents the trades of a market in the format of the
+// /historical_trades endpoint of the CoinGecko exchange API.
+type CoinGeckoTrades struct {
+	Buy  []CoinGeckoTrade `json:"buy"`
+	Sell []CoinGeckoTrade `json:"sell"`
+}
+
+// CoinGeckoTrade represents a single trade within CoinGeckoTrades.
+type CoinGeckoTrade struct {
+	TradeID        int64  `json:"trade_id"`
+	Price          string `json:"price"`
+	BaseVolume     string `json:"base_volume"`
+	TargetVolume   string `json:"target_volume"`
+	TradeTimestamp int64  `json:"trade_timestamp"`
+	Type           string `json:"type"`
+}
+
+// CMCSummary represents the 24h stats of a market in the format of the
+// /summary endpoint of the CoinMarketCap exchange API.
+type CMCSummary struct {
+	TradingPairs          string  `json:"trading_pairs"`
+	BaseCurrency          string  `json:"base_currency"`
+	QuoteCurrency         string  `json:"quote_currency"`
+	LastPrice             float64 `json:"last_price"`
+	LowestAsk             float64 `json:"lowest_ask"`
+	HighestBid            float64 `json:"highest_bid"`
+	BaseVolume            float64 `json:"base_volume"`
+	QuoteVolume           float64 `json:"quote_volume"`
+	PriceChangePercent24h float64 `json:"price_change_percent_24h"`
+	HighestPrice24h       float64 `json:"highest_price_24h"`
+	LowestPrice24h        float64 `json:"lowest_price_24h"`
+}
+
+// CMCTicker represents the 24h stats of a market in the format of the
+// /ticker endpoint of the CoinMarketCap exchange API, which maps each
+// "<base>_<quote>" pair to its CMCTicker.
+type CMCTicker struct {
+	BaseID      string  `json:"base_id"`
+	QuoteID     string  `json:"quote_id"`
+	LastPrice   float64 `json:"last_price"`
+	BaseVolume  float64 `json:"base_volume"`
+	QuoteVolume float64 `json:"quote_volume"`
+	IsFrozen    int     `json:"isFrozen"`
+}
+
+// CMCOrderbook represents the orderbook of a market in the format of the
+// /orderbook/{market_pair} endpoint of the CoinMarketCap exchange API.
+type CMCOrderbook struct {
+	Timestamp int64       `json:"timestamp"`
+	Bids      [][2]string `json:"bids"`
+	Asks      [][2]string `json:"asks"`
+}
+
+// CMCTrade represents a single trade in the format of the
+// /trades/{market_pair} endpoint of the CoinMarketCap exchange API.
+type CMCTrade struct {
+	TradeID     int64   `json:"trade_id"`
+	Price       float64 `json:"price"`
+	BaseVolume  float64 `json:"base_volume"`
+	QuoteVolume float64 `json:"quote_volume"`
+	Timestamp   int64   `json:"timestamp"`
+	Type        string  `json:"type"`
+}
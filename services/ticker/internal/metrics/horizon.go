@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	horizonclient "github.com/stellar/go/clients/horizonclient"
+)
+
+// horizonHTTP wraps the HTTP client used by horizonclient, observing the
+// latency of every request in HorizonRequestDuration.
+type horizonHTTP struct {
+	http horizonclient.HTTP
+}
+
+// InstrumentHorizonHTTP returns a horizonclient.HTTP that records the latency
+// of the requests sent through c.
+func InstrumentHorizonHTTP(c horizonclient.HTTP) horizonclient.HTTP {
+	if _, ok := c.(*horizonHTTP); ok {
+		return c
+	}
+	return &horizonHTTP{http: c}
+}
+
+func (h *horizonHTTP) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := h.http.Do(req)
+	observeHorizonRequest(req.URL, start, resp, err)
+	return resp, err
+}
+
+func (h *horizonHTTP) Get(u string) (*http.Response, error) {
+	start := time.Now()
+	resp, err := h.http.Get(u)
+	if parsed, pErr := url.Parse(u); pErr == nil {
+		observeHorizonRequest(parsed, start, resp, err)
+	}
+	return resp, err
+}
+
+func (h *horizonHTTP) PostForm(u string, data url.Values) (*http.Response, error) {
+	start := time.Now()
+	resp, err := h.http.PostForm(u, data)
+	if parsed, pErr := url.Parse(u); pErr == nil {
+		observeHorizonRequest(parsed, start, resp, err)
+	}
+	return resp, err
+}
+
+func observeHorizonRequest(u *url.URL, start time.Time, resp *http.Response, err error) {
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	HorizonRequestDuration.
+		WithLabelValues(horizonEndpoint(u), status).
+		Observe(time.Since(start).Seconds())
+}
+
+// horizonEndpoint returns the first segment of the request path, so that
+// e.g. /accounts/G.../offers is reported as "accounts".
+func horizonEndpoint(u *url.URL) string {
+	path := strings.Trim(u.Path, "/")
+	if path == "" {
+		return "root"
+	}
+	return strings.SplitN(path, "/", 2)[0]
+}
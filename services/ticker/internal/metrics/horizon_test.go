@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHorizonEndpoint(t *testing.T) {
+	for path, endpoint := range map[string]string{
+		"":                        "root",
+		"/":                       "root",
+		"/trades":                 "trades",
+		"/accounts/GABC/offers":   "accounts",
+		"/order_book?selling=XLM": "order_book",
+	} {
+		u, err := url.Parse("https://horizon.stellar.org" + path)
+		require.NoError(t, err)
+		assert.Equal(t, endpoint, horizonEndpoint(u), path)
+	}
+}
+
+func TestInstrumentHorizonHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := InstrumentHorizonHTTP(http.DefaultClient)
+	assert.Same(t, c, InstrumentHorizonHTTP(c))
+
+	before := testutil.CollectAndCount(HorizonRequestDuration)
+	resp, err := c.Get(server.URL + "/assets?limit=200")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// A new series is created for the endpoint and status code:
+	assert.Equal(t, before+1, testutil.CollectAndCount(HorizonRequestDuration))
+	resp, err = c.Get(server.URL + "/assets?cursor=next")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, before+1, testutil.CollectAndCount(HorizonRequestDuration))
+}
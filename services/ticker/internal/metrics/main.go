@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// lastTradeCloseTime holds the ledger close time (as a unix timestamp) of the
+// most recent trade ingested by this process.
+var lastTradeCloseTime int64
+
+var (
+	// TradesIngested counts the trades persisted by this process, by status:
+	// "stored" (in the trades table), "pending" (in the pending_trades table,
+	// as their assets are unknown) or "replayed" (moved from pending_trades).
+	TradesIngested = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stellar_ticker_trades_ingested_total",
+		Help: "Number of trades persisted, by status (stored, pending or replayed)",
+	}, []string{"status"})
+
+	// TradeCloseTimeLag reports how long ago the ledger of the most recent
+	// trade ingested by this process closed.
+	TradeCloseTimeLag = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "stellar_ticker_last_trade_close_time_lag_seconds",
+		Help: "Seconds elapsed since the ledger close time of the most recent ingested trade",
+	}, func() float64 {
+		closeTime := atomic.LoadInt64(&lastTradeCloseTime)
+		if closeTime == 0 {
+			return 0
+		}
+		return time.Since(time.Unix(closeTime, 0)).Seconds()
+	})
+
+	// HorizonRequestDuration observes the latency of the requests sent to
+	// Horizon, by endpoint (e.g. "trades") and status code.
+	HorizonRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "stellar_ticker_horizon_request_duration_seconds",
+		Help:    "Latency of the requests sent to Horizon, by endpoint and status code",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint", "status"})
+
+	// HorizonRequestRetries counts the requests retried by utils.Retry.
+	HorizonRequestRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stellar_ticker_horizon_request_retries_total",
+		Help: "Number of Horizon requests retried after a failure",
+	})
+
+	// HorizonRequestFailures counts the requests that still failed after all
+	// the retries allowed by utils.Retry.
+	HorizonRequestFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stellar_ticker_horizon_request_failures_total",
+		Help: "Number of Horizon requests that failed after exhausting their retries",
+	})
+
+	// AssetValidations counts the outcomes of the asset validation: "valid",
+	// "invalid", "discarded" (before fetching the TOML) or "error".
+	AssetValidations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stellar_ticker_asset_validations_total",
+		Help: "Number of assets processed, by validation outcome (valid, invalid, discarded or error)",
+	}, []string{"outcome"})
+
+	// TOMLRequests counts the requests of TOML files by result: "fetched",
+	// "not_modified" (revalidated with a conditional request), "cached"
+	// (served from the TOML cache) or "error".
+	TOMLRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stellar_ticker_toml_requests_total",
+		Help: "Number of TOML file lookups, by result (fetched, not_modified, cached or error)",
+	}, []string{"result"})
+
+	// JobRuns counts the runs of the jobs scheduled by the daemon, by job and
+	// status: "success", "error" or "skipped" (as the previous run was still
+	// in progress).
+	JobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stellar_ticker_job_runs_total",
+		Help: "Number of runs of the daemon jobs, by job and status (success, error or skipped)",
+	}, []string{"job", "status"})
+
+	// JobDuration observes the duration of the runs of the jobs scheduled by
+	// the daemon.
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "stellar_ticker_job_duration_seconds",
+		Help:    "Duration of the runs of the daemon jobs",
+		Buckets: []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600},
+	}, []string{"job"})
+
+	// ResolverDuration observes the latency of the GraphQL query resolvers.
+	ResolverDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "stellar_ticker_graphql_resolver_duration_seconds",
+		Help:    "Latency of the GraphQL query resolvers, by resolver and status",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"resolver", "status"})
+
+	// GraphQLSubscriptions reports the number of active GraphQL
+	// subscriptions, by subscription.
+	GraphQLSubscriptions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "stellar_ticker_graphql_subscriptions",
+		Help: "Number of active GraphQL subscriptions, by subscription",
+	}, []string{"subscription"})
+)
+
+// ObserveTradeCloseTime records the ledger close time of an ingested trade,
+// which is used to report TradeCloseTimeLag.
+func ObserveTradeCloseTime(closeTime time.Time) {
+	t := closeTime.Unix()
+	for {
+		last := atomic.LoadInt64(&lastTradeCloseTime)
+		if t <= last || atomic.CompareAndSwapInt64(&lastTradeCloseTime, last, t) {
+			return
+		}
+	}
+}
+
+// Handler returns the HTTP handler that exposes the metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve exposes the metrics on <address>/metrics. It is meant to be run in a
+// goroutine by the commands that don't already serve HTTP requests.
+func Serve(address string, l *hlog.Entry) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	server := &http.Server{
+		Addr:        address,
+		Handler:     mux,
+		ReadTimeout: 5 * time.Second,
+	}
+	l.Infof("Serving metrics on address %s\n", address)
+
+	if err := server.ListenAndServe(); err != nil {
+		l.Error("metrics server.ListenAndServe:", err)
+	}
+}
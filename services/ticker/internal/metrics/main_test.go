@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveTradeCloseTime(t *testing.T) {
+	assert.Equal(t, float64(0), testutil.ToFloat64(TradeCloseTimeLag))
+
+	now := time.Now()
+	ObserveTradeCloseTime(now.Add(-time.Hour))
+	lag := testutil.ToFloat64(TradeCloseTimeLag)
+	assert.InDelta(t, time.Hour.Seconds(), lag, 5)
+
+	// Older trades (e.g. replayed ones) don't increase the lag:
+	ObserveTradeCloseTime(now.Add(-2 * time.Hour))
+	assert.InDelta(t, time.Hour.Seconds(), testutil.ToFloat64(TradeCloseTimeLag), 5)
+
+	ObserveTradeCloseTime(now.Add(-time.Minute))
+	assert.InDelta(t, time.Minute.Seconds(), testutil.ToFloat64(TradeCloseTimeLag), 5)
+}
@@ -0,0 +1,176 @@
+package pricing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+// USD is the currency of the USD volumes and prices published by the ticker.
+const USD = "USD"
+
+// nativeIssuer is the issuer account of the native asset (XLM) in the database.
+const nativeIssuer = "native"
+
+// DefaultReferenceAssets are the reference assets used when none are
+// configured: Circle's USDC on the public network.
+var DefaultReferenceAssets = []string{
+	"USD=USDC:GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN",
+}
+
+// ReferenceAsset is an asset (e.g. a stablecoin) whose price is pegged to a
+// fiat currency.
+type ReferenceAsset struct {
+	Code   string
+	Issuer string
+}
+
+// References maps each fiat currency (e.g. "USD") to its reference assets.
+type References map[string][]ReferenceAsset
+
+// ParseReferences parses a list of reference assets formatted as
+// "<currency>=<code>:<issuer>" (e.g. "EUR=EURC:G...").
+func ParseReferences(values []string) (References, error) {
+	refs := References{}
+	for _, v := range values {
+		parts := strings.Split(v, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid reference asset %q, expected <currency>=<code>:<issuer>", v)
+		}
+
+		asset := strings.Split(parts[1], ":")
+		if len(asset) != 2 || asset[0] == "" || asset[1] == "" {
+			return nil, fmt.Errorf("invalid reference asset %q, expected <currency>=<code>:<issuer>", v)
+		}
+
+		currency := strings.ToUpper(parts[0])
+		refs[currency] = append(refs[currency], ReferenceAsset{Code: asset[0], Issuer: asset[1]})
+	}
+	return refs, nil
+}
+
+// CalcAssetPrices derives the price of every asset traded in the given
+// markets in each of the currencies of refs, keyed by asset ID and currency.
+//
+// The price of XLM in each currency is the volume-weighted price of its
+// markets against the currency's reference assets over the last 24 hours
+// (falling back to their most recent trades). Every other asset is priced
+// in XLM by triangulating through the markets connecting it to XLM, preferring
+// the shortest path and, among equally short ones, the market with the highest
+// XLM volume.
+func CalcAssetPrices(markets []tickerdb.MarketPrice, refs References) map[int32]map[string]float64 {
+	xlmPrices := calcXLMPrices(markets, refs)
+	prices := make(map[int32]map[string]float64)
+	if len(xlmPrices) == 0 {
+		return prices
+	}
+
+	for assetID, xlmValue := range calcXLMValues(markets) {
+		prices[assetID] = make(map[string]float64, len(xlmPrices))
+		for currency, xlmPrice := range xlmPrices {
+			prices[assetID][currency] = xlmValue * xlmPrice
+		}
+	}
+	return prices
+}
+
+// calcXLMPrices calculates the price of XLM in each currency of refs that has
+// at least one reference asset traded against XLM.
+func calcXLMPrices(markets []tickerdb.MarketPrice, refs References) map[string]float64 {
+	xlmPrices := make(map[string]float64)
+	for currency, assets := range refs {
+		var xlmVolume, fiatVolume, lastPrices float64
+		var numLastPrices int
+		for _, m := range markets {
+			if m.BaseAssetIssuer != nativeIssuer || !isReference(assets, m.CounterAssetCode, m.CounterAssetIssuer) {
+				continue
+			}
+			xlmVolume += m.BaseVolume24h
+			fiatVolume += m.CounterVolume24h
+			if m.LastPrice > 0 {
+				lastPrices += 1 / m.LastPrice
+				numLastPrices++
+			}
+		}
+
+		switch {
+		case xlmVolume > 0 && fiatVolume > 0:
+			xlmPrices[currency] = fiatVolume / xlmVolume
+		case numLastPrices > 0:
+			xlmPrices[currency] = lastPrices / float64(numLastPrices)
+		}
+	}
+	return xlmPrices
+}
+
+// calcXLMValues calculates the value of every asset traded in the given
+// markets in units of XLM, keyed by asset ID.
+func calcXLMValues(markets []tickerdb.MarketPrice) map[int32]float64 {
+	values := make(map[int32]float64)
+	for _, m := range markets {
+		if m.BaseAssetIssuer == nativeIssuer {
+			values[m.BaseAssetID] = 1
+		}
+	}
+
+	for len(values) > 0 {
+		// candidate values found in this pass, along with the XLM volume of
+		// the market they were derived from:
+		type candidate struct {
+			value     float64
+			xlmVolume float64
+		}
+		candidates := make(map[int32]candidate)
+		addCandidate := func(assetID int32, value, xlmVolume float64) {
+			if c, ok := candidates[assetID]; !ok || xlmVolume > c.xlmVolume {
+				candidates[assetID] = candidate{value: value, xlmVolume: xlmVolume}
+			}
+		}
+
+		for _, m := range markets {
+			price := marketPrice(m)
+			if price <= 0 {
+				continue
+			}
+
+			// prices are in units of the base asset, so one unit of
+			// counter is worth <price> units of base:
+			bValue, bKnown := values[m.BaseAssetID]
+			cValue, cKnown := values[m.CounterAssetID]
+			switch {
+			case bKnown && !cKnown:
+				addCandidate(m.CounterAssetID, price*bValue, m.BaseVolume24h*bValue)
+			case cKnown && !bKnown:
+				addCandidate(m.BaseAssetID, cValue/price, m.CounterVolume24h*cValue)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		for assetID, c := range candidates {
+			values[assetID] = c.value
+		}
+	}
+	return values
+}
+
+// marketPrice returns the volume-weighted price of a market over the last 24
+// hours, or the price of its most recent trade if it wasn't traded within
+// them, in units of the base asset.
+func marketPrice(m tickerdb.MarketPrice) float64 {
+	if m.BaseVolume24h > 0 && m.CounterVolume24h > 0 {
+		return m.BaseVolume24h / m.CounterVolume24h
+	}
+	return m.LastPrice
+}
+
+func isReference(assets []ReferenceAsset, code, issuer string) bool {
+	for _, a := range assets {
+		if a.Code == code && a.Issuer == issuer {
+			return true
+		}
+	}
+	return false
+}
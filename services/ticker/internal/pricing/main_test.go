@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const usdcIssuer = "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN"
+
+func TestParseReferences(t *testing.T) {
+	refs, err := ParseReferences([]string{
+		"USD=USDC:" + usdcIssuer,
+		"usd=USDT:GUSDT",
+		"EUR=EURC:GEURC",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, References{
+		"USD": {{Code: "USDC", Issuer: usdcIssuer}, {Code: "USDT", Issuer: "GUSDT"}},
+		"EUR": {{Code: "EURC", Issuer: "GEURC"}},
+	}, refs)
+
+	refs, err = ParseReferences(DefaultReferenceAssets)
+	require.NoError(t, err)
+	assert.Len(t, refs[USD], 1)
+
+	for _, v := range []string{"", "USD", "USD=", "=USDC:G", "USD=USDC", "USD=:G", "USD=USDC:", "USD=USDC:G:H"} {
+		_, err = ParseReferences([]string{v})
+		assert.Error(t, err, v)
+	}
+}
+
+func TestCalcAssetPrices(t *testing.T) {
+	const (
+		xlmID int32 = iota + 1
+		usdcID
+		btcID
+		ethID
+		orphanID
+		otherID
+	)
+	refs := References{USD: {{Code: "USDC", Issuer: usdcIssuer}}}
+
+	markets := []tickerdb.MarketPrice{
+		// 1000 XLM traded for 100 USDC, i.e. 1 XLM = 0.1 USD:
+		{
+			BaseAssetID: xlmID, BaseAssetCode: "XLM", BaseAssetIssuer: "native",
+			CounterAssetID: usdcID, CounterAssetCode: "USDC", CounterAssetIssuer: usdcIssuer,
+			BaseVolume24h: 1000, CounterVolume24h: 100, LastPrice: 11,
+		},
+		// 1 BTC = 200000 XLM:
+		{
+			BaseAssetID: xlmID, BaseAssetCode: "XLM", BaseAssetIssuer: "native",
+			CounterAssetID: btcID, CounterAssetCode: "BTC", CounterAssetIssuer: "GBTC",
+			BaseVolume24h: 400000, CounterVolume24h: 2, LastPrice: 190000,
+		},
+		// 1 BTC = 20 ETH:
+		{
+			BaseAssetID: ethID, BaseAssetCode: "ETH", BaseAssetIssuer: "GETH",
+			CounterAssetID: btcID, CounterAssetCode: "BTC", CounterAssetIssuer: "GBTC",
+			BaseVolume24h: 200, CounterVolume24h: 10, LastPrice: 21,
+		},
+		// a less liquid market pricing ETH at 900 USDC, which should be ignored:
+		{
+			BaseAssetID: usdcID, BaseAssetCode: "USDC", BaseAssetIssuer: usdcIssuer,
+			CounterAssetID: ethID, CounterAssetCode: "ETH", CounterAssetIssuer: "GETH",
+			BaseVolume24h: 900, CounterVolume24h: 1,
+		},
+		// a market without a price, which can't be used:
+		{
+			BaseAssetID: otherID, BaseAssetCode: "OTHER", BaseAssetIssuer: "GOTHER",
+			CounterAssetID: orphanID, CounterAssetCode: "ORPHAN", CounterAssetIssuer: "GORPHAN",
+		},
+	}
+
+	prices := CalcAssetPrices(markets, refs)
+	assert.Len(t, prices, 4)
+	assert.InDelta(t, 0.1, prices[xlmID][USD], 1e-9)
+	assert.InDelta(t, 1.0, prices[usdcID][USD], 1e-9)
+	assert.InDelta(t, 20000.0, prices[btcID][USD], 1e-9)
+	assert.InDelta(t, 1000.0, prices[ethID][USD], 1e-9)
+	assert.NotContains(t, prices, orphanID)
+	assert.NotContains(t, prices, otherID)
+
+	// without 24h volume, the price of XLM falls back to the last trade:
+	markets[0].BaseVolume24h = 0
+	markets[0].CounterVolume24h = 0
+	prices = CalcAssetPrices(markets, refs)
+	assert.InDelta(t, 1.0/11, prices[xlmID][USD], 1e-9)
+
+	// no prices can be derived without a reference market:
+	prices = CalcAssetPrices(markets[1:], refs)
+	assert.Empty(t, prices)
+	prices = CalcAssetPrices(markets, References{"EUR": {{Code: "EURC", Issuer: "GEURC"}}})
+	assert.Empty(t, prices)
+}
@@ -0,0 +1,126 @@
+// Package pubsub is an in-process publish/subscribe hub, through which the
+// ingestion (e.g. the trades stream and the orderbook refreshes) notifies the
+// GraphQL subscriptions served by the same process.
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+)
+
+// Topics of the events published by the ticker.
+const (
+	// TradesTopic receives a Trade whenever a streamed trade is stored.
+	TradesTopic = "trades"
+
+	// OrderbooksTopic receives an OrderbookUpdate whenever the orderbook
+	// stats of a market are refreshed.
+	OrderbooksTopic = "orderbooks"
+)
+
+// Trade is the event published on TradesTopic. Amounts and prices are the
+// decimal strings stored in the trades table.
+type Trade struct {
+	HorizonID          string
+	LedgerCloseTime    time.Time
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	BaseAmount         string
+	CounterAmount      string
+	Price              string
+	BaseIsSeller       bool
+	TradeType          string
+}
+
+// OrderbookUpdate is the event published on OrderbooksTopic.
+type OrderbookUpdate struct {
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	Stats              tickerdb.OrderbookStats
+}
+
+// Hub dispatches the events published on each topic to its subscribers.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan interface{}]struct{}
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[string]map[chan interface{}]struct{})}
+}
+
+// Publish sends event to the current subscribers of topic. It never blocks:
+// subscribers whose buffer is full miss the event.
+func (h *Hub) Publish(topic string, event interface{}) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[topic] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the events published on topic, which
+// buffers up to <buffer> events. The channel is closed once ctx is done.
+func (h *Hub) Subscribe(ctx context.Context, topic string, buffer int) <-chan interface{} {
+	ch := make(chan interface{}, buffer)
+
+	h.mu.Lock()
+	if h.subs[topic] == nil {
+		h.subs[topic] = make(map[chan interface{}]struct{})
+	}
+	h.subs[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		h.mu.Lock()
+		delete(h.subs[topic], ch)
+		if len(h.subs[topic]) == 0 {
+			delete(h.subs, topic)
+		}
+		h.mu.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
+
+// NumSubscribers returns the number of current subscribers of topic, so that
+// publishers can skip building events nobody listens to.
+func (h *Hub) NumSubscribers(topic string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs[topic])
+}
+
+// DefaultHub is the hub shared by the publishers and subscribers of this
+// process.
+var DefaultHub = NewHub()
+
+// Publish publishes event on topic through DefaultHub.
+func Publish(topic string, event interface{}) {
+	DefaultHub.Publish(topic, event)
+}
+
+// Subscribe subscribes to topic through DefaultHub.
+func Subscribe(ctx context.Context, topic string, buffer int) <-chan interface{} {
+	return DefaultHub.Subscribe(ctx, topic, buffer)
+}
+
+// NumSubscribers returns the number of subscribers of topic on DefaultHub.
+func NumSubscribers(topic string) int {
+	return DefaultHub.NumSubscribers(topic)
+}
@@ -0,0 +1,44 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub(t *testing.T) {
+	h := NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	trades := h.Subscribe(ctx, TradesTopic, 1)
+	orderbooks := h.Subscribe(ctx, OrderbooksTopic, 1)
+	assert.Equal(t, 1, h.NumSubscribers(TradesTopic))
+	assert.Equal(t, 0, h.NumSubscribers("other"))
+
+	// events are only delivered to the subscribers of their topic:
+	h.Publish(TradesTopic, Trade{HorizonID: "1"})
+	assert.Equal(t, Trade{HorizonID: "1"}, <-trades)
+	assert.Len(t, orderbooks, 0)
+
+	// publishing doesn't block when a subscriber's buffer is full:
+	h.Publish(TradesTopic, Trade{HorizonID: "2"})
+	h.Publish(TradesTopic, Trade{HorizonID: "3"})
+	assert.Equal(t, Trade{HorizonID: "2"}, <-trades)
+	assert.Len(t, trades, 0)
+
+	// channels are closed and unsubscribed once their context is done:
+	cancel()
+	select {
+	case _, ok := <-trades:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		require.Fail(t, "subscription was not closed")
+	}
+	require.Eventually(t, func() bool {
+		return h.NumSubscribers(TradesTopic) == 0
+	}, time.Second, 10*time.Millisecond)
+	h.Publish(TradesTopic, Trade{HorizonID: "4"})
+}
@@ -0,0 +1,550 @@
+package ticker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	horizonclient "github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
+	"github.com/stellar/go/support/errors"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// marketsMaxAge and assetsMaxAge are the number of seconds clients may cache
+// the REST responses for, matching how often the markets and assets are
+// refreshed.
+const (
+	marketsMaxAge   = 60
+	assetsMaxAge    = 300
+	orderbookMaxAge = 10
+)
+
+// errNotFound is returned by the REST handlers when a market or an asset
+// doesn't exist.
+var errNotFound = errors.New("not found")
+
+// restHandler serves the REST JSON API, which exposes the same data as the
+// generated JSON files, but queried live from the database.
+type restHandler struct {
+	db     *tickerdb.TickerSession
+	client *horizonclient.Client
+	logger *hlog.Entry
+
+	// minTradeSize is the minimum value in USD of the trades included in the
+	// price stats, unless the min_trade_size parameter is given.
+	minTradeSize float64
+}
+
+// AddRESTRoutes adds the REST JSON API routes (/markets, /markets/{pair},
+// /assets, /assets/{code}-{issuer} and /issuers), as well as the CoinGecko
+// (/coingecko/...) and CoinMarketCap (/cmc/...) exchange API routes, to mux.
+// Orderbooks are fetched live from Horizon through c, and the price stats only
+// include the trades worth at least minTradeSize in USD by default.
+func AddRESTRoutes(mux *http.ServeMux, s *tickerdb.TickerSession, c *horizonclient.Client, l *hlog.Entry, minTradeSize float64) {
+	h := &restHandler{db: s, client: c, logger: l, minTradeSize: minTradeSize}
+	mux.Handle("/markets", h.handle(marketsMaxAge, h.markets))
+	mux.Handle("/markets/", h.handle(marketsMaxAge, h.market))
+	mux.Handle("/assets", h.handle(assetsMaxAge, h.assets))
+	mux.Handle("/assets/", h.handle(assetsMaxAge, h.asset))
+	mux.Handle("/issuers", h.handle(assetsMaxAge, h.issuers))
+
+	mux.Handle("/coingecko/pairs", h.handle(marketsMaxAge, h.coinGeckoPairs))
+	mux.Handle("/coingecko/tickers", h.handle(marketsMaxAge, h.coinGeckoTickers))
+	mux.Handle("/coingecko/orderbook", h.handle(orderbookMaxAge, h.coinGeckoOrderbook))
+	mux.Handle("/coingecko/historical_trades", h.handle(marketsMaxAge, h.coinGeckoTrades))
+
+	mux.Handle("/cmc/summary", h.handle(marketsMaxAge, h.cmcSummary))
+	mux.Handle("/cmc/ticker", h.handle(marketsMaxAge, h.cmcTicker))
+	mux.Handle("/cmc/orderbook/", h.handle(orderbookMaxAge, h.cmcOrderbook))
+	mux.Handle("/cmc/trades/", h.handle(marketsMaxAge, h.cmcTrades))
+}
+
+// restResponse is returned by the REST handlers: Body is sent to the client,
+// while the ETag is computed from Data only, so that it doesn't change with
+// the generation timestamps.
+type restResponse struct {
+	Body interface{}
+	Data interface{}
+}
+
+// restError is returned by the REST handlers when the request is invalid.
+type restError struct {
+	Status  int
+	Message string
+}
+
+func (e restError) Error() string {
+	return e.Message
+}
+
+func (h *restHandler) handle(maxAge int, f func(*http.Request) (restResponse, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeRESTError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.logger.Infof("%s %s %s\n", r.RemoteAddr, r.Method, r.URL)
+
+		resp, err := f(r)
+		if rErr, ok := err.(restError); ok {
+			writeRESTError(w, rErr.Status, rErr.Message)
+			return
+		}
+		if err == errNotFound {
+			writeRESTError(w, http.StatusNotFound, "not found")
+			return
+		}
+		if err != nil {
+			// obfuscating sql errors to avoid exposing underlying
+			// implementation
+			h.logger.Error("could not serve REST request:", err)
+			writeRESTError(w, http.StatusInternalServerError, "could not retrieve the requested data")
+			return
+		}
+
+		etag, err := computeETag(resp.Data)
+		if err != nil {
+			writeRESTError(w, http.StatusInternalServerError, "could not retrieve the requested data")
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(resp.Body); err != nil {
+			h.logger.Error("could not encode REST response:", err)
+		}
+	})
+}
+
+// markets serves /markets, with the stats of every market as found in
+// markets.json (identified by its base and counter assets), optionally filtered
+// by base_asset_code, base_asset_issuer, counter_asset_code and
+// counter_asset_issuer.
+func (h *restHandler) markets(r *http.Request) (resp restResponse, err error) {
+	q := r.URL.Query()
+	summary, err := h.marketSummary(r, tickerdb.MarketFilter{
+		BaseAssetCode:      q.Get("base_asset_code"),
+		BaseAssetIssuer:    q.Get("base_asset_issuer"),
+		CounterAssetCode:   q.Get("counter_asset_code"),
+		CounterAssetIssuer: q.Get("counter_asset_issuer"),
+	}, false)
+	if err != nil {
+		return
+	}
+
+	if summary.Pairs == nil {
+		summary.Pairs = []MarketStats{}
+	}
+	resp.Data = summary.Pairs
+	resp.Body = summary
+	return
+}
+
+// market serves /markets/{pair}, with the stats of a single market in the
+// same format as /markets. Markets are identified either by their canonical ID
+// (e.g. /markets/XLM:native/BTC:G...) or by a trade pair name (e.g.
+// /markets/XLM_BTC), in which case the markets whose assets share the same
+// codes are aggregated.
+func (h *restHandler) market(r *http.Request) (resp restResponse, err error) {
+	pair := strings.TrimPrefix(r.URL.Path, "/markets/")
+	filter := tickerdb.MarketFilter{TradePairName: pair}
+	bCode, bIssuer, cCode, cIssuer, idErr := tickerdb.ParseMarketID(pair)
+	aggregateByCode := idErr != nil
+	if !aggregateByCode {
+		filter = tickerdb.MarketFilter{
+			BaseAssetCode:      bCode,
+			BaseAssetIssuer:    bIssuer,
+			CounterAssetCode:   cCode,
+			CounterAssetIssuer: cIssuer,
+		}
+	}
+
+	summary, err := h.marketSummary(r, filter, aggregateByCode)
+	if err != nil {
+		return
+	}
+	if len(summary.Pairs) == 0 {
+		err = errNotFound
+		return
+	}
+
+	resp.Data = summary.Pairs[0]
+	resp.Body = summary.Pairs[0]
+	return
+}
+
+// marketSummary generates the MarketSummary of the markets matching filter,
+// including the trailing windows given in the windows parameter (e.g.
+// ?windows=1h,30d). The min_trade_size parameter sets the minimum size of the
+// trades included in the price stats (the one of the handler by default).
+func (h *restHandler) marketSummary(r *http.Request, filter tickerdb.MarketFilter, aggregateByCode bool) (summary MarketSummary, err error) {
+	q := r.URL.Query()
+	minTradeSize, err := minTradeSizeParam(q.Get("min_trade_size"), h.minTradeSize)
+	if err != nil {
+		return
+	}
+
+	var windows []string
+	if w := q.Get("windows"); w != "" {
+		windows = strings.Split(w, ",")
+	}
+	for _, w := range windows {
+		if _, err = utils.ParseWindow(w); err != nil {
+			err = restError{http.StatusBadRequest, err.Error()}
+			return
+		}
+	}
+
+	return GenerateMarketSummary(h.db, windows, filter, aggregateByCode, minTradeSize)
+}
+
+// assets serves /assets, with every valid asset, optionally filtered by code
+// and / or issuer.
+func (h *restHandler) assets(r *http.Request) (resp restResponse, err error) {
+	q := r.URL.Query()
+	assets, err := h.filterAssets(r.Context(), q.Get("code"), q.Get("issuer"))
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	resp.Data = assets
+	resp.Body = AssetSummary{
+		GeneratedAt:        utils.TimeToUnixEpoch(now),
+		GeneratedAtRFC3339: utils.TimeToRFC3339(now),
+		Assets:             assets,
+	}
+	return
+}
+
+// asset serves /assets/{code}-{issuer}, with a single valid asset.
+func (h *restHandler) asset(r *http.Request) (resp restResponse, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/assets/"), "-", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		err = restError{http.StatusBadRequest, "assets must be identified as {code}-{issuer}"}
+		return
+	}
+
+	assets, err := h.filterAssets(r.Context(), parts[0], parts[1])
+	if err != nil {
+		return
+	}
+	if len(assets) == 0 {
+		err = errNotFound
+		return
+	}
+
+	resp.Data = assets[0]
+	resp.Body = assets[0]
+	return
+}
+
+// issuers serves /issuers, with every issuer in the database.
+func (h *restHandler) issuers(r *http.Request) (resp restResponse, err error) {
+	dbIssuers, err := h.db.GetAllIssuers(r.Context())
+	if err != nil {
+		return
+	}
+
+	issuers := []Issuer{}
+	for _, dbIssuer := range dbIssuers {
+		issuers = append(issuers, dbIssuerToIssuer(dbIssuer))
+	}
+
+	resp.Data = issuers
+	resp.Body = struct {
+		Issuers []Issuer `json:"issuers"`
+	}{issuers}
+	return
+}
+
+// coinGeckoPairs serves /coingecko/pairs, with the markets traded within the
+// last 24 hours.
+func (h *restHandler) coinGeckoPairs(r *http.Request) (resp restResponse, err error) {
+	pairs, err := GenerateCoinGeckoPairs(r.Context(), h.db, h.minTradeSize)
+	resp.Data = pairs
+	resp.Body = pairs
+	return
+}
+
+// coinGeckoTickers serves /coingecko/tickers, with the 24h stats of the
+// markets traded within the last 24 hours.
+func (h *restHandler) coinGeckoTickers(r *http.Request) (resp restResponse, err error) {
+	tickers, err := GenerateCoinGeckoTickers(r.Context(), h.db, h.minTradeSize)
+	resp.Data = tickers
+	resp.Body = tickers
+	return
+}
+
+// coinGeckoOrderbook serves /coingecko/orderbook?ticker_id=..., with the
+// orderbook of a market, limited to depth / 2 levels on each side if depth is
+// given.
+func (h *restHandler) coinGeckoOrderbook(r *http.Request) (resp restResponse, err error) {
+	q := r.URL.Query()
+	depth, err := intParam(q.Get("depth"), 0, "depth")
+	if err != nil {
+		return
+	}
+
+	ob := CoinGeckoOrderbook{
+		TickerID:  q.Get("ticker_id"),
+		Timestamp: utils.TimeToUnixEpoch(time.Now()),
+	}
+	ob.Bids, ob.Asks, err = h.fetchOrderbook(ob.TickerID, (depth+1)/2)
+	if err != nil {
+		return
+	}
+
+	resp.Data = struct{ Bids, Asks [][2]string }{ob.Bids, ob.Asks}
+	resp.Body = ob
+	return
+}
+
+// coinGeckoTrades serves /coingecko/historical_trades?ticker_id=..., with
+// the trades of a market, optionally filtered by type (buy or sell) and
+// closing time (start_time and end_time, as Unix timestamps).
+func (h *restHandler) coinGeckoTrades(r *http.Request) (resp restResponse, err error) {
+	q := r.URL.Query()
+	tradeType := q.Get("type")
+	if tradeType != "" && tradeType != "buy" && tradeType != "sell" {
+		err = restError{http.StatusBadRequest, "type must be buy or sell"}
+		return
+	}
+
+	limit, err := intParam(q.Get("limit"), 500, "limit")
+	if err != nil {
+		return
+	}
+	startTime, err := intParam(q.Get("start_time"), 0, "start_time")
+	if err != nil {
+		return
+	}
+	until := time.Now()
+	if q.Get("end_time") != "" {
+		var endTime int
+		if endTime, err = intParam(q.Get("end_time"), 0, "end_time"); err != nil {
+			return
+		}
+		until = time.Unix(int64(endTime), 0)
+	}
+
+	dbTrades, err := h.retrieveTrades(r, q.Get("ticker_id"), time.Unix(int64(startTime), 0), until, limit)
+	if err != nil {
+		return
+	}
+
+	trades := CoinGeckoTrades{Buy: []CoinGeckoTrade{}, Sell: []CoinGeckoTrade{}}
+	for _, dbTrade := range dbTrades {
+		trade := dbTradeToCoinGeckoTrade(dbTrade)
+		if trade.Type == "sell" && tradeType != "buy" {
+			trades.Sell = append(trades.Sell, trade)
+		} else if trade.Type == "buy" && tradeType != "sell" {
+			trades.Buy = append(trades.Buy, trade)
+		}
+	}
+
+	resp.Data = trades
+	resp.Body = trades
+	return
+}
+
+// cmcSummary serves /cmc/summary, with the 24h stats of the markets traded
+// within the last 24 hours.
+func (h *restHandler) cmcSummary(r *http.Request) (resp restResponse, err error) {
+	summary, err := GenerateCMCSummary(r.Context(), h.db, h.minTradeSize)
+	resp.Data = summary
+	resp.Body = summary
+	return
+}
+
+// cmcTicker serves /cmc/ticker, with the 24h stats of the markets traded
+// within the last 24 hours, keyed by market pair.
+func (h *restHandler) cmcTicker(r *http.Request) (resp restResponse, err error) {
+	tickers, err := GenerateCMCTicker(r.Context(), h.db, h.minTradeSize)
+	resp.Data = tickers
+	resp.Body = tickers
+	return
+}
+
+// cmcOrderbook serves /cmc/orderbook/{market_pair}, with the orderbook of a
+// market, limited to depth / 2 levels on each side if depth is given, or to
+// the best bid and ask if level is 1.
+func (h *restHandler) cmcOrderbook(r *http.Request) (resp restResponse, err error) {
+	q := r.URL.Query()
+	depth, err := intParam(q.Get("depth"), 0, "depth")
+	if err != nil {
+		return
+	}
+	levels := (depth + 1) / 2
+	if q.Get("level") == "1" {
+		levels = 1
+	}
+
+	ob := CMCOrderbook{Timestamp: utils.TimeToUnixEpoch(time.Now())}
+	ob.Bids, ob.Asks, err = h.fetchOrderbook(strings.TrimPrefix(r.URL.Path, "/cmc/orderbook/"), levels)
+	if err != nil {
+		return
+	}
+
+	resp.Data = struct{ Bids, Asks [][2]string }{ob.Bids, ob.Asks}
+	resp.Body = ob
+	return
+}
+
+// cmcTrades serves /cmc/trades/{market_pair}, with the trades of a market
+// within the last 24 hours.
+func (h *restHandler) cmcTrades(r *http.Request) (resp restResponse, err error) {
+	now := time.Now()
+	dbTrades, err := h.retrieveTrades(r, strings.TrimPrefix(r.URL.Path, "/cmc/trades/"), now.Add(-24*time.Hour), now, maxAggregatorTrades)
+	if err != nil {
+		return
+	}
+
+	trades := []CMCTrade{}
+	for _, dbTrade := range dbTrades {
+		trades = append(trades, dbTradeToCMCTrade(dbTrade))
+	}
+
+	resp.Data = trades
+	resp.Body = trades
+	return
+}
+
+// fetchOrderbook fetches the orderbook of a market, turning invalid ticker IDs
+// into bad requests.
+func (h *restHandler) fetchOrderbook(tickerID string, depth int) (bids [][2]string, asks [][2]string, err error) {
+	if _, _, err = parseTickerID(tickerID); err != nil {
+		err = restError{http.StatusBadRequest, err.Error()}
+		return
+	}
+	return FetchAggregatorOrderbook(h.client, h.logger, tickerID, depth)
+}
+
+// retrieveTrades retrieves the trades of a market, turning invalid ticker IDs
+// into bad requests.
+func (h *restHandler) retrieveTrades(r *http.Request, tickerID string, since, until time.Time, limit int) ([]tickerdb.Trade, error) {
+	if _, _, err := parseTickerID(tickerID); err != nil {
+		return nil, restError{http.StatusBadRequest, err.Error()}
+	}
+	return RetrieveAggregatorTrades(r.Context(), h.db, tickerID, since, until, limit)
+}
+
+// filterAssets returns the valid assets with the given code and issuer (any
+// of them if empty).
+func (h *restHandler) filterAssets(ctx context.Context, code, issuer string) ([]Asset, error) {
+	dbAssets, err := h.db.GetAssetsWithNestedIssuer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := retrieveAssetPrices(ctx, h.db)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := h.db.RetrieveLatestAssetSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assets := []Asset{}
+	for _, dbAsset := range dbAssets {
+		if code != "" && dbAsset.Code != code {
+			continue
+		}
+		if issuer != "" && dbAsset.IssuerAccount != issuer {
+			continue
+		}
+		asset := dbAssetToAsset(dbAsset)
+		setAssetPrices(&asset, prices[dbAsset.ID])
+		setAssetSupply(&asset, snapshots, dbAsset.ID)
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// dbIssuerToIssuer converts a tickerdb.Issuer to an Issuer.
+func dbIssuerToIssuer(i tickerdb.Issuer) Issuer {
+	return Issuer{
+		PublicKey:        i.PublicKey,
+		Name:             i.Name,
+		URL:              i.URL,
+		TOMLURL:          i.TOMLURL,
+		FederationServer: i.FederationServer,
+		AuthServer:       i.AuthServer,
+		TransferServer:   i.TransferServer,
+		WebAuthEndpoint:  i.WebAuthEndpoint,
+		DepositServer:    i.DepositServer,
+		OrgTwitter:       i.OrgTwitter,
+	}
+}
+
+// intParam parses a non-negative integer query parameter, returning
+// defaultValue if it is empty.
+func intParam(v string, defaultValue int, name string) (int, error) {
+	if v == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, restError{http.StatusBadRequest, name + " must be a non-negative integer"}
+	}
+	return n, nil
+}
+
+// minTradeSizeParam parses the minimum value in USD of the trades included in
+// the price stats of the markets, returning def if it is empty.
+func minTradeSizeParam(v string, def float64) (float64, error) {
+	if v == "" {
+		return def, nil
+	}
+	size, err := strconv.ParseFloat(v, 64)
+	if err != nil || !(size >= 0) || math.IsInf(size, 0) {
+		return 0, restError{http.StatusBadRequest, "min_trade_size must be a non-negative number"}
+	}
+	return size, nil
+}
+
+// computeETag returns a strong ETag derived from the JSON encoding of data.
+func computeETag(data interface{}) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagMatches reports whether the If-None-Match header matches etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func writeRESTError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{message})
+}
@@ -0,0 +1,73 @@
+package ticker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hlog "github.com/stellar/go/support/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTHandlerCaching(t *testing.T) {
+	h := &restHandler{logger: hlog.New()}
+	generatedAt := 0
+	handler := h.handle(60, func(r *http.Request) (restResponse, error) {
+		// The ETag must not change with the body's generation timestamp:
+		generatedAt++
+		data := []string{"XLM_BTC"}
+		return restResponse{
+			Body: map[string]interface{}{"generated_at": generatedAt, "pairs": data},
+			Data: data,
+		}, nil
+	})
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/markets", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=60", rr.Header().Get("Cache-Control"))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"generated_at": 1, "pairs": ["XLM_BTC"]}`, rr.Body.String())
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/markets", nil)
+	req.Header.Set("If-None-Match", `"other", W/`+etag)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Equal(t, etag, rr.Header().Get("ETag"))
+	assert.Empty(t, rr.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/markets", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/markets", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestRESTHandlerInvalidRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	AddRESTRoutes(mux, nil, nil, hlog.New(), 0)
+
+	for _, path := range []string{
+		"/markets?windows=abc",
+		"/markets?windows=1h,1y",
+		"/markets?min_trade_size=-1",
+		"/markets?min_trade_size=abc",
+		"/markets/XLM_BTC?min_trade_size=NaN",
+		"/markets/XLM_BTC?windows=1y",
+		"/assets/BTC",
+		"/assets/-GABC",
+	} {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, path)
+		assert.Contains(t, rr.Body.String(), `"error"`, path)
+	}
+}
@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/metrics"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// maxConsecutiveFailures is the number of consecutive failed runs after which
+// a job (and thus the scheduler) is reported as unhealthy.
+const maxConsecutiveFailures = 3
+
+// Job is a task run by the Scheduler every Interval. Run must return once ctx
+// is done, so that the scheduler can shut down gracefully. Jobs that never
+// return on their own (e.g. streams) are restarted on the next tick after they
+// fail.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// JobStatus reports the state of a scheduled job, as returned by the health
+// endpoint.
+type JobStatus struct {
+	Name                string     `json:"name"`
+	Interval            string     `json:"interval"`
+	Running             bool       `json:"running"`
+	LastStart           *time.Time `json:"last_start,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	SkippedRuns         int        `json:"skipped_runs"`
+}
+
+// Healthy returns false if the job failed too many times in a row.
+func (js JobStatus) Healthy() bool {
+	return js.ConsecutiveFailures < maxConsecutiveFailures
+}
+
+// Scheduler periodically runs a set of jobs, making sure that a job never runs
+// more than once at the same time: if a job is still running when its next run
+// is due, that run is skipped.
+type Scheduler struct {
+	logger *hlog.Entry
+	mutex  sync.RWMutex
+	jobs   []Job
+	status map[string]*JobStatus
+}
+
+// New creates a new Scheduler without any job.
+func New(l *hlog.Entry) *Scheduler {
+	return &Scheduler{
+		logger: l,
+		status: make(map[string]*JobStatus),
+	}
+}
+
+// Add schedules a job. Jobs with a non-positive interval are ignored, so that
+// they can be disabled through configuration.
+func (s *Scheduler) Add(job Job) {
+	if job.Interval <= 0 {
+		s.logger.Infof("Job %s is disabled\n", job.Name)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobs = append(s.jobs, job)
+	s.status[job.Name] = &JobStatus{
+		Name:     job.Name,
+		Interval: job.Interval.String(),
+	}
+}
+
+// Run runs every job right away and then every time its interval elapses,
+// until ctx is done. It then waits for the running jobs to return.
+func (s *Scheduler) Run(ctx context.Context) {
+	var loops, runs sync.WaitGroup
+
+	s.mutex.RLock()
+	jobs := append([]Job{}, s.jobs...)
+	s.mutex.RUnlock()
+
+	for _, job := range jobs {
+		loops.Add(1)
+		go func(job Job) {
+			defer loops.Done()
+
+			ticker := time.NewTicker(job.Interval)
+			defer ticker.Stop()
+
+			for {
+				s.trigger(ctx, job, &runs)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(job)
+	}
+
+	loops.Wait()
+	s.logger.Info("Waiting for the running jobs to finish")
+	runs.Wait()
+}
+
+// trigger starts a run of job, unless the previous one is still running.
+func (s *Scheduler) trigger(ctx context.Context, job Job, runs *sync.WaitGroup) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	status := s.status[job.Name]
+	if status.Running {
+		status.SkippedRuns++
+		s.mutex.Unlock()
+		s.logger.Infof("Skipping job %s, as its previous run is still in progress\n", job.Name)
+		metrics.JobRuns.WithLabelValues(job.Name, "skipped").Inc()
+		return
+	}
+	start := time.Now()
+	status.Running = true
+	status.LastStart = &start
+	s.mutex.Unlock()
+
+	runs.Add(1)
+	go func() {
+		defer runs.Done()
+
+		s.logger.Infof("Starting job %s\n", job.Name)
+		err := job.Run(ctx)
+		duration := time.Since(start)
+
+		s.mutex.Lock()
+		status.Running = false
+		if err != nil {
+			status.ConsecutiveFailures++
+			status.LastError = err.Error()
+		} else {
+			end := time.Now()
+			status.ConsecutiveFailures = 0
+			status.LastError = ""
+			status.LastSuccess = &end
+		}
+		s.mutex.Unlock()
+
+		metrics.JobDuration.WithLabelValues(job.Name).Observe(duration.Seconds())
+		if err != nil {
+			s.logger.Errorf("Job %s failed after %s: %v", job.Name, duration, err)
+			metrics.JobRuns.WithLabelValues(job.Name, "error").Inc()
+			return
+		}
+		s.logger.Infof("Job %s finished in %s\n", job.Name, duration)
+		metrics.JobRuns.WithLabelValues(job.Name, "success").Inc()
+	}()
+}
+
+// Status returns the status of every scheduled job.
+func (s *Scheduler) Status() (statuses []JobStatus) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, job := range s.jobs {
+		statuses = append(statuses, *s.status[job.Name])
+	}
+	return
+}
+
+// HealthHandler returns an HTTP handler reporting the status of every job.
+// It responds with 503 Service Unavailable if any of them is unhealthy.
+func (s *Scheduler) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := struct {
+			Status string      `json:"status"`
+			Jobs   []JobStatus `json:"jobs"`
+		}{Status: "ok", Jobs: s.Status()}
+
+		code := http.StatusOK
+		for _, job := range response.Jobs {
+			if !job.Healthy() {
+				response.Status = "unhealthy"
+				code = http.StatusServiceUnavailable
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			s.logger.Error("could not encode health response:", err)
+		}
+	})
+}
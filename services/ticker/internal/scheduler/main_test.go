@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hlog "github.com/stellar/go/support/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerSkipsOverlappingRuns(t *testing.T) {
+	s := New(hlog.New())
+	var numRuns, running, maxRunning int32
+
+	s.Add(Job{
+		Name:     "slow",
+		Interval: 5 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&numRuns, 1)
+			if n := atomic.AddInt32(&running, 1); n > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, n)
+			}
+			defer atomic.AddInt32(&running, -1)
+
+			// Runs until the scheduler shuts down:
+			<-ctx.Done()
+			return nil
+		},
+	})
+	s.Add(Job{Name: "disabled", Interval: 0})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the scheduler did not wait for the running job to return")
+	}
+
+	assert.Equal(t, int32(1), numRuns)
+	assert.Equal(t, int32(1), maxRunning)
+	assert.Equal(t, int32(0), running)
+
+	statuses := s.Status()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "slow", statuses[0].Name)
+	assert.False(t, statuses[0].Running)
+	assert.NotNil(t, statuses[0].LastSuccess)
+	assert.Greater(t, statuses[0].SkippedRuns, 0)
+}
+
+func TestSchedulerHealth(t *testing.T) {
+	s := New(hlog.New())
+	var numRuns int32
+
+	s.Add(Job{
+		Name:     "failing",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&numRuns, 1)
+			return errors.New("boom")
+		},
+	})
+
+	health := func() int {
+		rr := httptest.NewRecorder()
+		s.HealthHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+		return rr.Code
+	}
+	assert.Equal(t, http.StatusOK, health())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for atomic.LoadInt32(&numRuns) < maxConsecutiveFailures+1 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+	s.Run(ctx)
+
+	statuses := s.Status()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "boom", statuses[0].LastError)
+	assert.Nil(t, statuses[0].LastSuccess)
+	assert.False(t, statuses[0].Healthy())
+	assert.Equal(t, http.StatusServiceUnavailable, health())
+}
@@ -0,0 +1,259 @@
+package scraper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+)
+
+// AssetPolicy decides which assets are listed on the ticker (i.e. indexed).
+// Policies are composed of rules (see All and Any), each of them explaining
+// its decision.
+type AssetPolicy interface {
+	Evaluate(asset PolicyAsset) PolicyResult
+}
+
+// PolicyAsset is an asset evaluated by an AssetPolicy. Assets are evaluated a
+// first time with the Horizon stats only and, when TOML files are validated,
+// once more after fetching and verifying their TOML file (TOMLChecked), which
+// tells whether their domain is verified, along with the home domain of their
+// issuer and the SEP-1 findings of the file.
+type PolicyAsset struct {
+	Stat           hProtocol.AssetStat
+	TOMLChecked    bool
+	DomainVerified bool
+	HomeDomain     string
+	TOMLFindings   []TOMLFinding
+}
+
+// PolicyResult is the decision of an AssetPolicy: whether the asset is
+// listed, the rule that made the decision and the reason for it.
+type PolicyResult struct {
+	Listed bool
+	Rule   string
+	Reason string
+}
+
+// AssetPolicyFunc is an adapter to use a function as an AssetPolicy.
+type AssetPolicyFunc func(asset PolicyAsset) PolicyResult
+
+// Evaluate calls f(asset).
+func (f AssetPolicyFunc) Evaluate(asset PolicyAsset) PolicyResult {
+	return f(asset)
+}
+
+func pass(rule, format string, args ...interface{}) PolicyResult {
+	return PolicyResult{Listed: true, Rule: rule, Reason: fmt.Sprintf(format, args...)}
+}
+
+func fail(rule, format string, args ...interface{}) PolicyResult {
+	return PolicyResult{Listed: false, Rule: rule, Reason: fmt.Sprintf(format, args...)}
+}
+
+// All lists the assets passing every policy. It returns the first failure or,
+// if the asset passes them all, the result of the last policy along with the
+// reasons of all of them.
+func All(policies ...AssetPolicy) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		result := pass("all", "no rule to pass")
+		var reasons []string
+		for _, p := range policies {
+			result = p.Evaluate(asset)
+			if !result.Listed {
+				return result
+			}
+			reasons = append(reasons, result.Reason)
+		}
+		if len(reasons) > 0 {
+			result.Reason = strings.Join(reasons, "; ")
+		}
+		return result
+	})
+}
+
+// Any lists the assets passing at least one of the policies. It returns the
+// first success or, if the asset fails them all, the last failure.
+func Any(policies ...AssetPolicy) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		result := fail("any", "no rule to pass")
+		for _, p := range policies {
+			result = p.Evaluate(asset)
+			if result.Listed {
+				return result
+			}
+		}
+		return result
+	})
+}
+
+// MinSupply lists the assets with a positive supply of at least min.
+func MinSupply(min float64) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		amount, err := strconv.ParseFloat(asset.Stat.Amount, 64)
+		if err != nil || amount <= 0 {
+			return fail("min_supply", "no supply")
+		}
+		if amount < min {
+			return fail("min_supply", "supply of %s is below %g", asset.Stat.Amount, min)
+		}
+		return pass("min_supply", "supply of %s", asset.Stat.Amount)
+	})
+}
+
+// MinHolders lists the assets held by at least min accounts.
+func MinHolders(min int32) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		if asset.Stat.NumAccounts < min {
+			return fail("min_holders", "%d holders, fewer than %d", asset.Stat.NumAccounts, min)
+		}
+		return pass("min_holders", "%d holders, at least %d", asset.Stat.NumAccounts, min)
+	})
+}
+
+// DenyCodes rejects the assets with any of the given codes, whatever their
+// issuer.
+func DenyCodes(codes ...string) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		for _, code := range codes {
+			if asset.Stat.Code == code {
+				return fail("deny_codes", "code %s is discarded", code)
+			}
+		}
+		return pass("deny_codes", "code %s isn't discarded", asset.Stat.Code)
+	})
+}
+
+// Allow lists only the assets matching an entry of the allow list (see
+// ParseAssetListEntry).
+func Allow(entries ...string) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		if entry, ok := matchAssetList(asset.Stat, entries); ok {
+			return pass("allow", "%s is on the allow list", entry)
+		}
+		return fail("allow", "not on the allow list")
+	})
+}
+
+// Deny rejects the assets matching an entry of the deny list (see
+// ParseAssetListEntry).
+func Deny(entries ...string) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		if entry, ok := matchAssetList(asset.Stat, entries); ok {
+			return fail("deny", "%s is on the deny list", entry)
+		}
+		return pass("deny", "not on the deny list")
+	})
+}
+
+// TOMLRequirements lists the assets with a TOML file, which must be hosted on
+// HTTPS if requireHTTPS is set.
+func TOMLRequirements(requireHTTPS bool) AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		tomlURL := asset.Stat.Links.Toml.Href
+		if tomlURL == "" {
+			return fail("toml", "no TOML file")
+		}
+		// [StellarX Ticker]: TOML files should be hosted on HTTPS
+		if requireHTTPS && !strings.HasPrefix(tomlURL, "https://") {
+			return fail("toml", "TOML file %s isn't hosted on HTTPS", tomlURL)
+		}
+		return pass("toml", "TOML file at %s", tomlURL)
+	})
+}
+
+// DomainVerification lists the assets whose TOML file passes the SEP-1
+// verification without errors: served by the home domain of their issuer and
+// listing them as currencies (see verifySEP1). Assets whose TOML file wasn't
+// fetched yet pass.
+func DomainVerification() AssetPolicy {
+	return AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		if !asset.TOMLChecked {
+			return pass("domain_verification", "TOML file not checked yet")
+		}
+		if !asset.DomainVerified {
+			var problems []string
+			for _, f := range asset.TOMLFindings {
+				if f.Severity == FindingError {
+					problems = append(problems, f.Message)
+				}
+			}
+			if len(problems) == 0 {
+				return fail("domain_verification", "the TOML file couldn't be verified")
+			}
+			return fail("domain_verification", "%s", strings.Join(problems, "; "))
+		}
+		return pass("domain_verification", "domain %s verified", asset.HomeDomain)
+	})
+}
+
+// NewAssetPolicy returns the policy built from the discard rules: assets need
+// a supply of at least MinSupply and mustn't be on the deny list. Then assets
+// on the allow list (or from a trusted issuer) are listed, while the others
+// need MinAccounts holders, an allowed code and, if validateTOML is set,
+// either TrustedAccounts holders or a valid TOML file (on a verified domain if
+// RequireDomainVerification is set).
+func NewAssetPolicy(rules DiscardRules, validateTOML bool) AssetPolicy {
+	allowed := append([]string(nil), rules.AllowList...)
+	for issuer, trusted := range rules.TrustedIssuers {
+		if trusted {
+			allowed = append(allowed, issuer)
+		}
+	}
+
+	// [StellarX Ticker]: assets need at least some adoption to show up
+	listing := []AssetPolicy{
+		MinHolders(rules.MinAccounts),
+		DenyCodes(rules.DiscardedCodes...),
+	}
+	if validateTOML {
+		toml := []AssetPolicy{TOMLRequirements(rules.RequireHTTPSTOML)}
+		if rules.RequireDomainVerification {
+			toml = append(toml, DomainVerification())
+		}
+		// [StellarX Ticker]: assets with at least 100 accounts get a pass,
+		// even with toml issues
+		listing = append(listing, Any(MinHolders(rules.TrustedAccounts), All(toml...)))
+	}
+
+	return All(
+		MinSupply(rules.MinSupply),
+		Deny(rules.DenyList...),
+		Any(Allow(allowed...), All(listing...)),
+	)
+}
+
+// ParseAssetListEntry checks an entry of an allow or deny list, which is
+// either CODE:ISSUER (a single asset) or ISSUER (all the assets of an
+// issuer).
+func ParseAssetListEntry(entry string) (code string, issuer string, err error) {
+	issuer = entry
+	if i := strings.Index(entry, ":"); i >= 0 {
+		code, issuer = entry[:i], entry[i+1:]
+		if code == "" || len(code) > 12 {
+			err = errors.Errorf("%q has an invalid asset code", entry)
+			return
+		}
+	}
+	if !strkey.IsValidEd25519PublicKey(issuer) {
+		err = errors.Errorf("%q has an invalid issuer", entry)
+	}
+	return
+}
+
+// matchAssetList returns the entry of entries matching asset, if any.
+func matchAssetList(asset hProtocol.AssetStat, entries []string) (string, bool) {
+	for _, entry := range entries {
+		code, issuer, err := ParseAssetListEntry(entry)
+		if err != nil || issuer != asset.Asset.Issuer {
+			continue
+		}
+		if code == "" || code == asset.Asset.Code {
+			return entry, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,128 @@
+package scraper
+
+import (
+	"testing"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicyIssuer = "GCSAZVWXZKWS4XS223M5F54H2B6XPIIXZZGP7KEAIU6YSL5HDRGCI3DG"
+
+func policyAsset(code string, numAccounts int32, amount, tomlURL string) PolicyAsset {
+	stat := hProtocol.AssetStat{Amount: amount, NumAccounts: numAccounts}
+	stat.Asset.Code = code
+	stat.Asset.Issuer = testPolicyIssuer
+	stat.Links.Toml.Href = tomlURL
+	return PolicyAsset{Stat: stat}
+}
+
+func TestAssetPolicyRules(t *testing.T) {
+	asset := policyAsset("BTC", 20, "1000.5", "https://example.com/.well-known/stellar.toml")
+
+	result := MinSupply(10).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: true, Rule: "min_supply", Reason: "supply of 1000.5"}, result)
+	result = MinSupply(2000).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: false, Rule: "min_supply", Reason: "supply of 1000.5 is below 2000"}, result)
+
+	result = MinHolders(30).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: false, Rule: "min_holders", Reason: "20 holders, fewer than 30"}, result)
+
+	assert.False(t, DenyCodes("ETH", "BTC").Evaluate(asset).Listed)
+	assert.True(t, DenyCodes("ETH").Evaluate(asset).Listed)
+
+	assert.True(t, Allow(testPolicyIssuer).Evaluate(asset).Listed)
+	assert.True(t, Allow("BTC:"+testPolicyIssuer).Evaluate(asset).Listed)
+	assert.False(t, Allow("ETH:"+testPolicyIssuer).Evaluate(asset).Listed)
+	result = Deny("BTC:" + testPolicyIssuer).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: false, Rule: "deny", Reason: "BTC:" + testPolicyIssuer + " is on the deny list"}, result)
+
+	assert.True(t, TOMLRequirements(true).Evaluate(asset).Listed)
+	insecure := policyAsset("BTC", 20, "1000.5", "http://example.com/.well-known/stellar.toml")
+	assert.False(t, TOMLRequirements(true).Evaluate(insecure).Listed)
+	assert.True(t, TOMLRequirements(false).Evaluate(insecure).Listed)
+
+	assert.True(t, DomainVerification().Evaluate(asset).Listed)
+	asset.TOMLChecked = true
+	assert.False(t, DomainVerification().Evaluate(asset).Listed)
+	asset.DomainVerified = true
+	assert.True(t, DomainVerification().Evaluate(asset).Listed)
+}
+
+func TestAssetPolicyComposition(t *testing.T) {
+	asset := policyAsset("BTC", 20, "1000.5", "")
+
+	result := All(MinSupply(1), MinHolders(10)).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: true, Rule: "min_holders", Reason: "supply of 1000.5; 20 holders, at least 10"}, result)
+	result = All(MinSupply(1), MinHolders(30), TOMLRequirements(true)).Evaluate(asset)
+	assert.Equal(t, "min_holders", result.Rule)
+	assert.False(t, result.Listed)
+
+	result = Any(MinHolders(30), TOMLRequirements(true)).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: false, Rule: "toml", Reason: "no TOML file"}, result)
+	result = Any(MinHolders(30), MinSupply(1)).Evaluate(asset)
+	assert.Equal(t, "min_supply", result.Rule)
+	assert.True(t, result.Listed)
+
+	// custom rules can be composed with the built-in ones:
+	noBTC := AssetPolicyFunc(func(asset PolicyAsset) PolicyResult {
+		return PolicyResult{Listed: asset.Stat.Code != "BTC", Rule: "no_btc", Reason: "BTC isn't listed"}
+	})
+	result = All(MinSupply(1), noBTC).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: false, Rule: "no_btc", Reason: "BTC isn't listed"}, result)
+}
+
+func TestNewAssetPolicyLists(t *testing.T) {
+	rules := DefaultSettings().Discard
+	asset := policyAsset("BTC", 2, "1000.5", "")
+
+	result := NewAssetPolicy(rules, true).Evaluate(asset)
+	assert.Equal(t, PolicyResult{Listed: false, Rule: "min_holders", Reason: "2 holders, fewer than 10"}, result)
+
+	rules.AllowList = []string{"BTC:" + testPolicyIssuer}
+	result = NewAssetPolicy(rules, true).Evaluate(asset)
+	assert.True(t, result.Listed)
+	assert.Equal(t, "allow", result.Rule)
+
+	// the deny list takes precedence over the allow list:
+	rules.DenyList = []string{testPolicyIssuer}
+	result = NewAssetPolicy(rules, true).Evaluate(asset)
+	assert.False(t, result.Listed)
+	assert.Equal(t, "deny", result.Rule)
+
+	rules = DefaultSettings().Discard
+	rules.MinSupply = 5000
+	result = NewAssetPolicy(rules, true).Evaluate(policyAsset("BTC", 200, "1000.5", ""))
+	assert.False(t, result.Listed)
+	assert.Equal(t, "min_supply", result.Rule)
+
+	rules = DefaultSettings().Discard
+	rules.RequireDomainVerification = true
+	asset = policyAsset("BTC", 20, "1000.5", "https://example.com/.well-known/stellar.toml")
+	assert.True(t, NewAssetPolicy(rules, true).Evaluate(asset).Listed)
+	asset.TOMLChecked = true
+	result = NewAssetPolicy(rules, true).Evaluate(asset)
+	assert.False(t, result.Listed)
+	assert.Equal(t, "domain_verification", result.Rule)
+	asset.DomainVerified = true
+	assert.True(t, NewAssetPolicy(rules, true).Evaluate(asset).Listed)
+}
+
+func TestParseAssetListEntry(t *testing.T) {
+	code, issuer, err := ParseAssetListEntry("BTC:" + testPolicyIssuer)
+	assert.NoError(t, err)
+	assert.Equal(t, "BTC", code)
+	assert.Equal(t, testPolicyIssuer, issuer)
+
+	code, issuer, err = ParseAssetListEntry(testPolicyIssuer)
+	assert.NoError(t, err)
+	assert.Equal(t, "", code)
+	assert.Equal(t, testPolicyIssuer, issuer)
+
+	_, _, err = ParseAssetListEntry("BTC:GABC")
+	assert.EqualError(t, err, `"BTC:GABC" has an invalid issuer`)
+	_, _, err = ParseAssetListEntry(":" + testPolicyIssuer)
+	assert.Error(t, err)
+	_, _, err = ParseAssetListEntry("TOOLONGASSETCODE:" + testPolicyIssuer)
+	assert.Error(t, err)
+}
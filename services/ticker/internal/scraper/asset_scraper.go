@@ -1,12 +1,11 @@
 package scraper
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
-	"net/url"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,56 +13,36 @@ import (
 
 	"github.com/BurntSushi/toml"
 
+	"github.com/stellar/go/amount"
 	horizonclient "github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/clients/stellartoml"
 	hProtocol "github.com/stellar/go/protocols/horizon"
-	"github.com/stellar/go/services/ticker/internal/utils"
+	"github.com/stellar/go/services/ticker/internal/metrics"
 	"github.com/stellar/go/support/errors"
 	hlog "github.com/stellar/go/support/log"
 )
 
-// shouldDiscardAsset maps the criteria for discarding an asset from the asset index
-func shouldDiscardAsset(asset hProtocol.AssetStat, shouldValidateTOML bool) bool {
-	if asset.Amount == "" {
-		return true
-	}
-	f, _ := strconv.ParseFloat(asset.Amount, 64)
-	if f == 0.0 {
-		return true
-	}
-	// [StellarX Ticker]: assets need at least some adoption to show up
-	if asset.NumAccounts < 10 {
-		return true
-	}
-	if asset.Code == "REMOVE" {
-		return true
-	}
-	// [StellarX Ticker]: assets with at least 100 accounts get a pass,
-	// even with toml issues
-	if asset.NumAccounts >= 100 {
-		return false
+// decodeTOMLIssuer decodes retrieved TOML issuer data into a TOMLIssuer struct,
+// along with its SEP-1 representation.
+func decodeTOMLIssuer(tomlData string) (issuer TOMLIssuer, err error) {
+	_, err = toml.Decode(tomlData, &issuer)
+	if err != nil {
+		return
 	}
 
-	if shouldValidateTOML {
-		if asset.Links.Toml.Href == "" {
-			return true
-		}
-		// [StellarX Ticker]: TOML files should be hosted on HTTPS
-		if !strings.HasPrefix(asset.Links.Toml.Href, "https://") {
-			return true
-		}
+	var doc stellartoml.Response
+	if _, err = toml.Decode(tomlData, &doc); err != nil {
+		return
 	}
-
-	return false
-}
-
-// decodeTOMLIssuer decodes retrieved TOML issuer data into a TOMLIssuer struct
-func decodeTOMLIssuer(tomlData string) (issuer TOMLIssuer, err error) {
-	_, err = toml.Decode(tomlData, &issuer)
+	issuer.SEP1 = &doc
 	return
 }
 
-// fetchTOMLData fetches the TOML data from the URL.
-func fetchTOMLData(tomlURL string) (data string, err error) {
+// fetchTOMLData fetches the TOML data from the URL, which must be at most
+// stellartoml.StellarTomlMaxSize bytes long. If etag or lastModified are set,
+// the request is conditional, and the response has no body if the file wasn't
+// modified.
+func fetchTOMLData(tomlURL, etag, lastModified string) (resp tomlResponse, err error) {
 	if tomlURL == "" {
 		err = errors.New("Asset does not have a TOML URL")
 		return
@@ -81,67 +60,45 @@ func fetchTOMLData(tomlURL string) (data string, err error) {
 	}
 
 	req.Header.Set("User-Agent", "Stellar Ticker v1.0")
-	resp, err := client.Do(req)
-	if err != nil {
-		return
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	httpResp, err := client.Do(req)
 	if err != nil {
 		return
 	}
-
-	data = string(body)
-	return
-}
-
-func domainsMatch(tomlURL *url.URL, orgURL *url.URL) bool {
-	tomlDomainParts := strings.Split(tomlURL.Host, ".")
-	orgDomainParts := strings.Split(orgURL.Host, ".")
-
-	if len(orgDomainParts) < len(tomlDomainParts) {
-		// Org can only be a subdomain if it has more (or equal)
-		// pieces than the TOML domain
-		return false
+	defer httpResp.Body.Close()
+
+	resp = tomlResponse{
+		StatusCode:   httpResp.StatusCode,
+		ETag:         httpResp.Header.Get("ETag"),
+		LastModified: httpResp.Header.Get("Last-Modified"),
+		CacheControl: httpResp.Header.Get("Cache-Control"),
+		Expires:      httpResp.Header.Get("Expires"),
+		Date:         httpResp.Header.Get("Date"),
 	}
-
-	lenDiff := len(orgDomainParts) - len(tomlDomainParts)
-	orgDomainParts = orgDomainParts[lenDiff:]
-	orgRootDomain := strings.Join(orgDomainParts, ".")
-	return tomlURL.Host == orgRootDomain
-}
-
-// isDomainVerified performs some checking to ensure we can trust the Asset's domain
-func isDomainVerified(orgURL string, tomlURL string, hasCurrency bool) bool {
-	if tomlURL == "" {
-		return false
-	}
-
-	parsedTomlURL, err := url.Parse(tomlURL)
-	if err != nil || parsedTomlURL.Scheme != "https" {
-		return false
+	if httpResp.StatusCode == http.StatusNotModified && (etag != "" || lastModified != "") {
+		return
 	}
-
-	if !hasCurrency {
-		return false
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		err = errors.Errorf("stellar.toml request failed with status code %d", httpResp.StatusCode)
+		return
 	}
 
-	if orgURL == "" {
-		// if no orgURL is provided, we'll simply use tomlURL, so no need
-		// for domain verification
-		return true
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, stellartoml.StellarTomlMaxSize+1))
+	if err != nil {
+		return
 	}
-
-	parsedOrgURL, err := url.Parse(orgURL)
-	if err != nil || parsedOrgURL.Scheme != "https" {
-		return false
+	if len(body) > stellartoml.StellarTomlMaxSize {
+		err = errors.Errorf("stellar.toml response exceeds %d bytes limit", stellartoml.StellarTomlMaxSize)
+		return
 	}
 
-	if !domainsMatch(parsedTomlURL, parsedOrgURL) {
-		return false
-	}
-	return true
+	resp.Body = string(body)
+	return
 }
 
 // makeTomlAsset aggregates Horizon Data with TOML Data
@@ -167,11 +124,13 @@ func makeFinalAsset(
 	}
 
 	t.IssuerDetails.TOMLURL = asset.Links.Toml.Href
+	t.Supply = makeSupplyBreakdown(asset)
+	t.ContractID = asset.ContractID
+	t.NumContracts = t.Supply.NumContracts
+	t.ContractsAmount, _ = strconv.ParseFloat(t.Supply.Contracts, 64)
 
-	hasCurrency := false
 	for _, currency := range t.IssuerDetails.Currencies {
 		if currency.Code == asset.Code && currency.Issuer == asset.Issuer {
-			hasCurrency = true
 			t.AnchorAsset = currency.AnchorAsset
 			t.AnchorAssetType = currency.AnchorAssetType
 			t.DisplayDecimals = currency.DisplayDecimals
@@ -189,16 +148,6 @@ func makeFinalAsset(
 			break
 		}
 	}
-	t.AssetControlledByDomain = isDomainVerified(
-		t.IssuerDetails.Documentation.OrgURL,
-		asset.Links.Toml.Href,
-		hasCurrency,
-	)
-
-	if !hasCurrency {
-		t.AssetControlledByDomain = false
-	}
-
 	now := time.Now()
 	if len(errors) > 0 {
 		t.Error = fmt.Sprintf("%v", errors)
@@ -213,8 +162,37 @@ func makeFinalAsset(
 	return
 }
 
-// processAsset merges data from an AssetStat with data retrieved from its corresponding TOML file
-func processAsset(logger *hlog.Entry, asset hProtocol.AssetStat, tomlCache *TOMLCache, shouldValidateTOML bool) (FinalAsset, error) {
+// makeSupplyBreakdown splits the supply of an asset from its Horizon stats,
+// keeping the amounts as exact decimal strings. Amounts missing from the stats
+// (e.g. on older Horizon versions) are zero.
+func makeSupplyBreakdown(asset hProtocol.AssetStat) SupplyBreakdown {
+	exact := func(s string) string {
+		a, _ := amount.ParseInt64(s)
+		return amount.StringFromInt64(a)
+	}
+
+	return SupplyBreakdown{
+		NumAccounts:                        asset.NumAccounts,
+		NumAuthorizedToMaintainLiabilities: asset.Accounts.AuthorizedToMaintainLiabilities,
+		NumUnauthorized:                    asset.Accounts.Unauthorized,
+		NumClaimableBalances:               asset.NumClaimableBalances,
+		NumLiquidityPools:                  asset.NumLiquidityPools,
+		NumContracts:                       asset.NumContracts,
+		Authorized:                         exact(asset.Amount),
+		AuthorizedToMaintainLiabilities:    exact(asset.Balances.AuthorizedToMaintainLiabilities),
+		Unauthorized:                       exact(asset.Balances.Unauthorized),
+		ClaimableBalances:                  exact(asset.ClaimableBalancesAmount),
+		LiquidityPools:                     exact(asset.LiquidityPoolsAmount),
+		Contracts:                          exact(asset.ContractsAmount),
+		ArchivedContracts:                  exact(asset.ArchivedContractsAmount),
+	}
+}
+
+// processAsset merges data from an AssetStat with data retrieved from its corresponding TOML file.
+// When TOML files are validated, the file is verified against SEP-1 and the
+// home domain of the issuing account (see verifySEP1), and the asset is
+// controlled by its domain if no error is found.
+func processAsset(ctx context.Context, logger *hlog.Entry, asset hProtocol.AssetStat, homeDomain string, tomlCache *TOMLCache, shouldValidateTOML bool) (FinalAsset, error) {
 	var errors []error
 	var issuer TOMLIssuer
 
@@ -223,48 +201,136 @@ func processAsset(logger *hlog.Entry, asset hProtocol.AssetStat, tomlCache *TOML
 		logger = logger.WithField("asset_toml_url", tomlURL)
 		logger.Info("Collecting TOML for asset")
 
-		var ok bool
-		issuer, ok = tomlCache.Get(tomlURL)
-		if ok {
-			logger.Debug("Using cached TOML for asset")
-		} else {
-			logger.Debug("Fetching TOML for asset")
-			tomlData, err := fetchTOMLData(tomlURL)
-			if err != nil {
-				errors = append(errors, err)
-			}
+		issuer, errors = tomlCache.Get(ctx, logger, tomlURL)
+	}
 
-			issuer, err = decodeTOMLIssuer(tomlData)
-			if err != nil {
-				errors = append(errors, err)
-			}
+	finalAsset, err := makeFinalAsset(asset, issuer, errors)
+	if err != nil || !shouldValidateTOML {
+		return finalAsset, err
+	}
+
+	finalAsset.TOMLFindings = verifySEP1(asset, homeDomain, issuer, errors)
+	finalAsset.AssetControlledByDomain = !hasFindingErrors(finalAsset.TOMLFindings)
+	return finalAsset, nil
+}
+
+// homeDomainCache caches the home domains of the issuing accounts during an
+// asset refresh, along with the lookups that failed.
+type homeDomainCache struct {
+	mu      sync.Mutex
+	domains map[string]string
+	errs    map[string]error
+}
+
+// homeDomain returns the home_domain of the issuer account, which is looked
+// up once per refresh: when the lookup fails after retrying, the error is
+// returned for the other assets of the issuer as well.
+func (c *ScraperConfig) homeDomain(cache *homeDomainCache, issuer string) (string, error) {
+	cache.mu.Lock()
+	domain, ok := cache.domains[issuer]
+	err, failed := cache.errs[issuer]
+	cache.mu.Unlock()
+	if ok {
+		return domain, nil
+	} else if failed {
+		return "", err
+	}
 
-			tomlCache.Set(tomlURL, issuer)
+	err = c.retry(func() (err error) {
+		domain, err = c.Client.HomeDomainForAccount(issuer)
+		return
+	})
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if err != nil {
+		if cache.errs == nil {
+			cache.errs = make(map[string]error)
+		}
+		cache.errs[issuer] = err
+		return "", err
+	}
+	if cache.domains == nil {
+		cache.domains = make(map[string]string)
+	}
+	cache.domains[issuer] = domain
+	return domain, nil
+}
+
+// skippedEvaluationError is returned by evaluateAsset when the data an asset
+// is evaluated on couldn't be retrieved from Horizon, even after retrying. The
+// asset isn't evaluated then, so that it keeps its previous evaluation rather
+// than being delisted because of a Horizon outage.
+type skippedEvaluationError struct {
+	err error
+}
+
+func (e skippedEvaluationError) Error() string {
+	return "could not evaluate asset: " + e.err.Error()
+}
+
+// evaluateAsset applies policy to asset. Assets passing it are merged with
+// the data of their TOML file (fetched and verified when shouldValidateTOML is
+// set), and evaluated once more now that their domain is known. If the home
+// domain of the issuer can't be retrieved, a skippedEvaluationError is
+// returned.
+func (c *ScraperConfig) evaluateAsset(
+	logger *hlog.Entry,
+	policy AssetPolicy,
+	asset hProtocol.AssetStat,
+	tomlCache *TOMLCache,
+	homeDomains *homeDomainCache,
+	shouldValidateTOML bool,
+) (finalAsset FinalAsset, policyAsset PolicyAsset, result PolicyResult, err error) {
+	policyAsset = PolicyAsset{Stat: asset}
+	result = policy.Evaluate(policyAsset)
+	if !result.Listed {
+		return
+	}
+
+	var homeDomain string
+	if shouldValidateTOML {
+		homeDomain, err = c.homeDomain(homeDomains, asset.Asset.Issuer)
+		if err != nil {
+			err = skippedEvaluationError{errors.Wrap(err, "could not retrieve the home domain of the issuer")}
+			return
 		}
 	}
 
-	return makeFinalAsset(asset, issuer, errors)
+	finalAsset, err = processAsset(c.context(), logger, asset, homeDomain, tomlCache, shouldValidateTOML)
+	if err != nil {
+		result = fail("processing", "%v", err)
+		return
+	}
+
+	if shouldValidateTOML {
+		policyAsset = PolicyAsset{
+			Stat:           asset,
+			TOMLChecked:    true,
+			DomainVerified: finalAsset.AssetControlledByDomain,
+			HomeDomain:     homeDomain,
+			TOMLFindings:   finalAsset.TOMLFindings,
+		}
+		result = policy.Evaluate(policyAsset)
+	}
+	return
 }
 
-// parallelProcessAssets filters the assets that don't match the shouldDiscardAsset criteria.
-// non-trash assets are sent to the assetQueue.
-// The TOML validation is performed in parallel to improve performance.
+// parallelProcessAssets filters the assets that aren't listed by the asset
+// policy. Listed assets are sent to the assetQueue, and every decision is
+// reported to OnEvaluated. The TOML validation is performed in parallel to
+// improve performance.
 func (c *ScraperConfig) parallelProcessAssets(assets []hProtocol.AssetStat, parallelism int, assetQueue chan<- FinalAsset) (numNonTrash int, numTrash int) {
-	shouldValidateTOML := c.Client != horizonclient.DefaultTestNetClient // TOMLs shouldn't be validated on TestNet
+	policy := c.assetPolicy()
+	shouldValidateTOML := c.shouldValidateTOML()
+	homeDomains := &homeDomainCache{}
+	tomlCache := c.tomlCache()
 	var mutex = &sync.Mutex{}
 	var wg sync.WaitGroup
 	numAssets := len(assets)
 	chunkSize := int(math.Ceil(float64(numAssets) / float64(parallelism)))
 	wg.Add(parallelism)
 
-	// Sort assets by their toml URL so that assets with the same toml URL are
-	// grouped together. This is so that we can load each toml URL once, and
-	// cache the result for the subsequent assets without needing to store more
-	// than one toml in memory at a time.
-	sort.Slice(assets, func(i, j int) bool {
-		return assets[i].Links.Toml.Href < assets[j].Links.Toml.Href
-	})
-
 	// The assets are divided into chunks of chunkSize, and each goroutine is responsible
 	// for cleaning up one chunk
 	for i := 0; i < parallelism; i++ {
@@ -276,32 +342,36 @@ func (c *ScraperConfig) parallelProcessAssets(assets []hProtocol.AssetStat, para
 				end = numAssets
 			}
 
-			// Each routine running concurrently has a separate cache of TOMLs
-			// loaded. A single shared cache would be better, but this is a
-			// tradeoff for simplicity because a shared map mutated with HTTP
-			// lookups would have a significant amount of contention.
-			tomlCache := &TOMLCache{}
-
 			for j := start; j < end; j++ {
 				logger := c.Logger.
 					WithField("asset_code", assets[j].Asset.Code).
 					WithField("asset_issuer", assets[j].Asset.Issuer)
-				if !shouldDiscardAsset(assets[j], shouldValidateTOML) {
-					c.Logger.Debug("Processing asset")
-					finalAsset, err := processAsset(logger, assets[j], tomlCache, shouldValidateTOML)
-					if err != nil {
-						mutex.Lock()
-						numTrash++
-						mutex.Unlock()
-						continue
+				finalAsset, policyAsset, result, err := c.evaluateAsset(logger, policy, assets[j], tomlCache, homeDomains, shouldValidateTOML)
+				if _, skipped := err.(skippedEvaluationError); skipped {
+					logger.Warn("Skipping asset, keeping its previous evaluation: ", err)
+				} else if c.OnEvaluated != nil {
+					c.OnEvaluated(policyAsset, result)
+				}
+
+				switch {
+				case err != nil:
+					metrics.AssetValidations.WithLabelValues("error").Inc()
+				case !result.Listed:
+					logger.WithField("rule", result.Rule).Debugf("Discarding asset: %s", result.Reason)
+					metrics.AssetValidations.WithLabelValues("discarded").Inc()
+				default:
+					if finalAsset.IsValid {
+						metrics.AssetValidations.WithLabelValues("valid").Inc()
+					} else {
+						metrics.AssetValidations.WithLabelValues("invalid").Inc()
 					}
 					assetQueue <- finalAsset
-				} else {
-					c.Logger.Debug("Discarding asset")
-					mutex.Lock()
-					numTrash++
-					mutex.Unlock()
+					continue
 				}
+
+				mutex.Lock()
+				numTrash++
+				mutex.Unlock()
 			}
 		}(i * chunkSize)
 	}
@@ -313,9 +383,44 @@ func (c *ScraperConfig) parallelProcessAssets(assets []hProtocol.AssetStat, para
 	return
 }
 
+// explainAsset fetches the stats of an asset and evaluates it against the
+// asset policy.
+func (c *ScraperConfig) explainAsset(code, issuer string) (asset PolicyAsset, result PolicyResult, err error) {
+	var assetsPage hProtocol.AssetsPage
+	err = c.retry(func() error {
+		assetsPage, err = c.Client.Assets(horizonclient.AssetRequest{
+			ForAssetCode:   code,
+			ForAssetIssuer: issuer,
+		})
+		return err
+	})
+	if err != nil {
+		return
+	}
+	if len(assetsPage.Embedded.Records) == 0 {
+		err = errors.Errorf("asset %s:%s not found", code, issuer)
+		return
+	}
+
+	logger := c.Logger.WithField("asset_code", code).WithField("asset_issuer", issuer)
+	// processing errors are reported by the result
+	_, asset, result, err = c.evaluateAsset(
+		logger,
+		c.assetPolicy(),
+		assetsPage.Embedded.Records[0],
+		c.tomlCache(),
+		&homeDomainCache{},
+		c.shouldValidateTOML(),
+	)
+	if _, skipped := err.(skippedEvaluationError); !skipped {
+		err = nil
+	}
+	return
+}
+
 // retrieveAssets retrieves existing assets from the Horizon API. If limit=0, will fetch all assets.
 func (c *ScraperConfig) retrieveAssets(limit int) (assets []hProtocol.AssetStat, err error) {
-	r := horizonclient.AssetRequest{Limit: 200}
+	r := horizonclient.AssetRequest{Limit: c.pageSize()}
 
 	assetsPage, err := c.Client.Assets(r)
 	if err != nil {
@@ -325,7 +430,7 @@ func (c *ScraperConfig) retrieveAssets(limit int) (assets []hProtocol.AssetStat,
 	c.Logger.Info("Fetching assets from Horizon")
 
 	for assetsPage.Links.Next.Href != assetsPage.Links.Self.Href {
-		err = utils.Retry(5, 5*time.Second, c.Logger, func() error {
+		err = c.retry(func() error {
 			assetsPage, err = c.Client.Assets(r)
 			if err != nil {
 				c.Logger.Info("Horizon rate limit reached!")
@@ -353,7 +458,7 @@ func (c *ScraperConfig) retrieveAssets(limit int) (assets []hProtocol.AssetStat,
 		}
 		c.Logger.Debug("Cursor currently at:", n)
 
-		r = horizonclient.AssetRequest{Limit: 200, Cursor: n}
+		r = horizonclient.AssetRequest{Limit: c.pageSize(), Cursor: n}
 	}
 
 	c.Logger.Infof("Fetched: %d assets\n", len(assets))
@@ -362,7 +467,7 @@ func (c *ScraperConfig) retrieveAssets(limit int) (assets []hProtocol.AssetStat,
 
 // retrieveFilteredAssets retrieves assets filtered by issuer from the Horizon API.
 func (c *ScraperConfig) retrieveFilteredAssets(limit int, issuer ...string) (assets []hProtocol.AssetStat, err error) {
-	r := horizonclient.AssetRequest{Limit: 200, ForAssetIssuer: issuer[0]}
+	r := horizonclient.AssetRequest{Limit: c.pageSize(), ForAssetIssuer: issuer[0]}
 
 	assetsPage, err := c.Client.Assets(r)
 	if err != nil {
@@ -372,7 +477,7 @@ func (c *ScraperConfig) retrieveFilteredAssets(limit int, issuer ...string) (ass
 	c.Logger.Info("Fetching assets from Horizon")
 
 	for assetsPage.Links.Next.Href != assetsPage.Links.Self.Href {
-		err = utils.Retry(5, 5*time.Second, c.Logger, func() error {
+		err = c.retry(func() error {
 			assetsPage, err = c.Client.Assets(r)
 			if err != nil {
 				c.Logger.Info("Horizon rate limit reached!")
@@ -400,7 +505,7 @@ func (c *ScraperConfig) retrieveFilteredAssets(limit int, issuer ...string) (ass
 		}
 		c.Logger.Debug("Cursor currently at:", n)
 
-		r = horizonclient.AssetRequest{Limit: 200, Cursor: n, ForAssetIssuer: issuer[0]}
+		r = horizonclient.AssetRequest{Limit: c.pageSize(), Cursor: n, ForAssetIssuer: issuer[0]}
 	}
 
 	c.Logger.Infof("Fetched: %d assets\n", len(assets))
@@ -1,14 +1,17 @@
 package scraper
 
 import (
+	"context"
 	"fmt"
 	"github.com/stellar/go/clients/horizonclient"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 	hlog "github.com/stellar/go/support/log"
@@ -34,35 +37,39 @@ func TestAssetScraper_EURC(t *testing.T) {
 	//t.Log(nonTrash, trash)
 }
 
-func TestShouldDiscardAsset(t *testing.T) {
+func TestNewAssetPolicy(t *testing.T) {
+	rules := DefaultSettings().Discard
+	shouldDiscardAsset := func(asset hProtocol.AssetStat, rules DiscardRules, shouldValidateTOML bool) bool {
+		return !NewAssetPolicy(rules, shouldValidateTOML).Evaluate(PolicyAsset{Stat: asset}).Listed
+	}
 	testAsset := hProtocol.AssetStat{
 		Amount: "",
 	}
 
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), true)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
 	testAsset = hProtocol.AssetStat{
 		Amount: "0.0",
 	}
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), true)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
 	testAsset = hProtocol.AssetStat{
 		Amount: "0",
 	}
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), true)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
 	testAsset = hProtocol.AssetStat{
 		Amount:      "123901.0129310",
 		NumAccounts: 8,
 	}
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), true)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
 	testAsset = hProtocol.AssetStat{
 		Amount:      "123901.0129310",
 		NumAccounts: 12,
 	}
 	testAsset.Code = "REMOVE"
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), true)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
 	testAsset = hProtocol.AssetStat{
 		Amount:      "123901.0129310",
@@ -70,7 +77,7 @@ func TestShouldDiscardAsset(t *testing.T) {
 	}
 	testAsset.Code = "SOMETHINGVALID"
 	testAsset.Links.Toml.Href = ""
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), false)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), false)
 
 	testAsset = hProtocol.AssetStat{
 		Amount:      "123901.0129310",
@@ -78,7 +85,7 @@ func TestShouldDiscardAsset(t *testing.T) {
 	}
 	testAsset.Code = "SOMETHINGVALID"
 	testAsset.Links.Toml.Href = "http://www.stellar.org/.well-known/stellar.toml"
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), true)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
 	testAsset = hProtocol.AssetStat{
 		Amount:      "123901.0129310",
@@ -86,7 +93,7 @@ func TestShouldDiscardAsset(t *testing.T) {
 	}
 	testAsset.Code = "SOMETHINGVALID"
 	testAsset.Links.Toml.Href = ""
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), true)
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
 	testAsset = hProtocol.AssetStat{
 		Amount:      "123901.0129310",
@@ -94,71 +101,31 @@ func TestShouldDiscardAsset(t *testing.T) {
 	}
 	testAsset.Code = "SOMETHINGVALID"
 	testAsset.Links.Toml.Href = "https://www.stellar.org/.well-known/stellar.toml"
-	assert.Equal(t, shouldDiscardAsset(testAsset, true), false)
-}
-
-func TestDomainsMatch(t *testing.T) {
-	tomlURL, _ := url.Parse("https://stellar.org/stellar.toml")
-	orgURL, _ := url.Parse("https://stellar.org/")
-	assert.True(t, domainsMatch(tomlURL, orgURL))
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), false)
 
-	tomlURL, _ = url.Parse("https://assets.stellar.org/stellar.toml")
-	orgURL, _ = url.Parse("https://stellar.org/")
-	assert.False(t, domainsMatch(tomlURL, orgURL))
-
-	tomlURL, _ = url.Parse("https://stellar.org/stellar.toml")
-	orgURL, _ = url.Parse("https://home.stellar.org/")
-	assert.True(t, domainsMatch(tomlURL, orgURL))
+	// the thresholds are configurable:
+	testAsset = hProtocol.AssetStat{
+		Amount:      "123901.0129310",
+		NumAccounts: 40,
+	}
+	testAsset.Asset.Issuer = "GCSAZVWXZKWS4XS223M5F54H2B6XPIIXZZGP7KEAIU6YSL5HDRGCI3DG"
+	testAsset.Links.Toml.Href = "http://www.stellar.org/.well-known/stellar.toml"
+	rules.RequireHTTPSTOML = false
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), false)
 
-	tomlURL, _ = url.Parse("https://stellar.org/stellar.toml")
-	orgURL, _ = url.Parse("https://home.stellar.com/")
-	assert.False(t, domainsMatch(tomlURL, orgURL))
+	rules.MinAccounts = 50
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 
-	tomlURL, _ = url.Parse("https://stellar.org/stellar.toml")
-	orgURL, _ = url.Parse("https://stellar.com/")
-	assert.False(t, domainsMatch(tomlURL, orgURL))
-}
+	rules.TrustedIssuers = map[string]bool{testAsset.Asset.Issuer: true}
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), false)
 
-func TestIsDomainVerified(t *testing.T) {
-	tomlURL := "https://stellar.org/stellar.toml"
-	orgURL := "https://stellar.org/"
-	hasCurrency := true
-	assert.True(t, isDomainVerified(orgURL, tomlURL, hasCurrency))
-
-	tomlURL = "https://stellar.org/stellar.toml"
-	orgURL = ""
-	hasCurrency = true
-	assert.True(t, isDomainVerified(orgURL, tomlURL, hasCurrency))
-
-	tomlURL = ""
-	orgURL = ""
-	hasCurrency = true
-	assert.False(t, isDomainVerified(orgURL, tomlURL, hasCurrency))
-
-	tomlURL = "https://stellar.org/stellar.toml"
-	orgURL = "https://stellar.org/"
-	hasCurrency = false
-	assert.False(t, isDomainVerified(orgURL, tomlURL, hasCurrency))
-
-	tomlURL = "http://stellar.org/stellar.toml"
-	orgURL = "https://stellar.org/"
-	hasCurrency = true
-	assert.False(t, isDomainVerified(orgURL, tomlURL, hasCurrency))
-
-	tomlURL = "https://stellar.org/stellar.toml"
-	orgURL = "http://stellar.org/"
-	hasCurrency = true
-	assert.False(t, isDomainVerified(orgURL, tomlURL, hasCurrency))
-
-	tomlURL = "https://stellar.org/stellar.toml"
-	orgURL = "https://stellar.com/"
-	hasCurrency = true
-	assert.False(t, isDomainVerified(orgURL, tomlURL, hasCurrency))
+	testAsset.Amount = "0"
+	assert.Equal(t, shouldDiscardAsset(testAsset, rules, true), true)
 }
 
 func TestIgnoreInvalidTOMLUrls(t *testing.T) {
 	invalidURL := "https:// there is something wrong here.com/stellar.toml"
-	_, err := fetchTOMLData(invalidURL)
+	_, err := fetchTOMLData(invalidURL, "", "")
 
 	urlErr, ok := errors.Cause(err).(*url.Error)
 	if !ok {
@@ -180,14 +147,16 @@ func TestProcessAsset_notCached(t *testing.T) {
 	}
 	asset.Code = "SOMETHINGVALID"
 	asset.Links.Toml.Href = server.URL
-	tomlCache := &TOMLCache{}
-	finalAsset, err := processAsset(logger, asset, tomlCache, true)
+	tomlCache := NewTOMLCache(time.Hour, nil)
+	finalAsset, err := processAsset(context.Background(), logger, asset, "", tomlCache, true)
 	require.NoError(t, err)
 	assert.NotZero(t, finalAsset)
 	assert.Equal(t, "not cached signing key", finalAsset.IssuerDetails.SigningKey)
-	cachedTOML, ok := tomlCache.Get(server.URL)
-	assert.True(t, ok)
-	assert.Equal(t, TOMLIssuer{SigningKey: "not cached signing key"}, cachedTOML)
+	cachedTOML, errs := tomlCache.Get(context.Background(), logger, server.URL)
+	assert.Empty(t, errs)
+	assert.Equal(t, "not cached signing key", cachedTOML.SigningKey)
+	require.NotNil(t, cachedTOML.SEP1)
+	assert.Equal(t, "not cached signing key", cachedTOML.SEP1.SigningKey)
 }
 
 func TestProcessAsset_cached(t *testing.T) {
@@ -198,10 +167,97 @@ func TestProcessAsset_cached(t *testing.T) {
 	}
 	asset.Code = "SOMETHINGVALID"
 	asset.Links.Toml.Href = "url"
-	tomlCache := &TOMLCache{}
-	tomlCache.Set("url", TOMLIssuer{SigningKey: "signing key"})
-	finalAsset, err := processAsset(logger, asset, tomlCache, true)
+	tomlCache := NewTOMLCache(time.Hour, &memTOMLStore{entries: map[string]tickerdb.TOMLCacheEntry{
+		"url": {URL: "url", Body: `SIGNING_KEY="signing key"`, ExpiresAt: time.Now().Add(time.Hour)},
+	}})
+	finalAsset, err := processAsset(context.Background(), logger, asset, "", tomlCache, true)
 	require.NoError(t, err)
 	assert.NotZero(t, finalAsset)
 	assert.Equal(t, "signing key", finalAsset.IssuerDetails.SigningKey)
 }
+
+func TestParallelProcessAssets_horizonError(t *testing.T) {
+	numRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	settings := DefaultSettings()
+	settings.Retries = 2
+	settings.RetryDelay = time.Millisecond
+	var evaluated []PolicyAsset
+	sc := ScraperConfig{
+		Client:      &horizonclient.Client{HorizonURL: server.URL},
+		Logger:      hlog.New(),
+		Settings:    &settings,
+		OnEvaluated: func(asset PolicyAsset, result PolicyResult) { evaluated = append(evaluated, asset) },
+	}
+
+	asset := hProtocol.AssetStat{
+		Amount:      "123901.0129310",
+		NumAccounts: 100,
+	}
+	asset.Code = "SOMETHINGVALID"
+	asset.Issuer = "GDHU6WRG4IEQXM5NZ4BMPKOXHW76MZM4Y2IEMFDVXBSDP6SJY4ITNPP2"
+	asset.Links.Toml.Href = server.URL
+
+	// Assets whose issuer can't be looked up are neither evaluated nor listed,
+	// and the issuer is only looked up once:
+	assetQueue := make(chan FinalAsset, 2)
+	_, numTrash := sc.parallelProcessAssets([]hProtocol.AssetStat{asset, asset}, 1, assetQueue)
+	assert.Equal(t, 2, numTrash)
+	assert.Empty(t, evaluated)
+	_, ok := <-assetQueue
+	assert.False(t, ok)
+	assert.Equal(t, 2, numRequests)
+}
+
+func TestMakeSupplyBreakdown(t *testing.T) {
+	asset := hProtocol.AssetStat{
+		NumAccounts:             10,
+		NumClaimableBalances:    2,
+		NumLiquidityPools:       1,
+		NumContracts:            3,
+		Amount:                  "1000.0000000",
+		ClaimableBalancesAmount: "50.5000000",
+		LiquidityPoolsAmount:    "200.0000000",
+		ContractsAmount:         "25.0000000",
+	}
+	asset.Accounts.Unauthorized = 4
+	asset.Balances.Authorized = "1000.0000000"
+	asset.Balances.Unauthorized = "12.0000000"
+
+	assert.Equal(t, SupplyBreakdown{
+		NumAccounts:                     10,
+		NumUnauthorized:                 4,
+		NumClaimableBalances:            2,
+		NumLiquidityPools:               1,
+		NumContracts:                    3,
+		Authorized:                      "1000.0000000",
+		AuthorizedToMaintainLiabilities: "0.0000000",
+		Unauthorized:                    "12.0000000",
+		ClaimableBalances:               "50.5000000",
+		LiquidityPools:                  "200.0000000",
+		Contracts:                       "25.0000000",
+		ArchivedContracts:               "0.0000000",
+	}, makeSupplyBreakdown(asset))
+}
+
+func TestMakeFinalAssetContract(t *testing.T) {
+	asset := hProtocol.AssetStat{
+		Amount:          "1000.0000000",
+		ContractID:      "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE",
+		NumContracts:    3,
+		ContractsAmount: "25.0000000",
+	}
+	asset.Code = "USDC"
+	asset.Issuer = "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN"
+
+	finalAsset, err := makeFinalAsset(asset, TOMLIssuer{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE", finalAsset.ContractID)
+	assert.Equal(t, int32(3), finalAsset.NumContracts)
+	assert.Equal(t, 25.0, finalAsset.ContractsAmount)
+}
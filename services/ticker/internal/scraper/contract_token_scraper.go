@@ -0,0 +1,393 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/contractevents"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ContractTokenEvent is a transfer, mint, burn or clawback of a Soroban token
+// which isn't a Stellar Asset Contract (see SEP-41), in raw units of the
+// token. From is empty for mints, and To for burns and clawbacks.
+type ContractTokenEvent struct {
+	ContractID string
+	Type       contractevents.EventType
+	From       string
+	To         string
+	Amount     *big.Int
+}
+
+// ContractTokenChanges are the token events emitted within a ledger, along
+// with the token metadata written in it (keyed by contract ID).
+type ContractTokenChanges struct {
+	Ledger   uint32
+	Events   []ContractTokenEvent
+	Metadata map[string]tickerdb.ContractTokenMetadata
+}
+
+// ExtractContractTokenChanges reads the transactions of the ledger with the
+// given sequence from backend and extracts the events and metadata of the
+// Soroban tokens which aren't Stellar Asset Contracts (the supply of the
+// classic assets, including the one held by contracts, is retrieved from
+// Horizon instead).
+func ExtractContractTokenChanges(
+	ctx context.Context,
+	backend ledgerbackend.LedgerBackend,
+	networkPassphrase string,
+	sequence uint32,
+) (changes ContractTokenChanges, err error) {
+	changes = ContractTokenChanges{
+		Ledger:   sequence,
+		Metadata: make(map[string]tickerdb.ContractTokenMetadata),
+	}
+
+	reader, err := ingest.NewLedgerTransactionReader(ctx, backend, networkPassphrase, sequence)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	for {
+		var tx ingest.LedgerTransaction
+		tx, err = reader.Read()
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return
+		}
+
+		err = extractTransactionTokenChanges(tx, networkPassphrase, &changes)
+		if err != nil {
+			err = errors.Wrapf(err, "could not extract token events from ledger %d", sequence)
+			return
+		}
+	}
+
+	return
+}
+
+// extractTransactionTokenChanges adds the token events and metadata of a
+// successful transaction to changes.
+func extractTransactionTokenChanges(
+	tx ingest.LedgerTransaction,
+	networkPassphrase string,
+	changes *ContractTokenChanges,
+) error {
+	if !tx.Result.Successful() {
+		return nil
+	}
+
+	events, err := tx.GetDiagnosticEvents()
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if !event.InSuccessfulContractCall {
+			continue
+		}
+		if tokenEvent, ok := parseContractTokenEvent(event.Event, networkPassphrase); ok {
+			changes.Events = append(changes.Events, tokenEvent)
+		}
+	}
+
+	entryChanges, err := tx.GetChanges()
+	if err != nil {
+		return err
+	}
+	for _, change := range entryChanges {
+		if change.Type != xdr.LedgerEntryTypeContractData || change.Post == nil {
+			continue
+		}
+		if contractID, metadata, ok := parseContractTokenMetadata(*change.Post); ok {
+			changes.Metadata[contractID] = metadata
+		}
+	}
+	return nil
+}
+
+// parseContractTokenEvent parses a SEP-41 token event, ignoring the events of
+// Stellar Asset Contracts and the ones which aren't balance changes:
+//
+//	transfer: [transfer, from, to], mint: [mint, admin, to] or [mint, to],
+//	burn: [burn, from], clawback: [clawback, admin, from] or [clawback, from]
+//
+// A trailing string topic (e.g. the asset of SAC-like events) is ignored, and
+// the amount is either the data of the event or its "amount" entry.
+func parseContractTokenEvent(event xdr.ContractEvent, networkPassphrase string) (ContractTokenEvent, bool) {
+	if event.Type != xdr.ContractEventTypeContract || event.ContractId == nil || event.Body.V != 0 {
+		return ContractTokenEvent{}, false
+	}
+
+	_, err := contractevents.NewStellarAssetContractEvent(&event, networkPassphrase)
+	if err == nil || (errors.Cause(err) != contractevents.ErrNotStellarAssetContract &&
+		err != contractevents.ErrEventIntegrity) {
+		// events of actual Stellar Asset Contracts
+		return ContractTokenEvent{}, false
+	}
+
+	topics := event.Body.V0.Topics
+	if n := len(topics); n > 0 && topics[n-1].Type == xdr.ScValTypeScvString {
+		topics = topics[:n-1]
+	}
+	if len(topics) < 2 {
+		return ContractTokenEvent{}, false
+	}
+
+	fn, ok := topics[0].GetSym()
+	if !ok {
+		return ContractTokenEvent{}, false
+	}
+	eventType, ok := contractevents.STELLAR_ASSET_CONTRACT_TOPICS[fn]
+	if !ok {
+		return ContractTokenEvent{}, false
+	}
+
+	amount, ok := parseContractTokenAmount(event.Body.V0.Data)
+	if !ok {
+		return ContractTokenEvent{}, false
+	}
+
+	contractID, err := strkey.Encode(strkey.VersionByteContract, event.ContractId[:])
+	if err != nil {
+		return ContractTokenEvent{}, false
+	}
+	tokenEvent := ContractTokenEvent{ContractID: contractID, Type: eventType, Amount: amount}
+
+	// the address whose balance changes is the last one of the topics
+	var address string
+	switch {
+	case eventType == contractevents.EventTypeTransfer && len(topics) == 3:
+		tokenEvent.From, ok = scAddressString(topics[1])
+		if !ok {
+			return ContractTokenEvent{}, false
+		}
+		address = "to"
+	case eventType == contractevents.EventTypeMint && (len(topics) == 2 || len(topics) == 3):
+		address = "to"
+	case (eventType == contractevents.EventTypeBurn && len(topics) == 2) ||
+		(eventType == contractevents.EventTypeClawback && (len(topics) == 2 || len(topics) == 3)):
+		address = "from"
+	default:
+		return ContractTokenEvent{}, false
+	}
+
+	last, ok := scAddressString(topics[len(topics)-1])
+	if !ok {
+		return ContractTokenEvent{}, false
+	}
+	if address == "to" {
+		tokenEvent.To = last
+	} else {
+		tokenEvent.From = last
+	}
+	return tokenEvent, true
+}
+
+// parseContractTokenAmount returns the amount of a token event, which is
+// either its data or the "amount" entry of its data, as long as it isn't
+// negative.
+func parseContractTokenAmount(data xdr.ScVal) (*big.Int, bool) {
+	if m, ok := data.GetMap(); ok && m != nil {
+		for _, entry := range *m {
+			if sym, ok := entry.Key.GetSym(); ok && sym == "amount" {
+				data = entry.Val
+				break
+			}
+		}
+	}
+
+	parts, ok := data.GetI128()
+	if !ok {
+		return nil, false
+	}
+	amount := int128ToBigInt(parts)
+	if amount.Sign() < 0 {
+		return nil, false
+	}
+	return amount, true
+}
+
+// int128ToBigInt converts a 128-bit signed integer to a big.Int.
+func int128ToBigInt(parts xdr.Int128Parts) *big.Int {
+	hi := new(big.Int).Lsh(big.NewInt(int64(parts.Hi)), 64)
+	return hi.Add(hi, new(big.Int).SetUint64(uint64(parts.Lo)))
+}
+
+// scAddressString returns the strkey (G... or C...) of an address value.
+func scAddressString(v xdr.ScVal) (string, bool) {
+	address, ok := v.GetAddress()
+	if !ok {
+		return "", false
+	}
+	s, err := address.String()
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// parseContractTokenMetadata parses the name, symbol and number of decimals
+// that SEP-41 tokens built with the Soroban token SDK keep in the METADATA
+// entry of their instance storage. Stellar Asset Contracts are ignored.
+func parseContractTokenMetadata(entry xdr.LedgerEntry) (string, tickerdb.ContractTokenMetadata, bool) {
+	var metadata tickerdb.ContractTokenMetadata
+
+	data, ok := entry.Data.GetContractData()
+	if !ok || data.Key.Type != xdr.ScValTypeScvLedgerKeyContractInstance {
+		return "", metadata, false
+	}
+	instance, ok := data.Val.GetInstance()
+	if !ok || instance.Storage == nil ||
+		instance.Executable.Type == xdr.ContractExecutableTypeContractExecutableStellarAsset {
+		return "", metadata, false
+	}
+
+	var found bool
+	for _, item := range *instance.Storage {
+		if sym, ok := item.Key.GetSym(); !ok || sym != "METADATA" {
+			continue
+		}
+		fields, ok := item.Val.GetMap()
+		if !ok || fields == nil {
+			return "", metadata, false
+		}
+		for _, field := range *fields {
+			key, _ := field.Key.GetSym()
+			switch key {
+			case "decimal":
+				if decimals, ok := field.Val.GetU32(); ok {
+					metadata.Decimals = int32(decimals)
+					found = true
+				}
+			case "name":
+				if name, ok := field.Val.GetStr(); ok {
+					metadata.Name = string(name)
+				}
+			case "symbol":
+				if symbol, ok := field.Val.GetStr(); ok {
+					metadata.Symbol = string(symbol)
+				}
+			}
+		}
+	}
+	if !found {
+		return "", metadata, false
+	}
+
+	contractID, err := data.Contract.String()
+	if err != nil {
+		return "", metadata, false
+	}
+	return contractID, metadata, true
+}
+
+// ContractTokenUpdates aggregates the changes of a range of ledgers into the
+// net change of the supply and balances of each token, sorted by contract ID.
+func ContractTokenUpdates(changes []ContractTokenChanges) []tickerdb.ContractTokenUpdate {
+	type tokenDelta struct {
+		supply     *big.Int
+		balances   map[string]*big.Int
+		metadata   *tickerdb.ContractTokenMetadata
+		lastLedger uint32
+	}
+	deltas := make(map[string]*tokenDelta)
+	get := func(contractID string, ledger uint32) *tokenDelta {
+		d, ok := deltas[contractID]
+		if !ok {
+			d = &tokenDelta{supply: new(big.Int), balances: make(map[string]*big.Int)}
+			deltas[contractID] = d
+		}
+		if ledger > d.lastLedger {
+			d.lastLedger = ledger
+		}
+		return d
+	}
+	addBalance := func(d *tokenDelta, holder string, amount *big.Int) {
+		if _, ok := d.balances[holder]; !ok {
+			d.balances[holder] = new(big.Int)
+		}
+		d.balances[holder].Add(d.balances[holder], amount)
+	}
+
+	for _, ledgerChanges := range changes {
+		for contractID, metadata := range ledgerChanges.Metadata {
+			metadata := metadata
+			get(contractID, ledgerChanges.Ledger).metadata = &metadata
+		}
+
+		for _, event := range ledgerChanges.Events {
+			d := get(event.ContractID, ledgerChanges.Ledger)
+			negated := new(big.Int).Neg(event.Amount)
+			switch event.Type {
+			case contractevents.EventTypeTransfer:
+				addBalance(d, event.From, negated)
+				addBalance(d, event.To, event.Amount)
+			case contractevents.EventTypeMint:
+				d.supply.Add(d.supply, event.Amount)
+				addBalance(d, event.To, event.Amount)
+			case contractevents.EventTypeBurn, contractevents.EventTypeClawback:
+				d.supply.Add(d.supply, negated)
+				addBalance(d, event.From, negated)
+			}
+		}
+	}
+
+	updates := make([]tickerdb.ContractTokenUpdate, 0, len(deltas))
+	for contractID, d := range deltas {
+		balances := make(map[string]string, len(d.balances))
+		for holder, balance := range d.balances {
+			balances[holder] = balance.String()
+		}
+		updates = append(updates, tickerdb.ContractTokenUpdate{
+			ContractID: contractID,
+			Supply:     d.supply.String(),
+			Balances:   balances,
+			Metadata:   d.metadata,
+			LastLedger: int32(d.lastLedger),
+		})
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].ContractID < updates[j].ContractID
+	})
+	return updates
+}
+
+// EvaluateContractToken evaluates a Soroban token against the asset policy,
+// like a classic asset without TOML file: an asset of type contract, issued
+// by its contract, whose code is namespaced (see ContractToken.AssetCode).
+// Tokens whose supply is incomplete (e.g. created before the first ingested
+// ledger) or negative are never listed, since their stats would be wrong.
+func (c *ScraperConfig) EvaluateContractToken(token tickerdb.ContractToken) (asset PolicyAsset, result PolicyResult) {
+	amount := token.Amount()
+	stat := hProtocol.AssetStat{
+		Amount:      strconv.FormatFloat(amount, 'f', -1, 64),
+		NumAccounts: token.NumAccounts,
+	}
+	stat.Asset.Type = tickerdb.ContractAssetType
+	stat.Asset.Code = token.AssetCode()
+	stat.Asset.Issuer = token.ContractID
+	asset = PolicyAsset{Stat: stat}
+
+	switch {
+	case !token.SupplyComplete:
+		result = fail("contract_supply", "the supply is incomplete, since some events of the token were never ingested")
+	case amount < 0:
+		result = fail("contract_supply", "negative supply of %s", stat.Amount)
+	default:
+		result = c.assetPolicy().Evaluate(asset)
+	}
+	return
+}
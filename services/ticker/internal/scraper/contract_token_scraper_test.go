@@ -0,0 +1,273 @@
+package scraper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/contractevents"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTokenAdmin = "GA2YS6YBWIBUMUJCNYROC5TXYTTUA4TCZF7A4MJ2O4TTGT3LFNWIOMY4"
+	testTokenAlice = "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+	testTokenBob   = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+)
+
+var testTokenContract = xdr.Hash{0xca, 0xfe}
+
+func makeTokenEvent(contract xdr.Hash, data xdr.ScVal, topics ...xdr.ScVal) xdr.ContractEvent {
+	return xdr.ContractEvent{
+		Type:       xdr.ContractEventTypeContract,
+		ContractId: &contract,
+		Body: xdr.ContractEventBody{
+			V:  0,
+			V0: &xdr.ContractEventV0{Topics: topics, Data: data},
+		},
+	}
+}
+
+func tokenSym(s string) xdr.ScVal {
+	sym := xdr.ScSymbol(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}
+}
+
+func tokenStr(s string) xdr.ScVal {
+	str := xdr.ScString(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}
+}
+
+func tokenMap(entries ...xdr.ScMapEntry) xdr.ScVal {
+	m := xdr.ScMap(entries)
+	mp := &m
+	return xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &mp}
+}
+
+func tokenAccount(address string) xdr.ScVal {
+	return xdr.ScVal{
+		Type: xdr.ScValTypeScvAddress,
+		Address: &xdr.ScAddress{
+			Type:      xdr.ScAddressTypeScAddressTypeAccount,
+			AccountId: xdr.MustAddressPtr(address),
+		},
+	}
+}
+
+func tokenAmount(amount int64) xdr.ScVal {
+	return xdr.ScVal{
+		Type: xdr.ScValTypeScvI128,
+		I128: &xdr.Int128Parts{Lo: xdr.Uint64(amount)},
+	}
+}
+
+func TestParseContractTokenEvent(t *testing.T) {
+	passphrase := network.TestNetworkPassphrase
+	contractID := strkey.MustEncode(strkey.VersionByteContract, testTokenContract[:])
+
+	// SEP-41 transfers, mints, burns and clawbacks:
+	event, ok := parseContractTokenEvent(makeTokenEvent(testTokenContract, tokenAmount(100),
+		tokenSym("transfer"), tokenAccount(testTokenAlice), tokenAccount(testTokenBob),
+	), passphrase)
+	require.True(t, ok)
+	assert.Equal(t, ContractTokenEvent{
+		ContractID: contractID,
+		Type:       contractevents.EventTypeTransfer,
+		From:       testTokenAlice,
+		To:         testTokenBob,
+		Amount:     big.NewInt(100),
+	}, event)
+
+	event, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract, tokenAmount(50),
+		tokenSym("mint"), tokenAccount(testTokenAdmin), tokenAccount(testTokenAlice),
+	), passphrase)
+	require.True(t, ok)
+	assert.Equal(t, contractevents.EventTypeMint, event.Type)
+	assert.Equal(t, "", event.From)
+	assert.Equal(t, testTokenAlice, event.To)
+
+	// mints without admin, with a map of data:
+	event, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract,
+		tokenMap(xdr.ScMapEntry{Key: tokenSym("amount"), Val: tokenAmount(7)}),
+		tokenSym("mint"), tokenAccount(testTokenAlice),
+	), passphrase)
+	require.True(t, ok)
+	assert.Equal(t, testTokenAlice, event.To)
+	assert.Equal(t, big.NewInt(7), event.Amount)
+
+	event, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract, tokenAmount(20),
+		tokenSym("burn"), tokenAccount(testTokenBob),
+	), passphrase)
+	require.True(t, ok)
+	assert.Equal(t, contractevents.EventTypeBurn, event.Type)
+	assert.Equal(t, testTokenBob, event.From)
+	assert.Equal(t, "", event.To)
+
+	event, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract, tokenAmount(5),
+		tokenSym("clawback"), tokenAccount(testTokenAdmin), tokenAccount(testTokenBob), tokenStr("memo"),
+	), passphrase)
+	require.True(t, ok)
+	assert.Equal(t, contractevents.EventTypeClawback, event.Type)
+	assert.Equal(t, testTokenBob, event.From)
+
+	// other events, malformed events and negative amounts are ignored:
+	_, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract, tokenAmount(5),
+		tokenSym("approve"), tokenAccount(testTokenAlice), tokenAccount(testTokenBob),
+	), passphrase)
+	assert.False(t, ok)
+	_, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract, tokenStr("5"),
+		tokenSym("transfer"), tokenAccount(testTokenAlice), tokenAccount(testTokenBob),
+	), passphrase)
+	assert.False(t, ok)
+	_, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract, tokenAmount(5),
+		tokenSym("transfer"), tokenAccount(testTokenAlice),
+	), passphrase)
+	assert.False(t, ok)
+	_, ok = parseContractTokenEvent(makeTokenEvent(testTokenContract,
+		xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &xdr.Int128Parts{Hi: -1, Lo: 0}},
+		tokenSym("mint"), tokenAccount(testTokenAlice),
+	), passphrase)
+	assert.False(t, ok)
+
+	// events of Stellar Asset Contracts are ignored...
+	usd := xdr.MustNewCreditAsset("USD", testTokenAdmin)
+	sacEvent := contractevents.GenerateEvent(contractevents.EventTypeTransfer,
+		testTokenAlice, testTokenBob, testTokenAdmin, usd, big.NewInt(10), passphrase)
+	_, ok = parseContractTokenEvent(sacEvent, passphrase)
+	assert.False(t, ok)
+
+	// ...but not the ones of other contracts using the same format
+	sacEvent.ContractId = &testTokenContract
+	event, ok = parseContractTokenEvent(sacEvent, passphrase)
+	require.True(t, ok)
+	assert.Equal(t, contractID, event.ContractID)
+	assert.Equal(t, testTokenAlice, event.From)
+	assert.Equal(t, testTokenBob, event.To)
+	assert.Equal(t, big.NewInt(10), event.Amount)
+}
+
+func TestParseContractTokenMetadata(t *testing.T) {
+	contractID := strkey.MustEncode(strkey.VersionByteContract, testTokenContract[:])
+	decimals := xdr.Uint32(6)
+
+	makeInstance := func(executable xdr.ContractExecutableType) xdr.LedgerEntry {
+		wasmHash := xdr.Hash{0x01}
+		return xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeContractData,
+				ContractData: &xdr.ContractDataEntry{
+					Contract: xdr.ScAddress{
+						Type:       xdr.ScAddressTypeScAddressTypeContract,
+						ContractId: &testTokenContract,
+					},
+					Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+					Durability: xdr.ContractDataDurabilityPersistent,
+					Val: xdr.ScVal{
+						Type: xdr.ScValTypeScvContractInstance,
+						Instance: &xdr.ScContractInstance{
+							Executable: xdr.ContractExecutable{Type: executable, WasmHash: &wasmHash},
+							Storage: &xdr.ScMap{
+								{Key: tokenSym("Admin"), Val: tokenAccount(testTokenAdmin)},
+								{Key: tokenSym("METADATA"), Val: tokenMap(
+									xdr.ScMapEntry{Key: tokenSym("decimal"), Val: xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &decimals}},
+									xdr.ScMapEntry{Key: tokenSym("name"), Val: tokenStr("Test Token")},
+									xdr.ScMapEntry{Key: tokenSym("symbol"), Val: tokenStr("TEST")},
+								)},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	id, metadata, ok := parseContractTokenMetadata(makeInstance(xdr.ContractExecutableTypeContractExecutableWasm))
+	require.True(t, ok)
+	assert.Equal(t, contractID, id)
+	assert.Equal(t, tickerdb.ContractTokenMetadata{Name: "Test Token", Symbol: "TEST", Decimals: 6}, metadata)
+
+	// Stellar Asset Contracts are ignored
+	_, _, ok = parseContractTokenMetadata(makeInstance(xdr.ContractExecutableTypeContractExecutableStellarAsset))
+	assert.False(t, ok)
+}
+
+func TestContractTokenUpdates(t *testing.T) {
+	const token, otherToken = "CTOKEN", "CATOKEN"
+	metadata := tickerdb.ContractTokenMetadata{Name: "Test Token", Symbol: "TEST", Decimals: 7}
+
+	updates := ContractTokenUpdates([]ContractTokenChanges{
+		{
+			Ledger:   10,
+			Metadata: map[string]tickerdb.ContractTokenMetadata{token: metadata},
+			Events: []ContractTokenEvent{
+				{ContractID: token, Type: contractevents.EventTypeMint, To: testTokenAlice, Amount: big.NewInt(1000)},
+				{ContractID: token, Type: contractevents.EventTypeTransfer, From: testTokenAlice, To: testTokenBob, Amount: big.NewInt(300)},
+			},
+		},
+		{
+			Ledger: 12,
+			Events: []ContractTokenEvent{
+				{ContractID: token, Type: contractevents.EventTypeBurn, From: testTokenBob, Amount: big.NewInt(100)},
+				{ContractID: otherToken, Type: contractevents.EventTypeTransfer, From: testTokenBob, To: testTokenAlice, Amount: big.NewInt(5)},
+			},
+		},
+	})
+
+	require.Len(t, updates, 2)
+	assert.Equal(t, tickerdb.ContractTokenUpdate{
+		ContractID: otherToken,
+		Supply:     "0",
+		Balances:   map[string]string{testTokenBob: "-5", testTokenAlice: "5"},
+		LastLedger: 12,
+	}, updates[0])
+	assert.Equal(t, tickerdb.ContractTokenUpdate{
+		ContractID: token,
+		Supply:     "900",
+		Balances:   map[string]string{testTokenAlice: "700", testTokenBob: "200"},
+		Metadata:   &metadata,
+		LastLedger: 12,
+	}, updates[1])
+}
+
+func TestEvaluateContractToken(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Discard.SkipTOMLValidation = true
+	sc := ScraperConfig{Settings: &settings}
+	token := tickerdb.ContractToken{
+		ContractID:     "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE",
+		Symbol:         "USDC",
+		Decimals:       7,
+		Supply:         "10000000000",
+		NumAccounts:    50,
+		SupplyComplete: true,
+	}
+
+	asset, result := sc.EvaluateContractToken(token)
+	assert.True(t, result.Listed)
+	assert.Equal(t, "contract:USDC", asset.Stat.Asset.Code)
+	assert.Equal(t, token.ContractID, asset.Stat.Asset.Issuer)
+	assert.Equal(t, tickerdb.ContractAssetType, asset.Stat.Asset.Type)
+	assert.Equal(t, "1000", asset.Stat.Amount)
+
+	// The discard rules apply as for the classic assets:
+	token.NumAccounts = 1
+	_, result = sc.EvaluateContractToken(token)
+	assert.False(t, result.Listed)
+	assert.Equal(t, "min_holders", result.Rule)
+
+	token.NumAccounts = 50
+	token.SupplyComplete = false
+	_, result = sc.EvaluateContractToken(token)
+	assert.False(t, result.Listed)
+	assert.Equal(t, "contract_supply", result.Rule)
+
+	token.SupplyComplete = true
+	token.Supply = "-10"
+	_, result = sc.EvaluateContractToken(token)
+	assert.False(t, result.Listed)
+	assert.Equal(t, "contract_supply", result.Rule)
+}
@@ -0,0 +1,270 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/ingest/ledgerbackend"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+)
+
+// synthetic offer IDs (used by Horizon when the counter side of a trade did not
+// leave an offer in the book) are tagged with this type in their 2 highest bits.
+const toidOfferIDType = uint64(1)
+
+// ExtractLedgerTrades reads the transactions of the ledger with the given
+// sequence from backend and extracts the trades (orderbook and liquidity pool)
+// resulting from their operations. Trades are returned in the same format
+// (and with the same IDs) as they would be returned by Horizon's /trades
+// endpoint, with their assets normalized.
+func ExtractLedgerTrades(
+	ctx context.Context,
+	backend ledgerbackend.LedgerBackend,
+	networkPassphrase string,
+	sequence uint32,
+) (trades []hProtocol.Trade, err error) {
+	reader, err := ingest.NewLedgerTransactionReader(ctx, backend, networkPassphrase, sequence)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	header := reader.GetHeader().Header
+	closeTime := time.Unix(int64(header.ScpValue.CloseTime), 0).UTC()
+
+	for {
+		var tx ingest.LedgerTransaction
+		tx, err = reader.Read()
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return
+		}
+
+		var txTrades []hProtocol.Trade
+		txTrades, err = extractTransactionTrades(tx, uint32(header.LedgerSeq), closeTime)
+		if err != nil {
+			err = errors.Wrapf(err, "could not extract trades from ledger %d", sequence)
+			return
+		}
+		trades = append(trades, txTrades...)
+	}
+
+	return
+}
+
+// LedgerFromTradeID returns the sequence of the ledger that contains the trade
+// with the given ID (as assigned by Horizon, i.e. "<operation id>-<order>").
+func LedgerFromTradeID(id string) (uint32, error) {
+	opID, err := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid trade id %s", id)
+	}
+	return uint32(toid.Parse(opID).LedgerSequence), nil
+}
+
+// LedgerCursor returns a paging token that points right after all the trades
+// of the ledger with the given sequence, to be used as an ingestion cursor.
+func LedgerCursor(sequence uint32) string {
+	return fmt.Sprintf("%d-0", toid.AfterLedger(int32(sequence)).ToInt64())
+}
+
+// extractTransactionTrades extracts the trades resulting from the operations of
+// a successful transaction, mirroring Horizon's trade ingestion.
+func extractTransactionTrades(
+	tx ingest.LedgerTransaction,
+	ledgerSeq uint32,
+	closeTime time.Time,
+) (trades []hProtocol.Trade, err error) {
+	if !tx.Result.Successful() {
+		return
+	}
+
+	opResults, ok := tx.Result.OperationResults()
+	if !ok {
+		err = errors.New("transaction has no operation results")
+		return
+	}
+
+	for opidx, op := range tx.Envelope.Operations() {
+		claims, buyOffer, buyOfferExists := claimedAtoms(op, opResults[opidx])
+		opID := toid.New(int32(ledgerSeq), int32(tx.Index), int32(opidx+1)).ToInt64()
+
+		for order, claim := range claims {
+			// stellar-core garbage collects invalid offers, emitting them with
+			// zeroed amounts. These do not represent trades.
+			if claim.AmountBought() == 0 && claim.AmountSold() == 0 {
+				continue
+			}
+
+			trade := hProtocol.Trade{
+				ID:              fmt.Sprintf("%d-%d", opID, order),
+				PT:              fmt.Sprintf("%d-%d", opID, order),
+				LedgerCloseTime: closeTime,
+				BaseAmount:      amount.String(claim.AmountSold()),
+				CounterAmount:   amount.String(claim.AmountBought()),
+				BaseIsSeller:    true,
+			}
+
+			err = claim.AssetSold().Extract(&trade.BaseAssetType, &trade.BaseAssetCode, &trade.BaseAssetIssuer)
+			if err != nil {
+				return
+			}
+			err = claim.AssetBought().Extract(&trade.CounterAssetType, &trade.CounterAssetCode, &trade.CounterAssetIssuer)
+			if err != nil {
+				return
+			}
+
+			if claim.Type == xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool {
+				poolID := claim.MustLiquidityPool().LiquidityPoolId
+				var fee uint32
+				fee, err = liquidityPoolFee(tx, opidx, poolID)
+				if err != nil {
+					return
+				}
+				trade.TradeType = "liquidity_pool"
+				trade.BaseLiquidityPoolID = xdr.Hash(poolID).HexString()
+				trade.LiquidityPoolFeeBP = fee
+				trade.Price = hProtocol.TradePrice{
+					N: int64(claim.AmountBought()),
+					D: int64(claim.AmountSold()),
+				}
+			} else {
+				var price xdr.Price
+				price, err = offerPrice(tx, opidx, claim)
+				if err != nil {
+					return
+				}
+				trade.TradeType = "orderbook"
+				trade.BaseOfferID = fmt.Sprintf("%d", claim.OfferId())
+				trade.BaseAccount = claim.SellerId().Address()
+				trade.Price = hProtocol.TradePrice{N: int64(price.N), D: int64(price.D)}
+			}
+
+			if buyOfferExists {
+				trade.CounterOfferID = fmt.Sprintf("%d", buyOffer.OfferId)
+			} else {
+				trade.CounterOfferID = fmt.Sprintf("%d", uint64(opID)|toidOfferIDType<<62)
+			}
+
+			if source := op.SourceAccount; source != nil {
+				accountID := source.ToAccountId()
+				trade.CounterAccount = accountID.Address()
+			} else {
+				accountID := tx.Envelope.SourceAccount().ToAccountId()
+				trade.CounterAccount = accountID.Address()
+			}
+
+			NormalizeTradeAssets(&trade)
+			trades = append(trades, trade)
+		}
+	}
+
+	return
+}
+
+// claimedAtoms returns the offers and pools claimed by a successful operation
+// and, for offer management operations, the offer left in the book (if any).
+func claimedAtoms(op xdr.Operation, result xdr.OperationResult) (claims []xdr.ClaimAtom, buyOffer xdr.OfferEntry, buyOfferExists bool) {
+	switch op.Body.Type {
+	case xdr.OperationTypePathPaymentStrictReceive:
+		claims = result.MustTr().MustPathPaymentStrictReceiveResult().MustSuccess().Offers
+	case xdr.OperationTypePathPaymentStrictSend:
+		claims = result.MustTr().MustPathPaymentStrictSendResult().MustSuccess().Offers
+	case xdr.OperationTypeManageBuyOffer:
+		success := result.MustTr().MustManageBuyOfferResult().MustSuccess()
+		claims = success.OffersClaimed
+		buyOffer, buyOfferExists = success.Offer.GetOffer()
+	case xdr.OperationTypeManageSellOffer:
+		success := result.MustTr().MustManageSellOfferResult().MustSuccess()
+		claims = success.OffersClaimed
+		buyOffer, buyOfferExists = success.Offer.GetOffer()
+	case xdr.OperationTypeCreatePassiveSellOffer:
+		tr := result.MustTr()
+		// stellar-core creates results for CreatePassiveSellOffer operations
+		// with the ManageSellOffer result arm set.
+		var success xdr.ManageOfferSuccessResult
+		if tr.Type == xdr.OperationTypeManageSellOffer {
+			success = tr.MustManageSellOfferResult().MustSuccess()
+		} else {
+			success = tr.MustCreatePassiveSellOfferResult().MustSuccess()
+		}
+		claims = success.OffersClaimed
+		buyOffer, buyOfferExists = success.Offer.GetOffer()
+	}
+	return
+}
+
+// offerPrice returns the price of the claimed offer, as it was before the
+// operation was applied.
+func offerPrice(tx ingest.LedgerTransaction, opidx int, claim xdr.ClaimAtom) (price xdr.Price, err error) {
+	key := xdr.LedgerKey{}
+	if err = key.SetOffer(claim.SellerId(), uint64(claim.OfferId())); err != nil {
+		err = errors.Wrap(err, "could not create offer ledger key")
+		return
+	}
+
+	change, err := findOperationChange(tx, opidx, key)
+	if err != nil {
+		err = errors.Wrap(err, "could not find change for trade offer")
+		return
+	}
+
+	price = change.Pre.Data.MustOffer().Price
+	return
+}
+
+// liquidityPoolFee returns the fee (in basis points) of the given pool.
+func liquidityPoolFee(tx ingest.LedgerTransaction, opidx int, poolID xdr.PoolId) (fee uint32, err error) {
+	key := xdr.LedgerKey{}
+	if err = key.SetLiquidityPool(poolID); err != nil {
+		err = errors.Wrap(err, "could not create liquidity pool ledger key")
+		return
+	}
+
+	change, err := findOperationChange(tx, opidx, key)
+	if err != nil {
+		err = errors.Wrap(err, "could not find change for liquidity pool")
+		return
+	}
+
+	fee = uint32(change.Pre.Data.MustLiquidityPool().Body.MustConstantProduct().Params.Fee)
+	return
+}
+
+// findOperationChange finds the last change to the ledger entry with the given
+// key made by the operation at index opidx.
+func findOperationChange(tx ingest.LedgerTransaction, opidx int, key xdr.LedgerKey) (ingest.Change, error) {
+	changes, err := tx.GetOperationChanges(uint32(opidx))
+	if err != nil {
+		return ingest.Change{}, errors.Wrap(err, "could not determine changes for operation")
+	}
+
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		if change.Pre == nil {
+			continue
+		}
+
+		preKey, err := change.Pre.LedgerKey()
+		if err != nil {
+			return ingest.Change{}, errors.Wrap(err, "could not determine ledger key for change")
+		}
+		if key.Equals(preKey) {
+			return change, nil
+		}
+	}
+
+	return ingest.Change{}, errors.Errorf("could not find change for key %v", key)
+}
@@ -0,0 +1,234 @@
+package scraper
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/ingest"
+	"github.com/stellar/go/toid"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTransactionTrades(t *testing.T) {
+	issuer := "GA2YS6YBWIBUMUJCNYROC5TXYTTUA4TCZF7A4MJ2O4TTGT3LFNWIOMY4"
+	seller := "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+	buyer := "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+
+	native := xdr.MustNewNativeAsset()
+	usd := xdr.MustNewCreditAsset("USD", issuer)
+	poolID := xdr.PoolId{0xca, 0xfe}
+	closeTime := time.Unix(1556828400, 0).UTC()
+
+	// The buyer sells XLM for USD through an offer, crossing the seller's offer:
+	offerEntry := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeOffer,
+			Offer: &xdr.OfferEntry{
+				SellerId: xdr.MustAddress(seller),
+				OfferId:  42,
+				Selling:  usd,
+				Buying:   native,
+				Amount:   50_0000000,
+				Price:    xdr.Price{N: 2, D: 1},
+			},
+		},
+	}
+	offerKey, err := offerEntry.LedgerKey()
+	require.NoError(t, err)
+
+	// ...and then sends USD to itself through a liquidity pool:
+	poolEntry := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeLiquidityPool,
+			LiquidityPool: &xdr.LiquidityPoolEntry{
+				LiquidityPoolId: poolID,
+				Body: xdr.LiquidityPoolEntryBody{
+					Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+					ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+						Params: xdr.LiquidityPoolConstantProductParameters{
+							AssetA: native,
+							AssetB: usd,
+							Fee:    30,
+						},
+						ReserveA: 1000_0000000,
+						ReserveB: 500_0000000,
+					},
+				},
+			},
+		},
+	}
+
+	opSource := xdr.MustMuxedAddress(buyer)
+	tx := ingest.LedgerTransaction{
+		Index: 3,
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MustMuxedAddress(buyer),
+					Operations: []xdr.Operation{
+						{
+							Body: xdr.OperationBody{
+								Type: xdr.OperationTypeManageSellOffer,
+								ManageSellOfferOp: &xdr.ManageSellOfferOp{
+									Selling: native,
+									Buying:  usd,
+									Amount:  100_0000000,
+									Price:   xdr.Price{N: 1, D: 2},
+								},
+							},
+						},
+						{
+							SourceAccount: &opSource,
+							Body: xdr.OperationBody{
+								Type: xdr.OperationTypePathPaymentStrictSend,
+								PathPaymentStrictSendOp: &xdr.PathPaymentStrictSendOp{
+									SendAsset:   native,
+									SendAmount:  20_0000000,
+									Destination: xdr.MustMuxedAddress(buyer),
+									DestAsset:   usd,
+									DestMin:     1,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code: xdr.TransactionResultCodeTxSuccess,
+					Results: &[]xdr.OperationResult{
+						{
+							Code: xdr.OperationResultCodeOpInner,
+							Tr: &xdr.OperationResultTr{
+								Type: xdr.OperationTypeManageSellOffer,
+								ManageSellOfferResult: &xdr.ManageSellOfferResult{
+									Code: xdr.ManageSellOfferResultCodeManageSellOfferSuccess,
+									Success: &xdr.ManageOfferSuccessResult{
+										OffersClaimed: []xdr.ClaimAtom{
+											{
+												Type: xdr.ClaimAtomTypeClaimAtomTypeOrderBook,
+												OrderBook: &xdr.ClaimOfferAtom{
+													SellerId:     xdr.MustAddress(seller),
+													OfferId:      42,
+													AssetSold:    usd,
+													AmountSold:   50_0000000,
+													AssetBought:  native,
+													AmountBought: 100_0000000,
+												},
+											},
+										},
+										Offer: xdr.ManageOfferSuccessResultOffer{
+											Effect: xdr.ManageOfferEffectManageOfferDeleted,
+										},
+									},
+								},
+							},
+						},
+						{
+							Code: xdr.OperationResultCodeOpInner,
+							Tr: &xdr.OperationResultTr{
+								Type: xdr.OperationTypePathPaymentStrictSend,
+								PathPaymentStrictSendResult: &xdr.PathPaymentStrictSendResult{
+									Code: xdr.PathPaymentStrictSendResultCodePathPaymentStrictSendSuccess,
+									Success: &xdr.PathPaymentStrictSendResultSuccess{
+										Offers: []xdr.ClaimAtom{
+											{
+												Type: xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool,
+												LiquidityPool: &xdr.ClaimLiquidityAtom{
+													LiquidityPoolId: poolID,
+													AssetSold:       usd,
+													AmountSold:      10_0000000,
+													AssetBought:     native,
+													AmountBought:    20_0000000,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 2,
+			V2: &xdr.TransactionMetaV2{
+				Operations: []xdr.OperationMeta{
+					{
+						Changes: xdr.LedgerEntryChanges{
+							{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &offerEntry},
+							{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &offerKey},
+						},
+					},
+					{
+						Changes: xdr.LedgerEntryChanges{
+							{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &poolEntry},
+							{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &poolEntry},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	trades, err := extractTransactionTrades(tx, 100, closeTime)
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+
+	// Assets are normalized, so XLM is always the base asset:
+	offerTrade := trades[0]
+	assert.Equal(t, fmt.Sprintf("%d-0", toid.New(100, 3, 1).ToInt64()), offerTrade.ID)
+	assert.Equal(t, offerTrade.ID, offerTrade.PT)
+	assert.Equal(t, closeTime, offerTrade.LedgerCloseTime)
+	assert.Equal(t, "orderbook", offerTrade.TradeType)
+	assert.Equal(t, "XLM", offerTrade.BaseAssetCode)
+	assert.Equal(t, "native", offerTrade.BaseAssetType)
+	assert.Equal(t, "100.0000000", offerTrade.BaseAmount)
+	assert.Equal(t, buyer, offerTrade.BaseAccount)
+	assert.Equal(t, "USD", offerTrade.CounterAssetCode)
+	assert.Equal(t, issuer, offerTrade.CounterAssetIssuer)
+	assert.Equal(t, "50.0000000", offerTrade.CounterAmount)
+	assert.Equal(t, seller, offerTrade.CounterAccount)
+	assert.Equal(t, "42", offerTrade.CounterOfferID)
+	assert.False(t, offerTrade.BaseIsSeller)
+	assert.Equal(t, int64(1), offerTrade.Price.N)
+	assert.Equal(t, int64(2), offerTrade.Price.D)
+
+	poolTrade := trades[1]
+	assert.Equal(t, fmt.Sprintf("%d-0", toid.New(100, 3, 2).ToInt64()), poolTrade.ID)
+	assert.Equal(t, "liquidity_pool", poolTrade.TradeType)
+	assert.Equal(t, "20.0000000", poolTrade.BaseAmount)
+	assert.Equal(t, "10.0000000", poolTrade.CounterAmount)
+	assert.Equal(t, xdr.Hash(poolID).HexString(), poolTrade.CounterLiquidityPoolID)
+	assert.Equal(t, uint32(30), poolTrade.LiquidityPoolFeeBP)
+	assert.Equal(t, int64(10_0000000), poolTrade.Price.N)
+	assert.Equal(t, int64(20_0000000), poolTrade.Price.D)
+
+	// Failed transactions do not produce any trade:
+	tx.Result.Result.Result.Code = xdr.TransactionResultCodeTxFailed
+	trades, err = extractTransactionTrades(tx, 100, closeTime)
+	require.NoError(t, err)
+	assert.Empty(t, trades)
+}
+
+func TestLedgerFromTradeID(t *testing.T) {
+	id := fmt.Sprintf("%d-3", toid.New(45678, 2, 1).ToInt64())
+	seq, err := LedgerFromTradeID(id)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(45678), seq)
+
+	seq, err = LedgerFromTradeID(LedgerCursor(45678))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(45678), seq)
+	assert.True(t, LedgerCursor(45678) > id)
+
+	_, err = LedgerFromTradeID("not-an-id")
+	assert.Error(t, err)
+}
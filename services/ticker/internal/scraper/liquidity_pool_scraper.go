@@ -0,0 +1,110 @@
+package scraper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	horizonclient "github.com/stellar/go/clients/horizonclient"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/ticker/internal/utils"
+	"github.com/stellar/go/support/errors"
+)
+
+// retrieveLiquidityPools fetches all liquidity pools from Horizon, one page at a time.
+func (c *ScraperConfig) retrieveLiquidityPools() (pools []hProtocol.LiquidityPool, err error) {
+	r := horizonclient.LiquidityPoolsRequest{Limit: c.pageSize()}
+
+	c.Logger.Info("Fetching liquidity pools from Horizon")
+	for {
+		var page hProtocol.LiquidityPoolsPage
+		err = c.retry(func() error {
+			page, err = c.Client.LiquidityPools(r)
+			if err != nil {
+				c.Logger.Info("Horizon rate limit reached!")
+			}
+			return err
+		})
+		if err != nil {
+			err = errors.Wrap(err, "could not fetch liquidity pools")
+			return
+		}
+
+		records := page.Embedded.Records
+		pools = append(pools, records...)
+		if len(records) < int(r.Limit) {
+			return
+		}
+
+		r.Cursor = records[len(records)-1].PT
+		c.Logger.Debug("Cursor currently at:", r.Cursor)
+	}
+}
+
+// calcLiquidityPoolStats calculates the reserves, implied price and TVL of a
+// liquidity pool, with its assets ordered like the base and counter assets of
+// trades (see NormalizeTradeAssets).
+func calcLiquidityPoolStats(pool hProtocol.LiquidityPool) (stats LiquidityPoolStats, err error) {
+	if len(pool.Reserves) != 2 {
+		err = fmt.Errorf("liquidity pool %s has %d reserves", pool.ID, len(pool.Reserves))
+		return
+	}
+
+	stats = LiquidityPoolStats{
+		PoolID:          pool.ID,
+		FeeBP:           pool.FeeBP,
+		TotalTrustlines: pool.TotalTrustlines,
+	}
+	if stats.TotalShares, err = strconv.ParseFloat(pool.TotalShares, 64); err != nil {
+		return
+	}
+
+	base, counter := pool.Reserves[0], pool.Reserves[1]
+	if counter.Asset == "native" || (base.Asset != "native" && base.Asset > counter.Asset) {
+		base, counter = counter, base
+	}
+
+	stats.BaseAssetType, stats.BaseAssetCode, stats.BaseAssetIssuer, err = parseReserveAsset(base.Asset)
+	if err != nil {
+		return
+	}
+	stats.CounterAssetType, stats.CounterAssetCode, stats.CounterAssetIssuer, err = parseReserveAsset(counter.Asset)
+	if err != nil {
+		return
+	}
+
+	if stats.BaseReserve, err = strconv.ParseFloat(base.Amount, 64); err != nil {
+		return
+	}
+	if stats.CounterReserve, err = strconv.ParseFloat(counter.Amount, 64); err != nil {
+		return
+	}
+
+	// Like trade prices, the implied price is in units of the base asset, and
+	// both reserves have the same value at that price:
+	stats.Price = utils.CalcPoolPrice(stats.BaseReserve, stats.CounterReserve)
+	stats.TVL = 2 * stats.CounterReserve
+	return
+}
+
+// parseReserveAsset parses the asset of a liquidity pool reserve, identified
+// as "native" or "<code>:<issuer>", using the "XLM" code and "native" issuer
+// for the native asset.
+func parseReserveAsset(asset string) (assetType, code, issuer string, err error) {
+	if asset == "native" {
+		return string(horizonclient.AssetTypeNative), "XLM", "native", nil
+	}
+
+	parts := strings.Split(asset, ":")
+	if len(parts) != 2 {
+		err = fmt.Errorf("invalid reserve asset %s", asset)
+		return
+	}
+
+	code, issuer = parts[0], parts[1]
+	assetType = string(horizonclient.AssetType4)
+	if len(code) > 4 {
+		assetType = string(horizonclient.AssetType12)
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package scraper
+
+import (
+	"testing"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcLiquidityPoolStats(t *testing.T) {
+	issuer := "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB"
+	pool := hProtocol.LiquidityPool{
+		ID:              "abcdef",
+		FeeBP:           30,
+		TotalTrustlines: 12,
+		TotalShares:     "500.0000000",
+		Reserves: []hProtocol.LiquidityPoolReserve{
+			{Asset: "BTC:" + issuer, Amount: "10.0000000"},
+			{Asset: "native", Amount: "1000.0000000"},
+		},
+	}
+
+	// The native asset is always the base asset:
+	stats, err := calcLiquidityPoolStats(pool)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", stats.PoolID)
+	assert.Equal(t, uint32(30), stats.FeeBP)
+	assert.Equal(t, uint64(12), stats.TotalTrustlines)
+	assert.Equal(t, 500.0, stats.TotalShares)
+	assert.Equal(t, "native", stats.BaseAssetType)
+	assert.Equal(t, "XLM", stats.BaseAssetCode)
+	assert.Equal(t, "native", stats.BaseAssetIssuer)
+	assert.Equal(t, "credit_alphanum4", stats.CounterAssetType)
+	assert.Equal(t, "BTC", stats.CounterAssetCode)
+	assert.Equal(t, issuer, stats.CounterAssetIssuer)
+	assert.Equal(t, 1000.0, stats.BaseReserve)
+	assert.Equal(t, 10.0, stats.CounterReserve)
+	assert.Equal(t, 100.0, stats.Price)
+	assert.Equal(t, 20.0, stats.TVL)
+
+	// Otherwise, the assets are sorted lexicographically:
+	pool.Reserves = []hProtocol.LiquidityPoolReserve{
+		{Asset: "ETHEREUM:" + issuer, Amount: "4.0000000"},
+		{Asset: "BTC:" + issuer, Amount: "2.0000000"},
+	}
+	stats, err = calcLiquidityPoolStats(pool)
+	require.NoError(t, err)
+	assert.Equal(t, "BTC", stats.BaseAssetCode)
+	assert.Equal(t, "ETHEREUM", stats.CounterAssetCode)
+	assert.Equal(t, "credit_alphanum12", stats.CounterAssetType)
+	assert.Equal(t, 0.5, stats.Price)
+	assert.Equal(t, 8.0, stats.TVL)
+
+	pool.Reserves = pool.Reserves[:1]
+	_, err = calcLiquidityPoolStats(pool)
+	assert.Error(t, err)
+
+	pool.Reserves = []hProtocol.LiquidityPoolReserve{
+		{Asset: "BTC", Amount: "2.0000000"},
+		{Asset: "native", Amount: "4.0000000"},
+	}
+	_, err = calcLiquidityPoolStats(pool)
+	assert.Error(t, err)
+}
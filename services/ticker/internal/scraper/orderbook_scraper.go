@@ -2,8 +2,8 @@ package scraper
 
 import (
 	"math"
+	"sort"
 	"strconv"
-	"time"
 
 	"github.com/pkg/errors"
 	horizonclient "github.com/stellar/go/clients/horizonclient"
@@ -27,10 +27,29 @@ func (c *ScraperConfig) fetchOrderbook(bType, bCode, bIssuer, cType, cCode, cIss
 		CounterAssetIssuer: cIssuer,
 		HighestBid:         math.Inf(-1), // start with -Inf to make sure we catch the correct max bid
 		LowestAsk:          math.Inf(1),  // start with +Inf to make sure we catch the correct min ask
+		SlippageAmount:     c.SlippageAmount,
 	}
+	if obStats.SlippageAmount == 0 {
+		obStats.SlippageAmount = DefaultSlippageAmount
+	}
+	summary, err = c.fetchOrderbookSummary(bType, bCode, bIssuer, cType, cCode, cIssuer)
+	if err != nil {
+		return obStats, err
+	}
+
+	err = calcOrderbookStats(&obStats, summary)
+	if err != nil {
+		return obStats, errors.Wrap(err, "could not calculate orderbook stats")
+	}
+	return obStats, nil
+}
+
+// fetchOrderbookSummary fetches the bids and asks of the orderbook selling the
+// base asset for the counter asset provided in the parameters
+func (c *ScraperConfig) fetchOrderbookSummary(bType, bCode, bIssuer, cType, cCode, cIssuer string) (summary hProtocol.OrderBookSummary, err error) {
 	r := createOrderbookRequest(bType, bCode, bIssuer, cType, cCode, cIssuer)
 
-	err = utils.Retry(5, 5*time.Second, c.Logger, func() error {
+	err = c.retry(func() error {
 		summary, err = c.Client.OrderBook(r)
 		if err != nil {
 			c.Logger.Info("Horizon rate limit reached!")
@@ -38,14 +57,9 @@ func (c *ScraperConfig) fetchOrderbook(bType, bCode, bIssuer, cType, cCode, cIss
 		return err
 	})
 	if err != nil {
-		return obStats, errors.Wrap(err, "could not fetch orderbook summary")
-	}
-
-	err = calcOrderbookStats(&obStats, summary)
-	if err != nil {
-		return obStats, errors.Wrap(err, "could not calculate orderbook stats")
+		err = errors.Wrap(err, "could not fetch orderbook summary")
 	}
-	return obStats, nil
+	return
 }
 
 // calcOrderbookStats calculates the NumBids, BidVolume, BidMax, NumAsks, AskVolume and AskMin
@@ -101,9 +115,112 @@ func calcOrderbookStats(obStats *OrderbookStats, summary hProtocol.OrderBookSumm
 		obStats.HighestBid = 0
 	}
 
+	return calcOrderbookDepth(obStats, summary)
+}
+
+// orderbookLevel represents a price level of an orderbook, with its price in
+// units of the counter asset and its amount in units of both assets.
+type orderbookLevel struct {
+	price         float64
+	baseAmount    float64
+	counterAmount float64
+}
+
+// calcOrderbookDepth calculates the depth at 2%, 5% and 10% from the mid price
+// and the buy and sell slippage of a given OrderbookStats instance, whose
+// HighestBid and LowestAsk must have already been calculated.
+func calcOrderbookDepth(obStats *OrderbookStats, summary hProtocol.OrderBookSummary) error {
+	if obStats.HighestBid == 0 || obStats.LowestAsk == 0 {
+		return nil
+	}
+	midPrice := (obStats.HighestBid + obStats.LowestAsk) / 2
+
+	bids, err := parseOrderbookLevels(summary.Bids, true)
+	if err != nil {
+		return errors.Wrap(err, "invalid bid amount")
+	}
+	asks, err := parseOrderbookLevels(summary.Asks, false)
+	if err != nil {
+		return errors.Wrap(err, "invalid ask amount")
+	}
+
+	obStats.BidDepth2Pct = calcDepthAtPct(bids, midPrice, -2)
+	obStats.BidDepth5Pct = calcDepthAtPct(bids, midPrice, -5)
+	obStats.BidDepth10Pct = calcDepthAtPct(bids, midPrice, -10)
+	obStats.AskDepth2Pct = calcDepthAtPct(asks, midPrice, 2)
+	obStats.AskDepth5Pct = calcDepthAtPct(asks, midPrice, 5)
+	obStats.AskDepth10Pct = calcDepthAtPct(asks, midPrice, 10)
+
+	if avgPrice := calcAvgPriceForAmount(asks, obStats.SlippageAmount); avgPrice != 0 {
+		obStats.BuySlippage = 100 * (avgPrice - midPrice) / midPrice
+	}
+	if avgPrice := calcAvgPriceForAmount(bids, obStats.SlippageAmount); avgPrice != 0 {
+		obStats.SellSlippage = 100 * (midPrice - avgPrice) / midPrice
+	}
 	return nil
 }
 
+// parseOrderbookLevels converts the price levels of an orderbook summary into
+// orderbookLevels, sorted from the best to the worst price. On Horizon, bid
+// amounts are in units of counter, while ask amounts are in units of base.
+func parseOrderbookLevels(priceLevels []hProtocol.PriceLevel, isBid bool) ([]orderbookLevel, error) {
+	levels := make([]orderbookLevel, 0, len(priceLevels))
+	for _, pl := range priceLevels {
+		price := float64(pl.PriceR.N) / float64(pl.PriceR.D)
+		amount, err := strconv.ParseFloat(pl.Amount, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		level := orderbookLevel{price: price, baseAmount: amount, counterAmount: amount * price}
+		if isBid {
+			level.baseAmount, level.counterAmount = amount/price, amount
+		}
+		levels = append(levels, level)
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		if isBid {
+			return levels[i].price > levels[j].price
+		}
+		return levels[i].price < levels[j].price
+	})
+	return levels, nil
+}
+
+// calcDepthAtPct sums the counter amount of the levels priced within pct
+// percent of the mid price (below it for negative percentages, i.e. bids).
+func calcDepthAtPct(levels []orderbookLevel, midPrice float64, pct float64) (depth float64) {
+	limit := midPrice * (1 + pct/100)
+	for _, level := range levels {
+		if (pct < 0 && level.price < limit) || (pct > 0 && level.price > limit) {
+			break
+		}
+		depth += level.counterAmount
+	}
+	return
+}
+
+// calcAvgPriceForAmount calculates the average price of filling amount units
+// of the base asset against the given levels, or 0 if they can't fill it.
+func calcAvgPriceForAmount(levels []orderbookLevel, amount float64) float64 {
+	if amount <= 0 {
+		return 0
+	}
+
+	remaining := amount
+	total := 0.0
+	for _, level := range levels {
+		filled := math.Min(remaining, level.baseAmount)
+		total += filled * level.price
+		remaining -= filled
+		if remaining <= 0 {
+			return total / amount
+		}
+	}
+	return 0
+}
+
 // createOrderbookRequest generates a horizonclient.OrderBookRequest based on the base
 // and counter asset parameters provided
 func createOrderbookRequest(bType, bCode, bIssuer, cType, cCode, cIssuer string) horizonclient.OrderBookRequest {
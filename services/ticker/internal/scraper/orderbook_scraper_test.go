@@ -0,0 +1,69 @@
+package scraper
+
+import (
+	"math"
+	"testing"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcOrderbookStats(t *testing.T) {
+	// Bid amounts are in units of counter, while ask amounts are in units of base:
+	summary := hProtocol.OrderBookSummary{
+		Bids: []hProtocol.PriceLevel{
+			{PriceR: hProtocol.Price{N: 97, D: 100}, Amount: "97.0000000"},
+			{PriceR: hProtocol.Price{N: 99, D: 100}, Amount: "99.0000000"},
+			{PriceR: hProtocol.Price{N: 91, D: 100}, Amount: "91.0000000"},
+		},
+		Asks: []hProtocol.PriceLevel{
+			{PriceR: hProtocol.Price{N: 101, D: 100}, Amount: "100.0000000"},
+			{PriceR: hProtocol.Price{N: 104, D: 100}, Amount: "100.0000000"},
+			{PriceR: hProtocol.Price{N: 120, D: 100}, Amount: "100.0000000"},
+		},
+	}
+
+	newOrderbookStats := func(slippageAmount float64) OrderbookStats {
+		return OrderbookStats{
+			HighestBid:     math.Inf(-1),
+			LowestAsk:      math.Inf(1),
+			SlippageAmount: slippageAmount,
+		}
+	}
+
+	obStats := newOrderbookStats(150)
+	err := calcOrderbookStats(&obStats, summary)
+	require.NoError(t, err)
+	assert.Equal(t, 3, obStats.NumBids)
+	assert.Equal(t, 0.99, obStats.HighestBid)
+	assert.Equal(t, 3, obStats.NumAsks)
+	assert.Equal(t, 1.01, obStats.LowestAsk)
+
+	assert.InDelta(t, 99.0, obStats.BidDepth2Pct, 1e-9)
+	assert.InDelta(t, 196.0, obStats.BidDepth5Pct, 1e-9)
+	assert.InDelta(t, 287.0, obStats.BidDepth10Pct, 1e-9)
+	assert.InDelta(t, 101.0, obStats.AskDepth2Pct, 1e-9)
+	assert.InDelta(t, 205.0, obStats.AskDepth5Pct, 1e-9)
+	assert.InDelta(t, 205.0, obStats.AskDepth10Pct, 1e-9)
+
+	// Buying 150 base costs 100 * 1.01 + 50 * 1.04 = 153 counter, and selling
+	// them yields 100 * 0.99 + 50 * 0.97 = 147.5 counter, with a mid price of 1:
+	assert.InDelta(t, 2.0, obStats.BuySlippage, 1e-9)
+	assert.InDelta(t, 1.6666666667, obStats.SellSlippage, 1e-9)
+
+	// Without enough liquidity, the slippage is 0:
+	obStats = newOrderbookStats(1000)
+	err = calcOrderbookStats(&obStats, summary)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, obStats.BuySlippage)
+	assert.Equal(t, 0.0, obStats.SellSlippage)
+	assert.InDelta(t, 287.0, obStats.BidDepth10Pct, 1e-9)
+
+	// Depth and slippage need both sides of the orderbook:
+	obStats = newOrderbookStats(150)
+	err = calcOrderbookStats(&obStats, hProtocol.OrderBookSummary{Bids: summary.Bids})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, obStats.BidDepth2Pct)
+	assert.Equal(t, 0.0, obStats.SellSlippage)
+}
@@ -2,15 +2,23 @@ package scraper
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/stellar/go/amount"
 	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/ticker/internal/metrics"
 	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/services/ticker/internal/utils"
 	hlog "github.com/stellar/go/support/log"
 	"math/big"
-	"strconv"
+	"time"
 )
 
+// ErrAssetsNotFound is returned by FindBaseAndCounter when the base or the
+// counter asset of a trade can't be found in the database.
+var ErrAssetsNotFound = errors.New("base or counter asset no found")
+
 // TODO: 30 sec for an insert of 100k records -> 12k rows?
 func PersistTrades(
 	ctx context.Context,
@@ -18,34 +26,148 @@ func PersistTrades(
 	l *hlog.Entry,
 	trades []hProtocol.Trade,
 ) error {
+	dbTrades, pendingTrades := convertTrades(ctx, s, l, trades)
+
+	l.Infof("Inserting %d entries in the database.\n", len(dbTrades))
+	if err := s.BulkInsertTrades(ctx, dbTrades); err != nil {
+		l.Error("Could not insert trades: ", err)
+		return err
+	}
+	observeIngestedTrades(dbTrades, nil)
+
+	if len(pendingTrades) > 0 {
+		l.Infof("Inserting %d pending entries in the database.\n", len(pendingTrades))
+		if err := s.BulkInsertPendingTrades(ctx, pendingTrades); err != nil {
+			l.Error("Could not insert pending trades: ", err)
+			return err
+		}
+		observeIngestedTrades(nil, pendingTrades)
+	}
+
+	return nil
+}
+
+// PersistTradesAndCursor persists trades like PersistTrades, but also advances
+// the cursor of the given ingestion job to pagingToken within the same database
+// transaction.
+func PersistTradesAndCursor(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	l *hlog.Entry,
+	trades []hProtocol.Trade,
+	job string,
+	pagingToken string,
+) error {
+	dbTrades, pendingTrades := convertTrades(ctx, s, l, trades)
+
+	l.Infof(
+		"Inserting %d entries (%d pending) in the database (%s cursor: %s).\n",
+		len(dbTrades),
+		len(pendingTrades),
+		job,
+		pagingToken,
+	)
+	err := s.BulkInsertTradesAndUpdateCursor(ctx, dbTrades, pendingTrades, job, pagingToken)
+	if err != nil {
+		return err
+	}
+
+	observeIngestedTrades(dbTrades, pendingTrades)
+	return nil
+}
+
+// observeIngestedTrades updates the ingestion metrics with newly persisted
+// trades and pending trades.
+func observeIngestedTrades(dbTrades []tickerdb.Trade, pendingTrades []tickerdb.PendingTrade) {
+	metrics.TradesIngested.WithLabelValues("stored").Add(float64(len(dbTrades)))
+	metrics.TradesIngested.WithLabelValues("pending").Add(float64(len(pendingTrades)))
+
+	for _, trade := range dbTrades {
+		metrics.ObserveTradeCloseTime(trade.LedgerCloseTime)
+	}
+	for _, trade := range pendingTrades {
+		metrics.ObserveTradeCloseTime(trade.LedgerCloseTime)
+	}
+}
+
+// ReplayPendingTrades moves the pending trades whose base and counter assets
+// can now be found in the database (e.g. after an asset refresh) to the trades
+// table, returning the number of trades replayed.
+func ReplayPendingTrades(ctx context.Context, s *tickerdb.TickerSession, l *hlog.Entry) (int, error) {
+	pendingTrades, err := s.GetResolvablePendingTrades(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	var dbTrades []tickerdb.Trade
+	var horizonIDs []string
+	for _, pt := range pendingTrades {
+		var trade hProtocol.Trade
+		var dbTrade tickerdb.Trade
+		if err = json.Unmarshal([]byte(pt.Trade), &trade); err != nil {
+			l.Error("Could not decode pending trade: ", pt.HorizonID, err)
+			continue
+		}
+
+		dbTrade, err = HProtocolTradeToDBTrade(trade, pt.BaseAssetID, pt.CounterAssetID)
+		if err != nil {
+			l.Error("Could not convert entry to DB Trade: ", err)
+			continue
+		}
+		dbTrades = append(dbTrades, dbTrade)
+		horizonIDs = append(horizonIDs, pt.HorizonID)
+	}
+
+	if len(horizonIDs) == 0 {
+		return 0, nil
+	}
+
+	l.Infof("Replaying %d pending trades.\n", len(horizonIDs))
+	if err = s.ResolvePendingTrades(ctx, dbTrades, horizonIDs); err != nil {
+		return 0, err
+	}
+
+	metrics.TradesIngested.WithLabelValues("replayed").Add(float64(len(horizonIDs)))
+	return len(horizonIDs), nil
+}
 
+// convertTrades converts trades into tickerdb.Trades. Trades whose base or
+// counter asset can't be found in the database are converted into
+// tickerdb.PendingTrades instead, so that they can be replayed later.
+func convertTrades(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	l *hlog.Entry,
+	trades []hProtocol.Trade,
+) (dbTrades []tickerdb.Trade, pendingTrades []tickerdb.PendingTrade) {
 	for _, trade := range trades {
-		var bID, cID int32
 		bID, cID, err := FindBaseAndCounter(ctx, s, trade)
+		if err == ErrAssetsNotFound {
+			pendingTrade, err := HProtocolTradeToPendingTrade(trade)
+			if err != nil {
+				l.Error("Could not convert entry to pending DB Trade: ", err)
+				continue
+			}
+			pendingTrades = append(pendingTrades, pendingTrade)
+			continue
+		}
 		if err != nil {
+			l.Error("Could not find trade assets: ", err)
 			continue
 		}
 
-		var dbTrade tickerdb.Trade
-		dbTrade, err = HProtocolTradeToDBTrade(trade, bID, cID)
+		dbTrade, err := HProtocolTradeToDBTrade(trade, bID, cID)
 		if err != nil {
 			l.Error("Could not convert entry to DB Trade: ", err)
 			continue
 		}
 		dbTrades = append(dbTrades, dbTrade)
 	}
-
-	l.Infof("Inserting %d entries in the database.\n", len(dbTrades))
-	if err := s.BulkInsertTrades(ctx, dbTrades); err != nil {
-		fmt.Println(err)
-	}
-
-	return nil
+	return
 }
 
 // FindBaseAndCounter tries to find the Base and Counter assets IDs in the database,
-// and returns an error if it doesn't find any.
+// and returns ErrAssetsNotFound if it doesn't find any.
 func FindBaseAndCounter(ctx context.Context, s *tickerdb.TickerSession, trade hProtocol.Trade) (bID int32, cID int32, err error) {
 	bFound, bID, err := s.GetAssetByCodeAndIssuerAccount(
 		ctx,
@@ -66,45 +188,87 @@ func FindBaseAndCounter(ctx context.Context, s *tickerdb.TickerSession, trade hP
 	}
 
 	if !bFound || !cFound {
-		err = errors.New("base or counter asset no found")
+		err = ErrAssetsNotFound
 		return
 	}
 
 	return
 }
 
+// HProtocolTradeToPendingTrade converts from a hProtocol.Trade to a tickerdb.PendingTrade
+func HProtocolTradeToPendingTrade(hpt hProtocol.Trade) (trade tickerdb.PendingTrade, err error) {
+	encoded, err := json.Marshal(hpt)
+	if err != nil {
+		return
+	}
+
+	trade = tickerdb.PendingTrade{
+		HorizonID:          hpt.ID,
+		LedgerCloseTime:    hpt.LedgerCloseTime,
+		BaseAssetCode:      hpt.BaseAssetCode,
+		BaseAssetIssuer:    hpt.BaseAssetIssuer,
+		CounterAssetCode:   hpt.CounterAssetCode,
+		CounterAssetIssuer: hpt.CounterAssetIssuer,
+		Trade:              string(encoded),
+		CreatedAt:          time.Now(),
+	}
+
+	return
+}
+
+// priceDecimals is the number of decimal places kept when storing the price
+// of a trade. Its exact value is also stored as a rational (PriceN / PriceD).
+const priceDecimals = 20
+
 // HProtocolTradeToDBTrade converts from a hProtocol.Trade to a tickerdb.Trade
 func HProtocolTradeToDBTrade(
 	hpt hProtocol.Trade,
 	baseAssetID int32,
 	counterAssetID int32,
 ) (trade tickerdb.Trade, err error) {
-	fBaseAmount, err := strconv.ParseFloat(hpt.BaseAmount, 64)
+	baseAmount, err := amount.ParseInt64(hpt.BaseAmount)
 	if err != nil {
 		return
 	}
-	fCounterAmount, err := strconv.ParseFloat(hpt.CounterAmount, 64)
+	counterAmount, err := amount.ParseInt64(hpt.CounterAmount)
 	if err != nil {
 		return
 	}
 
+	if hpt.Price.N == 0 || hpt.Price.D == 0 {
+		err = fmt.Errorf("invalid price %d/%d", hpt.Price.N, hpt.Price.D)
+		return
+	}
 	rPrice := big.NewRat(hpt.Price.D, hpt.Price.N)
-	fPrice, _ := rPrice.Float64()
+
+	tradeType := hpt.TradeType
+	if tradeType == "" {
+		tradeType = tickerdb.TradeTypeOrderbook
+	}
+	poolID := hpt.BaseLiquidityPoolID
+	if poolID == "" {
+		poolID = hpt.CounterLiquidityPoolID
+	}
 
 	trade = tickerdb.Trade{
-		HorizonID:       hpt.ID,
-		LedgerCloseTime: hpt.LedgerCloseTime,
-		OfferID:         hpt.OfferID,
-		BaseOfferID:     hpt.BaseOfferID,
-		BaseAccount:     hpt.BaseAccount,
-		BaseAmount:      fBaseAmount,
-		BaseAssetID:     baseAssetID,
-		CounterOfferID:  hpt.CounterOfferID,
-		CounterAccount:  hpt.CounterAccount,
-		CounterAmount:   fCounterAmount,
-		CounterAssetID:  counterAssetID,
-		BaseIsSeller:    hpt.BaseIsSeller,
-		Price:           fPrice,
+		HorizonID:          hpt.ID,
+		LedgerCloseTime:    hpt.LedgerCloseTime,
+		OfferID:            hpt.OfferID,
+		BaseOfferID:        hpt.BaseOfferID,
+		BaseAccount:        hpt.BaseAccount,
+		BaseAmount:         amount.StringFromInt64(baseAmount),
+		BaseAssetID:        baseAssetID,
+		CounterOfferID:     hpt.CounterOfferID,
+		CounterAccount:     hpt.CounterAccount,
+		CounterAmount:      amount.StringFromInt64(counterAmount),
+		CounterAssetID:     counterAssetID,
+		BaseIsSeller:       hpt.BaseIsSeller,
+		Price:              utils.RatString(rPrice, priceDecimals),
+		PriceN:             rPrice.Num().Int64(),
+		PriceD:             rPrice.Denom().Int64(),
+		TradeType:          tradeType,
+		LiquidityPoolID:    poolID,
+		LiquidityPoolFeeBP: int32(hpt.LiquidityPoolFeeBP),
 	}
 
 	return
@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHProtocolTradeToPendingTrade(t *testing.T) {
+	closeTime := time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC)
+	trade := hProtocol.Trade{
+		ID:                 "429496729601-0",
+		PT:                 "429496729601-0",
+		LedgerCloseTime:    closeTime,
+		BaseAmount:         "100.0000000",
+		BaseAssetType:      "native",
+		BaseAssetCode:      "XLM",
+		BaseAssetIssuer:    "native",
+		CounterAmount:      "10.0000000",
+		CounterAssetType:   "credit_alphanum4",
+		CounterAssetCode:   "BTC",
+		CounterAssetIssuer: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Price:              hProtocol.TradePrice{N: 1, D: 10},
+	}
+
+	pendingTrade, err := HProtocolTradeToPendingTrade(trade)
+	require.NoError(t, err)
+	assert.Equal(t, "429496729601-0", pendingTrade.HorizonID)
+	assert.Equal(t, closeTime, pendingTrade.LedgerCloseTime)
+	assert.Equal(t, "XLM", pendingTrade.BaseAssetCode)
+	assert.Equal(t, "native", pendingTrade.BaseAssetIssuer)
+	assert.Equal(t, "BTC", pendingTrade.CounterAssetCode)
+	assert.Equal(t, trade.CounterAssetIssuer, pendingTrade.CounterAssetIssuer)
+
+	// The original trade can be recovered in order to be replayed:
+	var decoded hProtocol.Trade
+	err = json.Unmarshal([]byte(pendingTrade.Trade), &decoded)
+	require.NoError(t, err)
+
+	dbTrade, err := HProtocolTradeToDBTrade(decoded, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "429496729601-0", dbTrade.HorizonID)
+	assert.True(t, closeTime.Equal(dbTrade.LedgerCloseTime))
+	assert.Equal(t, "100.0000000", dbTrade.BaseAmount)
+	assert.Equal(t, "10.0000000", dbTrade.CounterAmount)
+	assert.Equal(t, "10", dbTrade.Price)
+}
+
+func TestHProtocolTradeToDBTrade_liquidityPool(t *testing.T) {
+	trade := hProtocol.Trade{
+		ID:                  "429496729601-1",
+		LedgerCloseTime:     time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC),
+		TradeType:           "liquidity_pool",
+		LiquidityPoolFeeBP:  30,
+		BaseLiquidityPoolID: "abcdef",
+		BaseAmount:          "100.0000000",
+		CounterAccount:      "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		CounterAmount:       "10.0000000",
+		Price:               hProtocol.TradePrice{N: 1, D: 10},
+	}
+
+	dbTrade, err := HProtocolTradeToDBTrade(trade, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "liquidity_pool", dbTrade.TradeType)
+	assert.Equal(t, "abcdef", dbTrade.LiquidityPoolID)
+	assert.Equal(t, int32(30), dbTrade.LiquidityPoolFeeBP)
+
+	trade.TradeType = ""
+	trade.BaseLiquidityPoolID = ""
+	trade.LiquidityPoolFeeBP = 0
+	dbTrade, err = HProtocolTradeToDBTrade(trade, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "orderbook", dbTrade.TradeType)
+	assert.Equal(t, "", dbTrade.LiquidityPoolID)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	horizonclient "github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/clients/stellartoml"
 	hProtocol "github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/ticker/internal/utils"
 	hlog "github.com/stellar/go/support/log"
@@ -15,8 +16,29 @@ type ScraperConfig struct {
 	Client horizonclient.ClientInterface
 	Logger *hlog.Entry
 	Ctx    *context.Context
+
+	// SlippageAmount is the amount of the base asset used to calculate the
+	// slippage of orderbooks (DefaultSlippageAmount if zero).
+	SlippageAmount float64
+
+	// Settings tunes the requests to Horizon and the asset discard rules
+	// (DefaultSettings if nil).
+	Settings *Settings
+
+	// OnEvaluated, if set, is called with the decision of the asset policy
+	// about every asset processed. It may be called concurrently.
+	OnEvaluated func(asset PolicyAsset, result PolicyResult)
+
+	// TOMLCache caches the TOML files of the assets, and is shared by the
+	// goroutines validating them (a new in-memory cache for each refresh if
+	// nil).
+	TOMLCache *TOMLCache
 }
 
+// DefaultSlippageAmount is the default amount of the base asset used to
+// calculate the slippage of orderbooks.
+const DefaultSlippageAmount = 1000.0
+
 // TOMLDoc is the interface for storing TOML Issuer Documentation.
 // See: https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0001.md#currency-documentation
 type TOMLDoc struct {
@@ -58,6 +80,10 @@ type TOMLIssuer struct {
 	Documentation    TOMLDoc        `toml:"DOCUMENTATION"`
 	Currencies       []TOMLCurrency `toml:"CURRENCIES"`
 	TOMLURL          string         `toml:"-"`
+
+	// SEP1 is the same file decoded by clients/stellartoml, which is
+	// verified against SEP-1 (nil if it couldn't be decoded).
+	SEP1 *stellartoml.Response `toml:"-"`
 }
 
 // FinalAsset is the interface to represent the aggregated Asset data.
@@ -91,6 +117,42 @@ type FinalAsset struct {
 	CollateralAddressSignatures []string   `json:"collateral_address_signatures"`
 	Countries                   string     `json:"countries"`
 	Status                      string     `json:"status"`
+
+	// ContractID is the ID of the Stellar Asset Contract of the asset (or of
+	// the contract of a Soroban token), while NumContracts and
+	// ContractsAmount are the number of contracts holding the asset and the
+	// amount they hold.
+	ContractID      string  `json:"contract_id"`
+	NumContracts    int32   `json:"num_contracts"`
+	ContractsAmount float64 `json:"contracts_amount"`
+
+	// TOMLFindings are the SEP-1 compliance issues of the asset's TOML
+	// file, when TOML files are validated.
+	TOMLFindings []TOMLFinding `json:"-"`
+
+	// Supply is the breakdown of the supply of the asset reported by
+	// Horizon.
+	Supply SupplyBreakdown `json:"-"`
+}
+
+// SupplyBreakdown splits the supply of an asset by where it's held: in
+// accounts (by authorization status), claimable balances, liquidity pools and
+// contracts (archived or not), along with the number of holders of each kind.
+type SupplyBreakdown struct {
+	NumAccounts                        int32
+	NumAuthorizedToMaintainLiabilities int32
+	NumUnauthorized                    int32
+	NumClaimableBalances               int32
+	NumLiquidityPools                  int32
+	NumContracts                       int32
+
+	Authorized                      string
+	AuthorizedToMaintainLiabilities string
+	Unauthorized                    string
+	ClaimableBalances               string
+	LiquidityPools                  string
+	Contracts                       string
+	ArchivedContracts               string
 }
 
 // OrderbookStats represents the Orderbook stats for a given asset
@@ -109,6 +171,43 @@ type OrderbookStats struct {
 	LowestAsk          float64
 	Spread             float64
 	SpreadMidPoint     float64
+
+	// Bid and ask volumes (in units of the counter asset) within 2%, 5% and
+	// 10% of the mid price.
+	BidDepth2Pct  float64
+	BidDepth5Pct  float64
+	BidDepth10Pct float64
+	AskDepth2Pct  float64
+	AskDepth5Pct  float64
+	AskDepth10Pct float64
+
+	// BuySlippage and SellSlippage are the percentages by which the average
+	// price of buying or selling SlippageAmount units of the base asset
+	// deviates from the mid price (0 if the orderbook can't fill them).
+	SlippageAmount float64
+	BuySlippage    float64
+	SellSlippage   float64
+}
+
+// LiquidityPoolStats represents the stats of a liquidity pool, with its assets
+// ordered like the base and counter assets of trades. The implied Price is in
+// units of the base asset (like trade prices), and the TVL in units of the
+// counter asset.
+type LiquidityPoolStats struct {
+	PoolID             string
+	FeeBP              uint32
+	TotalTrustlines    uint64
+	TotalShares        float64
+	BaseAssetType      string
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetType   string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	BaseReserve        float64
+	CounterReserve     float64
+	Price              float64
+	TVL                float64
 }
 
 // ProcessAllAssets fetches assets from the Horizon public net. If limit = 0, will fetch all assets.
@@ -147,6 +246,13 @@ func (c *ScraperConfig) ProcessSpecificAssets(limit int, issuer string, parallel
 	return
 }
 
+// ExplainAsset fetches the asset with the given code and issuer from Horizon
+// and evaluates it against the asset policy, fetching its TOML file if needed.
+func (c *ScraperConfig) ExplainAsset(code, issuer string) (PolicyAsset, PolicyResult, error) {
+	c.Logger.Infof("Evaluating asset %s:%s\n", code, issuer)
+	return c.explainAsset(code, issuer)
+}
+
 // FetchAllTrades fetches all trades for a given period, respecting the limit. If limit = 0,
 // will fetch all trades for that given period.
 func (c *ScraperConfig) FetchAllTrades(
@@ -166,6 +272,29 @@ func (c *ScraperConfig) FetchAllTrades(
 	return
 }
 
+// IngestTradesFromCursor ingests all trades after cursor (and not older than since),
+// respecting the limit, advancing the cursor of the given job as pages are persisted.
+// If limit = 0, will ingest all trades up to the most recent one.
+func (c *ScraperConfig) IngestTradesFromCursor(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	cursor string,
+	job string,
+	since time.Time,
+	limit int) (numTrades int, err error) {
+	c.Logger.Info("Ingesting trades from Horizon with cursor at:", cursor)
+
+	numTrades, err = c.ingestTradesFromCursor(ctx, s, cursor, job, since, limit)
+
+	c.Logger.Infof("Ingested: %d trades\n", numTrades)
+	return
+}
+
+// FetchLatestCursor fetches the paging token of the most recent trade on Horizon.
+func (c *ScraperConfig) FetchLatestCursor() (string, error) {
+	return c.retrieveLatestCursor()
+}
+
 // FetchFilteredTrades fetches all trades filtered by issuer for a given period, respecting the limit. If limit = 0,
 // will fetch all trades for that given period.
 func (c *ScraperConfig) FetchFilteredTrades(since time.Time, limit int, issuer string) (trades []hProtocol.Trade, err error) {
@@ -193,6 +322,32 @@ func (c *ScraperConfig) FetchOrderbookForAssets(bType, bCode, bIssuer, cType, cC
 	return c.fetchOrderbook(bType, bCode, bIssuer, cType, cCode, cIssuer)
 }
 
+// FetchOrderbookSummary fetches the bids and asks of the orderbook for the base and counter assets provided in the parameters
+func (c *ScraperConfig) FetchOrderbookSummary(bType, bCode, bIssuer, cType, cCode, cIssuer string) (hProtocol.OrderBookSummary, error) {
+	c.Logger.Infof("Fetching orderbook for %s:%s / %s:%s\n", bCode, bIssuer, cCode, cIssuer)
+	return c.fetchOrderbookSummary(bType, bCode, bIssuer, cType, cCode, cIssuer)
+}
+
+// FetchLiquidityPools fetches the stats of every liquidity pool. Pools whose
+// stats can't be calculated are skipped.
+func (c *ScraperConfig) FetchLiquidityPools() (stats []LiquidityPoolStats, err error) {
+	pools, err := c.retrieveLiquidityPools()
+	if err != nil {
+		return
+	}
+
+	for _, pool := range pools {
+		poolStats, pErr := calcLiquidityPoolStats(pool)
+		if pErr != nil {
+			c.Logger.Error("could not calculate liquidity pool stats:", pErr)
+			continue
+		}
+		stats = append(stats, poolStats)
+	}
+	c.Logger.Infof("Fetched: %d liquidity pools\n", len(stats))
+	return
+}
+
 // NormalizeTradeAssets enforces the following rules:
 // 1. native asset type refers to a "XLM" code and a "native" issuer
 // 2. native is always the base asset (and if not, base and counter are swapped)
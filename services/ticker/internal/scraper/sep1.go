@@ -0,0 +1,153 @@
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/stellar/go/clients/stellartoml"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/strkey"
+)
+
+// Severities of the SEP-1 findings. Errors prevent the domain of an asset
+// from being verified, while warnings are only reported.
+const (
+	FindingError   = "error"
+	FindingWarning = "warning"
+)
+
+// TOMLFinding is a SEP-1 compliance issue found while verifying the
+// stellar.toml file of an asset.
+// See: https://github.com/stellar/stellar-protocol/blob/master/ecosystem/sep-0001.md
+type TOMLFinding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// validAnchorAssetTypes are the values of anchor_asset_type allowed by SEP-1.
+var validAnchorAssetTypes = map[string]bool{
+	"fiat":       true,
+	"crypto":     true,
+	"nft":        true,
+	"stock":      true,
+	"bond":       true,
+	"commodity":  true,
+	"realestate": true,
+	"other":      true,
+}
+
+// validCurrencyStatuses are the values of status allowed by SEP-1.
+var validCurrencyStatuses = map[string]bool{
+	"live":    true,
+	"dead":    true,
+	"test":    true,
+	"private": true,
+}
+
+// verifySEP1 checks the stellar.toml file of asset against SEP-1: it must be
+// served over HTTPS by the home domain of the issuing account, have a valid
+// SIGNING_KEY if any, and list the asset in its CURRENCIES. Problems found
+// while fetching the file are given as fetchErrors.
+func verifySEP1(asset hProtocol.AssetStat, homeDomain string, issuer TOMLIssuer, fetchErrors []error) (findings []TOMLFinding) {
+	add := func(check, severity, format string, args ...interface{}) {
+		findings = append(findings, TOMLFinding{
+			Check:    check,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	tomlURL := asset.Links.Toml.Href
+	parsedURL, err := url.Parse(tomlURL)
+	if tomlURL == "" || err != nil {
+		add("toml_url", FindingError, "the asset has no valid TOML URL")
+		return
+	}
+	if parsedURL.Scheme != "https" {
+		add("https", FindingError, "%s isn't served over HTTPS", tomlURL)
+	}
+
+	switch {
+	case homeDomain == "":
+		add("home_domain", FindingError, "the issuing account has no home_domain")
+	case !strings.EqualFold(parsedURL.Host, homeDomain) || parsedURL.Path != stellartoml.WellKnownPath:
+		add("home_domain", FindingError, "%s isn't the stellar.toml file of the home_domain %s", tomlURL, homeDomain)
+	}
+
+	for _, err := range fetchErrors {
+		add("fetch", FindingError, "%v", err)
+	}
+	doc := issuer.SEP1
+	if doc == nil {
+		if len(fetchErrors) == 0 {
+			add("fetch", FindingError, "the stellar.toml file couldn't be retrieved")
+		}
+		return
+	}
+
+	if doc.SigningKey == "" {
+		add("signing_key", FindingWarning, "SIGNING_KEY is missing")
+	} else if !strkey.IsValidEd25519PublicKey(doc.SigningKey) {
+		add("signing_key", FindingError, "SIGNING_KEY %q isn't a valid public key", doc.SigningKey)
+	}
+
+	if len(doc.Accounts) > 0 && !containsString(doc.Accounts, asset.Asset.Issuer) {
+		add("accounts", FindingWarning, "ACCOUNTS doesn't list the issuing account")
+	}
+
+	var currency *stellartoml.Currency
+	for i := range doc.Currencies {
+		c := &doc.Currencies[i]
+		if c.Issuer != "" && !strkey.IsValidEd25519PublicKey(c.Issuer) {
+			add("currencies", FindingWarning, "CURRENCIES lists %s with an invalid issuer %q", c.Code, c.Issuer)
+		}
+		if c.Code == asset.Asset.Code && c.Issuer == asset.Asset.Issuer {
+			currency = c
+		}
+	}
+	if currency == nil {
+		add("currency", FindingError, "CURRENCIES doesn't list %s:%s", asset.Asset.Code, asset.Asset.Issuer)
+		return
+	}
+
+	if currency.Status != "" && !validCurrencyStatuses[currency.Status] {
+		add("currency_status", FindingWarning, "status %q isn't one of live, dead, test or private", currency.Status)
+	} else if currency.Status != "" && currency.Status != "live" {
+		add("currency_status", FindingWarning, "the asset's status is %q", currency.Status)
+	}
+	if currency.DisplayDecimals < 0 || currency.DisplayDecimals > 7 {
+		add("display_decimals", FindingWarning, "display_decimals %d isn't between 0 and 7", currency.DisplayDecimals)
+	}
+	for _, c := range issuer.Currencies {
+		if c.Code == currency.Code && c.Issuer == currency.Issuer {
+			if c.IsAssetAnchored && c.AnchorAssetType == "" {
+				add("anchor_asset_type", FindingWarning, "anchor_asset_type is missing for an anchored asset")
+			} else if c.AnchorAssetType != "" && !validAnchorAssetTypes[strings.ToLower(c.AnchorAssetType)] {
+				add("anchor_asset_type", FindingWarning, "anchor_asset_type %q isn't valid", c.AnchorAssetType)
+			}
+			break
+		}
+	}
+	return
+}
+
+// hasFindingErrors tells whether findings include errors.
+func hasFindingErrors(findings []TOMLFinding) bool {
+	for _, f := range findings {
+		if f.Severity == FindingError {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package scraper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/clients/stellartoml"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/support/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sep1Issuer = "GCSAZVWXZKWS4XS223M5F54H2B6XPIIXZZGP7KEAIU6YSL5HDRGCI3DG"
+
+func sep1Asset(tomlURL string) hProtocol.AssetStat {
+	asset := hProtocol.AssetStat{Amount: "100", NumAccounts: 20}
+	asset.Asset.Code = "USD"
+	asset.Asset.Issuer = sep1Issuer
+	asset.Links.Toml.Href = tomlURL
+	return asset
+}
+
+func sep1Checks(findings []TOMLFinding) (checks []string) {
+	for _, f := range findings {
+		checks = append(checks, f.Severity+":"+f.Check)
+	}
+	return
+}
+
+func TestVerifySEP1(t *testing.T) {
+	issuer, err := decodeTOMLIssuer(`
+SIGNING_KEY = "` + sep1Issuer + `"
+ACCOUNTS = ["` + sep1Issuer + `"]
+
+[[CURRENCIES]]
+code = "USD"
+issuer = "` + sep1Issuer + `"
+display_decimals = 2
+is_asset_anchored = true
+anchor_asset_type = "fiat"
+status = "live"
+`)
+	require.NoError(t, err)
+
+	asset := sep1Asset("https://example.com/.well-known/stellar.toml")
+	findings := verifySEP1(asset, "example.com", issuer, nil)
+	assert.Empty(t, findings)
+	assert.False(t, hasFindingErrors(findings))
+
+	// the TOML file must be served over HTTPS by the home domain:
+	findings = verifySEP1(sep1Asset("http://example.com/.well-known/stellar.toml"), "example.com", issuer, nil)
+	assert.Equal(t, []string{"error:https"}, sep1Checks(findings))
+	findings = verifySEP1(asset, "other.com", issuer, nil)
+	assert.Equal(t, []string{"error:home_domain"}, sep1Checks(findings))
+	findings = verifySEP1(asset, "", issuer, nil)
+	assert.Equal(t, []string{"error:home_domain"}, sep1Checks(findings))
+	findings = verifySEP1(sep1Asset("https://example.com/stellar.toml"), "example.com", issuer, nil)
+	assert.Equal(t, []string{"error:home_domain"}, sep1Checks(findings))
+
+	// the asset must be listed in CURRENCIES:
+	other := sep1Asset("https://example.com/.well-known/stellar.toml")
+	other.Asset.Code = "EUR"
+	findings = verifySEP1(other, "example.com", issuer, nil)
+	assert.Equal(t, []string{"error:currency"}, sep1Checks(findings))
+	assert.True(t, hasFindingErrors(findings))
+
+	findings = verifySEP1(asset, "example.com", TOMLIssuer{}, []error{errors.New("timeout")})
+	assert.Equal(t, []TOMLFinding{{Check: "fetch", Severity: FindingError, Message: "timeout"}}, findings)
+}
+
+func TestVerifySEP1Warnings(t *testing.T) {
+	issuer, err := decodeTOMLIssuer(`
+SIGNING_KEY = "GINVALID"
+ACCOUNTS = ["GBNZILSTVQZ4R7IKQDGHYGY2QXL5QOFJYQMXPKWRRM5PAV7Y4M67AQUA"]
+
+[[CURRENCIES]]
+code = "USD"
+issuer = "` + sep1Issuer + `"
+display_decimals = 9
+is_asset_anchored = true
+status = "test"
+`)
+	require.NoError(t, err)
+
+	findings := verifySEP1(sep1Asset("https://example.com/.well-known/stellar.toml"), "example.com", issuer, nil)
+	assert.Equal(t, []string{
+		"error:signing_key",
+		"warning:accounts",
+		"warning:currency_status",
+		"warning:display_decimals",
+		"warning:anchor_asset_type",
+	}, sep1Checks(findings))
+}
+
+func TestFetchTOMLDataLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/large":
+			fmt.Fprint(w, strings.Repeat("#", stellartoml.StellarTomlMaxSize+1))
+		default:
+			fmt.Fprint(w, `SIGNING_KEY="key"`)
+		}
+	}))
+	defer server.Close()
+
+	resp, err := fetchTOMLData(server.URL+"/.well-known/stellar.toml", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, `SIGNING_KEY="key"`, resp.Body)
+
+	resp, err = fetchTOMLData(server.URL+"/missing", "", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.EqualError(t, err, "stellar.toml request failed with status code 404")
+
+	_, err = fetchTOMLData(server.URL+"/large", "", "")
+	assert.EqualError(t, err, fmt.Sprintf("stellar.toml response exceeds %d bytes limit", stellartoml.StellarTomlMaxSize))
+}
@@ -0,0 +1,144 @@
+package scraper
+
+import (
+	"context"
+	"time"
+
+	horizonclient "github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/services/ticker/internal/utils"
+)
+
+// Settings tunes the requests made to Horizon and the asset discard rules.
+type Settings struct {
+	// PageSize is the number of records requested per page of assets, trades
+	// and liquidity pools (200 at most).
+	PageSize int
+
+	// Retries is the number of attempts of each request to Horizon, the first
+	// retry happening after RetryDelay (doubled on each subsequent retry).
+	Retries    int
+	RetryDelay time.Duration
+
+	// Parallelism is the number of goroutines validating the assets (and
+	// fetching their TOML files).
+	Parallelism int
+
+	// TOMLCacheTTL is the longest time a TOML file is cached before being
+	// requested again (see TOMLCache).
+	TOMLCacheTTL time.Duration
+
+	Discard DiscardRules
+
+	// Policy decides which assets are indexed (NewAssetPolicy(Discard) if
+	// nil).
+	Policy AssetPolicy
+}
+
+// DiscardRules are the criteria for discarding an asset from the asset index
+// (see NewAssetPolicy).
+type DiscardRules struct {
+	// MinSupply is the supply an asset needs to be indexed (which must be
+	// positive in any case).
+	MinSupply float64
+
+	// MinAccounts is the number of accounts an asset needs to be indexed.
+	MinAccounts int32
+
+	// TrustedAccounts is the number of accounts from which an asset is
+	// indexed even if its TOML file can't be validated.
+	TrustedAccounts int32
+
+	// DiscardedCodes are asset codes that are never indexed.
+	DiscardedCodes []string
+
+	// RequireHTTPSTOML discards the assets whose TOML file isn't hosted on
+	// HTTPS (when TOML files are validated).
+	RequireHTTPSTOML bool
+
+	// SkipTOMLValidation disables the validation of TOML files, which is
+	// always skipped on TestNet.
+	SkipTOMLValidation bool
+
+	// TrustedIssuers are the issuers whose assets are indexed regardless of
+	// their number of accounts and TOML file, as long as they have a supply.
+	TrustedIssuers map[string]bool
+
+	// AllowList and DenyList are CODE:ISSUER or ISSUER entries (see
+	// ParseAssetListEntry). Allowed assets are indexed like the ones of
+	// trusted issuers, while denied assets are never indexed.
+	AllowList []string
+	DenyList  []string
+
+	// RequireDomainVerification discards the assets which aren't listed as
+	// currencies on the TOML file of their domain (when TOML files are
+	// validated).
+	RequireDomainVerification bool
+}
+
+// DefaultSettings returns the settings used when none are configured.
+func DefaultSettings() Settings {
+	return Settings{
+		PageSize:     200,
+		Retries:      5,
+		RetryDelay:   5 * time.Second,
+		Parallelism:  20,
+		TOMLCacheTTL: DefaultTOMLCacheTTL,
+		Discard: DiscardRules{
+			MinAccounts:      10,
+			TrustedAccounts:  100,
+			DiscardedCodes:   []string{"REMOVE"},
+			RequireHTTPSTOML: true,
+		},
+	}
+}
+
+// settings returns the settings of c, or the default ones if unset.
+func (c *ScraperConfig) settings() Settings {
+	if c.Settings == nil {
+		return DefaultSettings()
+	}
+	return *c.Settings
+}
+
+// pageSize returns the number of records to request per page.
+func (c *ScraperConfig) pageSize() uint {
+	return uint(c.settings().PageSize)
+}
+
+// shouldValidateTOML tells whether the TOML files of assets are validated,
+// which they never are on TestNet.
+func (c *ScraperConfig) shouldValidateTOML() bool {
+	return c.Client != horizonclient.DefaultTestNetClient && !c.settings().Discard.SkipTOMLValidation
+}
+
+// assetPolicy returns the policy deciding which assets are indexed.
+func (c *ScraperConfig) assetPolicy() AssetPolicy {
+	s := c.settings()
+	if s.Policy != nil {
+		return s.Policy
+	}
+	return NewAssetPolicy(s.Discard, c.shouldValidateTOML())
+}
+
+// tomlCache returns the cache of the TOML files, which is kept in memory
+// only if unset.
+func (c *ScraperConfig) tomlCache() *TOMLCache {
+	if c.TOMLCache != nil {
+		return c.TOMLCache
+	}
+	return NewTOMLCache(c.settings().TOMLCacheTTL, nil)
+}
+
+// context returns the context of c, or an empty one if unset.
+func (c *ScraperConfig) context() context.Context {
+	if c.Ctx == nil {
+		return context.Background()
+	}
+	return *c.Ctx
+}
+
+// retry runs f with the retry budget of c.
+func (c *ScraperConfig) retry(f func() error) error {
+	s := c.settings()
+	return utils.Retry(s.Retries, s.RetryDelay, c.Logger, f)
+}
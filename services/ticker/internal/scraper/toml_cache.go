@@ -1,27 +1,244 @@
 package scraper
 
-// TOMLCache caches a max of one toml at a time. Old records are discarded when
-// new records are added.
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/services/ticker/internal/metrics"
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/errors"
+	hlog "github.com/stellar/go/support/log"
+)
+
+// DefaultTOMLCacheTTL is the default longest time a TOML file is cached
+// before being requested again.
+const DefaultTOMLCacheTTL = 6 * time.Hour
+
+// tomlErrorTTL is the longest time a failure to retrieve a TOML file is
+// cached, so that the assets sharing a broken TOML file don't all request it
+// again.
+const tomlErrorTTL = 5 * time.Minute
+
+// tomlMinLifetime is the shortest time a TOML file is cached (unless the TTL
+// is shorter), even if its headers forbid caching it, so that the assets
+// sharing it don't all request it during a refresh.
+const tomlMinLifetime = time.Minute
+
+// TOMLStore persists the TOML files cached by a TOMLCache (see
+// tickerdb.TickerSession). GetTOMLCacheEntry fails if the file of url was
+// never cached.
+type TOMLStore interface {
+	GetTOMLCacheEntry(ctx context.Context, url string) (tickerdb.TOMLCacheEntry, error)
+	SaveTOMLCacheEntry(ctx context.Context, entry tickerdb.TOMLCacheEntry, changed bool) error
+}
+
+// TOMLCache caches the TOML files of the assets, and is safe for concurrent
+// use. Each file is requested at most once at a time, and then kept for the
+// TTL (or for the shorter lifetime given by its Cache-Control or Expires
+// headers), after which it's revalidated with a conditional request using its
+// ETag and Last-Modified headers. If a store is given, the cached files are
+// persisted, so that they survive across refreshes.
 type TOMLCache struct {
-	set        bool
-	tomlURL    string
-	tomlIssuer TOMLIssuer
+	ttl   time.Duration
+	store TOMLStore
+
+	mu      sync.Mutex
+	entries map[string]*tomlCacheEntry
+}
+
+// tomlCacheEntry is the cached TOML file of a URL. Its mutex is held while
+// the file is requested.
+type tomlCacheEntry struct {
+	mu     sync.Mutex
+	loaded bool
+	entry  tickerdb.TOMLCacheEntry
+	issuer TOMLIssuer
+	errs   []error
+}
+
+// NewTOMLCache creates a TOMLCache keeping files for ttl, persisted in store
+// (which can be nil).
+func NewTOMLCache(ttl time.Duration, store TOMLStore) *TOMLCache {
+	return &TOMLCache{
+		ttl:     ttl,
+		store:   store,
+		entries: make(map[string]*tomlCacheEntry),
+	}
+}
+
+// Get returns the TOML file at tomlURL, requesting it if it isn't cached or
+// has expired, along with the errors met while retrieving or decoding it.
+// Failures to retrieve a file are cached as well, for tomlErrorTTL at most.
+func (c *TOMLCache) Get(ctx context.Context, logger *hlog.Entry, tomlURL string) (TOMLIssuer, []error) {
+	if tomlURL == "" {
+		return TOMLIssuer{}, []error{errors.New("Asset does not have a TOML URL")}
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[tomlURL]
+	if !ok {
+		e = &tomlCacheEntry{}
+		c.entries[tomlURL] = e
+	}
+	c.mu.Unlock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.loaded && c.store != nil {
+		// files missing from the store are requested as if never cached
+		if entry, err := c.store.GetTOMLCacheEntry(ctx, tomlURL); err == nil {
+			e.set(entry)
+		}
+	}
+	if e.loaded && time.Now().Before(e.entry.ExpiresAt) {
+		logger.Debug("Using cached TOML for asset")
+		metrics.TOMLRequests.WithLabelValues("cached").Inc()
+		return e.issuer, e.errs
+	}
+
+	logger.Debug("Fetching TOML for asset")
+	entry, changed := c.refresh(tomlURL, e.entry)
+	e.set(entry)
+	if c.store != nil {
+		if err := c.store.SaveTOMLCacheEntry(ctx, entry, changed); err != nil {
+			// the file stays cached in memory
+			logger.Error("Could not store the TOML file:", err)
+		}
+	}
+	return e.issuer, e.errs
+}
+
+// refresh requests the file of tomlURL, conditionally if a version of it is
+// cached in entry, and returns the updated entry. changed tells whether a new
+// version of the file was retrieved.
+func (c *TOMLCache) refresh(tomlURL string, entry tickerdb.TOMLCacheEntry) (tickerdb.TOMLCacheEntry, bool) {
+	now := time.Now()
+	entry.URL = tomlURL
+	entry.CheckedAt = now
+
+	resp, err := fetchTOMLData(tomlURL, entry.ETag, entry.LastModified)
+	switch {
+	case err != nil:
+		metrics.TOMLRequests.WithLabelValues("error").Inc()
+		// the previous version of the file (if any) is kept, but not used
+		entry.StatusCode = int32(resp.StatusCode)
+		entry.LastError = err.Error()
+		entry.ExpiresAt = now.Add(minDuration(c.ttl, tomlErrorTTL))
+		return entry, false
+	case resp.StatusCode == http.StatusNotModified:
+		metrics.TOMLRequests.WithLabelValues("not_modified").Inc()
+		entry.ETag, entry.LastModified = resp.validators(entry.ETag, entry.LastModified)
+	default:
+		metrics.TOMLRequests.WithLabelValues("fetched").Inc()
+		entry.ETag, entry.LastModified = resp.ETag, resp.LastModified
+	}
+
+	changed := resp.StatusCode != http.StatusNotModified &&
+		(entry.FetchedAt.IsZero() || resp.Body != entry.Body)
+	if resp.StatusCode != http.StatusNotModified {
+		entry.Body = resp.Body
+	}
+	entry.StatusCode = int32(resp.StatusCode)
+	entry.LastError = ""
+	entry.FetchedAt = now
+	entry.ExpiresAt = now.Add(resp.lifetime(c.ttl))
+	return entry, changed
 }
 
-// Get returns the toml for the given URL if it is cached. Ok is true only if
-// the toml returned was found in the cache.
-func (c TOMLCache) Get(tomlURL string) (tomlIssuer TOMLIssuer, ok bool) {
-	if c.set && c.tomlURL == tomlURL {
-		tomlIssuer = c.tomlIssuer
-		ok = true
+// set caches entry, decoding its body.
+func (e *tomlCacheEntry) set(entry tickerdb.TOMLCacheEntry) {
+	e.loaded = true
+	e.entry = entry
+	e.issuer = TOMLIssuer{}
+	e.errs = nil
+
+	if entry.LastError != "" {
+		e.errs = append(e.errs, errors.New(entry.LastError))
+		return
 	}
-	return
+
+	issuer, err := decodeTOMLIssuer(entry.Body)
+	if err != nil {
+		e.errs = append(e.errs, err)
+	}
+	e.issuer = issuer
+}
+
+// tomlResponse is the response to a request of a TOML file.
+type tomlResponse struct {
+	StatusCode   int
+	Body         string
+	ETag         string
+	LastModified string
+	CacheControl string
+	Expires      string
+	Date         string
 }
 
-// Set adds the given toml to the cache using the toml URL as its key for lookup
-// later.
-func (c *TOMLCache) Set(tomlURL string, tomlIssuer TOMLIssuer) {
-	c.set = true
-	c.tomlURL = tomlURL
-	c.tomlIssuer = tomlIssuer
+// validators returns the ETag and Last-Modified headers of r, or the given
+// ones if r doesn't include them (e.g. in a 304 response).
+func (r tomlResponse) validators(etag, lastModified string) (string, string) {
+	if r.ETag != "" {
+		etag = r.ETag
+	}
+	if r.LastModified != "" {
+		lastModified = r.LastModified
+	}
+	return etag, lastModified
+}
+
+// lifetime returns how long the file of r can be cached: its max-age (or
+// the time until it expires), between tomlMinLifetime and ttl.
+func (r tomlResponse) lifetime(ttl time.Duration) time.Duration {
+	return minDuration(ttl, maxDuration(tomlMinLifetime, r.maxAge(ttl)))
+}
+
+// maxAge returns the lifetime given by the Cache-Control or Expires headers
+// of r, or ttl if there's none.
+func (r tomlResponse) maxAge(ttl time.Duration) time.Duration {
+	for _, directive := range strings.Split(r.CacheControl, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			return 0
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if r.Expires != "" {
+		expires, err := http.ParseTime(r.Expires)
+		if err != nil {
+			// invalid dates mean that the file has already expired
+			return 0
+		}
+		date, err := http.ParseTime(r.Date)
+		if err != nil {
+			date = time.Now()
+		}
+		return expires.Sub(date)
+	}
+	return ttl
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
 }
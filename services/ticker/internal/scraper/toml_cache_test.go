@@ -1,48 +1,171 @@
 package scraper
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stellar/go/services/ticker/internal/tickerdb"
+	"github.com/stellar/go/support/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// memTOMLStore is a TOMLStore keeping the entries in memory.
+type memTOMLStore struct {
+	mu      sync.Mutex
+	entries map[string]tickerdb.TOMLCacheEntry
+	history []string
+}
+
+func (s *memTOMLStore) GetTOMLCacheEntry(ctx context.Context, url string) (tickerdb.TOMLCacheEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[url]
+	if !ok {
+		return entry, sql.ErrNoRows
+	}
+	return entry, nil
+}
+
+func (s *memTOMLStore) SaveTOMLCacheEntry(ctx context.Context, entry tickerdb.TOMLCacheEntry, changed bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		s.entries = make(map[string]tickerdb.TOMLCacheEntry)
+	}
+	s.entries[entry.URL] = entry
+	if changed {
+		s.history = append(s.history, entry.Body)
+	}
+	return nil
+}
+
 func TestTOMLCache(t *testing.T) {
-	c := TOMLCache{}
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `SIGNING_KEY="signing key"`)
+	}))
+	defer server.Close()
 
-	toml, ok := c.Get("")
-	assert.False(t, ok)
-	assert.Zero(t, toml)
+	ctx := context.Background()
+	c := NewTOMLCache(time.Hour, nil)
 
-	c.Set("url", TOMLIssuer{SigningKey: "signing key"})
-	toml, ok = c.Get("")
-	assert.False(t, ok)
-	assert.Zero(t, toml)
-	toml, ok = c.Get("url")
-	assert.True(t, ok)
-	assert.Equal(t, TOMLIssuer{SigningKey: "signing key"}, toml)
-	toml, ok = c.Get("otherurl")
-	assert.False(t, ok)
-	assert.Zero(t, toml)
+	// Concurrent lookups of the same file request it once:
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			toml, errs := c.Get(ctx, log.DefaultLogger, server.URL)
+			assert.Empty(t, errs)
+			assert.Equal(t, "signing key", toml.SigningKey)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
 
-	c.Set("otherurl", TOMLIssuer{SigningKey: "other signing key"})
-	toml, ok = c.Get("")
-	assert.False(t, ok)
-	assert.Zero(t, toml)
-	toml, ok = c.Get("url")
-	assert.False(t, ok)
+	toml, errs := c.Get(ctx, log.DefaultLogger, "")
+	assert.Len(t, errs, 1)
 	assert.Zero(t, toml)
-	toml, ok = c.Get("otherurl")
-	assert.True(t, ok)
-	assert.Equal(t, TOMLIssuer{SigningKey: "other signing key"}, toml)
+}
 
-	c.Set("url", TOMLIssuer{SigningKey: "changed signing key"})
-	toml, ok = c.Get("")
-	assert.False(t, ok)
-	assert.Zero(t, toml)
-	toml, ok = c.Get("url")
-	assert.True(t, ok)
-	assert.Equal(t, TOMLIssuer{SigningKey: "changed signing key"}, toml)
-	toml, ok = c.Get("otherurl")
-	assert.False(t, ok)
-	assert.Zero(t, toml)
+func TestTOMLCacheConditionalRequests(t *testing.T) {
+	var requests, notModified int32
+	body := `SIGNING_KEY="first key"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` && body == `SIGNING_KEY="first key"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store := &memTOMLStore{}
+	c := NewTOMLCache(0, store)
+
+	toml, errs := c.Get(ctx, log.DefaultLogger, server.URL)
+	assert.Empty(t, errs)
+	assert.Equal(t, "first key", toml.SigningKey)
+
+	// With a TTL of zero, the file is revalidated with its ETag:
+	toml, errs = c.Get(ctx, log.DefaultLogger, server.URL)
+	assert.Empty(t, errs)
+	assert.Equal(t, "first key", toml.SigningKey)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+
+	entry := store.entries[server.URL]
+	assert.Equal(t, `"v1"`, entry.ETag)
+	assert.Equal(t, int32(http.StatusNotModified), entry.StatusCode)
+	assert.Equal(t, `SIGNING_KEY="first key"`, entry.Body)
+
+	// A new cache picks the file up from the store, and new versions are
+	// recorded in the history:
+	body = `SIGNING_KEY="second key"`
+	toml, errs = NewTOMLCache(0, store).Get(ctx, log.DefaultLogger, server.URL)
+	assert.Empty(t, errs)
+	assert.Equal(t, "second key", toml.SigningKey)
+	assert.Equal(t, []string{`SIGNING_KEY="first key"`, `SIGNING_KEY="second key"`}, store.history)
+}
+
+func TestTOMLCacheErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store := &memTOMLStore{}
+	c := NewTOMLCache(time.Hour, store)
+
+	for i := 0; i < 2; i++ {
+		_, errs := c.Get(ctx, log.DefaultLogger, server.URL)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "stellar.toml request failed with status code 500")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	entry := store.entries[server.URL]
+	assert.Equal(t, "stellar.toml request failed with status code 500", entry.LastError)
+	assert.Equal(t, int32(http.StatusInternalServerError), entry.StatusCode)
+	assert.WithinDuration(t, time.Now().Add(tomlErrorTTL), entry.ExpiresAt, time.Minute)
+	assert.Empty(t, store.history)
+}
+
+func TestTOMLResponseLifetime(t *testing.T) {
+	ttl := time.Hour
+	date := "Mon, 02 Jan 2006 15:04:05 GMT"
+	for _, tc := range []struct {
+		resp     tomlResponse
+		lifetime time.Duration
+	}{
+		{tomlResponse{}, ttl},
+		{tomlResponse{CacheControl: "public, max-age=600"}, 10 * time.Minute},
+		{tomlResponse{CacheControl: "max-age=86400"}, ttl},
+		{tomlResponse{CacheControl: "no-store"}, tomlMinLifetime},
+		{tomlResponse{CacheControl: "max-age=0"}, tomlMinLifetime},
+		{tomlResponse{Date: date, Expires: "Mon, 02 Jan 2006 15:34:05 GMT"}, 30 * time.Minute},
+		{tomlResponse{Date: date, Expires: "0"}, tomlMinLifetime},
+		{tomlResponse{CacheControl: "max-age=300", Date: date, Expires: "Mon, 02 Jan 2006 15:34:05 GMT"}, 5 * time.Minute},
+	} {
+		assert.Equal(t, tc.lifetime, tc.resp.lifetime(ttl), "%+v", tc.resp)
+	}
+
+	assert.Equal(t, time.Duration(0), tomlResponse{CacheControl: "max-age=600"}.lifetime(0))
 }
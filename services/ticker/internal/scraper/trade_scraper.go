@@ -6,8 +6,6 @@ import (
 	"github.com/stellar/go/support/errors"
 	"time"
 
-	"github.com/stellar/go/services/ticker/internal/utils"
-
 	horizonclient "github.com/stellar/go/clients/horizonclient"
 	hProtocol "github.com/stellar/go/protocols/horizon"
 	hlog "github.com/stellar/go/support/log"
@@ -38,7 +36,7 @@ func (c *ScraperConfig) retrieveTrades(
 	l *hlog.Entry,
 	since time.Time,
 	limit int) (trades []hProtocol.Trade, err error) {
-	r := horizonclient.TradeRequest{Limit: 200, Order: horizonclient.OrderDesc}
+	r := horizonclient.TradeRequest{Limit: c.pageSize(), Order: horizonclient.OrderDesc}
 
 	c.Logger.Info("Retrieving trades")
 	tradesPage, err := c.Client.Trades(r)
@@ -91,7 +89,7 @@ func (c *ScraperConfig) retrieveTrades(
 		}
 		r.Cursor = n
 
-		if err = utils.Retry(5, 5*time.Second, c.Logger, func() error {
+		if err = c.retry(func() error {
 			tradesPage, err = c.Client.Trades(r)
 			if err != nil {
 				c.Logger.Info("Horizon rate limit reached!")
@@ -106,9 +104,88 @@ func (c *ScraperConfig) retrieveTrades(
 	return
 }
 
+// ingestTradesFromCursor retrieves trades from the Horizon API in ascending
+// order, starting right after cursor, and persists each page along with the
+// paging token of its last trade as the cursor of the given ingestion job.
+// Trades older than since are skipped (but still advance the cursor). If
+// limit = 0, will ingest all trades up to the most recent one.
+func (c *ScraperConfig) ingestTradesFromCursor(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	cursor string,
+	job string,
+	since time.Time,
+	limit int,
+) (numTrades int, err error) {
+	r := horizonclient.TradeRequest{Limit: c.pageSize(), Order: horizonclient.OrderAsc, Cursor: cursor}
+
+	for {
+		var tradesPage hProtocol.TradesPage
+		err = c.retry(func() error {
+			var rErr error
+			tradesPage, rErr = c.Client.Trades(r)
+			if rErr != nil {
+				c.Logger.Info("Horizon rate limit reached!")
+			}
+			return rErr
+		})
+		if err != nil {
+			return
+		}
+
+		records := tradesPage.Embedded.Records
+		if len(records) == 0 {
+			return
+		}
+
+		var trades []hProtocol.Trade
+		for _, t := range records {
+			if t.LedgerCloseTime.After(since) {
+				NormalizeTradeAssets(&t)
+				trades = append(trades, t)
+			}
+		}
+
+		last := len(records) < int(r.Limit)
+		r.Cursor = records[len(records)-1].PT
+
+		// Enforcing limit of results:
+		if limit != 0 && numTrades+len(trades) >= limit {
+			trades = trades[0 : limit-numTrades]
+			r.Cursor = trades[len(trades)-1].PT
+			last = true
+		}
+
+		if err = PersistTradesAndCursor(ctx, s, c.Logger, trades, job, r.Cursor); err != nil {
+			err = errors.Wrap(err, "could not persist trades")
+			return
+		}
+		numTrades += len(trades)
+
+		if last {
+			return
+		}
+	}
+}
+
+// retrieveLatestCursor returns the paging token of the most recent trade
+// known to Horizon, or an empty string if there are no trades.
+func (c *ScraperConfig) retrieveLatestCursor() (cursor string, err error) {
+	r := horizonclient.TradeRequest{Limit: 1, Order: horizonclient.OrderDesc}
+	tradesPage, err := c.Client.Trades(r)
+	if err != nil {
+		return
+	}
+
+	if len(tradesPage.Embedded.Records) > 0 {
+		cursor = tradesPage.Embedded.Records[0].PT
+	}
+	return
+}
+
 // retrieveFilteredTrades retrieves trades by issuer from the Horizon API for the last timeDelta period.
 func (c *ScraperConfig) retrieveFilteredTrades(since time.Time, limit int, issuer string) (trades []hProtocol.Trade, err error) {
-	r := horizonclient.TradeRequest{Limit: 200, Order: horizonclient.OrderDesc, BaseAssetIssuer: issuer}
+	r := horizonclient.TradeRequest{Limit: c.pageSize(), Order: horizonclient.OrderDesc, BaseAssetIssuer: issuer}
 
 	c.Logger.Info("Retrieving trades")
 	tradesPage, err := c.Client.Trades(r)
@@ -145,7 +222,7 @@ func (c *ScraperConfig) retrieveFilteredTrades(since time.Time, limit int, issue
 		c.Logger.Debug("Cursor currently at:", n)
 		r.Cursor = n
 
-		err = utils.Retry(5, 5*time.Second, c.Logger, func() error {
+		err = c.retry(func() error {
 			tradesPage, err = c.Client.Trades(r)
 			if err != nil {
 				c.Logger.Info("Horizon rate limit reached!")
@@ -168,7 +245,7 @@ func (c *ScraperConfig) streamTrades(h horizonclient.TradeHandler, cursor string
 	}
 
 	r := horizonclient.TradeRequest{
-		Limit:  200,
+		Limit:  c.pageSize(),
 		Cursor: cursor,
 	}
 
@@ -192,6 +269,8 @@ func addNativeData(trade *hProtocol.Trade) {
 func reverseAssets(trade *hProtocol.Trade) {
 	trade.BaseAmount, trade.CounterAmount = trade.CounterAmount, trade.BaseAmount
 	trade.BaseAccount, trade.CounterAccount = trade.CounterAccount, trade.BaseAccount
+	trade.BaseOfferID, trade.CounterOfferID = trade.CounterOfferID, trade.BaseOfferID
+	trade.BaseLiquidityPoolID, trade.CounterLiquidityPoolID = trade.CounterLiquidityPoolID, trade.BaseLiquidityPoolID
 	trade.BaseAssetCode, trade.CounterAssetCode = trade.CounterAssetCode, trade.BaseAssetCode
 	trade.BaseAssetType, trade.CounterAssetType = trade.CounterAssetType, trade.BaseAssetType
 	trade.BaseAssetIssuer, trade.CounterAssetIssuer = trade.CounterAssetIssuer, trade.BaseAssetIssuer
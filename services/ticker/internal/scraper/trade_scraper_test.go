@@ -11,12 +11,14 @@ import (
 func TestReverseAssets(t *testing.T) {
 	baseAmount := "10.0"
 	baseAccount := "BASEACCOUNT"
+	baseOfferID := "1234"
 	baseAssetCode := "BASECODE"
 	baseAssetType := "BASEASSETTYPE"
 	baseAssetIssuer := "BASEASSETISSUER"
 
 	counterAmount := "5.0"
 	counterAccount := "COUNTERACCOUNT"
+	counterLiquidityPoolID := "COUNTERLIQUIDITYPOOLID"
 	counterAssetCode := "COUNTERASSETCODE"
 	counterAssetType := "COUNTERASSETTYPE"
 	counterAssetIssuer := "COUNTERASSETISSUER"
@@ -32,18 +34,20 @@ func TestReverseAssets(t *testing.T) {
 	}
 
 	trade1 := hProtocol.Trade{
-		BaseAmount:         baseAmount,
-		BaseAccount:        baseAccount,
-		BaseAssetCode:      baseAssetCode,
-		BaseAssetType:      baseAssetType,
-		BaseAssetIssuer:    baseAssetIssuer,
-		CounterAmount:      counterAmount,
-		CounterAccount:     counterAccount,
-		CounterAssetCode:   counterAssetCode,
-		CounterAssetType:   counterAssetType,
-		CounterAssetIssuer: counterAssetIssuer,
-		BaseIsSeller:       baseIsSeller,
-		Price:              price,
+		BaseAmount:             baseAmount,
+		BaseAccount:            baseAccount,
+		BaseOfferID:            baseOfferID,
+		BaseAssetCode:          baseAssetCode,
+		BaseAssetType:          baseAssetType,
+		BaseAssetIssuer:        baseAssetIssuer,
+		CounterAmount:          counterAmount,
+		CounterAccount:         counterAccount,
+		CounterLiquidityPoolID: counterLiquidityPoolID,
+		CounterAssetCode:       counterAssetCode,
+		CounterAssetType:       counterAssetType,
+		CounterAssetIssuer:     counterAssetIssuer,
+		BaseIsSeller:           baseIsSeller,
+		Price:                  price,
 	}
 
 	fmt.Println(trade1)
@@ -52,12 +56,16 @@ func TestReverseAssets(t *testing.T) {
 
 	assert.Equal(t, counterAmount, trade1.BaseAmount)
 	assert.Equal(t, counterAccount, trade1.BaseAccount)
+	assert.Equal(t, counterLiquidityPoolID, trade1.BaseLiquidityPoolID)
+	assert.Equal(t, "", trade1.BaseOfferID)
 	assert.Equal(t, counterAssetCode, trade1.BaseAssetCode)
 	assert.Equal(t, counterAssetType, trade1.BaseAssetType)
 	assert.Equal(t, counterAssetIssuer, trade1.BaseAssetIssuer)
 
 	assert.Equal(t, baseAmount, trade1.CounterAmount)
 	assert.Equal(t, baseAccount, trade1.CounterAccount)
+	assert.Equal(t, baseOfferID, trade1.CounterOfferID)
+	assert.Equal(t, "", trade1.CounterLiquidityPoolID)
 	assert.Equal(t, baseAssetCode, trade1.CounterAssetCode)
 	assert.Equal(t, baseAssetType, trade1.CounterAssetType)
 	assert.Equal(t, baseAssetIssuer, trade1.CounterAssetIssuer)
@@ -0,0 +1,27 @@
+package ticker
+
+import (
+	"sync/atomic"
+
+	"github.com/stellar/go/services/ticker/internal/scraper"
+)
+
+// settings holds the scraper settings used by the actions, which can be
+// replaced while they run (e.g. when the daemon reloads its configuration).
+var settings atomic.Pointer[scraper.Settings]
+
+// SetScraperSettings sets the settings of the scrapers used by the actions
+// started from now on.
+func SetScraperSettings(s scraper.Settings) {
+	settings.Store(&s)
+}
+
+// scraperSettings returns the current scraper settings, or the default ones
+// if they were never set.
+func scraperSettings() *scraper.Settings {
+	if s := settings.Load(); s != nil {
+		return s
+	}
+	s := scraper.DefaultSettings()
+	return &s
+}
@@ -121,6 +121,61 @@ func getBaseAndCounterCodes(pairName string) (string, string, error) {
 	return assets[0], assets[1], nil
 }
 
+// marketIDField is the SQL expression of the canonical ID of the market
+// between bAsset and cAsset, as built by MarketID.
+const marketIDField = "concat(bAsset.code, ':', bAsset.issuer_account, '/', cAsset.code, ':', cAsset.issuer_account)"
+
+// tradePairNameField is the SQL expression of the trade pair name of the
+// market between bAsset and cAsset (e.g. "XLM_BTC"), using the anchor asset
+// codes when they're set.
+const tradePairNameField = "concat(COALESCE(NULLIF(bAsset.anchor_asset_code, ''), bAsset.code), '_', COALESCE(NULLIF(cAsset.anchor_asset_code, ''), cAsset.code))"
+
+// MarketID returns the canonical, issuer-qualified ID of the market between a
+// base and a counter asset (e.g. "XLM:native/USD:GDUKMGUGDZQK6YH..."). Unlike
+// trade pair names (e.g. "XLM_USD"), it tells apart the markets of assets that
+// share the same code but have different issuers.
+func MarketID(bCode, bIssuer, cCode, cIssuer string) string {
+	return fmt.Sprintf("%s:%s/%s:%s", bCode, bIssuer, cCode, cIssuer)
+}
+
+// ParseMarketID parses a market ID built by MarketID into its base and counter
+// asset codes and issuers.
+func ParseMarketID(id string) (bCode, bIssuer, cCode, cIssuer string, err error) {
+	assets := strings.Split(id, "/")
+	if len(assets) != 2 {
+		err = errors.New("invalid market id")
+		return
+	}
+
+	base := strings.Split(assets[0], ":")
+	counter := strings.Split(assets[1], ":")
+	if len(base) != 2 || len(counter) != 2 || base[0] == "" || base[1] == "" || counter[0] == "" || counter[1] == "" {
+		err = errors.New("invalid market id")
+		return
+	}
+
+	return base[0], base[1], counter[0], counter[1], nil
+}
+
+// SplitMarketIDs splits a comma-separated list of market IDs, as returned in
+// the market_ids column of the market queries.
+func SplitMarketIDs(ids string) []string {
+	if ids == "" {
+		return []string{}
+	}
+	return strings.Split(ids, ",")
+}
+
+// marketIDSelector returns the SQL expression used to group markets: their
+// canonical ID, or an empty string when aggregating them by asset code (so
+// that markets are grouped by trade pair name only).
+func marketIDSelector(aggregateByCode bool) string {
+	if aggregateByCode {
+		return "''"
+	}
+	return marketIDField
+}
+
 // performUpsertQuery introspects a dbStruct interface{} and performs an insert query
 // (if the conflictConstraint isn't broken), otherwise it updates the instance on the
 // db, preserving the old values for the fields in preserveFields
@@ -127,3 +127,20 @@ func TestGetBaseAndCounterCodes(t *testing.T) {
 	_, _, err = getBaseAndCounterCodes("BTC")
 	require.Error(t, err)
 }
+
+func TestMarketID(t *testing.T) {
+	id := MarketID("XLM", "native", "USD", "GDUKMGUGDZQK6YHYA5Z6AY2G4XDSZPSZ3SW5UN3ARVMO6QSRDWP5YLEX")
+	assert.Equal(t, "XLM:native/USD:GDUKMGUGDZQK6YHYA5Z6AY2G4XDSZPSZ3SW5UN3ARVMO6QSRDWP5YLEX", id)
+
+	bCode, bIssuer, cCode, cIssuer, err := ParseMarketID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "XLM", bCode)
+	assert.Equal(t, "native", bIssuer)
+	assert.Equal(t, "USD", cCode)
+	assert.Equal(t, "GDUKMGUGDZQK6YHYA5Z6AY2G4XDSZPSZ3SW5UN3ARVMO6QSRDWP5YLEX", cIssuer)
+
+	for _, id := range []string{"", "XLM_USD", "XLM:native", "XLM:native/USD", "XLM:native/:G", "XLM:native/USD:G/BTC:G"} {
+		_, _, _, _, err = ParseMarketID(id)
+		assert.Error(t, err, id)
+	}
+}
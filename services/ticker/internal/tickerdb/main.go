@@ -1,6 +1,7 @@
 package tickerdb
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -48,6 +49,9 @@ type Asset struct {
 	Status                      string    `db:"status"`
 	IssuerID                    int32     `db:"issuer_id"`
 	Issuer                      Issuer    `db:"-"`
+	ContractID                  string    `db:"contract_id"`
+	NumContracts                int32     `db:"num_contracts"`
+	ContractsAmount             float64   `db:"contracts_amount"`
 }
 
 // Issuer represents an entry on the issuers table
@@ -65,22 +69,180 @@ type Issuer struct {
 	OrgTwitter       string `db:"org_twitter"`
 }
 
-// Trade represents an entry on the trades table
+// TradeTypeOrderbook and TradeTypeLiquidityPool identify the venue of a trade,
+// i.e. whether it filled an offer or traded against a liquidity pool (AMM).
+const (
+	TradeTypeOrderbook     = "orderbook"
+	TradeTypeLiquidityPool = "liquidity_pool"
+)
+
+// Trade represents an entry on the trades table. Amounts and the price are
+// exact decimal strings (stored as NUMERIC), and PriceN / PriceD hold the
+// exact price as a reduced rational, i.e. the inverse of the Horizon price
+// (D / N), as built by the scraper (or 0 / 0 for legacy trades).
+// LiquidityPoolID and LiquidityPoolFeeBP are only set for liquidity pool
+// trades.
 type Trade struct {
-	ID              int64     `db:"id"`
-	HorizonID       string    `db:"horizon_id"`
-	LedgerCloseTime time.Time `db:"ledger_close_time"`
-	OfferID         string    `db:"offer_id"`
-	BaseOfferID     string    `db:"base_offer_id"`
-	BaseAccount     string    `db:"base_account"`
-	BaseAmount      float64   `db:"base_amount"`
-	BaseAssetID     int32     `db:"base_asset_id"`
-	CounterOfferID  string    `db:"counter_offer_id"`
-	CounterAccount  string    `db:"counter_account"`
-	CounterAmount   float64   `db:"counter_amount"`
-	CounterAssetID  int32     `db:"counter_asset_id"`
-	BaseIsSeller    bool      `db:"base_is_seller"`
-	Price           float64   `db:"price"`
+	ID                 int64     `db:"id"`
+	HorizonID          string    `db:"horizon_id"`
+	LedgerCloseTime    time.Time `db:"ledger_close_time"`
+	OfferID            string    `db:"offer_id"`
+	BaseOfferID        string    `db:"base_offer_id"`
+	BaseAccount        string    `db:"base_account"`
+	BaseAmount         string    `db:"base_amount"`
+	BaseAssetID        int32     `db:"base_asset_id"`
+	CounterOfferID     string    `db:"counter_offer_id"`
+	CounterAccount     string    `db:"counter_account"`
+	CounterAmount      string    `db:"counter_amount"`
+	CounterAssetID     int32     `db:"counter_asset_id"`
+	BaseIsSeller       bool      `db:"base_is_seller"`
+	Price              string    `db:"price"`
+	PriceN             int64     `db:"price_n"`
+	PriceD             int64     `db:"price_d"`
+	TradeType          string    `db:"trade_type"`
+	LiquidityPoolID    string    `db:"liquidity_pool_id"`
+	LiquidityPoolFeeBP int32     `db:"liquidity_pool_fee_bp"`
+
+	// Anomaly flags, set once the trade was checked (see FlagsChecked):
+	// trades flagged as self-trades, wash trades or price outliers are
+	// excluded from the clean volumes of the markets.
+	IsSelfTrade  bool `db:"is_self_trade"`
+	IsWashTrade  bool `db:"is_wash_trade"`
+	IsOutlier    bool `db:"is_outlier"`
+	FlagsChecked bool `db:"flags_checked"`
+}
+
+// TradeWithAssets represents a trade along with the codes and issuer accounts
+// of its base and counter assets.
+type TradeWithAssets struct {
+	Trade
+	BaseAssetCode      string `db:"base_asset_code"`
+	BaseAssetIssuer    string `db:"base_asset_issuer"`
+	CounterAssetCode   string `db:"counter_asset_code"`
+	CounterAssetIssuer string `db:"counter_asset_issuer"`
+}
+
+// TradeCursor identifies the position of a trade within the pages of
+// RetrieveTradesPage, which are sorted by close time and ID.
+type TradeCursor struct {
+	LedgerCloseTime time.Time
+	ID              int64
+}
+
+// PendingTrade represents an entry on the pending_trades table, which holds
+// the trades whose base or counter asset couldn't be found in the assets
+// table when they were ingested. Trade is the JSON-encoded Horizon trade.
+type PendingTrade struct {
+	ID                 int64     `db:"id"`
+	HorizonID          string    `db:"horizon_id"`
+	LedgerCloseTime    time.Time `db:"ledger_close_time"`
+	BaseAssetCode      string    `db:"base_asset_code"`
+	BaseAssetIssuer    string    `db:"base_asset_issuer"`
+	CounterAssetCode   string    `db:"counter_asset_code"`
+	CounterAssetIssuer string    `db:"counter_asset_issuer"`
+	Trade              string    `db:"trade"`
+	CreatedAt          time.Time `db:"created_at"`
+}
+
+// ResolvablePendingTrade represents a PendingTrade whose base and counter
+// assets are now in the assets table, along with their IDs.
+// Note: this struct does *not* directly map to a db entity.
+type ResolvablePendingTrade struct {
+	PendingTrade
+	BaseAssetID    int32 `db:"base_asset_id"`
+	CounterAssetID int32 `db:"counter_asset_id"`
+}
+
+// Candle represents an entry on the candles table
+type Candle struct {
+	ID             int64     `db:"id"`
+	BaseAssetID    int32     `db:"base_asset_id"`
+	CounterAssetID int32     `db:"counter_asset_id"`
+	Resolution     string    `db:"resolution"`
+	OpenTime       time.Time `db:"open_time"`
+	Open           float64   `db:"open"`
+	High           float64   `db:"high"`
+	Low            float64   `db:"low"`
+	Close          float64   `db:"close"`
+	BaseVolume     float64   `db:"base_volume"`
+	CounterVolume  float64   `db:"counter_volume"`
+	TradeCount     int64     `db:"trade_count"`
+	FirstTradeTime time.Time `db:"first_trade_time"`
+	LastTradeTime  time.Time `db:"last_trade_time"`
+}
+
+// AssetPrice represents an entry on the asset_prices table, holding the
+// price of an asset in units of a fiat currency (e.g. "USD").
+type AssetPrice struct {
+	ID        int32     `db:"id"`
+	AssetID   int32     `db:"asset_id"`
+	Currency  string    `db:"currency"`
+	Price     float64   `db:"price"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// AssetEvaluation represents an entry on the asset_evaluations table: the
+// latest decision of the asset listing policy about an asset (listed or not),
+// along with the rule that made it and its reason. TOMLFindings is the
+// JSON-encoded list of SEP-1 compliance findings of the asset's TOML file.
+type AssetEvaluation struct {
+	ID            int32     `db:"id"`
+	Code          string    `db:"code"`
+	IssuerAccount string    `db:"issuer_account"`
+	Listed        bool      `db:"listed"`
+	Rule          string    `db:"rule"`
+	Reason        string    `db:"reason"`
+	NumAccounts   int32     `db:"num_accounts"`
+	Amount        string    `db:"amount"`
+	TOMLURL       string    `db:"toml_url"`
+	EvaluatedAt   time.Time `db:"evaluated_at"`
+	HomeDomain    string    `db:"home_domain"`
+	TOMLFindings  string    `db:"toml_findings"`
+}
+
+// TOMLCacheEntry represents an entry on the toml_cache table, holding the
+// last stellar.toml file retrieved from a URL (Body, empty if it could never
+// be retrieved) along with its HTTP validators (ETag and Last-Modified) and
+// the error of the last request, if any. FetchedAt is when Body was last
+// retrieved or revalidated, CheckedAt when the URL was last requested, and
+// ExpiresAt when the file should be requested again.
+type TOMLCacheEntry struct {
+	ID           int32     `db:"id"`
+	URL          string    `db:"url"`
+	Body         string    `db:"body"`
+	ETag         string    `db:"etag"`
+	LastModified string    `db:"last_modified"`
+	StatusCode   int32     `db:"status_code"`
+	LastError    string    `db:"last_error"`
+	FetchedAt    time.Time `db:"fetched_at"`
+	CheckedAt    time.Time `db:"checked_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+// TOMLVersion represents an entry on the toml_history table: a version of
+// the stellar.toml file at URL, first retrieved at FetchedAt.
+type TOMLVersion struct {
+	ID        int32     `db:"id"`
+	URL       string    `db:"url"`
+	Body      string    `db:"body"`
+	ETag      string    `db:"etag"`
+	FetchedAt time.Time `db:"fetched_at"`
+}
+
+// MarketPrice represents the recent prices of a market between two valid
+// assets, used to derive the fiat prices of assets. LastPrice is the price of
+// the most recent trade within the last 7 days, in units of the base asset.
+// Note: this struct does *not* directly map to a db entity.
+type MarketPrice struct {
+	BaseAssetID        int32   `db:"base_asset_id"`
+	BaseAssetCode      string  `db:"base_asset_code"`
+	BaseAssetIssuer    string  `db:"base_asset_issuer"`
+	CounterAssetID     int32   `db:"counter_asset_id"`
+	CounterAssetCode   string  `db:"counter_asset_code"`
+	CounterAssetIssuer string  `db:"counter_asset_issuer"`
+	BaseVolume24h      float64 `db:"base_volume_24h"`
+	CounterVolume24h   float64 `db:"counter_volume_24h"`
+	LastPrice          float64 `db:"last_price"`
 }
 
 // OrderbookStats represents an entry on the orderbook_stats table
@@ -97,12 +259,177 @@ type OrderbookStats struct {
 	Spread         float64   `db:"spread"`
 	SpreadMidPoint float64   `db:"spread_mid_point"`
 	UpdatedAt      time.Time `db:"updated_at"`
+
+	// Depth within 2%, 5% and 10% of the mid price, in units of the counter
+	// asset, and the slippage (in %) of buying / selling SlippageAmount units
+	// of the base asset:
+	BidDepth2Pct   float64 `db:"bid_depth_2pct"`
+	BidDepth5Pct   float64 `db:"bid_depth_5pct"`
+	BidDepth10Pct  float64 `db:"bid_depth_10pct"`
+	AskDepth2Pct   float64 `db:"ask_depth_2pct"`
+	AskDepth5Pct   float64 `db:"ask_depth_5pct"`
+	AskDepth10Pct  float64 `db:"ask_depth_10pct"`
+	SlippageAmount float64 `db:"slippage_amount"`
+	BuySlippage    float64 `db:"buy_slippage"`
+	SellSlippage   float64 `db:"sell_slippage"`
+}
+
+// OrderbookSnapshot represents an entry on the orderbook_snapshots table,
+// which keeps the orderbook stats of every refresh (unlike orderbook_stats,
+// which only holds the latest ones).
+type OrderbookSnapshot struct {
+	ID             int64     `db:"id"`
+	BaseAssetID    int32     `db:"base_asset_id"`
+	CounterAssetID int32     `db:"counter_asset_id"`
+	NumBids        int       `db:"num_bids"`
+	BidVolume      float64   `db:"bid_volume"`
+	HighestBid     float64   `db:"highest_bid"`
+	NumAsks        int       `db:"num_asks"`
+	AskVolume      float64   `db:"ask_volume"`
+	LowestAsk      float64   `db:"lowest_ask"`
+	Spread         float64   `db:"spread"`
+	SpreadMidPoint float64   `db:"spread_mid_point"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// AssetSnapshot represents an entry on the asset_snapshots table, which
+// keeps the supply breakdown of an asset whenever it changes between asset
+// refreshes (unlike assets, which only holds the latest number of accounts and
+// amount). The latest snapshot of each asset is referenced by the
+// latest_asset_snapshots table.
+// Balances are split by authorization status (see AssetStatBalances on
+// Horizon), while NumAccounts and Authorized match the assets table. Amounts
+// are exact decimal strings (stored as NUMERIC).
+type AssetSnapshot struct {
+	ID                                 int64     `db:"id"`
+	AssetID                            int32     `db:"asset_id"`
+	NumAccounts                        int32     `db:"num_accounts"`
+	NumAuthorizedToMaintainLiabilities int32     `db:"num_authorized_to_maintain_liabilities"`
+	NumUnauthorized                    int32     `db:"num_unauthorized"`
+	NumClaimableBalances               int32     `db:"num_claimable_balances"`
+	NumLiquidityPools                  int32     `db:"num_liquidity_pools"`
+	NumContracts                       int32     `db:"num_contracts"`
+	Authorized                         string    `db:"authorized"`
+	AuthorizedToMaintainLiabilities    string    `db:"authorized_to_maintain_liabilities"`
+	Unauthorized                       string    `db:"unauthorized"`
+	ClaimableBalances                  string    `db:"claimable_balances"`
+	LiquidityPools                     string    `db:"liquidity_pools"`
+	Contracts                          string    `db:"contracts"`
+	ArchivedContracts                  string    `db:"archived_contracts"`
+	CreatedAt                          time.Time `db:"created_at"`
+}
+
+// LockedSupply is the part of the supply which can't be transferred: the
+// balances of accounts that aren't (fully) authorized to hold the asset,
+// claimable balances and the balances of archived contracts.
+func (a AssetSnapshot) LockedSupply() float64 {
+	return sumAmounts(a.AuthorizedToMaintainLiabilities, a.Unauthorized, a.ClaimableBalances, a.ArchivedContracts)
+}
+
+// CirculatingSupply is the part of the supply which can be transferred: the
+// balances of authorized accounts, liquidity pools and live contracts.
+func (a AssetSnapshot) CirculatingSupply() float64 {
+	return sumAmounts(a.Authorized, a.LiquidityPools, a.Contracts)
+}
+
+// TotalSupply is the whole supply of the asset, either circulating or
+// locked.
+func (a AssetSnapshot) TotalSupply() float64 {
+	return sumAmounts(
+		a.Authorized,
+		a.AuthorizedToMaintainLiabilities,
+		a.Unauthorized,
+		a.ClaimableBalances,
+		a.LiquidityPools,
+		a.Contracts,
+		a.ArchivedContracts,
+	)
+}
+
+// sumAmounts adds up exact decimal amounts, ignoring the invalid (e.g. empty)
+// ones, and converts the sum to a float64.
+func sumAmounts(amounts ...string) float64 {
+	sum := new(big.Rat)
+	for _, a := range amounts {
+		if r, ok := new(big.Rat).SetString(a); ok {
+			sum.Add(sum, r)
+		}
+	}
+	f, _ := sum.Float64()
+	return f
+}
+
+// ContractToken represents an entry on the contract_tokens table: a Soroban
+// token which isn't a Stellar Asset Contract, tracked from its SEP-41 events.
+// Supply is in raw (i.e. not divided by 10^Decimals) units, while NumAccounts
+// and NumContracts are the number of accounts and contracts holding a
+// positive balance of the token as of LastLedger.
+type ContractToken struct {
+	ID           int32     `db:"id"`
+	ContractID   string    `db:"contract_id"`
+	Name         string    `db:"name"`
+	Symbol       string    `db:"symbol"`
+	Decimals     int32     `db:"decimals"`
+	Supply       string    `db:"supply"`
+	NumAccounts  int32     `db:"num_accounts"`
+	NumContracts int32     `db:"num_contracts"`
+	LastLedger   int32     `db:"last_ledger"`
+	UpdatedAt    time.Time `db:"updated_at"`
+
+	// SupplyComplete is set for the tokens whose whole history was ingested,
+	// i.e. whose metadata was written along with their first ingested events,
+	// as long as their supply and balances never went negative.
+	SupplyComplete bool `db:"supply_complete"`
+}
+
+// LiquidityPool represents an entry on the liquidity_pools table. Reserves are
+// in units of each asset, while Price (the price implied by the reserves, in
+// units of the base asset, like trade prices), TVL and FeeIncome24h (the fees
+// earned within the last 24 hours) are in units of the counter asset.
+type LiquidityPool struct {
+	ID              int32     `db:"id"`
+	PoolID          string    `db:"pool_id"`
+	BaseAssetID     int32     `db:"base_asset_id"`
+	CounterAssetID  int32     `db:"counter_asset_id"`
+	FeeBP           int32     `db:"fee_bp"`
+	TotalTrustlines int64     `db:"total_trustlines"`
+	TotalShares     float64   `db:"total_shares"`
+	BaseReserve     float64   `db:"base_reserve"`
+	CounterReserve  float64   `db:"counter_reserve"`
+	Price           float64   `db:"price"`
+	TVL             float64   `db:"tvl"`
+	FeeIncome24h    float64   `db:"fee_income_24h"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// MarketLiquidityPool represents a LiquidityPool along with its base and
+// counter assets.
+// Note: this struct does *not* directly map to a db entity.
+type MarketLiquidityPool struct {
+	LiquidityPool
+	BaseAssetCode      string `db:"base_asset_code"`
+	BaseAssetIssuer    string `db:"base_asset_issuer"`
+	CounterAssetCode   string `db:"counter_asset_code"`
+	CounterAssetIssuer string `db:"counter_asset_issuer"`
+}
+
+// IngestState represents an entry on the ingest_state table, which holds
+// the paging token of the last trade processed by each ingestion job.
+type IngestState struct {
+	Job         string    `db:"job"`
+	PagingToken string    `db:"paging_token"`
+	UpdatedAt   time.Time `db:"updated_at"`
 }
 
 // Market represent the aggregated market data retrieved from the database.
+// MarketID is the canonical ID of the market (empty when markets are
+// aggregated by asset code), and MarketIDs is a comma-separated list of the
+// IDs of the markets it includes.
 // Note: this struct does *not* directly map to a db entity.
 type Market struct {
 	TradePair          string    `db:"trade_pair_name"`
+	MarketID           string    `db:"market_id"`
+	MarketIDs          string    `db:"market_ids"`
 	BaseVolume24h      float64   `db:"base_volume_24h"`
 	CounterVolume24h   float64   `db:"counter_volume_24h"`
 	TradeCount24h      int64     `db:"trade_count_24h"`
@@ -125,6 +452,98 @@ type Market struct {
 	NumAsks            int       `db:"num_asks"`
 	AskVolume          float64   `db:"ask_volume"`
 	LowestAsk          float64   `db:"lowest_ask"`
+
+	// Volumes split by venue, and the liquidity of the market's pools:
+	OrderbookBaseVolume24h    float64 `db:"orderbook_base_volume_24h"`
+	OrderbookCounterVolume24h float64 `db:"orderbook_counter_volume_24h"`
+	PoolBaseVolume24h         float64 `db:"pool_base_volume_24h"`
+	PoolCounterVolume24h      float64 `db:"pool_counter_volume_24h"`
+	PoolBaseReserve           float64 `db:"pool_base_reserve"`
+	PoolCounterReserve        float64 `db:"pool_counter_reserve"`
+	PoolTVL                   float64 `db:"pool_tvl"`
+	PoolFeeIncome24h          float64 `db:"pool_fee_income_24h"`
+
+	// USD volumes, and the USD price of the base asset:
+	BaseVolume24hUSD    float64 `db:"base_volume_24h_usd"`
+	CounterVolume24hUSD float64 `db:"counter_volume_24h_usd"`
+	PriceUSD            float64 `db:"price_usd"`
+
+	// Orderbook depth and slippage:
+	BidDepth2Pct   float64 `db:"bid_depth_2pct"`
+	BidDepth5Pct   float64 `db:"bid_depth_5pct"`
+	BidDepth10Pct  float64 `db:"bid_depth_10pct"`
+	AskDepth2Pct   float64 `db:"ask_depth_2pct"`
+	AskDepth5Pct   float64 `db:"ask_depth_5pct"`
+	AskDepth10Pct  float64 `db:"ask_depth_10pct"`
+	SlippageAmount float64 `db:"slippage_amount"`
+	BuySlippage    float64 `db:"buy_slippage"`
+	SellSlippage   float64 `db:"sell_slippage"`
+
+	// Exact decimal representations of the aggregated amounts and prices:
+	BaseVolume24hExact    string `db:"base_volume_24h_exact"`
+	CounterVolume24hExact string `db:"counter_volume_24h_exact"`
+	OpenPrice24hExact     string `db:"open_price_24h_exact"`
+	LowestPrice24hExact   string `db:"lowest_price_24h_exact"`
+	HighestPrice24hExact  string `db:"highest_price_24h_exact"`
+	BaseVolume7dExact     string `db:"base_volume_7d_exact"`
+	CounterVolume7dExact  string `db:"counter_volume_7d_exact"`
+	LastPriceExact        string `db:"last_price_exact"`
+
+	// Volumes excluding the trades flagged as anomalous (self-trades, wash
+	// trades and price outliers), and the number of flagged trades:
+	CleanBaseVolume24h    float64 `db:"clean_base_volume_24h"`
+	CleanCounterVolume24h float64 `db:"clean_counter_volume_24h"`
+	FlaggedTradeCount24h  int64   `db:"flagged_trade_count_24h"`
+	CleanBaseVolume7d     float64 `db:"clean_base_volume_7d"`
+	CleanCounterVolume7d  float64 `db:"clean_counter_volume_7d"`
+
+	// Volume- and time-weighted average prices, and median trade prices,
+	// excluding flagged trades and the ones below the minimum trade size:
+	VWAP24h        float64 `db:"vwap_24h"`
+	TWAP24h        float64 `db:"twap_24h"`
+	MedianPrice24h float64 `db:"median_price_24h"`
+	VWAP7d         float64 `db:"vwap_7d"`
+	TWAP7d         float64 `db:"twap_7d"`
+	MedianPrice7d  float64 `db:"median_price_7d"`
+}
+
+// MarketWindow represents the aggregated market data of a trade pair during
+// a trailing window of time (e.g. the last 30 days), identified by WindowName.
+// Note: this struct does *not* directly map to a db entity.
+type MarketWindow struct {
+	TradePair     string    `db:"trade_pair_name"`
+	MarketID      string    `db:"market_id"`
+	MarketIDs     string    `db:"market_ids"`
+	WindowName    string    `db:"window_name"`
+	BaseVolume    float64   `db:"base_volume"`
+	CounterVolume float64   `db:"counter_volume"`
+	TradeCount    int64     `db:"trade_count"`
+	OpenPrice     float64   `db:"open_price"`
+	LowestPrice   float64   `db:"lowest_price"`
+	HighestPrice  float64   `db:"highest_price"`
+	PriceChange   float64   `db:"price_change"`
+	LastPrice     float64   `db:"last_price"`
+	CloseTime     time.Time `db:"close_time"`
+
+	// Exact decimal representations of the aggregated amounts and prices:
+	BaseVolumeExact    string `db:"base_volume_exact"`
+	CounterVolumeExact string `db:"counter_volume_exact"`
+	OpenPriceExact     string `db:"open_price_exact"`
+	LowestPriceExact   string `db:"lowest_price_exact"`
+	HighestPriceExact  string `db:"highest_price_exact"`
+	LastPriceExact     string `db:"last_price_exact"`
+
+	// Volumes excluding the trades flagged as anomalous, and the number of
+	// flagged trades:
+	CleanBaseVolume    float64 `db:"clean_base_volume"`
+	CleanCounterVolume float64 `db:"clean_counter_volume"`
+	FlaggedTradeCount  int64   `db:"flagged_trade_count"`
+
+	// Volume- and time-weighted average prices, and median trade price,
+	// excluding flagged trades and the ones below the minimum trade size:
+	VWAP        float64 `db:"vwap"`
+	TWAP        float64 `db:"twap"`
+	MedianPrice float64 `db:"median_price"`
 }
 
 // PartialMarket represents the aggregated market data for a
@@ -133,6 +552,8 @@ type Market struct {
 // Note: this struct does *not* directly map to a db entity.
 type PartialMarket struct {
 	TradePairName        string    `db:"trade_pair_name"`
+	MarketID             string    `db:"market_id"`
+	MarketIDs            string    `db:"market_ids"`
 	BaseAssetID          int32     `db:"base_asset_id"`
 	BaseAssetCode        string    `db:"base_asset_code"`
 	BaseAssetIssuer      string    `db:"base_asset_issuer"`
@@ -158,6 +579,88 @@ type PartialMarket struct {
 	IntervalStart        time.Time `db:"interval_start"`
 	FirstLedgerCloseTime time.Time `db:"first_ledger_close_time"`
 	LastLedgerCloseTime  time.Time `db:"last_ledger_close_time"`
+
+	// Volumes split by venue, and the liquidity of the market's pools:
+	OrderbookBaseVolume    float64 `db:"orderbook_base_volume"`
+	OrderbookCounterVolume float64 `db:"orderbook_counter_volume"`
+	PoolBaseVolume         float64 `db:"pool_base_volume"`
+	PoolCounterVolume      float64 `db:"pool_counter_volume"`
+	PoolBaseReserve        float64 `db:"pool_base_reserve"`
+	PoolCounterReserve     float64 `db:"pool_counter_reserve"`
+	PoolTVL                float64 `db:"pool_tvl"`
+	PoolFeeIncome24h       float64 `db:"pool_fee_income_24h"`
+
+	// USD volumes, and the USD price of the base asset:
+	BaseVolumeUSD    float64 `db:"base_volume_usd"`
+	CounterVolumeUSD float64 `db:"counter_volume_usd"`
+	PriceUSD         float64 `db:"price_usd"`
+
+	// Orderbook depth and slippage:
+	BidDepth2Pct   float64 `db:"bid_depth_2pct"`
+	BidDepth5Pct   float64 `db:"bid_depth_5pct"`
+	BidDepth10Pct  float64 `db:"bid_depth_10pct"`
+	AskDepth2Pct   float64 `db:"ask_depth_2pct"`
+	AskDepth5Pct   float64 `db:"ask_depth_5pct"`
+	AskDepth10Pct  float64 `db:"ask_depth_10pct"`
+	SlippageAmount float64 `db:"slippage_amount"`
+	BuySlippage    float64 `db:"buy_slippage"`
+	SellSlippage   float64 `db:"sell_slippage"`
+
+	// Exact decimal representations of the aggregated amounts and prices:
+	BaseVolumeExact    string `db:"base_volume_exact"`
+	CounterVolumeExact string `db:"counter_volume_exact"`
+	OpenExact          string `db:"open_price_exact"`
+	LowExact           string `db:"lowest_price_exact"`
+	HighExact          string `db:"highest_price_exact"`
+	CloseExact         string `db:"last_price_exact"`
+
+	// Volumes excluding the trades flagged as anomalous, and the number of
+	// flagged trades:
+	CleanBaseVolume    float64 `db:"clean_base_volume"`
+	CleanCounterVolume float64 `db:"clean_counter_volume"`
+	FlaggedTradeCount  int32   `db:"flagged_trade_count"`
+
+	// Volume- and time-weighted average prices, and median trade price,
+	// excluding flagged trades and the ones below the minimum trade size:
+	VWAP        float64 `db:"vwap"`
+	TWAP        float64 `db:"twap"`
+	MedianPrice float64 `db:"median_price"`
+}
+
+// PairCandle represents the OHLCV data of a trade pair (aggregated by
+// asset code) for a single resolution bucket.
+// Note: this struct does *not* directly map to a db entity.
+type PairCandle struct {
+	TradePairName string    `db:"trade_pair_name"`
+	Resolution    string    `db:"resolution"`
+	OpenTime      time.Time `db:"open_time"`
+	Open          float64   `db:"open"`
+	High          float64   `db:"high"`
+	Low           float64   `db:"low"`
+	Close         float64   `db:"close"`
+	BaseVolume    float64   `db:"base_volume"`
+	CounterVolume float64   `db:"counter_volume"`
+	TradeCount    int64     `db:"trade_count"`
+}
+
+// OrderbookHistoryEntry represents the orderbook snapshots of a trade pair
+// (aggregated by asset code) taken within a single resolution bucket. Counts,
+// volumes and spreads are averaged over the snapshots of each market, then
+// summed (or, for the spreads, averaged) across markets.
+// Note: this struct does *not* directly map to a db entity.
+type OrderbookHistoryEntry struct {
+	TradePairName  string    `db:"trade_pair_name"`
+	Resolution     string    `db:"resolution"`
+	StartTime      time.Time `db:"start_time"`
+	SnapshotCount  int64     `db:"snapshot_count"`
+	NumBids        int64     `db:"num_bids"`
+	BidVolume      float64   `db:"bid_volume"`
+	HighestBid     float64   `db:"highest_bid"`
+	NumAsks        int64     `db:"num_asks"`
+	AskVolume      float64   `db:"ask_volume"`
+	LowestAsk      float64   `db:"lowest_ask"`
+	Spread         float64   `db:"spread"`
+	SpreadMidPoint float64   `db:"spread_mid_point"`
 }
 
 // CreateSession returns a new TickerSession that connects to the given db settings
@@ -0,0 +1,63 @@
+-- +migrate Up
+CREATE TABLE candles (
+    id bigserial NOT NULL PRIMARY KEY,
+
+    base_asset_id integer REFERENCES assets (id) NOT NULL,
+    counter_asset_id integer REFERENCES assets (id) NOT NULL,
+    resolution text NOT NULL,
+    open_time timestamptz NOT NULL,
+
+    open double precision NOT NULL,
+    high double precision NOT NULL,
+    low double precision NOT NULL,
+    close double precision NOT NULL,
+    base_volume double precision NOT NULL,
+    counter_volume double precision NOT NULL,
+    trade_count bigint NOT NULL,
+
+    first_trade_time timestamptz NOT NULL,
+    last_trade_time timestamptz NOT NULL
+);
+
+ALTER TABLE ONLY public.candles
+    ADD CONSTRAINT candles_pair_resolution_open_time_key UNIQUE (base_asset_id, counter_asset_id, resolution, open_time);
+
+CREATE INDEX candles_resolution_open_time_idx ON public.candles (resolution, open_time DESC);
+
+-- Seed the candles with the trades that are already in the database
+INSERT INTO candles (
+    base_asset_id,
+    counter_asset_id,
+    resolution,
+    open_time,
+    open,
+    high,
+    low,
+    close,
+    base_volume,
+    counter_volume,
+    trade_count,
+    first_trade_time,
+    last_trade_time
+)
+SELECT
+    t.base_asset_id,
+    t.counter_asset_id,
+    r.resolution,
+    to_timestamp(floor(extract(epoch FROM t.ledger_close_time) / r.seconds) * r.seconds) AS open_time,
+    (array_agg(t.price ORDER BY t.ledger_close_time ASC))[1],
+    max(t.price),
+    min(t.price),
+    (array_agg(t.price ORDER BY t.ledger_close_time DESC))[1],
+    sum(t.base_amount),
+    sum(t.counter_amount),
+    count(*),
+    min(t.ledger_close_time),
+    max(t.ledger_close_time)
+FROM trades AS t
+    CROSS JOIN (VALUES ('1m', 60), ('5m', 300), ('15m', 900), ('1h', 3600), ('4h', 14400), ('1d', 86400)) AS r (resolution, seconds)
+WHERE t.base_asset_id IS NOT NULL AND t.counter_asset_id IS NOT NULL
+GROUP BY t.base_asset_id, t.counter_asset_id, r.resolution, open_time;
+
+-- +migrate Down
+DROP TABLE candles;
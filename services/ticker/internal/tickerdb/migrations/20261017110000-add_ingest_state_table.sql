@@ -0,0 +1,9 @@
+-- +migrate Up
+CREATE TABLE ingest_state (
+    job text NOT NULL PRIMARY KEY,
+    paging_token text NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE ingest_state;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE pending_trades (
+    id serial NOT NULL PRIMARY KEY,
+    horizon_id text NOT NULL,
+    ledger_close_time timestamptz NOT NULL,
+    base_asset_code text NOT NULL,
+    base_asset_issuer text NOT NULL,
+    counter_asset_code text NOT NULL,
+    counter_asset_issuer text NOT NULL,
+    trade jsonb NOT NULL,
+    created_at timestamptz NOT NULL,
+    UNIQUE (horizon_id)
+);
+
+CREATE INDEX pending_trades_base_asset_idx ON pending_trades (base_asset_code, base_asset_issuer);
+CREATE INDEX pending_trades_counter_asset_idx ON pending_trades (counter_asset_code, counter_asset_issuer);
+
+-- +migrate Down
+DROP TABLE pending_trades;
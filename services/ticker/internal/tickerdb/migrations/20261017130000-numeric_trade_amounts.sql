@@ -0,0 +1,31 @@
+-- +migrate Up
+ALTER TABLE trades
+    ALTER COLUMN base_amount TYPE numeric,
+    ALTER COLUMN counter_amount TYPE numeric,
+    ALTER COLUMN price TYPE numeric,
+    ADD COLUMN price_n bigint NOT NULL DEFAULT 0,
+    ADD COLUMN price_d bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE candles
+    ALTER COLUMN open TYPE numeric,
+    ALTER COLUMN high TYPE numeric,
+    ALTER COLUMN low TYPE numeric,
+    ALTER COLUMN close TYPE numeric,
+    ALTER COLUMN base_volume TYPE numeric,
+    ALTER COLUMN counter_volume TYPE numeric;
+
+-- +migrate Down
+ALTER TABLE candles
+    ALTER COLUMN open TYPE double precision,
+    ALTER COLUMN high TYPE double precision,
+    ALTER COLUMN low TYPE double precision,
+    ALTER COLUMN close TYPE double precision,
+    ALTER COLUMN base_volume TYPE double precision,
+    ALTER COLUMN counter_volume TYPE double precision;
+
+ALTER TABLE trades
+    DROP COLUMN price_n,
+    DROP COLUMN price_d,
+    ALTER COLUMN base_amount TYPE double precision,
+    ALTER COLUMN counter_amount TYPE double precision,
+    ALTER COLUMN price TYPE double precision;
@@ -0,0 +1,41 @@
+-- +migrate Up
+ALTER TABLE trades
+    ADD COLUMN trade_type text NOT NULL DEFAULT 'orderbook',
+    ADD COLUMN liquidity_pool_id text NOT NULL DEFAULT '',
+    ADD COLUMN liquidity_pool_fee_bp integer NOT NULL DEFAULT 0;
+
+CREATE INDEX trades_liquidity_pool_id_idx ON trades (liquidity_pool_id, ledger_close_time)
+    WHERE liquidity_pool_id <> '';
+
+CREATE TABLE liquidity_pools (
+    id serial NOT NULL PRIMARY KEY,
+    pool_id text NOT NULL,
+
+    base_asset_id integer REFERENCES assets (id) NOT NULL,
+    counter_asset_id integer REFERENCES assets (id) NOT NULL,
+
+    fee_bp integer NOT NULL,
+    total_trustlines bigint NOT NULL,
+    total_shares numeric NOT NULL,
+
+    base_reserve numeric NOT NULL,
+    counter_reserve numeric NOT NULL,
+    price numeric NOT NULL,
+    tvl numeric NOT NULL,
+    fee_income_24h numeric NOT NULL DEFAULT 0,
+
+    updated_at timestamptz NOT NULL
+);
+ALTER TABLE ONLY public.liquidity_pools
+    ADD CONSTRAINT liquidity_pools_pool_id_key UNIQUE (pool_id);
+CREATE INDEX liquidity_pools_base_counter_asset_idx ON liquidity_pools (base_asset_id, counter_asset_id);
+
+-- +migrate Down
+DROP TABLE liquidity_pools;
+
+DROP INDEX trades_liquidity_pool_id_idx;
+
+ALTER TABLE trades
+    DROP COLUMN trade_type,
+    DROP COLUMN liquidity_pool_id,
+    DROP COLUMN liquidity_pool_fee_bp;
@@ -0,0 +1,55 @@
+-- +migrate Up
+CREATE TABLE orderbook_snapshots (
+    id bigserial NOT NULL PRIMARY KEY,
+
+    base_asset_id integer REFERENCES assets (id) NOT NULL,
+    counter_asset_id integer REFERENCES assets (id) NOT NULL,
+
+    num_bids bigint NOT NULL,
+    bid_volume double precision NOT NULL,
+    highest_bid double precision NOT NULL,
+
+    num_asks bigint NOT NULL,
+    ask_volume double precision NOT NULL,
+    lowest_ask double precision NOT NULL,
+
+    spread double precision NOT NULL,
+    spread_mid_point double precision NOT NULL,
+
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX orderbook_snapshots_base_counter_asset_created_at_idx
+    ON public.orderbook_snapshots (base_asset_id, counter_asset_id, created_at);
+CREATE INDEX orderbook_snapshots_created_at_idx ON public.orderbook_snapshots (created_at);
+
+-- Seed the snapshots with the latest stats of each market
+INSERT INTO orderbook_snapshots (
+    base_asset_id,
+    counter_asset_id,
+    num_bids,
+    bid_volume,
+    highest_bid,
+    num_asks,
+    ask_volume,
+    lowest_ask,
+    spread,
+    spread_mid_point,
+    created_at
+)
+SELECT
+    base_asset_id,
+    counter_asset_id,
+    num_bids,
+    bid_volume,
+    highest_bid,
+    num_asks,
+    ask_volume,
+    lowest_ask,
+    spread,
+    spread_mid_point,
+    updated_at
+FROM orderbook_stats;
+
+-- +migrate Down
+DROP TABLE orderbook_snapshots;
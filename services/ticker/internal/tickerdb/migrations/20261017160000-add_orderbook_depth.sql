@@ -0,0 +1,67 @@
+-- +migrate Up
+ALTER TABLE orderbook_stats
+    ADD COLUMN bid_depth_2pct double precision NOT NULL DEFAULT 0,
+    ADD COLUMN bid_depth_5pct double precision NOT NULL DEFAULT 0,
+    ADD COLUMN bid_depth_10pct double precision NOT NULL DEFAULT 0,
+    ADD COLUMN ask_depth_2pct double precision NOT NULL DEFAULT 0,
+    ADD COLUMN ask_depth_5pct double precision NOT NULL DEFAULT 0,
+    ADD COLUMN ask_depth_10pct double precision NOT NULL DEFAULT 0,
+    ADD COLUMN slippage_amount double precision NOT NULL DEFAULT 0,
+    ADD COLUMN buy_slippage double precision NOT NULL DEFAULT 0,
+    ADD COLUMN sell_slippage double precision NOT NULL DEFAULT 0;
+
+-- Depths are summed across the markets of a trade pair, while the slippage is
+-- the lowest one among them (i.e. the one of the most liquid market).
+CREATE OR REPLACE VIEW aggregated_orderbook AS
+    SELECT
+        concat(bAsset.code, '_', cAsset.code) as trade_pair_name,
+        bAsset.code as base_asset_code,
+        cAsset.code as counter_asset_code,
+        COALESCE(sum(os.num_bids), 0) AS num_bids,
+        COALESCE(sum(os.bid_volume), 0.0) AS bid_volume,
+        COALESCE(max(os.highest_bid), 0.0) AS highest_bid,
+        COALESCE(sum(os.num_asks), 0) AS num_asks,
+        COALESCE(sum(os.ask_volume), 0.0) AS ask_volume,
+        COALESCE(min(os.lowest_ask), 0.0) AS lowest_ask,
+        COALESCE(sum(os.bid_depth_2pct), 0.0) AS bid_depth_2pct,
+        COALESCE(sum(os.bid_depth_5pct), 0.0) AS bid_depth_5pct,
+        COALESCE(sum(os.bid_depth_10pct), 0.0) AS bid_depth_10pct,
+        COALESCE(sum(os.ask_depth_2pct), 0.0) AS ask_depth_2pct,
+        COALESCE(sum(os.ask_depth_5pct), 0.0) AS ask_depth_5pct,
+        COALESCE(sum(os.ask_depth_10pct), 0.0) AS ask_depth_10pct,
+        COALESCE(max(os.slippage_amount), 0.0) AS slippage_amount,
+        COALESCE(min(NULLIF(os.buy_slippage, 0)), 0.0) AS buy_slippage,
+        COALESCE(min(NULLIF(os.sell_slippage, 0)), 0.0) AS sell_slippage
+    FROM orderbook_stats AS os
+    JOIN assets AS bAsset ON os.base_asset_id = bAsset.id
+    JOIN assets AS cAsset on os.counter_asset_id = cAsset.id
+    GROUP BY trade_pair_name, base_asset_code, counter_asset_code;
+
+-- +migrate Down
+DROP VIEW IF EXISTS aggregated_orderbook;
+CREATE VIEW aggregated_orderbook AS
+    SELECT
+        concat(bAsset.code, '_', cAsset.code) as trade_pair_name,
+        bAsset.code as base_asset_code,
+        cAsset.code as counter_asset_code,
+        COALESCE(sum(os.num_bids), 0) AS num_bids,
+        COALESCE(sum(os.bid_volume), 0.0) AS bid_volume,
+        COALESCE(max(os.highest_bid), 0.0) AS highest_bid,
+        COALESCE(sum(os.num_asks), 0) AS num_asks,
+        COALESCE(sum(os.ask_volume), 0.0) AS ask_volume,
+        COALESCE(min(os.lowest_ask), 0.0) AS lowest_ask
+    FROM orderbook_stats AS os
+    JOIN assets AS bAsset ON os.base_asset_id = bAsset.id
+    JOIN assets AS cAsset on os.counter_asset_id = cAsset.id
+    GROUP BY trade_pair_name, base_asset_code, counter_asset_code;
+
+ALTER TABLE orderbook_stats
+    DROP COLUMN bid_depth_2pct,
+    DROP COLUMN bid_depth_5pct,
+    DROP COLUMN bid_depth_10pct,
+    DROP COLUMN ask_depth_2pct,
+    DROP COLUMN ask_depth_5pct,
+    DROP COLUMN ask_depth_10pct,
+    DROP COLUMN slippage_amount,
+    DROP COLUMN buy_slippage,
+    DROP COLUMN sell_slippage;
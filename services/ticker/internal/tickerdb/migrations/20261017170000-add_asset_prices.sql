@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE asset_prices (
+    id serial NOT NULL PRIMARY KEY,
+    asset_id integer REFERENCES assets (id) NOT NULL,
+    currency text NOT NULL,
+    price double precision NOT NULL,
+    updated_at timestamptz NOT NULL
+);
+ALTER TABLE ONLY public.asset_prices
+    ADD CONSTRAINT asset_prices_asset_currency_key UNIQUE (asset_id, currency);
+
+-- +migrate Down
+DROP TABLE asset_prices;
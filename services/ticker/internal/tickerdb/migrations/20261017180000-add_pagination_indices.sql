@@ -0,0 +1,8 @@
+-- +migrate Up
+CREATE INDEX trades_base_counter_asset_close_time_idx
+    ON public.trades (base_asset_id, counter_asset_id, ledger_close_time DESC, id DESC);
+CREATE INDEX assets_issuer_id_idx ON public.assets (issuer_id, id);
+
+-- +migrate Down
+DROP INDEX trades_base_counter_asset_close_time_idx;
+DROP INDEX assets_issuer_id_idx;
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE asset_evaluations (
+    id serial NOT NULL PRIMARY KEY,
+    code text NOT NULL,
+    issuer_account text NOT NULL,
+    listed boolean NOT NULL,
+    rule text NOT NULL,
+    reason text NOT NULL,
+    num_accounts integer NOT NULL,
+    amount numeric NOT NULL,
+    toml_url text NOT NULL,
+    evaluated_at timestamptz NOT NULL
+);
+ALTER TABLE ONLY public.asset_evaluations
+    ADD CONSTRAINT asset_evaluations_code_issuer_key UNIQUE (code, issuer_account);
+
+-- +migrate Down
+DROP TABLE asset_evaluations;
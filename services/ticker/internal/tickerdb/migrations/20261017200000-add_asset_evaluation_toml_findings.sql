@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE asset_evaluations
+    ADD COLUMN home_domain text NOT NULL DEFAULT '',
+    ADD COLUMN toml_findings jsonb NOT NULL DEFAULT '[]';
+
+-- +migrate Down
+ALTER TABLE asset_evaluations
+    DROP COLUMN home_domain,
+    DROP COLUMN toml_findings;
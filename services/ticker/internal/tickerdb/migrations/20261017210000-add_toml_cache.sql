@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE toml_cache (
+    id serial NOT NULL PRIMARY KEY,
+    url text NOT NULL,
+    body text NOT NULL,
+    etag text NOT NULL,
+    last_modified text NOT NULL,
+    status_code integer NOT NULL,
+    last_error text NOT NULL,
+    fetched_at timestamptz NOT NULL,
+    checked_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+ALTER TABLE ONLY public.toml_cache
+    ADD CONSTRAINT toml_cache_url_key UNIQUE (url);
+
+CREATE TABLE toml_history (
+    id serial NOT NULL PRIMARY KEY,
+    url text NOT NULL,
+    body text NOT NULL,
+    etag text NOT NULL,
+    fetched_at timestamptz NOT NULL
+);
+CREATE INDEX toml_history_url_fetched_at_idx ON toml_history (url, fetched_at DESC);
+
+-- +migrate Down
+DROP TABLE toml_history;
+DROP TABLE toml_cache;
@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE asset_snapshots (
+    id bigserial NOT NULL PRIMARY KEY,
+    asset_id integer REFERENCES assets (id) NOT NULL,
+
+    num_accounts integer NOT NULL,
+    num_authorized_to_maintain_liabilities integer NOT NULL DEFAULT 0,
+    num_unauthorized integer NOT NULL DEFAULT 0,
+    num_claimable_balances integer NOT NULL DEFAULT 0,
+    num_liquidity_pools integer NOT NULL DEFAULT 0,
+    num_contracts integer NOT NULL DEFAULT 0,
+
+    authorized numeric NOT NULL,
+    authorized_to_maintain_liabilities numeric NOT NULL DEFAULT 0,
+    unauthorized numeric NOT NULL DEFAULT 0,
+    claimable_balances numeric NOT NULL DEFAULT 0,
+    liquidity_pools numeric NOT NULL DEFAULT 0,
+    contracts numeric NOT NULL DEFAULT 0,
+    archived_contracts numeric NOT NULL DEFAULT 0,
+
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX asset_snapshots_asset_id_created_at_idx
+    ON public.asset_snapshots (asset_id, created_at);
+
+-- Seed the snapshots with the latest stats of each asset
+INSERT INTO asset_snapshots (asset_id, num_accounts, authorized, created_at)
+SELECT id, num_accounts, amount, last_checked
+FROM assets;
+
+-- +migrate Down
+DROP TABLE asset_snapshots;
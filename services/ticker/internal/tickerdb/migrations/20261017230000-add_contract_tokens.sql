@@ -0,0 +1,35 @@
+-- +migrate Up
+ALTER TABLE assets ADD COLUMN contract_id text NOT NULL DEFAULT '';
+ALTER TABLE assets ADD COLUMN num_contracts integer NOT NULL DEFAULT 0;
+ALTER TABLE assets ADD COLUMN contracts_amount double precision NOT NULL DEFAULT 0;
+
+CREATE INDEX assets_contract_id_idx ON public.assets (contract_id);
+
+CREATE TABLE contract_tokens (
+    id serial NOT NULL PRIMARY KEY,
+    contract_id text NOT NULL,
+    name text NOT NULL DEFAULT '',
+    symbol text NOT NULL DEFAULT '',
+    decimals integer NOT NULL DEFAULT 7,
+    supply numeric NOT NULL DEFAULT 0,
+    num_accounts integer NOT NULL DEFAULT 0,
+    num_contracts integer NOT NULL DEFAULT 0,
+    last_ledger integer NOT NULL DEFAULT 0,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT contract_tokens_contract_id_key UNIQUE (contract_id)
+);
+
+CREATE TABLE contract_token_balances (
+    contract_id text NOT NULL,
+    holder text NOT NULL,
+    balance numeric NOT NULL DEFAULT 0,
+    PRIMARY KEY (contract_id, holder)
+);
+
+-- +migrate Down
+DROP TABLE contract_token_balances;
+DROP TABLE contract_tokens;
+DROP INDEX assets_contract_id_idx;
+ALTER TABLE assets DROP COLUMN contracts_amount;
+ALTER TABLE assets DROP COLUMN num_contracts;
+ALTER TABLE assets DROP COLUMN contract_id;
@@ -0,0 +1,14 @@
+-- +migrate Up
+ALTER TABLE trades ADD COLUMN is_self_trade boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE trades ADD COLUMN is_wash_trade boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE trades ADD COLUMN is_outlier boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE trades ADD COLUMN flags_checked boolean NOT NULL DEFAULT FALSE;
+
+CREATE INDEX trades_unchecked_idx ON public.trades (ledger_close_time, id) WHERE NOT flags_checked;
+
+-- +migrate Down
+DROP INDEX trades_unchecked_idx;
+ALTER TABLE trades DROP COLUMN flags_checked;
+ALTER TABLE trades DROP COLUMN is_outlier;
+ALTER TABLE trades DROP COLUMN is_wash_trade;
+ALTER TABLE trades DROP COLUMN is_self_trade;
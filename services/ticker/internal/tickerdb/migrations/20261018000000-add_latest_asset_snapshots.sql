@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE latest_asset_snapshots (
+    asset_id integer REFERENCES assets (id) NOT NULL PRIMARY KEY,
+    snapshot_id bigint REFERENCES asset_snapshots (id) NOT NULL
+);
+
+INSERT INTO latest_asset_snapshots (asset_id, snapshot_id)
+SELECT DISTINCT ON (asset_id) asset_id, id
+FROM asset_snapshots
+ORDER BY asset_id, created_at DESC;
+
+CREATE INDEX asset_snapshots_created_at_idx ON public.asset_snapshots (created_at);
+
+-- +migrate Down
+DROP INDEX asset_snapshots_created_at_idx;
+DROP TABLE latest_asset_snapshots;
@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE contract_tokens ADD COLUMN supply_complete boolean NOT NULL DEFAULT FALSE;
+
+-- The tokens tracked so far may have been created before the first ingested
+-- ledger, so they're unlisted until they're evaluated again.
+UPDATE assets SET is_valid = FALSE, validation_error = 'the supply of the token is incomplete'
+WHERE type = 'contract';
+
+-- Token symbols are namespaced, so that they never match a classic code.
+UPDATE assets SET code = left('contract:' || t.symbol, 64)
+FROM contract_tokens AS t
+WHERE assets.type = 'contract' AND assets.issuer_account = t.contract_id AND t.symbol <> '';
+
+-- +migrate Down
+UPDATE assets SET code = t.symbol
+FROM contract_tokens AS t
+WHERE assets.type = 'contract' AND assets.issuer_account = t.contract_id AND t.symbol <> '';
+
+ALTER TABLE contract_tokens DROP COLUMN supply_complete;
@@ -11,6 +11,23 @@
 // migrations/20190425110313-add_orderbook_stats.sql (749B)
 // migrations/20190426092321-add_aggregated_orderbook_view.sql (831B)
 // migrations/20220909100700-trades_pk_to_bigint.sql (220B)
+// migrations/20261017100000-add_candles_table.sql (1.958kB)
+// migrations/20261017110000-add_ingest_state_table.sql (192B)
+// migrations/20261017120000-add_pending_trades_table.sql (648B)
+// migrations/20261017130000-numeric_trade_amounts.sql (1.062kB)
+// migrations/20261017140000-add_liquidity_pools.sql (1.302kB)
+// migrations/20261017150000-add_orderbook_snapshots.sql (1.351kB)
+// migrations/20261017160000-add_orderbook_depth.sql (3.28kB)
+// migrations/20261017170000-add_asset_prices.sql (397B)
+// migrations/20261017180000-add_pagination_indices.sql (330B)
+// migrations/20261017190000-add_asset_evaluations.sql (528B)
+// migrations/20261017200000-add_asset_evaluation_toml_findings.sql (264B)
+// migrations/20261017210000-add_toml_cache.sql (771B)
+// migrations/20261017220000-add_asset_snapshots.sql (1.175kB)
+// migrations/20261017230000-add_contract_tokens.sql (1.236kB)
+// migrations/20261017235000-add_trade_flags.sql (649B)
+// migrations/20261018000000-add_latest_asset_snapshots.sql (527B)
+// migrations/20261018010000-add_contract_token_supply_complete.sql (844B)
 
 package bdata
 
@@ -79,7 +96,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _migrations20190404184050InitialSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x41\x8f\x9b\x30\x10\x85\xef\xfe\x15\x73\x64\xd5\xdd\x4a\xad\xaa\x5e\xf6\x44\x17\x2a\x45\xa5\xb0\xa5\x70\xc8\xc9\x32\x66\x14\x46\x6b\x3c\xd4\x36\x49\xe9\xaf\xaf\x20\x69\xab\xa4\x64\x95\x23\xf8\x7b\x6f\xac\xe7\x37\xe2\xe1\x01\xde\xf4\xb4\x73\x2a\x20\xd4\x83\x10\x4f\x65\x1a\x57\x29\x54\xf1\xa7\x2c\x85\x61\x6c\x0c\xe9\xb7\xca\x7b\x0c\x1e\x22\x01\x00\x40\x2d\x78\x74\xa4\x0c\xe4\x45\x05\x79\x9d\x65\xf0\x5c\x6e\xbe\xc6\xe5\x16\xbe\xa4\xdb\xfb\x85\xd1\xdc\x22\xe8\x4e\x39\xa5\x03\x3a\xd8\x2b\x37\x91\xdd\x45\xef\xde\xdf\xfd\x15\x1d\x41\xf2\x7e\x44\x07\x01\x7f\x86\x8b\x93\x30\x0d\x6b\x16\x1f\x3f\x5c\x5a\xd8\xb1\x97\x4a\x6b\x1e\x6d\xf0\x40\x36\xe0\x0e\xdd\x05\xa2\xc6\xd0\x49\x87\x3f\x46\x72\xd8\x42\xc3\x6c\x50\xd9\x75\x66\xcf\x5a\x35\x06\xaf\x41\xfd\x3c\x06\x5a\x1e\x67\x66\x70\xa8\xc9\x13\xff\x47\xcd\x71\x49\xcd\x36\x38\x36\x06\x5b\xd9\x4c\xb2\xe5\x5e\x91\xbd\x66\x6b\x75\xc7\x4e\xfe\xd1\xdd\x98\xdd\x99\xea\xe6\xb8\xc8\xcb\xbd\x32\x74\x2d\x86\xe5\x4c\x05\x62\x2b\xd1\x39\x5e\x7d\x1a\xa3\x7c\x38\x99\x04\xea\xd1\x07\xd5\x0f\x70\xa0\xd0\x2d\x9f\xf0\x8b\x2d\xae\x29\x74\x87\xfa\x05\x5f\xd1\x24\xe9\xe7\xb8\xce\x2a\xb0\x7c\x88\xfe\x5d\x5b\xdc\x3d\x0a\x11\x67\x55\x5a\x9e\x4a\x59\xe4\xd9\xf6\xbc\x99\xcb\x8c\x38\x49\xe0\xa9\xc8\xbf\x57\x65\xbc\xc9\xab\xe3\x23\xf8\x25\x4d\x79\x6c\x99\x7c\xc1\x09\xea\x7c\xf3\xad\x4e\x21\x9a\xff\xdf\x9f\xea\x37\x0f\x38\xdb\x83\x84\x0f\x56\x88\xa4\x2c\x9e\xd7\xf6\xe0\x51\xfc\x0e\x00\x00\xff\xff\x48\x16\x89\x51\x35\x03\x00\x00")
+var _migrations20190404184050InitialSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x41\x8f\x94\x40\x10\x85\xef\xfd\x2b\xea\xc8\xc6\x5d\x13\x8d\xf1\xb2\x27\x5c\x30\x99\x88\xb0\x22\x1c\xe6\xd4\x69\x9a\xca\x50\xd9\xa6\x0b\xbb\x9b\x19\xf1\xd7\x1b\x98\x51\x33\x23\xb3\x99\x23\xd4\xf7\x5e\x91\xc7\x2b\xf1\xf0\x00\x6f\x7a\xda\x39\x15\x10\xea\x41\x88\xa7\x32\x8d\xab\x14\xaa\xf8\x53\x96\xc2\x30\x36\x86\xf4\x5b\xe5\x3d\x06\x0f\x91\x00\x00\xa0\x16\x3c\x3a\x52\x06\xf2\xa2\x82\xbc\xce\x32\x78\x2e\x37\x5f\xe3\x72\x0b\x5f\xd2\xed\xfd\xc2\x68\x6e\x11\x74\xa7\x9c\xd2\x01\x1d\xec\x95\x9b\xc8\xee\xa2\x77\xef\xef\xfe\x8a\x8e\x20\x79\x3f\xa2\x83\x80\x3f\xc3\xc5\x24\x4c\xc3\x9a\xc5\xc7\x0f\x97\x16\x76\xec\xa5\xd2\x9a\x47\x1b\x3c\x90\x0d\xb8\x43\x77\x81\xa8\x31\x74\xd2\xe1\x8f\x91\x1c\xb6\xd0\x30\x1b\x54\x76\x9d\xd9\xb3\x56\x8d\xc1\x6b\x50\x3f\xaf\x81\x96\xc7\x99\x19\x1c\x6a\xf2\xc4\xff\x51\x73\x5c\x52\xb3\x0d\x8e\x8d\xc1\x56\x36\x93\x6c\xb9\x57\x64\xaf\xd9\x5a\xdd\xb1\x93\x7f\x74\x37\x66\x77\xa6\xba\x39\x2e\xf2\x72\xaf\x0c\x5d\x8b\x61\x99\xa9\x40\x6c\x25\x3a\xc7\xab\xbf\xc6\x28\x1f\x4e\x26\x81\x7a\xf4\x41\xf5\x03\x1c\x28\x74\xcb\x23\xfc\x62\x8b\x6b\x0a\xdd\xa1\x7e\xc1\x57\x34\x49\xfa\x39\xae\xb3\x0a\x2c\x1f\xa2\x7f\x9f\x2d\xee\x1e\x85\x88\xb3\x2a\x2d\x4f\xa5\x2c\xf2\x6c\x7b\xde\xcc\x65\x47\x9c\x24\xf0\x54\xe4\xdf\xab\x32\xde\xe4\x15\x1c\x27\x4b\x9a\xf2\xd8\x32\xf9\x82\x13\xd4\xf9\xe6\x5b\x9d\x42\x34\xbf\xbf\x3f\xd5\x6f\x5e\x70\x76\x07\x09\x1f\xac\x10\x49\x59\x3c\xaf\xdd\xc1\xa3\xf8\x3d\x00\x48\x16\x89\x51\x35\x03\x00\x00")
 
 func migrations20190404184050InitialSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -99,7 +116,7 @@ func migrations20190404184050InitialSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20190405112544Increase_asset_code_sizeSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe2\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x28\x4d\xca\xc9\x4c\xd6\x4b\x2c\x2e\x4e\x2d\x29\xe6\x52\x50\x50\x50\x80\xc8\x3a\xfb\xfb\x84\xfa\xfa\x29\x24\xe7\xa7\xa4\x2a\x94\x54\x16\xa4\x2a\x24\x67\x24\x16\x25\x26\x97\xa4\x16\x29\x94\x25\x16\x55\x66\xe6\xa5\x6b\x98\x99\x68\x5a\x73\x91\x62\x58\x62\x5e\x72\x46\x7e\x51\x3c\x58\x3a\x9e\xb0\xc9\xc8\xae\x76\xc9\x2f\xcf\xa3\x96\xbb\x0d\x8d\x68\xe5\x6e\xb0\xc9\x80\x00\x00\x00\xff\xff\x5e\x84\x69\x2a\x6e\x01\x00\x00")
+var _migrations20190405112544Increase_asset_code_sizeSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe2\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x28\x4d\xca\xc9\x4c\xd6\x4b\x2c\x2e\x4e\x2d\x29\xe6\x52\x50\x50\x50\x80\xc8\x3a\xfb\xfb\x84\xfa\xfa\x29\x24\xe7\xa7\xa4\x2a\x94\x54\x16\xa4\x2a\x24\x67\x24\x16\x25\x26\x97\xa4\x16\x29\x94\x25\x16\x55\x66\xe6\xa5\x6b\x98\x99\x68\x5a\x73\x91\x62\x58\x62\x5e\x72\x46\x7e\x51\x3c\x58\x3a\x9e\xb0\xc9\xc8\xae\x76\xc9\x2f\xcf\xa3\x96\xbb\x0d\x8d\x68\xe5\x6e\x43\x23\x4d\x6b\x2e\xc0\x00\x5e\x84\x69\x2a\x6e\x01\x00\x00")
 
 func migrations20190405112544Increase_asset_code_sizeSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -119,7 +136,7 @@ func migrations20190405112544Increase_asset_code_sizeSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20190408115724Add_new_asset_fieldsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x4d\x8f\x9b\x30\x10\x86\xef\xfc\x8a\x51\x2e\x39\x34\xa9\x7a\xeb\x21\x27\x5a\xc8\x89\x40\x45\xe1\x6c\x19\x3c\xa5\xa3\x1a\x83\x3c\xb6\x9a\xfc\xfb\x2a\x1f\xdd\x64\xd7\x64\x13\xb4\x57\xfb\x7d\x07\x33\x7a\x9e\x68\xbd\x86\x4f\x3d\x75\x56\x3a\x84\x7a\x8c\xe2\xac\x4a\x4b\xa8\xe2\x6f\x59\x0a\xa3\x6f\x34\xb5\x9f\x25\x33\x3a\x8e\x00\x00\xca\x34\x8f\x77\x29\x7c\x2f\xb2\x7a\x97\x03\x31\x7b\xb4\x50\x15\x97\xa4\xf8\x83\x87\x4d\xf4\x60\x44\x9c\x24\xff\xfb\x8a\x78\xd4\xf2\x20\x14\xb6\xd4\x4b\xcd\x40\xc6\x61\x87\x16\xf2\xa2\x82\xbc\xce\x32\x48\xd2\x6d\x5c\x67\x15\x7c\x5d\xbd\xed\x2e\x8c\xec\x71\x01\x0e\xf7\x2e\x8c\x2f\x97\x61\x5e\x21\xb7\x33\xf2\xed\x60\x14\x39\x1a\x0c\x3f\xdf\x21\x16\xa7\x1f\x15\xd2\xb4\xbf\x07\x8b\x0a\x9a\x61\xd0\x28\x4d\xd8\xde\xc6\xd9\xcf\x34\x18\xf0\x8b\xf6\xa8\x84\xf1\x7d\x83\x16\x1a\xea\xc8\x4c\x7c\xf8\x4b\x50\xeb\xe5\x7e\x7e\x89\x58\x78\xa3\xa9\x27\xf7\xde\x3b\xab\xb2\x0e\x9f\x69\x51\x61\x3f\x1e\x97\x23\xc8\xb0\xb3\xbe\x9d\xb9\xa8\x76\xd0\x5a\x3a\xb4\x52\x0b\xa9\x94\x45\x66\xfc\x50\x5b\x30\x75\x46\x3a\x6f\xe7\x8d\xf1\xc6\x59\x9a\x53\x59\xb0\x93\xce\xf3\x7d\x8c\x36\xd1\x2b\x9f\x92\xe1\xaf\x99\x65\xd4\xd5\xa3\xa3\x55\x67\xbf\x1e\x1a\x95\x94\xc5\x8f\x7b\x4a\xad\x82\xc4\x59\x9c\x89\xf3\x93\x20\xe1\xf9\x55\x84\xf0\x2e\x00\x3e\x8c\xdc\x22\x1d\xde\x5e\xc9\x9d\x1c\xfe\x02\x68\x78\x7b\x87\xc1\xa9\xf7\x87\xac\x3d\x93\xba\x61\x6a\x2a\x7e\x61\x67\x62\x8f\x17\x46\x36\xd1\xbf\x00\x00\x00\xff\xff\xd3\x88\x81\xf2\x5b\x05\x00\x00")
+var _migrations20190408115724Add_new_asset_fieldsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\xc1\x8e\x9b\x30\x10\x86\xef\x3c\xc5\x88\xcb\x1e\xba\x5b\xf5\xd6\x43\x4e\xb4\xb0\x27\x16\x2a\x0a\x67\xcb\xe0\x29\x1d\xd5\x18\xe4\xb1\xd5\xec\xdb\x57\x9b\xa4\x4a\xda\x21\x4d\x50\xaf\x9e\xff\x1f\x8c\xf5\x7d\xc9\xd3\x13\xbc\x9b\x68\xf4\x3a\x20\x74\x4b\x92\x95\x6d\xd1\x40\x9b\x7d\x2a\x0b\x58\x62\x6f\x69\x78\xaf\x99\x31\x70\x02\x00\xd0\x14\x55\xf6\x52\xc0\xe7\xba\xec\x5e\x2a\x20\xe6\x88\x1e\xda\xfa\x94\x54\x3f\xf0\x75\x97\xdc\x58\x91\xe5\xf9\xef\xbe\x21\x5e\xac\x7e\x55\x06\x07\x9a\xb4\x65\x20\x17\x70\x44\x0f\x55\xdd\x42\xd5\x95\x25\xe4\xc5\x73\xd6\x95\x2d\x7c\x7c\xfc\xbb\x9b\x3a\x3d\x61\x0a\x01\xf7\x41\xc6\x1f\x1e\x64\xde\x20\x0f\x1b\xf2\xc3\xec\x0c\x05\x9a\x1d\xdf\xdf\x21\x56\x87\x1f\x55\xda\x0d\xdf\x67\x8f\x06\xfa\x79\xb6\xa8\x9d\x6c\x3f\x67\xe5\xd7\x42\x2c\xf8\x46\x7b\x34\xca\xc5\xa9\x47\x0f\x3d\x8d\xe4\x56\x3e\xfc\x41\xd4\x26\xbd\xdf\x5e\x22\x56\xd1\x59\x9a\x28\xfc\xeb\x9e\x6d\xd3\xc9\x6b\x7a\x34\x38\x2d\x6f\x8f\xa3\xc8\x71\xf0\x71\xd8\xf8\x50\xc3\x6c\xad\x0e\xe8\xb5\x55\xda\x18\x8f\xcc\xf8\x5f\x6d\xc5\x34\x3a\x1d\xa2\xdf\xb6\x26\xba\xe0\x69\x4b\x25\xe5\xa0\x43\xe4\xeb\x18\xed\x92\x3f\x7c\xca\xe7\x9f\x6e\x93\x51\x67\x8f\xde\xac\x3a\xfa\x75\xd3\xa8\xbc\xa9\xbf\x5c\x53\xea\x51\x24\x8e\xe2\xac\x9c\x1f\x04\x91\xe7\x67\x11\xe4\x4c\x00\x2f\x23\x97\x48\xcb\xe9\x99\x5c\x39\xbb\x04\x54\x4e\xaf\x30\x28\x83\x6b\xac\xdd\x93\xba\x60\x6a\x2d\x7e\x62\x47\x8e\x52\x0e\x3a\x44\x4e\x77\xc9\xaf\x01\x00\xd3\x88\x81\xf2\x5b\x05\x00\x00")
 
 func migrations20190408115724Add_new_asset_fieldsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -139,7 +156,7 @@ func migrations20190408115724Add_new_asset_fieldsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20190408155841Add_issuers_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x53\xc1\x72\xda\x30\x10\xbd\xeb\x2b\x76\x72\x22\xd3\xa4\x3f\x90\x93\x8b\x45\xc7\x83\x23\x53\xc5\x3e\x70\xd2\x98\x68\x21\x9a\x1a\x89\x4a\xeb\xd2\xfc\x7d\xc7\xb6\x30\x1e\x06\x68\xaf\xfb\x9e\x76\xf7\xbd\xb7\x62\xcf\xcf\xf0\x65\x6f\x76\xbe\x26\x84\xea\xc0\xe6\x92\x27\x25\x87\x32\xf9\x96\x73\x38\xb4\x9b\xc6\xbc\x7f\x35\x21\xb4\xe8\x03\xcc\x18\x00\x80\xd1\x10\xd0\x9b\xba\x01\x51\x94\x20\xaa\x3c\x87\x95\xcc\x5e\x13\xb9\x86\x25\x5f\x3f\xf5\x9c\xe1\xa1\xfa\x89\x9f\x40\xf8\x87\x46\xe6\x80\xda\x7a\x8f\xd7\xea\xad\x6f\xae\x95\xc9\xed\x1b\x75\x03\xdb\xa2\x46\x5f\x93\x71\x56\x05\xf4\xbf\xd1\x5f\x23\xd5\x2d\x7d\xdc\x81\xc9\xd7\x36\x6c\xd1\xdf\xa1\x1c\x71\xa3\xfa\x2e\x68\xf5\xc1\x19\x4b\xd7\x48\x1a\x0f\x2e\x18\xba\xd3\xc6\xf9\x9d\xa2\xa3\x21\xba\x84\xd9\xe3\x0b\xeb\x92\xc8\x7a\xa7\xa3\x7d\xd0\xd9\x17\x3e\x5c\xdb\x68\xd8\x20\xb4\xd6\xfc\x6a\x91\x25\x79\xc9\x65\xcc\xa7\x10\xf9\xfa\x22\xa4\x7e\x4e\x92\xa6\x30\x2f\xc4\x5b\x29\x93\x4c\x94\x93\x34\xd4\xd0\x04\x2a\x91\xfd\xa8\x38\xcc\xce\x48\x5c\x20\xd1\x1a\x16\x4b\xd8\x7a\xb7\x87\x3a\x04\xa4\x00\xe4\xe0\xd4\x7b\x3a\x3b\x8e\x1d\x48\x93\xa9\x79\xf5\x2a\xe2\x03\x65\x34\x18\x4b\xb8\x43\x3f\x2a\x7d\x61\xff\xd5\x65\xdc\x7d\xdb\x6d\x3d\xc0\xea\x74\x87\x8b\x42\xf2\xec\xbb\xe8\xce\x0d\x66\xe3\xa8\x47\x90\x7c\xc1\x25\x17\x73\xfe\x06\xe3\xc9\x1a\x1d\x95\xa5\xd8\x20\x21\xac\x06\x6b\x97\xf8\x39\x15\xf9\x8f\x9d\x52\x59\xac\x4e\xd2\x1e\xce\x9e\x3d\x5c\x88\x99\xc6\x71\x5b\x51\x14\xf3\xee\x34\x46\x45\xfd\x3f\x39\x45\xd2\xd5\x9f\xce\x06\xc6\xed\xc7\x2f\x9a\xba\xa3\x65\xec\x6f\x00\x00\x00\xff\xff\xda\x82\x34\x58\xb6\x03\x00\x00")
+var _migrations20190408155841Add_issuers_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x53\x4d\x73\x9b\x30\x10\xbd\xeb\x57\xbc\xc9\xc9\x99\x26\xfd\x03\x3e\x51\x23\x77\x18\x13\xe1\x2a\x70\xf0\x89\xc1\xd1\xda\xd1\x14\x23\x57\x12\x75\xf3\xef\x3b\x80\x8c\x3d\x19\xec\xf6\xba\xfb\xf4\x3e\xf6\x01\x7b\x7e\xc6\x97\x83\xde\xdb\xca\x13\x8a\x23\x5b\x48\x1e\xe5\x1c\x79\xf4\x2d\xe5\x38\xb6\xdb\x5a\xbf\x7d\xd5\xce\xb5\x64\x1d\x66\x0c\x00\xb4\x82\x23\xab\xab\x1a\x22\xcb\x21\x8a\x34\xc5\x5a\x26\x2f\x91\xdc\x60\xc5\x37\x4f\x3d\x66\x78\x58\xfe\xa4\x0f\x78\xfa\xe3\x47\xe4\xb0\x6d\xaa\x03\x4d\xcd\x5b\x5b\x4f\x8d\xbd\x39\xd4\xe5\x8d\xdd\x8e\x14\xd9\xca\x6b\xd3\x94\x8e\xec\x6f\xb2\x53\xa0\xaa\xf5\xef\x77\xd6\xde\x56\x8d\xdb\x91\xbd\x03\x39\xd1\xb6\xec\x59\xa8\x51\x47\xa3\x1b\x3f\x05\x52\x74\x34\x4e\xfb\x3b\x34\xc6\xee\x4b\x7f\xd2\xde\x7f\x5e\xb3\xc7\x39\xeb\x9a\x48\xfa\x4b\x87\xbb\xa3\x3b\x9f\x7b\x37\x6d\xad\xb0\x25\xb4\x8d\xfe\xd5\x12\x8b\xd2\x9c\xcb\xd0\x4f\x26\xd2\xcd\xa7\x92\x7a\x9d\x28\x8e\xb1\xc8\xc4\x6b\x2e\xa3\x44\xe4\x01\xd1\xb5\x51\x0e\x24\x28\x44\xf2\xa3\xe0\x98\x5d\x36\xc1\x40\xa4\x14\x96\x2b\xec\xac\x39\xa0\x72\x8e\xbc\x83\x37\x38\x73\x5f\x6b\x07\xd9\x01\x74\xa5\x9a\x16\x2f\x22\x3c\x28\xb5\x82\x6e\x3c\xed\xc9\x8e\x49\xe7\xec\xbf\x58\x46\xef\xbb\xce\xf5\xb0\x2e\x83\x0d\x2c\x33\xc9\x93\xef\xa2\xfb\xdc\x30\x1b\xa5\x1e\x21\xf9\x92\x4b\x2e\x16\xfc\xf5\xec\x18\x33\xad\x42\xb2\x98\x6a\xf2\x84\x75\x2f\x88\x15\x7d\x5c\x87\xfc\x87\xa7\x58\x66\xeb\x73\xb4\x87\xcb\xcd\x1e\xe6\xec\x66\x1d\xb7\x13\x85\x30\x6f\x46\x51\x48\xd4\x71\x8d\x95\x74\xf3\xa7\xcb\x01\x83\xfb\xf1\x17\x8d\xcd\xa9\x61\xec\xef\x00\xda\x82\x34\x58\xb6\x03\x00\x00")
 
 func migrations20190408155841Add_issuers_tableSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -159,7 +176,7 @@ func migrations20190408155841Add_issuers_tableSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20190409152216Add_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\x41\x6e\xc2\x30\x10\x45\xf7\x3e\xc5\x2c\x41\x85\x13\xb0\x4a\xc1\x95\x50\xd3\x40\xdd\x64\xc1\x2a\x72\xe2\x21\x1d\xc9\x89\x23\x8f\x51\x2b\x4e\x5f\xe1\xd2\x54\xb4\x0d\x2a\x9b\x6c\xfe\xcb\xff\xcf\xd2\x88\xf9\x1c\xee\x5a\x6a\xbc\x0e\x08\x45\x2f\x96\x4a\x26\xb9\x84\x3c\xb9\x4f\x25\x04\xaf\x0d\x32\x4c\x04\x00\x00\x19\x60\xf4\xa4\x2d\x64\x9b\x1c\xb2\x22\x4d\x61\xab\xd6\x4f\x89\xda\xc1\xa3\xdc\xcd\x22\xf3\xea\x3c\x1d\x5d\x57\x92\x81\x80\xef\xe1\x9b\x2c\xb2\xf5\x73\x21\x67\x22\x52\x16\x4d\x83\xbe\xac\xad\x63\x2c\x03\xb5\x08\xa7\x0f\x07\xdd\xf6\xe1\x38\xfc\xf3\xd9\xe8\xf6\x7b\xf4\xbf\xfa\xce\x45\x95\x66\x2c\xc7\x88\x01\xd0\x75\xed\x0e\x5d\x18\xcf\xdb\x18\x1b\x77\xa8\x2c\x42\xef\xb1\x26\x26\xd7\xfd\x89\x32\x63\x38\x6d\x51\x17\xb0\x41\x0f\x4a\x3e\x48\x25\xb3\xa5\x7c\x81\x98\x31\x4c\xc8\x4c\xcf\x7a\x71\x16\xfd\x55\xc3\x2f\xe6\x8a\xe4\x80\xfc\xcb\x73\xa0\x6f\x52\x8d\xaf\x23\x2e\x19\xad\x45\x0f\x95\x73\x16\xf5\xcf\xea\xde\x53\x8d\xe3\xfb\x62\xba\x10\x17\xe7\xb4\x72\x6f\x9d\x58\xa9\xcd\xf6\xe2\x9c\x16\xe2\x23\x00\x00\xff\xff\xab\x60\xb3\x30\x74\x02\x00\x00")
+var _migrations20190409152216Add_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xb1\x6e\xc2\x30\x10\x86\x77\x3f\xc5\x8d\xa0\xc2\x13\x30\xa5\xe0\x4a\xa8\x69\xa0\x6e\x32\x30\x45\x4e\x7c\xa4\x27\x39\x71\xe4\x33\x6a\xc5\xd3\x57\xb8\x34\x15\x6d\x83\xca\xe2\xe5\xff\xfc\xdf\xa7\xd3\x89\xf9\x1c\xee\x5a\x6a\xbc\x0e\x08\x45\x2f\x96\x4a\x26\xb9\x84\x3c\xb9\x4f\x25\x04\xaf\x0d\x32\x4c\x04\x00\x00\x19\x60\xf4\xa4\x2d\x64\x9b\x1c\xb2\x22\x4d\x61\xab\xd6\x4f\x89\xda\xc1\xa3\xdc\xcd\x22\xf3\xea\x3c\x1d\x5d\x57\x92\x81\x80\xef\xe1\x9b\x2c\xb2\xf5\x73\x21\x67\x22\x52\x16\x4d\x83\xbe\xac\xad\x63\x2c\x03\xb5\x08\xa7\x87\x83\x6e\xfb\x70\x1c\xfe\x7c\x36\xba\xfd\x1e\xfd\xaf\xbe\x73\x51\xa5\x19\xcb\x31\x62\x00\x74\x5d\xbb\x43\x17\xc6\xf3\x36\xc6\xc6\x1d\x2a\x8b\xd0\x7b\xac\x89\xc9\x75\x7f\xa2\xcc\x18\x4e\x36\xd4\x05\x6c\xd0\x83\x92\x0f\x52\xc9\x6c\x29\x5f\x20\x66\x0c\x13\x32\xd3\xb3\x5e\x1c\x8b\xfe\xaa\xe1\x17\x73\x45\x72\x40\xfe\xe5\x39\xd0\x37\xa9\xc6\x45\x10\x97\x8c\xd6\xa2\x87\xca\x39\x8b\xfa\x67\x75\xef\xa9\xc6\xf1\xf9\x62\xba\x10\x17\xe7\xb4\x72\x6f\x9d\x58\xa9\xcd\xf6\xe2\x9c\x16\xe2\x63\x00\xab\x60\xb3\x30\x74\x02\x00\x00")
 
 func migrations20190409152216Add_trades_tableSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -179,7 +196,7 @@ func migrations20190409152216Add_trades_tableSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20190409172610Rename_assets_desc_descriptionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe2\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x28\x4d\xca\xc9\x4c\xd6\x4b\x2c\x2e\x4e\x2d\x29\xe6\x52\x50\x50\x50\x08\x72\xf5\x73\xf4\x75\x55\x70\xf6\xf7\x09\xf5\xf5\x53\x50\x4a\x49\x2d\x4e\x56\x52\x08\xf1\x57\x00\x31\x8a\x32\x0b\x4a\x32\xf3\xf3\xac\xb9\x50\x8c\x74\xc9\x2f\xcf\x23\xc9\x50\x24\xa3\x40\x26\x43\xec\xb0\xe6\x02\x04\x00\x00\xff\xff\x80\x17\x6b\xa4\xa8\x00\x00\x00")
+var _migrations20190409172610Rename_assets_desc_descriptionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe2\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x28\x4d\xca\xc9\x4c\xd6\x4b\x2c\x2e\x4e\x2d\x29\xe6\x52\x50\x50\x50\x08\x72\xf5\x73\xf4\x75\x55\x70\xf6\xf7\x09\xf5\xf5\x53\x50\x4a\x49\x2d\x4e\x56\x52\x08\xf1\x57\x00\x31\x8a\x32\x0b\x4a\x32\xf3\xf3\xac\xb9\x50\x8c\x74\xc9\x2f\xcf\x23\xc9\x50\x24\xa3\x40\x26\x2b\xa5\xa4\x16\x27\x2b\x59\x73\x01\x06\x00\x80\x17\x6b\xa4\xa8\x00\x00\x00")
 
 func migrations20190409172610Rename_assets_desc_descriptionSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -199,7 +216,7 @@ func migrations20190409172610Rename_assets_desc_descriptionSql() (*asset, error)
 	return a, nil
 }
 
-var _migrations20190410094830Add_assets_issuer_account_fieldSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\xbf\xee\x82\x30\x1c\x04\xf0\xbd\x4f\x71\xe3\xef\x17\xc5\x17\x60\xaa\x94\x81\xa4\x7e\xab\xd8\x0e\x4e\x04\x6b\x63\x88\x0a\x84\x96\xa8\x6f\x6f\x8c\x3a\x48\xfc\x37\x5f\xf2\xc9\xdd\xb1\x28\xc2\xe8\x50\x6d\xbb\x32\x38\x98\x96\x71\xa9\xd3\x1c\x9a\x4f\x65\x8a\xb6\x5f\xef\x2b\x3b\x29\xbd\x77\xc1\x33\x00\xe0\x42\x20\x51\xd2\xcc\x08\x95\xf7\xbd\xeb\x8a\xd2\xda\xa6\xaf\x03\x82\x3b\x05\x90\xd2\x20\x23\x65\xcc\xbe\x38\x22\x57\x73\x24\x8a\x96\x3a\xe7\x19\x69\xdc\xa2\xc2\x36\x1b\x57\xdc\xe1\x9d\x3b\x0f\x18\x45\x72\xf5\xb6\xd3\x27\xea\xd1\xd1\x50\xb6\x30\x29\xfe\xae\xd9\x78\x30\xe0\x3f\x66\xec\xe9\x0b\xd1\x1c\xeb\xdf\x56\xbc\xb8\x23\x66\x97\x00\x00\x00\xff\xff\xd9\xd1\xc9\x0f\x58\x01\x00\x00")
+var _migrations20190410094830Add_assets_issuer_account_fieldSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\xbf\xee\x82\x30\x1c\x04\xf0\xbd\x4f\x71\xe3\xef\x17\xc5\x17\x60\xaa\x94\x81\xa4\x7e\xab\xd8\x0e\x4e\x04\x6b\x63\x88\x0a\x84\x96\xa8\x6f\x6f\x8c\x3a\x48\xfc\x37\x5f\xf2\xc9\xdd\xb1\x28\xc2\xe8\x50\x6d\xbb\x32\x38\x98\x96\x71\xa9\xd3\x1c\x9a\x4f\x65\x8a\xb6\x5f\xef\x2b\x3b\x29\xbd\x77\xc1\x33\x00\xe0\x42\x20\x51\xd2\xcc\x08\x95\xf7\xbd\xeb\x8a\xd2\xda\xa6\xaf\x03\x82\x3b\x05\x90\xd2\x20\x23\x65\xcc\xbe\x38\x22\x57\x73\x24\x8a\x96\x3a\xe7\x19\x69\xdc\xa2\xc2\x36\x1b\x57\xdc\xe1\x9d\x3b\x0f\x18\x45\x72\xf5\xb6\xd3\x27\xea\xd1\xd1\x50\xb6\x30\x29\xfe\xae\xd9\x78\x30\xe0\x3f\x66\xec\xe9\x0b\xd1\x1c\xeb\xdf\x56\xbc\xb8\x23\x66\x97\x01\x00\xd9\xd1\xc9\x0f\x58\x01\x00\x00")
 
 func migrations20190410094830Add_assets_issuer_account_fieldSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -219,7 +236,7 @@ func migrations20190410094830Add_assets_issuer_account_fieldSql() (*asset, error
 	return a, nil
 }
 
-var _migrations20190411165735Data_seed_and_indicesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x54\x4d\x6f\x1a\x3d\x10\xbe\xef\xaf\xf0\x2d\xa0\x37\x81\xdc\x5e\x29\x51\x0f\x28\x6c\x54\x24\x02\x15\x90\x36\x37\xcb\xd8\x03\x58\xf1\xc7\xd6\x33\x86\xf0\xef\xab\xdd\xb5\x13\x36\x24\x6a\x7a\xda\xf9\x78\x3c\xf3\xec\x7c\x15\x57\x57\xec\x3f\xab\xb7\x41\x10\xb0\xc7\xaa\x56\x97\x00\x8a\x4d\x10\x23\x04\xb6\xf1\x81\x5d\x38\x41\x7a\x0f\x17\x4c\x20\x02\x61\x31\x99\x2d\xcb\xc5\x8a\x4d\x66\xab\x39\xab\xe2\xda\x68\x39\xd0\x0d\x1a\x59\xaf\x60\x8c\x25\x23\x7f\x86\xe3\x65\xa3\x3b\x61\xa1\x95\x62\x30\xad\x40\xde\x1a\xfe\xaa\x6d\x40\x41\x10\xa4\xbd\xe3\x08\x61\x0f\xa1\x35\x8b\x48\xbb\x8e\x81\x82\x70\xb8\x81\xd0\x31\x1e\x60\xcd\x1b\x24\x38\x55\x79\xed\xa8\x35\x2b\xa8\x3c\x6a\xea\x40\x7d\xd8\x72\x3a\x68\x22\x08\x45\x9f\xfd\x1c\x4d\x1f\xcb\x65\xe2\x9c\x7f\xb2\x05\x5e\x2c\x09\x8c\x11\x81\x8d\x61\x0f\xc6\x57\x16\x1c\xb1\x7b\x1f\x9d\x6a\x58\x66\xd4\x8e\xa8\xba\x19\x0e\xb1\x05\x0f\x7c\xd8\x66\xcf\xbf\x7e\xeb\x48\x78\x33\x1c\x26\x76\x03\xe9\x6d\x0e\x5b\x47\x2d\xfa\xb7\xc5\x47\x75\x6f\x3b\x92\x7e\x41\x7a\x95\xca\x4c\xc7\x2a\x49\x2e\x5a\x2e\xa4\xf4\xd1\x11\x9e\x14\x35\xc0\xef\xa8\x03\xa8\x8e\x69\xef\xa5\x58\x9b\xf4\x50\xd8\xfa\x4d\x92\xeb\x2c\x5c\x7a\x47\xc1\x1b\x03\x8a\xaf\x8f\x5c\x79\x2b\xb4\x4b\x7e\x27\x77\x3e\xf0\x0c\xcb\x2c\x3a\xe6\x37\x4a\x1a\xf9\x5e\x18\x9d\x72\x37\x62\xdb\x7a\x08\xc1\xa7\x46\x19\x81\x74\x8a\x6a\x74\xb9\x03\xf9\x9c\x39\x2b\x8d\x95\x11\x47\xae\x40\x6a\x2b\x0c\xbe\x9f\x34\x05\x28\x83\xae\xea\xc0\x97\xa9\x3a\x4e\xe9\x5a\xc5\x57\x1a\x2d\xb3\x96\x66\x8e\xbb\xd1\x2f\xa0\xb8\x8b\x76\x9d\x87\xc6\x8a\x97\x8e\xae\x91\x47\x67\xb4\xd5\x94\xdf\x04\x50\x60\x9b\x54\x5c\x3b\xa4\x10\xe5\x49\x1e\xe9\x8d\x11\x04\x41\x18\x2e\x94\x0a\x80\x08\x9f\x7a\x38\xea\xad\x13\x14\xc3\x1b\x24\x3a\x0a\x3a\xab\x48\x82\xe2\x2b\xff\x7a\xe5\x78\xae\x50\x52\x53\xaf\xcf\xa6\xfb\x69\xfa\x90\x47\xad\x33\xe8\xd7\xed\xe7\x7e\x34\x5d\x96\x67\xe2\xf5\x20\xb9\x57\x8b\xc7\xf2\xe3\xc1\x3d\xf7\x38\x7f\xe8\xf5\xcf\xc4\xff\xdf\xed\xd5\x34\x5a\x70\xf8\xc9\x36\x9c\x52\xf8\x8c\xe6\x57\x37\xab\xb7\x2c\xa7\xe5\xdd\x8a\x69\xc5\xee\x17\xf3\x87\xf7\x07\xeb\xd7\xf7\x72\x51\x9e\x1c\x2c\xf6\xed\xed\xdc\x8d\x66\xe3\xd3\x8b\x51\xbb\xfe\xb2\xa6\xfd\x6e\x89\x9b\xad\xbd\x5b\x94\xa3\x55\xc9\x26\xb3\x71\xf9\x54\x1f\x30\x05\xc8\x0d\xa8\x2d\x04\x2e\x8d\x47\xe0\xa4\x2d\x70\xad\x5e\xd8\x7c\x96\xd9\xb5\x30\xd6\x3b\xc3\xb1\x71\xb9\xbc\xab\xa3\x76\xce\xf6\xd8\x1f\x5c\x31\x5e\xcc\x7f\x7c\x21\xcb\x6d\xf1\x27\x00\x00\xff\xff\xa1\x61\x7e\x4c\xf2\x05\x00\x00")
+var _migrations20190411165735Data_seed_and_indicesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x54\xcd\x6e\x1a\x31\x10\xbe\xef\x53\xf8\xb6\xa0\x26\x90\x5b\xa5\x44\x39\xa0\xb0\x51\x91\x08\x54\x40\xda\xdc\x2c\x63\x0f\x60\xc5\x3f\xdb\xf1\x18\xc2\xdb\x57\xbb\xeb\x6d\x58\x48\xd4\xf6\xe4\xf9\xf9\x66\xe6\xf3\x78\x3c\xd9\xf5\x35\xfb\x62\xf5\x16\x05\x01\x7b\x2e\x2b\x75\x09\xa0\xd8\x24\x84\x08\xc8\x36\x1e\x59\xee\x04\xe9\x3d\xe4\x4c\x84\x00\x14\xb2\xc9\x6c\x59\x2c\x56\x6c\x32\x5b\xcd\x59\x19\xd7\x46\xcb\x81\xae\xd1\x81\xf5\x32\xc6\x58\x32\xf2\x57\x38\x5e\xd5\xba\x13\x16\x1a\x29\xa2\x69\x04\xf2\xd6\xf0\x3f\xda\x06\x14\xa0\x20\xed\x1d\x0f\x80\x7b\xc0\x06\x24\x22\xed\x3a\x06\x42\xe1\xc2\x06\xb0\x63\x3c\xc0\x9a\xd7\x48\x70\xaa\xf4\xda\x51\x13\xac\xa0\xf4\x41\x53\x07\xea\x71\xcb\xe9\xa0\x89\x00\xb3\x3e\xfb\x31\x9a\x3e\x17\xcb\xc4\xb9\xbd\x64\x13\x9c\x2f\x09\x8c\x11\xc8\xc6\xb0\x07\xe3\x4b\x0b\x8e\xd8\xa3\x8f\x4e\xd5\x2c\x5b\xd4\x8e\xa8\xbc\x1d\x0e\x43\x03\x1e\x78\xdc\xb6\x9e\xff\x3d\xab\x4c\xe1\x76\x38\x4c\xec\x06\xd2\xdb\x36\x6d\x95\x35\xeb\xdf\x65\x1f\xf5\xbd\x79\x91\x74\x05\xe9\x55\x6a\x33\x1d\xcb\x24\xb9\x68\xb9\x90\xd2\x47\x47\xe1\xa4\xa9\x08\xbf\xa2\x46\x50\x1d\xd3\xde\x4b\xb1\x36\x29\x50\xd8\x2a\x26\xc9\x55\x15\x2e\xbd\x23\xf4\xc6\x80\xe2\xeb\x23\x57\xde\x0a\xed\x92\xdf\xc9\x9d\x47\xde\xc2\x5a\x16\x1d\xf3\x3b\x25\x1d\xf8\x5e\x18\x9d\x6a\xd7\x62\xdd\x54\x0e\x88\x3e\x3d\x94\x11\x81\x4e\x51\xb5\x2e\x77\x20\x5f\x5b\xce\x4a\x87\xd2\x88\x23\x57\x20\xb5\x15\x26\x9c\x4f\x9a\x82\x20\x51\x97\x55\xe2\xab\xd4\x1d\xa7\x74\xa5\x26\xa8\x0e\x89\x59\x43\xb3\xcd\xbb\xd1\x6f\xa0\xb8\x8b\x76\xdd\x0e\x8d\x15\x6f\x1d\x5d\x07\x1e\x9d\xd1\x56\x53\x1b\x83\xa0\xc0\xd6\xa5\xb8\x76\x81\x30\xca\x93\x3a\xd2\x1b\x23\x08\x50\x18\x2e\x94\x42\x08\x01\x3e\xf5\xf0\xa0\xb7\x4e\x50\xc4\x77\x48\x74\x84\xba\x55\x03\x09\x8a\x49\x6e\xbe\x1c\xd7\xaa\xa3\xa6\xb7\xbe\x98\xee\x97\xe9\x53\x7e\xf5\xc1\xa0\xdf\x34\xc6\xc7\xd1\x74\x59\x5c\x88\x37\x83\xe4\x5e\x2d\x9e\x8b\xb3\x81\xcd\x3f\xf3\x38\x7f\xe8\xf5\x2f\xc4\xaf\x67\xff\x6a\x1a\x2d\xb8\x90\x77\x43\xf3\xfc\x92\x42\xe7\x38\x71\xe4\x9f\xc4\x9e\x9f\xbd\x65\x31\x2d\x1e\x56\x4c\x2b\xf6\xb8\x98\x3f\x9d\x2f\xac\x9f\xdf\x8a\x45\x91\x8c\xd5\xc2\x62\xf7\xef\xeb\x6e\x34\x1b\x9f\x6e\x0c\x76\xff\xd7\x6f\xda\xef\xb6\xb8\xfe\xb5\x0f\x8b\x62\xb4\x2a\xd8\x64\x36\x2e\x5e\x18\xa1\x50\x10\xb8\x01\xb5\x05\xe4\xd2\xf8\x00\x9c\xb4\x05\xae\xd5\x1b\x9b\xcf\x5a\x76\x0d\x8c\xf5\x2e\x70\x6c\x5c\x2c\x1f\xaa\xac\x9d\xb5\x3d\xf6\x07\x97\x8d\x17\xf3\xef\xff\x50\xe5\x2e\xfb\x3d\x00\xa1\x61\x7e\x4c\xf2\x05\x00\x00")
 
 func migrations20190411165735Data_seed_and_indicesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -239,7 +256,7 @@ func migrations20190411165735Data_seed_and_indicesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20190425110313Add_orderbook_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\xcd\x6e\xea\x30\x10\x85\xf7\x7e\x8a\x59\x82\x2e\xdc\x17\x60\x95\x4b\x7c\x25\xd4\x34\xa1\x26\x59\xb0\xb2\x1c\x3c\x0a\xa3\xfc\x38\xf2\x38\x45\xed\xd3\x57\x44\x15\x15\x69\x0b\x55\xd7\x3e\xe7\x7c\x23\xf9\x13\xcb\x25\xfc\x69\xa9\xf2\x26\x20\x14\xbd\x58\x2b\x19\xe5\x12\xf2\xe8\x5f\x22\xc1\x79\x8b\xbe\x74\xae\xd6\x1c\x4c\x60\x98\x09\x00\x00\xb2\xc0\xe8\xc9\x34\x90\x66\x39\xa4\x45\x92\xc0\x56\x6d\x1e\x23\xb5\x87\x07\xb9\x5f\x88\x31\x54\x1a\x46\x6d\x98\x31\x68\xb2\x40\x5d\xc0\x0a\x3d\x28\xf9\x5f\x2a\x99\xae\xe5\x0e\xc6\x37\x86\x19\xd9\xf9\x65\x67\x31\x56\x0f\x6e\xe8\x02\xfa\x5f\xb4\xc7\x7a\x37\xb4\xba\x24\xcb\x50\x52\x45\x5d\x98\x8c\x97\x64\xf5\xb3\x6b\x86\x16\xc1\xba\xa1\x6c\x10\x7a\x8f\x07\x62\x72\xdd\x24\x79\xa4\xea\x88\x1c\xce\x5b\xb7\xa2\x17\xa6\xe1\xfa\x1b\xa6\xe1\xfa\x87\xcc\xc6\x9d\xce\x48\xc3\xf5\x5d\x24\xf7\x1e\xcd\xcd\xcb\x3e\x52\xba\x25\xab\x7b\x77\xbe\xec\xde\xec\xd0\x5b\x13\xd0\x6a\x13\x20\x50\x8b\x1c\x4c\xdb\x87\xd7\x4b\x4a\xcc\x57\x22\x4a\x72\xa9\xde\x0d\xc9\xd2\x64\x0f\xfd\x50\x36\x74\xf8\x3b\xb1\x65\x9c\x8b\xe2\x18\xd6\x59\xba\xcb\x55\xb4\x49\xf3\xa9\x50\x7a\xb4\xe4\xfa\xbf\x6b\x7c\x81\x22\xdd\x3c\x15\x12\x66\x57\x12\x2d\x3e\x89\x31\x5f\x89\x2b\x7d\x63\x77\xea\x44\xac\xb2\xed\xd7\xfa\xae\xc4\x5b\x00\x00\x00\xff\xff\x06\x01\x94\xcd\xed\x02\x00\x00")
+var _migrations20190425110313Add_orderbook_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\xcd\x6e\xea\x30\x10\x85\xf7\x7e\x8a\x59\x82\x2e\xdc\x17\x60\x95\x4b\x7c\x25\xd4\x34\xa1\x26\x59\xb0\xb2\x1c\x3c\x0a\xa3\xfc\x38\xf2\x38\x45\xed\xd3\x57\x44\x15\x15\x69\x0b\x55\xd7\x39\xe7\x7c\xa3\xf8\x13\xcb\x25\xfc\x69\xa9\xf2\x26\x20\x14\xbd\x58\x2b\x19\xe5\x12\xf2\xe8\x5f\x22\xc1\x79\x8b\xbe\x74\xae\xd6\x1c\x4c\x60\x98\x09\x00\x00\xb2\xc0\xe8\xc9\x34\x90\x66\x39\xa4\x45\x92\xc0\x56\x6d\x1e\x23\xb5\x87\x07\xb9\x5f\x88\x31\x54\x1a\x46\x6d\x98\x31\x68\xb2\x40\x5d\xc0\x0a\x3d\x28\xf9\x5f\x2a\x99\xae\xe5\x0e\xc6\x6f\x0c\x33\xb2\xf3\xcb\xce\x62\xac\x1e\xdc\xd0\x05\xf4\xbf\x68\x8f\xf5\x6e\x68\x75\x49\x96\xa1\xa4\x8a\xba\x30\x19\x2f\xc9\xea\x67\xd7\x0c\x2d\x82\x75\x43\xd9\x20\xf4\x1e\x0f\xc4\xe4\xba\x49\xf2\x48\xd5\x11\x39\x9c\xb7\x6e\x45\x2f\x4c\xc3\xf5\x37\x4c\xc3\xf5\x0f\x99\x8d\x3b\x9d\x91\x86\xeb\xbb\x48\xee\x3d\x9a\x9b\x97\x7d\xa4\x74\x4b\x56\xf7\xee\xfc\x37\xee\xcd\x0e\xbd\x35\x01\xad\x36\x01\x02\xb5\xc8\xc1\xb4\x7d\x78\xbd\xa4\xc4\x7c\x25\xa2\x24\x97\xea\xdd\x90\x2c\x4d\xf6\xd0\x0f\x65\x43\x87\xbf\x13\x5b\xc6\x2b\xa3\x38\x86\x75\x96\xee\x72\x15\x6d\xd2\x7c\x2a\x94\x1e\x2d\xb9\x7e\xef\x1a\x5f\xa0\x48\x37\x4f\x85\x84\xd9\x95\x44\x8b\x4f\x62\xcc\x57\xe2\x4a\xdf\xd8\x9d\x3a\x11\xab\x6c\xfb\xb5\xbe\x2b\xf1\x36\x00\x06\x01\x94\xcd\xed\x02\x00\x00")
 
 func migrations20190425110313Add_orderbook_statsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -259,7 +276,7 @@ func migrations20190425110313Add_orderbook_statsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20190426092321Add_aggregated_orderbook_viewSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x93\xcf\x6e\xb2\x40\x14\xc5\xf7\x3c\xc5\xdd\xa9\xf9\xd0\x7c\x7b\xd3\x05\xc5\xb1\xa1\xb1\x62\x40\xfb\x67\x35\xb9\x30\x13\x9c\x20\x8c\xe1\x0e\xb5\x8f\xdf\x0c\x44\x81\x88\x65\x45\x0e\xf7\x77\x39\x99\x73\xc6\x99\xcf\xe1\x5f\xa1\xb2\x0a\x8d\x84\xc3\xd9\xf1\x23\xe6\xed\x19\x84\x11\x44\x6c\xb7\xf1\x7c\x06\xef\x01\xfb\x00\xcc\xb2\x4a\x66\x68\xa4\xe0\xba\x12\xb2\x4a\xb4\xce\xc1\x8b\x1d\x00\x80\x98\x6d\x98\xbf\x6f\x5e\xed\x93\xea\x32\x45\x33\x4d\x3c\x22\x69\x16\xa9\x16\xd2\x85\x09\x9f\xb8\x90\x76\xca\x0c\x90\xc0\x54\x28\x24\x3f\xa3\xaa\x78\x89\x85\x74\x6f\x1b\x7a\xa8\x9d\x4b\x90\x24\x47\x2b\xf1\x66\x5b\xf7\xa7\xe1\x5c\xaa\xeb\xd2\xc8\x6a\x74\xd4\x0f\xbd\x0d\x8b\x7d\x36\xa5\xba\x98\x6a\x5a\x94\x75\xc1\x13\x25\x68\xe6\xc2\xff\x19\x78\x31\x5c\x85\xc7\x48\xa2\x04\xff\xd6\xa7\xba\x90\x16\x5a\xb4\x58\x27\x8e\x80\x05\xfe\x58\xf0\xa8\xb2\xa3\x24\x63\xd7\xf7\xc8\x9e\xfa\xb7\x4d\xa4\x7c\x68\xd3\x0a\x8f\x11\xa4\xfc\xde\x66\x27\x8e\xd9\x54\xa5\x05\x4f\xfa\x62\xfd\x20\xe5\x3d\xb0\x13\x1b\x6e\x1d\x85\x6f\x70\x2b\x00\x27\x83\x86\xec\x98\xa6\xe6\xf3\x6b\x18\x6c\xa1\x39\xfe\x46\x6d\x73\x84\x70\x0b\xf6\xf8\xba\x14\x95\x80\xa7\x6b\xc8\x4a\x8c\x91\x6d\xb2\xa0\x4b\x4b\x0e\x73\x6d\xe0\x74\x00\xbf\x44\xe1\x61\x07\xcf\x5f\x77\x8d\xba\xab\xce\x48\x47\x96\xce\xe0\x0a\xac\xf4\xa5\x74\x56\x51\xb8\x6b\x7b\x1f\xac\x81\x7d\x06\xf1\x3e\x1e\xbd\x01\x4b\xe7\x37\x00\x00\xff\xff\x72\xc3\x7e\xff\x3f\x03\x00\x00")
+var _migrations20190426092321Add_aggregated_orderbook_viewSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x93\xcf\x8f\xaa\x30\x10\xc7\xef\xfc\x15\x73\x53\xf3\x90\xbc\xbb\x79\x07\x1e\xd6\x0d\x1b\x57\x0c\xe8\xfe\x38\x35\x03\x6d\xb0\x41\xa8\x61\xca\xba\x7f\xfe\xa6\x10\x05\x22\x26\x1c\xc8\x87\xf9\x4c\x27\xcc\xb7\xce\x72\x09\x7f\x4a\x95\xd7\x68\x24\x1c\x2f\x4e\x10\x33\xff\xc0\x20\x8a\x21\x66\xfb\xad\x1f\x30\x78\x0f\xd9\x07\x60\x9e\xd7\x32\x47\x23\x05\xd7\xb5\x90\x75\xaa\x75\x01\x7e\xe2\x00\x00\x24\x6c\xcb\x82\x43\xfb\x6a\x9f\x4c\x57\x19\x9a\x79\xea\x13\x49\xe3\x65\x5a\x48\x17\x66\x7c\xe6\x42\xd6\x93\x05\x20\x81\xa9\x51\x48\x7e\x41\x55\xf3\x0a\x4b\xe9\xde\x3b\x0c\x54\x5b\x97\x22\x49\x8e\x16\x71\xeb\xf6\x75\x83\x86\xb6\x2e\xd3\x4d\x65\x64\x3d\x59\x1a\x44\xfe\x96\x25\x01\x9b\x53\x53\xce\x35\x79\x55\x53\xf2\x54\x09\x5a\xb8\xf0\x77\x01\x7e\x02\x37\xf0\x5c\x49\x95\xe0\xdf\xfa\xdc\x94\xd2\x4a\x5e\xa7\xf5\x70\x42\x2c\xf1\xc7\x8a\x27\x95\x9f\x24\x19\x7b\xde\xc0\x1c\xd0\xe7\x67\xda\xa9\x90\x8a\xf1\x98\x16\x3c\x57\x90\x8a\xc7\x31\x7b\x38\x21\x96\xaa\xb2\xe2\x59\x5f\xed\x3c\x48\xc5\x40\xec\x61\xeb\x6d\xe2\xe8\x0d\xee\x01\xe0\x64\xd0\x90\xed\xaf\xa9\xfd\xfc\x1a\x85\x3b\x68\x7f\x7f\x4b\xbb\x3d\x42\xb4\x03\x4d\xde\x60\x8b\x4a\xc0\xbf\xdb\x92\x95\x98\x32\xbb\xcd\x82\xae\xac\x39\xde\x6b\x2b\x67\x23\xf9\x25\x8e\x8e\x7b\xf8\xff\xf5\x90\xa8\x87\xe8\x4c\x64\x64\xe5\x8c\xae\xc0\x5a\x5f\x2b\x67\x1d\x47\xfb\x2e\xf7\xe1\x06\xd8\x67\x98\x1c\x92\xc9\x1b\xb0\x72\x7e\x07\x00\x72\xc3\x7e\xff\x3f\x03\x00\x00")
 
 func migrations20190426092321Add_aggregated_orderbook_viewSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -279,7 +296,7 @@ func migrations20190426092321Add_aggregated_orderbook_viewSql() (*asset, error)
 	return a, nil
 }
 
-var _migrations20220909100700Trades_pk_to_bigintSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe2\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x29\x4a\x4c\x49\x2d\x56\x80\x08\x39\xfb\xfb\x84\xfa\xfa\x29\x64\xa6\x28\x84\x44\x06\xb8\x2a\x38\x79\xba\x7b\xfa\x85\x58\x43\xd5\x07\xbb\x06\x86\xba\xfa\x39\xc3\xb4\xc4\x67\xa6\xc4\x17\xa7\x16\x2a\x38\x06\x43\xd5\x29\x38\x47\x3a\xfb\xb8\x5a\x73\x71\xa1\x58\xe7\x92\x5f\x9e\x47\xb4\x85\x44\xd9\x06\xb2\xca\xcf\x1f\x66\x1b\x20\x00\x00\xff\xff\xed\x82\xee\x3c\xdc\x00\x00\x00")
+var _migrations20220909100700Trades_pk_to_bigintSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe2\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x29\x4a\x4c\x49\x2d\x56\x80\x08\x39\xfb\xfb\x84\xfa\xfa\x29\x64\xa6\x28\x84\x44\x06\xb8\x2a\x38\x79\xba\x7b\xfa\x85\x58\x43\xd5\x07\xbb\x06\x86\xba\xfa\x39\xc3\xb4\xc4\x67\xa6\xc4\x17\xa7\x16\x2a\x38\x06\x43\xd5\x29\x38\x47\x3a\xfb\xb8\x5a\x73\x71\xa1\x58\xe7\x92\x5f\x9e\x47\xb4\x85\x44\xd9\x06\xb2\xca\xcf\x5f\xc1\x39\xd2\xd9\xc7\xd5\x9a\x0b\x30\x00\xed\x82\xee\x3c\xdc\x00\x00\x00")
 
 func migrations20220909100700Trades_pk_to_bigintSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -299,6 +316,346 @@ func migrations20220909100700Trades_pk_to_bigintSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations20261017100000Add_candles_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x55\xdd\x72\x9b\x3c\x10\xbd\xe7\x29\xf6\xce\x22\x1f\xf6\x17\x4f\xd3\x4c\x3b\xb9\x22\x46\x69\x69\x1d\x48\x05\x6e\xeb\xe9\x74\x18\x19\x14\x5b\x53\xfe\x46\x92\x1b\xa7\x4f\xdf\x41\x18\x6c\x30\xad\xdb\xde\x78\xbc\xda\xb3\x67\xd9\xb3\x47\x30\x1e\xc3\x7f\x19\x5f\x0b\xaa\x18\x2c\x4a\x63\x46\xb0\x1d\x62\x08\xed\xdb\x39\x86\x98\xe6\x49\xca\x24\x20\x03\x00\x80\x27\xb0\xe2\x6b\xc9\x04\xa7\x29\x78\x7e\x08\xde\x62\x3e\x87\x07\xe2\xde\xdb\x64\x09\xef\xf1\xd2\x32\x34\x6e\x45\x25\x8b\xa8\x94\x4c\x45\x3c\x01\x9e\x2b\xb6\x66\x02\x08\xbe\xc3\x04\x7b\x33\x1c\x80\xce\x49\x40\x3c\x31\x5b\x1e\x4b\x97\xc6\xc5\x36\x57\x4c\xfc\x63\xb5\x60\xb2\x48\xb7\x8a\x17\x39\x28\xb6\x53\xbd\x6c\x51\xb2\x3c\x52\x3c\x63\x50\xfd\x48\x45\xb3\x52\xfd\x38\xc2\xb4\x20\x48\x8a\xed\x2a\x65\x50\x0a\x16\x73\x59\xb1\x75\x89\x36\x7c\xbd\x39\x87\x49\x8b\xa7\x73\x90\x38\x2d\x24\x3b\x07\xd2\x5a\x7e\x2f\xd2\x6d\x76\x16\xda\x68\xf7\x67\x68\x25\x68\xc2\x22\x5d\x53\x6d\x95\xe7\xc7\x72\x69\xbe\x47\x2e\xa4\x8a\x6a\xdc\x6f\x64\xab\xa0\x29\x3d\x8f\x34\xcc\x1b\xc3\xb0\xe7\x21\x26\x7b\x73\xf9\xde\x7c\x09\xe5\x76\x95\xf2\x78\xb2\x37\x9a\xee\x6b\x3b\x0e\xcc\x7c\x2f\x08\x89\xed\x7a\x61\xe3\xc1\xa8\xa4\x5c\x44\x87\x0d\x47\xed\x3a\xa3\x6f\xec\x19\x16\x9e\xfb\x61\x81\x01\x75\xbc\x67\x9d\xf8\xc9\x82\x03\x83\x05\x2d\x45\xf5\x68\x7b\xe3\xbb\x9e\x83\x3f\xb7\x4d\x07\xfb\xf1\x64\x07\xbe\xd7\x7b\x74\x40\x83\xcc\xe0\xe0\x60\x56\xd1\x8f\xc7\x10\x30\x96\x80\xda\xb0\x86\x1d\x9e\xb8\xda\xe8\x03\x2d\xb2\x04\xb5\xa1\x0a\xa8\x60\x40\x53\xc1\x68\xf2\x0c\x3c\xd7\xe9\x84\x2a\x5a\x0d\x66\xb8\x5e\x80\x49\x08\xae\x17\xfa\x2d\x09\x3a\xf8\xa4\x9d\x72\xf0\x2e\xf5\xef\x48\xef\x56\x1c\xc2\x83\xcb\x5b\x2f\x1f\x39\xf6\xc4\x97\x43\xee\x3b\xf1\x98\x35\x68\xa9\x41\xf7\x18\xa6\x11\xe0\x39\x9e\x85\x3a\xa9\x26\x03\xa3\xa9\xc9\x2f\x86\x9b\xf4\xc7\x53\x45\xd4\x9a\x11\x3d\xa6\x45\x21\x10\xdb\x29\x41\x63\x85\x58\x59\xc4\x1b\xb8\x23\xfe\x3d\xa8\x49\xca\x92\x35\x13\x91\x9e\x50\x57\x98\xf0\x3f\x88\x89\x64\x71\x91\x27\xd2\x84\x8b\xe3\xc0\x0e\xfa\xb2\x21\x2a\x04\x7d\x8e\xe8\x7a\x8d\xd4\xa4\x14\x3c\x66\xe0\x13\x07\x13\xb8\x5d\x0e\x91\x83\x1d\xcc\x4c\xf3\xcb\xf4\x6b\x5d\x9d\xd1\x5d\x53\x66\xee\x4f\x78\xde\x3b\xf9\xdb\x0e\x0e\xee\xb4\x90\xdb\x0c\x35\x52\x66\x95\x76\x7b\xda\xfa\xbc\x55\xf3\x38\xa5\x0f\xd1\xc5\x3e\xaa\x9f\xe8\xa4\x4f\x93\xa5\xbb\xc1\xac\x51\xcb\x5b\xed\x56\x82\x1d\x80\xd2\xe8\x19\xf1\x83\x00\xde\xf9\xae\x07\xe8\xa3\x3d\x5f\xe0\x00\xd0\x68\x9a\x8d\x2c\xb8\xbe\x34\x2d\x40\xa3\x97\xd5\xff\x17\x97\x75\x30\xd5\xd1\xeb\x26\xda\x54\xa9\xeb\x7d\x74\x55\x45\xd3\xab\xab\x26\x99\x8c\x2c\x78\x75\x5d\x85\x7a\x4b\xa2\x7b\x2b\x9b\x05\x1a\x9f\xde\x62\x82\xfb\xd6\x02\x37\x68\x5f\x56\x60\x7b\xce\x80\xcb\x8e\x21\xc6\x1b\xe2\x2f\x1e\x6a\xf9\xbb\x16\x1d\x28\xb4\xba\xd6\x3c\xd8\xa7\x7e\x33\xb4\x1f\x60\xa7\x78\xca\x0d\x87\xf8\x0f\xdd\x0f\xf0\x8d\xf1\x73\x00\xfb\xca\xa8\x4b\xa6\x07\x00\x00")
+
+func migrations20261017100000Add_candles_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017100000Add_candles_tableSql,
+		"migrations/20261017100000-add_candles_table.sql",
+	)
+}
+
+func migrations20261017100000Add_candles_tableSql() (*asset, error) {
+	bytes, err := migrations20261017100000Add_candles_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017100000-add_candles_table.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x41, 0x9f, 0xe9, 0xfa, 0x23, 0x66, 0x3f, 0x18, 0xe3, 0x81, 0x65, 0xf1, 0x8d, 0x27, 0xdd, 0xd7, 0xe5, 0xfd, 0x53, 0x5a, 0x88, 0x9f, 0x1b, 0x3a, 0xcb, 0xfd, 0x32, 0x3c, 0x53, 0xb7, 0x21, 0x54}}
+	return a, nil
+}
+
+var _migrations20261017110000Add_ingest_state_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xb1\xaa\xc2\x30\x14\x87\xf1\xfd\x3c\xc5\x7f\xbc\x17\xed\x13\x74\xaa\x36\x83\x58\xdb\x12\xda\xa1\x53\x89\xf4\x10\xa2\x34\x09\xe6\x88\xe2\xd3\x8b\x0a\xa2\xe0\xfc\xfd\x86\x2f\xcb\xb0\x98\x9d\x3d\x19\x61\xf4\x91\xd6\x5a\x15\x9d\x42\x57\xac\x2a\x05\xe7\x2d\x27\x19\x93\x3c\xe2\x1f\x01\xc0\x21\xec\x21\x7c\x15\xd4\x4d\x87\xba\xaf\x2a\xb4\x7a\xb3\x2b\xf4\x80\xad\x1a\x96\x4f\x12\x8d\x75\xde\x8e\x12\x8e\xec\xbf\xed\xab\x9f\xe3\x64\x84\xa7\xd1\x08\xc4\xcd\x9c\xc4\xcc\x51\x6e\x6f\x44\xff\x39\xd1\xe7\x55\x19\x2e\x9e\x4a\xdd\xb4\x3f\xae\x72\xba\x0f\x00\x3e\xed\x7b\x11\xc0\x00\x00\x00")
+
+func migrations20261017110000Add_ingest_state_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017110000Add_ingest_state_tableSql,
+		"migrations/20261017110000-add_ingest_state_table.sql",
+	)
+}
+
+func migrations20261017110000Add_ingest_state_tableSql() (*asset, error) {
+	bytes, err := migrations20261017110000Add_ingest_state_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017110000-add_ingest_state_table.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x32, 0x50, 0x5f, 0xa8, 0x55, 0xf6, 0x50, 0xef, 0xdd, 0xcf, 0xd6, 0x29, 0xa, 0x4b, 0x7a, 0xf2, 0x53, 0x8b, 0x8b, 0x65, 0xbc, 0xf9, 0xf2, 0x36, 0x96, 0x6e, 0xe5, 0x92, 0x18, 0xd8, 0xf1, 0xeb}}
+	return a, nil
+}
+
+var _migrations20261017120000Add_pending_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x92\xb1\x6e\x83\x30\x10\x86\x77\x3f\xc5\x8d\x41\x4d\x9e\x80\x89\x16\x0f\xa8\x14\x52\x04\x52\x33\x59\x0e\x3e\x51\x57\x60\x23\xfb\xa2\x46\x79\xfa\x2a\x89\xd2\x12\x87\x26\x8b\x17\x7f\xfe\x7f\xdd\xe7\x5b\xad\xe0\x69\xd0\x9d\x93\x84\xd0\x8c\xec\xa5\xe2\x49\xcd\xa1\x4e\x9e\x73\x0e\x23\x1a\xa5\x4d\x27\xc8\x49\x85\x1e\x16\x0c\x00\x40\x2b\xf0\xe8\xb4\xec\xa1\x28\x6b\x28\x9a\x3c\x87\x75\x95\xbd\x25\xd5\x06\x5e\xf9\x66\x79\x62\x3e\xad\xd3\x07\x6b\x84\x56\x40\xb8\xa7\x5f\xf2\x7c\xdb\xa3\xea\xd0\x89\xb6\xb7\x1e\x05\xe9\x01\xe1\x78\x78\x92\xc3\x48\x87\x80\xdd\x4a\x8f\x42\x7a\x8f\x24\x5a\xab\x70\x2e\x6e\x82\x68\xef\x77\xe8\xe6\xa0\xd6\xee\x0c\xa1\x7b\x10\x75\x4d\xfd\x9f\x76\x12\x02\x5f\xde\x9a\x6d\x98\xe0\x50\x12\x2a\x21\xe9\xce\x50\x4d\x91\xbd\x37\x1c\x16\x7f\x9a\x22\x16\xc5\xec\x22\x3f\x2b\x52\xfe\x11\xc8\x17\xd3\x29\xd5\x1e\xca\xe2\xe6\x77\x26\xc4\x51\xd5\xf2\x56\x4c\x14\xdf\xad\x08\xa6\x9f\x6f\xb9\x86\xce\x45\xc1\xc3\x4b\x17\x9b\xee\x56\x6a\xbf\x0d\x4b\xab\x72\x3d\xbb\x5b\x31\xfb\x19\x00\x10\x45\xa2\xe9\x88\x02\x00\x00")
+
+func migrations20261017120000Add_pending_trades_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017120000Add_pending_trades_tableSql,
+		"migrations/20261017120000-add_pending_trades_table.sql",
+	)
+}
+
+func migrations20261017120000Add_pending_trades_tableSql() (*asset, error) {
+	bytes, err := migrations20261017120000Add_pending_trades_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017120000-add_pending_trades_table.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5b, 0x9f, 0xfd, 0xc9, 0x51, 0x0, 0xe9, 0x14, 0xa, 0x9d, 0xfb, 0x87, 0xda, 0xf8, 0xec, 0x1d, 0x4d, 0x1e, 0x93, 0x45, 0x55, 0x24, 0x3b, 0x30, 0x2b, 0x2, 0xba, 0x73, 0x8e, 0x94, 0x89, 0x9b}}
+	return a, nil
+}
+
+var _migrations20261017130000Numeric_trade_amountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\xcf\x6e\x84\x20\x10\x87\xef\x3c\xc5\xdc\xeb\x26\xbd\x7b\xb2\xc5\x9e\xa8\x6e\x36\x78\xe8\x69\x83\x30\x71\x49\x90\x31\xa8\xdd\xd7\x6f\xda\x26\x8d\xeb\x9f\x8a\x57\xf8\x7e\x81\xf9\x66\xe6\x74\x82\xa7\xd6\x36\x41\x0d\x08\x55\xc7\x32\x21\xf3\x0b\xc8\xec\x45\xe4\x30\x04\x65\xb0\x67\x00\x00\xbf\xc7\xaf\xa5\xa8\xde\x0b\xa8\x55\x8f\x57\xd5\xd2\xe8\x07\x90\x1f\xe7\x1c\xfc\xd8\x62\xb0\x3a\x59\xa2\xfa\x1b\xc2\x10\x49\x77\xc1\x6a\x5c\x83\x38\x7f\x40\xae\x1e\x6a\xdb\x58\x3f\x40\x51\x4a\x28\x2a\x21\x80\xe7\x6f\x59\x25\x24\x3c\x6f\x24\xcc\x76\x22\x65\x0f\x55\x6b\xe5\x8d\x5b\x2b\x9b\x3a\xf4\x7b\x15\xdc\x6c\x73\xdb\x63\x1c\xdd\xf7\x10\xed\xa8\xc7\x3d\xe8\xa7\x0d\x9f\xe4\xc6\x16\x63\xdb\xb0\x42\xa7\x8c\x4d\x47\x80\xd3\xdd\x1f\xd5\x61\x68\xac\x1d\x42\x17\x50\xdb\xde\x92\xff\xd7\x4b\x04\xfc\x27\x28\x82\x9d\x98\x8a\xa0\x17\xca\x62\x5e\x58\x71\x37\x8f\xa5\x6c\x6b\x71\xf8\xa5\x3c\xcf\x66\x37\xd9\xb8\x30\x49\xc4\xaa\x1d\xf8\xf0\xc1\xd8\x64\xf9\xe6\x74\xca\xbe\x06\x00\x6a\x4c\x79\xfc\x26\x04\x00\x00")
+
+func migrations20261017130000Numeric_trade_amountsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017130000Numeric_trade_amountsSql,
+		"migrations/20261017130000-numeric_trade_amounts.sql",
+	)
+}
+
+func migrations20261017130000Numeric_trade_amountsSql() (*asset, error) {
+	bytes, err := migrations20261017130000Numeric_trade_amountsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017130000-numeric_trade_amounts.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb2, 0x3e, 0x99, 0x4e, 0xd7, 0xe5, 0x37, 0x14, 0xb9, 0x1d, 0x73, 0xad, 0x9d, 0xae, 0x3a, 0x8, 0x47, 0x64, 0x3b, 0xcd, 0x4f, 0x38, 0x37, 0xad, 0xf4, 0xe2, 0x47, 0x43, 0x4d, 0xe9, 0x2d, 0x8e}}
+	return a, nil
+}
+
+var _migrations20261017140000Add_liquidity_poolsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x54\x5d\x6f\xd3\x30\x14\x7d\xf7\xaf\xb8\x6f\x6d\x45\x87\x10\xe2\x2d\x08\x29\x2c\x9e\xa8\xc8\xd2\x91\x25\x82\x3e\x59\x4e\x7c\xd7\x59\x4b\xe2\x60\xdf\x8c\x95\x5f\x8f\x9a\x74\xb4\xcd\x07\x95\x78\xf5\x39\xe7\xfa\x9e\xa3\x63\x5f\x5d\xc1\x9b\x52\x6f\xad\x24\x84\xb4\x66\x7e\x98\xf0\x18\x12\xff\x73\xc8\x81\xac\x54\xe8\x18\x00\x80\x1f\x04\x70\xbd\x0e\xd3\xdb\xa8\x3b\x15\xb4\xab\x11\x08\x5f\x08\xa2\x75\x02\x51\x1a\x86\x10\xf0\x1b\x3f\x0d\x13\x98\x19\xab\xd0\x66\xc6\x3c\xcd\x96\x7d\x71\xa1\x7f\x36\x5a\x69\xda\x89\xda\x98\x42\x68\x35\x35\xe3\xa2\xf4\x01\x51\x64\x35\xe8\x8a\x70\x8b\x76\x38\xe1\x9d\xc7\xd8\x75\xcc\xfd\x84\xc3\x2a\x0a\xf8\x8f\x83\x1b\x31\xd8\x40\x68\xf5\x02\xeb\x83\x2f\x07\xf3\x01\x61\x09\x05\xaa\x2d\x5a\x91\x17\xc6\xa1\x20\x5d\xe2\xa2\x5d\xee\xfb\x17\x1e\xf3\x11\x4b\x1f\x3f\xc1\x6c\x76\xbc\xbe\x0b\xf3\x9c\xe6\x60\xde\x8e\xd0\x0a\x1c\x5a\x2d\x8b\xa3\x81\xbb\x78\x75\xeb\xc7\x1b\xf8\xca\x37\x5d\x06\xa3\x49\x2d\x59\x8b\x65\xd2\xa1\x90\xce\x21\xed\x19\xaf\x61\xc4\xfc\x86\xc7\x3c\xba\xe6\xf7\xd0\x62\x0e\xe6\x5a\x2d\x4e\xb4\x7b\x69\x6e\x9a\x8a\xd0\xfe\x87\xba\xbd\x79\x22\xff\x6e\x65\x32\x24\x0b\x41\xb6\x71\x54\xe8\x0a\x1d\x64\x7a\xab\x2b\x1a\x65\xb9\x47\x69\xd1\x41\xd5\x94\x68\x75\x3e\x6a\xd0\xa2\x43\xfb\x8c\x23\x9c\x53\x23\xff\x66\xd5\x56\xe7\x53\x18\x3d\x17\x13\xc8\xde\xa5\xae\x72\x53\xa2\x78\xff\xe1\x71\x40\x3a\xb6\xed\xb0\x6e\x53\x2b\x49\xa8\x84\x24\xd8\xf7\xc4\x91\x2c\x6b\xfa\xfd\x97\xcf\x16\xde\xd9\x0b\x5b\x47\xe1\x06\xea\x26\x2b\x74\xfe\xb6\x57\x90\x93\xfa\x47\xf7\x49\xec\xaf\xa2\xa4\xdf\xa1\xd7\xc2\x89\x27\xdc\x41\x1a\xad\xbe\xa5\x1c\xe6\x87\xb3\x85\x77\xde\xfe\xbe\xb4\xcd\xb5\x5f\x81\xf6\x1d\xf4\x98\x30\x3f\xeb\xd8\x72\xd0\x9b\x85\xc7\xd8\xe9\x27\x12\x98\x5f\x15\x0b\xe2\xf5\xdd\x78\xf1\x3d\xd6\x81\x97\x1f\xa5\xc7\xa6\x7e\xa3\x76\xc0\xe0\x3b\x5a\x0e\xb0\xc1\xd8\x8b\x94\x07\x44\x91\xd5\x1e\xfb\x33\x00\xaf\x10\x45\x3a\x16\x05\x00\x00")
+
+func migrations20261017140000Add_liquidity_poolsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017140000Add_liquidity_poolsSql,
+		"migrations/20261017140000-add_liquidity_pools.sql",
+	)
+}
+
+func migrations20261017140000Add_liquidity_poolsSql() (*asset, error) {
+	bytes, err := migrations20261017140000Add_liquidity_poolsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017140000-add_liquidity_pools.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdd, 0xf3, 0x6c, 0xd6, 0x88, 0x4, 0x8a, 0x1e, 0xa2, 0x3c, 0x80, 0x29, 0xd2, 0xf0, 0xd4, 0xe, 0xf9, 0x82, 0x20, 0xd5, 0x4f, 0x55, 0x63, 0xaa, 0xa8, 0x2a, 0x44, 0x79, 0x2a, 0x91, 0x8e, 0x6a}}
+	return a, nil
+}
+
+var _migrations20261017150000Add_orderbook_snapshotsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x94\x4f\x8f\xda\x30\x10\xc5\xef\xfe\x14\x73\x5c\x54\xe8\x17\xe0\x44\xc1\x2b\xa1\xb2\xc9\x2a\x64\xa5\xee\xc9\x72\xe2\x29\x19\xe5\x8f\x23\xcf\xa4\x54\xfd\xf4\x55\xa2\x2e\x90\x2c\x0b\x55\x6f\xbd\x26\xcf\xef\x3d\x7b\x7e\xf6\x62\x01\x9f\x6a\x3a\x04\x2b\x08\x2f\xad\x5a\x27\x7a\x95\x6a\x48\x57\x5f\x76\x1a\x7c\x70\x18\x32\xef\x4b\xc3\x8d\x6d\xb9\xf0\xc2\xf0\xa0\x00\x00\xc8\x41\x46\x07\xc6\x40\xb6\x82\x28\x4e\x21\x7a\xd9\xed\xe0\x39\xd9\x3e\xad\x92\x57\xf8\xaa\x5f\xe7\x6a\xd0\x65\x96\xd1\x58\x66\x14\x43\x0e\xa8\x11\x3c\x60\x80\x44\x3f\xea\x44\x47\x6b\xbd\x87\xe1\x1f\xc3\x03\xb9\xd9\xc9\x67\x3e\x2c\xcd\x7d\xd7\x08\x86\x7f\x58\x3d\x2c\x6f\xba\xda\x64\xe4\xb8\xef\x49\x8d\x4c\xcc\x33\x72\xe6\x87\xaf\xba\x1a\xc1\xf9\x2e\xab\x10\xda\x80\x39\x31\xf9\x66\xa2\x2c\xe8\x50\x20\x4b\xef\x75\x4b\x7a\xca\xb4\x5c\x7e\x90\x69\xb9\xfc\xcb\xcc\xca\x1f\xfb\x48\xcb\xe5\xdd\x48\x6e\x03\xda\x9b\xcd\xce\x2a\x53\x93\x33\xad\xef\x4f\xe3\x9e\x6d\x1e\xd0\x0a\x3a\x63\x05\x84\x6a\x64\xb1\x75\x2b\xbf\x4e\x2a\x35\x5b\xaa\x37\x54\xb6\xd1\x46\x7f\xbb\x86\x8a\x19\x86\x3f\x1e\xe3\xd9\xd7\x90\xfb\x39\xec\x20\x8e\xa0\xed\xb2\x8a\xf2\xcf\x57\x71\x1b\x11\x34\x7f\x47\xc5\xfc\xa2\xea\x6c\x79\xbf\xd3\xb8\xc0\xbd\xf0\x91\xb7\x5a\x2c\x60\x8f\xe8\x40\x0a\x84\xb3\xe8\x48\x52\x0c\x9f\x2a\x2b\xc8\x02\x2c\x56\x18\xfc\x77\x40\x9b\x17\x50\xdb\x50\xa2\xa8\x6d\xb4\xd7\x49\x0a\xdb\x28\x8d\x6f\xdc\xaa\xf1\x5e\xaf\xde\x82\xf9\x08\xee\x29\xcc\xef\x90\x3d\xcb\x7b\x2e\xa7\x1c\x4e\x69\xbb\x84\xe5\x3a\x38\x7f\x4a\x9d\x8e\x45\xcd\xd4\x5e\xef\xf4\x3a\xfd\xaf\xfa\x77\xad\x7b\xeb\xff\x98\xc4\x4f\x97\x13\xe9\x87\xb7\x54\xea\xf2\x55\xdc\xf8\x63\xa3\x36\x49\xfc\xfc\xf1\xab\xb8\x54\xbf\x07\x00\x73\x5f\xa9\x86\x47\x05\x00\x00")
+
+func migrations20261017150000Add_orderbook_snapshotsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017150000Add_orderbook_snapshotsSql,
+		"migrations/20261017150000-add_orderbook_snapshots.sql",
+	)
+}
+
+func migrations20261017150000Add_orderbook_snapshotsSql() (*asset, error) {
+	bytes, err := migrations20261017150000Add_orderbook_snapshotsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017150000-add_orderbook_snapshots.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x99, 0x12, 0xe9, 0xb9, 0x9f, 0x7e, 0xf8, 0x1f, 0x24, 0x2a, 0x15, 0x47, 0x5e, 0x71, 0x71, 0xa1, 0x9b, 0x73, 0x2c, 0x16, 0xa7, 0x9, 0xbc, 0x98, 0x17, 0x55, 0x35, 0x7e, 0x6b, 0x96, 0xcd, 0xf9}}
+	return a, nil
+}
+
+var _migrations20261017160000Add_orderbook_depthSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x96\x4f\x93\x9a\x4c\x10\xc6\xef\x7e\x8a\xbe\xad\xd6\xab\x16\xbe\x55\x9c\xb6\x72\x60\x15\x53\xa6\x58\xd9\x42\xcc\x9f\x13\x35\x30\xb3\x38\x25\xc3\x10\x66\xc8\x26\xdf\x3e\xd5\xb0\x0a\x28\x44\xe3\xde\x92\x54\x79\xa0\xba\xfb\x79\xa6\x1b\xe9\x1f\x4c\x26\xf0\x9f\xe0\x71\x4e\x34\x83\x6d\x36\xb0\x1c\xdf\xf6\xc0\xb7\x1e\x1c\x1b\x64\x4e\x59\x1e\x4a\xb9\x0f\x94\x26\x5a\x0d\x00\x00\xac\xc5\x02\xe6\xae\xb3\x7d\x5c\x43\xc8\x69\x40\x59\xa6\x77\xc1\xff\x59\xa4\x81\xca\x22\x4c\x18\x64\x39\x8b\xb8\xe2\x32\x85\xb5\xeb\xc3\x7a\xeb\x38\xb0\xb0\x97\xd6\xd6\xf1\xc1\x18\xf7\x5b\x98\x6f\xb7\x98\x19\xb7\x7a\x10\xb5\x7f\xeb\x24\xb5\x85\xf9\x76\x8b\xdb\x27\x51\x09\xcf\x32\x12\xb3\x80\x08\x59\xa4\xb7\x79\x84\xc5\x8f\xe0\xe0\x73\x5b\x13\x2c\x49\x7e\xcb\xe1\x7e\x30\x98\x4c\x60\x81\xb3\x2b\x20\x39\x03\x55\x08\xc1\x28\x90\x28\x97\x4a\x81\xde\x31\x10\x24\xdf\x33\xad\x40\x3e\x03\x01\x9d\x13\xca\x20\x23\x3c\x1f\xc3\xcb\x8e\x27\xac\x2c\x39\x9e\xc8\x15\xda\x61\x28\x91\x2f\x4c\x69\x90\x29\x03\x22\x64\x1a\x63\x9d\x80\x21\x9f\xb2\x29\x5e\x96\x09\xf9\x5c\x5e\x0a\xa9\x34\x24\xfc\x6b\xc1\xe9\xeb\x61\xa3\xe9\x60\xee\xd9\x96\x6f\x83\xeb\x81\x67\x3f\x39\xd6\xdc\x86\x8f\x2b\xfb\x13\x90\x38\xce\x59\x4c\x34\xa3\xc1\x71\x47\xc0\xda\x94\x37\x72\x63\x3b\xf6\xdc\x2f\x2f\xf1\x17\xc9\x34\x22\x7a\x18\x5a\x4a\x31\x3d\x8d\x24\x65\x63\xb8\x0b\xee\xc6\x10\xd5\x91\x11\x10\x55\xcd\x14\xe0\x4c\x41\x4a\x04\x1b\x1f\x1d\x1a\x52\xac\x0b\x89\x62\x01\xc1\x50\x80\xda\xba\xae\x61\x88\x75\x11\xfe\xff\x2c\xef\x2c\x9d\xbb\x96\x63\x6f\xe6\xf6\x50\x15\x62\x28\xd5\x34\x2d\x44\x10\x72\xaa\x46\x63\x30\x46\x60\x6d\xe0\x10\xe8\x97\xe0\xda\x7d\x93\x49\x21\x18\x8a\xa6\x95\xac\x0e\x76\x08\x05\xf9\x8e\xc2\x1d\x8f\x77\x4c\x69\x3c\xaf\xa1\x6c\x44\xfb\xcf\xc4\xae\x88\xda\xb7\xdb\xc4\x40\xbf\x04\xd7\xf2\xac\xcd\x3a\xd8\x21\x14\x3c\x45\x61\xf5\xe4\x04\x44\xed\x1b\xc2\x3a\xd8\x7f\x62\xcd\x23\xa4\x62\x43\xdc\x4e\x5c\x63\x60\xf6\x19\x98\x57\x1a\xcc\x8c\x3e\x87\x99\xf1\x4b\x8b\x1a\x45\x27\x43\xb4\x13\xd7\x18\x98\x7d\x06\xe6\x95\x06\x33\xa3\xcf\xa1\x6f\x88\xd7\x27\xed\x84\x85\x0d\x8b\x93\x4c\x97\x05\x4f\x87\x48\xb9\xd5\x12\x9b\x69\x12\x11\x9f\xbd\x86\x55\x2b\x75\xc9\xa7\x05\xc6\x13\xa3\x56\xae\x34\x5a\x7a\xee\xe3\xe9\x3b\x18\x6f\x80\x54\x65\xfa\x83\xbb\xc2\xd7\x8e\x42\x2a\x62\x23\xe5\xfa\x83\xbb\x06\x6c\xb8\x86\x04\xa7\xf0\xee\xc0\x10\x4e\xbb\x94\x15\x38\x40\xa6\xa8\x6c\x63\xa3\x14\x47\x2d\xf1\x7b\xcf\xdd\x3e\xc1\xc3\x97\x33\x60\x9d\x91\xa9\x03\x41\x15\xe8\x8f\xdf\x1b\x0b\xf9\x92\x0e\x16\x9e\xfb\x54\x61\x75\xb5\x04\xfb\xf3\x6a\xe3\x6f\x3a\x01\x7b\x7f\xa0\xf1\x3f\x04\xff\xe5\x08\xae\x10\xfc\x07\xef\xc8\xa5\x8f\xf0\x72\x65\x0e\x5f\x6b\x9c\x9e\x11\xb9\x3b\x6f\x5e\xc8\xcf\x8c\xce\x82\x2e\xe4\x77\xe7\xcd\x0b\xf9\x9e\x03\x3a\x69\xdc\x2c\x38\x67\x6c\x4b\xde\x04\xe7\xfd\xe0\xe7\x00\xd3\x66\x5f\x37\xd0\x0c\x00\x00")
+
+func migrations20261017160000Add_orderbook_depthSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017160000Add_orderbook_depthSql,
+		"migrations/20261017160000-add_orderbook_depth.sql",
+	)
+}
+
+func migrations20261017160000Add_orderbook_depthSql() (*asset, error) {
+	bytes, err := migrations20261017160000Add_orderbook_depthSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017160000-add_orderbook_depth.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xaf, 0x60, 0x70, 0x32, 0x2a, 0xd1, 0xcc, 0x67, 0x25, 0xe9, 0x5d, 0x10, 0x3d, 0x55, 0xc7, 0xca, 0x8a, 0xcb, 0x29, 0xf6, 0x6c, 0x83, 0x6d, 0xc8, 0x9a, 0x26, 0x12, 0xa8, 0x3f, 0x91, 0x95, 0x40}}
+	return a, nil
+}
+
+var _migrations20261017170000Add_asset_pricesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\xcd\x6a\xeb\x30\x14\x84\xf7\x7a\x8a\x59\x26\xdc\xe4\xbe\x40\x56\x6a\xac\x42\xa8\x2b\xa7\x8a\xbc\xf0\xca\x28\xd6\x21\x88\xfa\x47\x48\x32\x6d\xfa\xf4\x05\xbb\x31\x69\xe8\xf2\x70\x66\x86\xf9\x66\xbb\xc5\xbf\xce\x5d\x82\x49\x84\xd2\xb3\xbd\x12\x5c\x0b\x68\xfe\x94\x0b\x98\x18\x29\xd5\x3e\xb8\x86\x22\x56\x0c\x00\x9c\x45\xa4\xe0\x4c\x0b\x59\x68\xc8\x32\xcf\x71\x54\x87\x57\xae\x2a\xbc\x88\x6a\x33\x69\x66\x9b\xb3\x70\x7d\xa2\x0b\x05\x28\xf1\x2c\x94\x90\x7b\x71\x9a\x23\x23\x56\xce\xae\x97\x84\xd9\xd5\x8c\x21\x50\xdf\x5c\x91\xe8\x33\x3d\xfc\xa6\x0a\xb0\xc3\x78\x6e\x09\x3e\x50\xe3\xa2\x1b\xfa\x07\xd1\xe8\xad\x49\x64\x6b\x93\x90\x5c\x47\x31\x99\xce\xa7\xaf\x45\xc4\xd6\x3b\xc6\x73\x2d\xd4\x0f\x5c\x21\xf3\x0a\x7e\x3c\xb7\xae\xf9\x7f\x0f\x3a\x65\xf1\x2c\xc3\xbe\x90\x27\xad\xf8\x41\xea\x5f\x43\xd4\xf3\x71\xab\x5b\xbf\xd3\x15\xa5\x3c\xbc\x95\x02\xab\x1b\xf9\x66\xa1\x59\xef\x18\xbb\x9f\x38\x1b\x3e\x7a\x96\xa9\xe2\xf8\xc7\xc4\x3b\xf6\x3d\x00\x06\xd4\xb5\xa2\x8d\x01\x00\x00")
+
+func migrations20261017170000Add_asset_pricesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017170000Add_asset_pricesSql,
+		"migrations/20261017170000-add_asset_prices.sql",
+	)
+}
+
+func migrations20261017170000Add_asset_pricesSql() (*asset, error) {
+	bytes, err := migrations20261017170000Add_asset_pricesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017170000-add_asset_prices.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x60, 0x4e, 0x42, 0x8b, 0xe9, 0x28, 0xa1, 0x6a, 0x11, 0x7c, 0x69, 0xb0, 0xda, 0x4e, 0x61, 0xe3, 0xcd, 0xd9, 0xea, 0xe4, 0xa3, 0xa1, 0x63, 0xfb, 0x4e, 0x50, 0x88, 0x1, 0x25, 0xc3, 0xe8, 0x9e}}
+	return a, nil
+}
+
+var _migrations20261017180000Add_pagination_indicesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x8f\xcd\x6a\x84\x30\x10\xc7\xef\x79\x8a\x39\x2a\xd5\xbe\x40\x4e\xc5\xe4\xd0\x8b\x16\xdb\x42\x6f\x43\x34\x83\x0c\xf8\x45\x26\xd2\x7d\xfc\x45\x85\x25\xc2\x5e\xf6\x16\xf2\x9f\xdf\xff\xa3\x2c\xe1\x6d\xe2\x21\xb8\x48\xf0\xbb\xaa\xaa\xb5\x1f\x3f\x16\x3e\x6b\x63\xff\x20\x06\xe7\x49\xb0\x73\x42\xd8\x2f\xdb\x1c\x29\xa0\x13\xa1\x88\xfd\xb8\x08\x61\xe4\x89\x90\xfd\x4d\x01\x00\x34\x35\xac\x5b\x37\x72\xff\x7e\x62\x90\x1d\xdc\x79\xcf\xbe\x80\xab\xc3\xfe\x33\x92\x1f\x28\x24\x66\x60\xec\x77\x55\x00\xfb\xe3\x91\xeb\x6b\x9d\x03\x14\x64\x91\x8d\x02\xb2\xdf\xa3\x93\xd8\x53\x86\xec\xa1\xef\x46\xb9\x56\x2a\x9d\x68\x96\xff\x59\x99\xb6\xf9\x7a\x71\xa2\x4e\xa1\x67\x45\xb4\xba\x0f\x00\xf4\x8a\x91\x71\x4a\x01\x00\x00")
+
+func migrations20261017180000Add_pagination_indicesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017180000Add_pagination_indicesSql,
+		"migrations/20261017180000-add_pagination_indices.sql",
+	)
+}
+
+func migrations20261017180000Add_pagination_indicesSql() (*asset, error) {
+	bytes, err := migrations20261017180000Add_pagination_indicesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017180000-add_pagination_indices.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x71, 0xd6, 0xfa, 0xe, 0x76, 0x68, 0x9f, 0x87, 0x75, 0x28, 0xa0, 0xa3, 0x88, 0x35, 0xb, 0xc6, 0xfd, 0x5e, 0xaf, 0xea, 0x31, 0xa1, 0x9a, 0x3, 0x30, 0xe9, 0xed, 0xd5, 0x1c, 0x6, 0xc1, 0x11}}
+	return a, nil
+}
+
+var _migrations20261017190000Add_asset_evaluationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x91\xcb\x6e\xc2\x30\x10\x45\xf7\xfe\x8a\x59\x82\x0a\xfd\x01\x56\x29\xf1\x02\x35\x75\xa8\xeb\x2c\x58\x59\x26\x19\x21\xab\x7e\x20\x7b\xdc\xd7\xd7\x57\x50\x8a\xaa\x90\x6e\xe7\x1e\xcd\x95\xce\x5d\x2e\xe1\xce\xdb\x43\x32\x84\xd0\x1d\xd9\x5a\xf2\x4a\x71\x50\xd5\x43\xc3\xc1\xe4\x8c\xa4\xf1\xcd\xb8\x62\xc8\xc6\x90\x61\xc6\x00\x00\xec\x00\x19\x93\x35\x0e\x44\xab\x40\x74\x4d\x03\x5b\xb9\x79\xaa\xe4\x0e\x1e\xf9\x6e\x71\x66\xfa\x38\x20\x10\x7e\xd0\x95\xf9\xb9\xdb\x9c\x0b\x26\x6d\xfa\x3e\x96\x40\x53\x84\xb3\x99\x70\x80\x7d\x8c\x0e\x4d\x18\x85\xa9\xb8\xc9\xb7\x09\x4d\x8e\x61\x2a\x09\xc5\xff\xb6\x65\xb0\x81\xf0\x80\x69\x84\x18\x7f\x4a\x21\x14\x8f\xc9\xf6\xa3\x90\xa2\x77\xba\x24\x37\xf5\xfb\xa2\x06\x07\x6d\x08\xc8\x7a\xcc\x64\xfc\x91\xbe\xae\x18\x9b\xaf\x58\xd5\x28\x2e\x2f\x46\x5b\xd1\xec\xe0\x58\xf6\xce\xf6\xf7\x37\x76\xcf\x75\x55\x5d\xc3\xba\x15\x2f\x4a\x56\x1b\xa1\x6e\x27\xd0\x27\xb1\xfa\x62\xf1\x15\x3f\xa1\x13\x9b\xe7\x8e\xc3\xec\x74\x5f\x8c\xf4\xce\x57\x8c\xfd\x1d\xb8\x8e\xef\x81\xd5\xb2\xdd\xfe\x37\xf0\x8a\x7d\x0f\x00\x91\xc8\x6d\x76\x10\x02\x00\x00")
+
+func migrations20261017190000Add_asset_evaluationsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017190000Add_asset_evaluationsSql,
+		"migrations/20261017190000-add_asset_evaluations.sql",
+	)
+}
+
+func migrations20261017190000Add_asset_evaluationsSql() (*asset, error) {
+	bytes, err := migrations20261017190000Add_asset_evaluationsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017190000-add_asset_evaluations.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6d, 0x3c, 0x4, 0x5a, 0xcb, 0x94, 0x6e, 0x9c, 0xc6, 0x9f, 0xa7, 0x5f, 0x48, 0xe1, 0xaf, 0xed, 0xba, 0x1e, 0x41, 0x33, 0x87, 0x8a, 0xc6, 0x4f, 0x99, 0xf6, 0x90, 0xe9, 0xfa, 0x22, 0x68, 0xcb}}
+	return a, nil
+}
+
+var _migrations20261017200000Add_asset_evaluation_toml_findingsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\xbb\x0e\xc2\x20\x18\x47\xf1\x9d\xa7\xf8\x6f\x1d\x6c\x9f\xa0\x13\x4a\x9d\xb0\x35\x0d\x4c\xc6\x10\x4c\xb1\x62\x0a\x18\xf9\xbc\x3c\xbe\xab\x97\x0e\xce\x27\x27\xf9\x55\x15\x16\xc1\x8f\x57\x4b\x0e\xfa\xc2\xb8\x54\x4d\x0f\xc5\x97\xb2\x81\xcd\xd9\x91\x71\x77\x3b\xdd\x2c\xf9\x14\x33\x03\x00\x2e\x04\x56\x9d\xd4\x9b\x16\xa7\x14\x9c\x19\x52\xb0\x3e\x82\xdc\x93\xd0\x76\x0a\xad\x96\x12\xa2\x59\x73\x2d\x15\x8a\xa2\xfc\x9e\x28\x85\xc9\x1c\x7d\x1c\x7c\x1c\x33\xce\x39\xc5\xc3\xcc\xb7\xdb\x17\x35\x63\xef\x38\x91\x1e\xf1\x0f\x9e\xe8\xbb\xed\x8c\xaf\xfc\x89\x1f\x8e\x9a\xbd\x06\x00\x91\x9e\x4d\x8e\x08\x01\x00\x00")
+
+func migrations20261017200000Add_asset_evaluation_toml_findingsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017200000Add_asset_evaluation_toml_findingsSql,
+		"migrations/20261017200000-add_asset_evaluation_toml_findings.sql",
+	)
+}
+
+func migrations20261017200000Add_asset_evaluation_toml_findingsSql() (*asset, error) {
+	bytes, err := migrations20261017200000Add_asset_evaluation_toml_findingsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017200000-add_asset_evaluation_toml_findings.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x12, 0x89, 0xc7, 0xca, 0xe4, 0xfa, 0x34, 0xf8, 0x5f, 0xf1, 0xd, 0x33, 0x42, 0x9a, 0xc, 0xa2, 0xb6, 0xd0, 0xbb, 0x9a, 0xaa, 0x95, 0x45, 0xc4, 0xdc, 0xa7, 0x88, 0xf6, 0xb8, 0xea, 0x5a, 0xef}}
+	return a, nil
+}
+
+var _migrations20261017210000Add_toml_cacheSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x92\xbf\x6e\xc2\x30\x10\x87\xf7\x3c\xc5\x8d\xa0\x42\x5f\x20\x53\x4a\x3c\x44\x4d\x1d\x1a\x12\xa9\x4c\x91\xb1\x0f\x62\xe1\xe0\xc8\xbe\xa8\xd0\xa7\xaf\xa0\xa8\x50\xea\x8a\xad\xeb\x2f\x5f\xee\xcf\x77\x9e\x4e\xe1\xa1\xd3\x1b\x27\x08\xa1\xee\xa3\x59\xc9\x92\x8a\x41\x95\x3c\xe5\x0c\xc8\x76\xa6\x91\x42\xb6\x08\xa3\x08\x00\x40\x2b\xf0\xe8\xb4\x30\xc0\x8b\x0a\x78\x9d\xe7\x30\x2f\xb3\x97\xa4\x5c\xc2\x33\x5b\x4e\x4e\xcc\xe0\x0c\x10\xee\xe9\x1b\xf9\x8a\x57\x56\x1d\x42\x39\x92\xd8\x84\x72\x23\x3c\x35\x9d\x55\x7a\xad\x51\x85\x00\x4f\x82\x06\xdf\x48\xab\x10\xf4\x8e\x70\x83\x2e\x54\x02\x9d\xb3\x2e\xf4\xff\x1a\x49\xb6\xa8\x1a\x41\x40\xba\x43\x4f\xa2\xeb\xe9\xe3\x06\x92\x2d\xca\xed\x3d\x08\xf7\xbd\x76\xe8\xff\x82\xa2\x71\x1c\x25\x79\xc5\xca\xb3\xd5\x82\xe7\x4b\xe8\x87\x95\xd1\xf2\xf1\x62\xf8\xd4\x2e\x49\x53\x98\x15\x7c\x51\x95\x49\xc6\xab\x2b\xff\xcd\xe0\x4c\xb3\xc5\x03\xd4\x3c\x7b\xad\x19\x8c\x06\x67\xc6\x71\x14\x38\x57\xab\x3d\x59\x77\xf8\xff\x83\xdd\xf1\x79\xb4\x70\x9e\x36\xe3\x29\x7b\xfb\x31\xed\x69\xbd\x4b\x81\x46\xab\x3d\x14\xfc\x66\xa1\xc1\x99\xc9\x75\x97\x94\x2d\x66\x47\x07\xd7\x2f\x38\xb5\xef\xbb\x28\x2d\x8b\x79\x40\x49\xfc\xeb\x83\x14\xb2\xc5\x38\xfa\x1c\x00\x7e\xef\xa9\x81\x03\x03\x00\x00")
+
+func migrations20261017210000Add_toml_cacheSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017210000Add_toml_cacheSql,
+		"migrations/20261017210000-add_toml_cache.sql",
+	)
+}
+
+func migrations20261017210000Add_toml_cacheSql() (*asset, error) {
+	bytes, err := migrations20261017210000Add_toml_cacheSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017210000-add_toml_cache.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3c, 0xf5, 0x45, 0x5, 0xdd, 0x5e, 0x8b, 0xb0, 0xd0, 0x74, 0xf3, 0x6b, 0x61, 0xca, 0xe5, 0xda, 0xee, 0x4c, 0xca, 0x38, 0x1a, 0xb5, 0x95, 0x59, 0xc6, 0x20, 0xa6, 0xb, 0xff, 0x53, 0x77, 0x1e}}
+	return a, nil
+}
+
+var _migrations20261017220000Add_asset_snapshotsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x94\x41\x8f\xda\x30\x10\x85\xef\xfe\x15\x73\xdc\x55\x43\xd5\xfb\x9e\x28\x18\x09\x95\x0d\xab\x10\xa4\xee\xc9\x1a\xec\x29\x19\xd5\x71\xd2\x78\xd2\x6d\xf7\xd7\x57\x1b\x4a\x42\xb3\x14\xb8\x5a\xef\x7b\x6f\x3c\xf2\xf3\x64\x02\x1f\x4a\xde\x37\x28\x04\xdb\x5a\xcd\x32\x3d\xcd\x35\xe4\xd3\xcf\x2b\x0d\x18\x23\x89\x89\x01\xeb\x58\x54\x12\xe1\x4e\x01\x00\xb0\x83\x1d\xef\x23\x35\x8c\x1e\xd2\x75\x0e\xe9\x76\xb5\x82\xa7\x6c\xf9\x38\xcd\x9e\xe1\x8b\x7e\x4e\x3a\xd9\x01\x66\x07\x1c\x84\xf6\xd4\x40\xa6\x17\x3a\xd3\xe9\x4c\x6f\x0e\xc6\x11\xee\xd8\xdd\xf7\x0e\x89\xea\xb0\xd0\x96\x06\xad\xad\xda\x20\xb1\x47\x07\x4d\x2f\x69\xa5\xa8\x1a\x7e\x25\x67\xa4\x32\x25\x72\x10\xe4\x60\x3c\xe3\x8e\x3d\x0b\xd3\x7b\x18\xe6\x7a\x31\xdd\xae\x72\xf8\x34\xd8\xb4\x61\x30\xba\x09\xb0\x1e\xb9\xc4\x9d\x27\xb3\x43\x8f\xc1\xde\x98\xe3\xf9\x47\xcb\x8e\xe5\xb7\xa9\xab\xca\xdf\xc6\xd8\x2a\x48\x83\x56\x2e\xab\xbb\x88\x93\x4b\x84\xb6\xa4\x86\x6d\xaf\x4d\x46\x82\xff\xae\x6b\x0c\x8e\x47\x6a\xc3\x85\x94\xb1\xf8\xcc\x9a\xae\x21\xe3\x15\x5d\x8d\xe8\xd7\x73\x4d\x89\x8d\x2d\xf8\x27\x39\x73\x1b\xd2\x2d\xcc\x36\x84\x42\xce\xa0\x80\x70\x49\x51\xb0\xac\xe5\xb5\xd7\xab\xfb\x07\x75\xec\xca\x32\x9d\xeb\xaf\xe3\xae\x98\xe3\xf3\x37\x83\x93\x61\xf7\xab\x33\x5f\xa7\x50\xb7\x3b\xcf\xf6\xe3\xbb\x86\x1d\xb1\x04\x06\xee\x2d\x6b\x32\x81\x0d\x91\x03\x29\x08\x06\xf9\x0b\x4b\xd1\x1d\x79\x14\x8a\x02\x51\x50\x22\x54\xdf\x80\xd0\x16\x87\x91\xd4\x32\xdd\xe8\x2c\x87\x65\x9a\xaf\xe1\x42\xdc\x69\xef\x92\x93\xf7\xf2\xcf\x20\x6a\xa3\x57\x7a\x96\xc3\x19\xa0\x7c\x6b\x6c\x02\x1e\xa3\x18\x5b\x90\xfd\x4e\x4e\x2d\xb2\xf5\xe3\xdf\xae\x1f\xae\xd0\xff\x34\xf3\xea\x25\xa8\x79\xb6\x7e\x3a\xff\xd3\x3c\xa8\x3f\x03\x00\x2e\xbb\xf9\xd2\x97\x04\x00\x00")
+
+func migrations20261017220000Add_asset_snapshotsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017220000Add_asset_snapshotsSql,
+		"migrations/20261017220000-add_asset_snapshots.sql",
+	)
+}
+
+func migrations20261017220000Add_asset_snapshotsSql() (*asset, error) {
+	bytes, err := migrations20261017220000Add_asset_snapshotsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017220000-add_asset_snapshots.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe5, 0xd1, 0x4c, 0x86, 0xf, 0x37, 0x50, 0x6d, 0x7b, 0x5f, 0x4a, 0x8a, 0x7, 0x70, 0x74, 0x8, 0x8f, 0x14, 0x2a, 0xe6, 0x2d, 0x37, 0xe, 0x66, 0x2e, 0x49, 0xa9, 0xcc, 0x80, 0x76, 0x65, 0x42}}
+	return a, nil
+}
+
+var _migrations20261017230000Add_contract_tokensSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x4f\x6f\x9c\x30\x10\xc5\xef\x7c\x8a\xb9\x25\x55\x93\x2a\xb7\x1e\xf6\x44\x17\x57\x5a\x95\x98\x94\x82\xd4\x9c\x2c\xaf\x3d\x4a\xad\xf8\x0f\xc2\xb6\x9a\xed\xa7\xaf\x12\x60\x17\x92\x05\xf6\x3c\x3f\x3f\x3f\xbf\xe7\xb9\xbd\x85\xcf\x46\x3d\xb5\x3c\x20\xd4\x4d\x92\xe6\x15\x29\xa1\x4a\xbf\xe5\x04\xb8\xf7\x18\x3c\xa4\x59\x06\xdb\x22\xaf\xef\x29\x08\x67\x43\xcb\x45\x60\x4a\x42\xc0\x97\x00\xb4\xa8\x80\xd6\x79\x0e\x19\xf9\x9e\xd6\x79\x05\x57\x57\x9b\x15\x0d\x1b\x0d\x1b\x74\x3c\x28\x1b\xf0\x09\xdb\x8f\x42\x77\x9b\x0b\xbd\x78\xc6\x8d\x8b\x36\x80\x74\x71\xaf\x11\x9a\x16\x85\xf2\xca\xd9\xb3\x9a\xc9\xb6\x24\x69\x45\x60\x47\x33\xf2\xbb\x57\x3d\xda\x61\x4a\x32\x25\x5f\xa0\xa0\xd0\xc4\xbd\x56\xe2\x4b\x7f\xed\xf5\x88\xf8\x74\x12\xe9\xac\x1d\x67\xc1\x3d\xa3\xf5\x70\x9d\x00\x00\x28\x09\x1e\x5b\xc5\xf5\xc9\xc6\x43\xb9\xbb\x4f\xcb\x47\xf8\x41\x1e\x6f\xde\x98\xd9\x38\xbb\xb1\xe5\x06\x67\x63\xee\x10\x7f\x30\x7b\xa7\x57\x20\x89\x42\x19\xae\x17\xc2\xfe\xda\xab\xc5\xa6\xd1\x07\xb0\xd1\x60\xab\xc4\x47\xec\xae\xf7\x15\x0d\xe3\x42\xbc\x86\xbe\x54\xe0\x09\x1e\xde\xb9\x4e\x6b\xee\x03\xd3\x28\x5f\x99\x35\x36\x36\x92\x07\x94\x8c\x07\x08\xca\xa0\x0f\xdc\x34\xe1\xdf\x11\xef\xa0\x6d\x41\x7f\x55\x65\xba\xa3\xd5\xfb\x9e\x26\xad\x3f\xe3\x01\x6a\xba\xfb\x59\x93\x69\xd7\xc9\x4a\xdb\x6c\xcf\x35\xb7\x02\x87\xd6\x47\x67\xa7\xa5\x74\x6e\xfe\x38\x2d\xb1\x3d\x37\xe9\x75\x56\xb3\x1f\xfd\xa1\x89\xd3\x9b\x5e\xbb\x73\x3c\x5e\xea\xcc\xfd\xb5\x49\x56\x16\x0f\xcb\x0f\xd8\xcc\x33\xc3\x6c\x69\x67\xce\x6e\xeb\x9b\xe2\xcc\xba\xae\x1e\x98\x7c\x9c\x8b\xe5\x99\x92\x9b\xe4\xff\x00\xe4\xb1\x1f\xa1\xd4\x04\x00\x00")
+
+func migrations20261017230000Add_contract_tokensSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017230000Add_contract_tokensSql,
+		"migrations/20261017230000-add_contract_tokens.sql",
+	)
+}
+
+func migrations20261017230000Add_contract_tokensSql() (*asset, error) {
+	bytes, err := migrations20261017230000Add_contract_tokensSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017230000-add_contract_tokens.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7f, 0x3f, 0x85, 0xf1, 0x8, 0xe6, 0xc1, 0xf0, 0x64, 0x8a, 0x9f, 0x98, 0xd9, 0xd2, 0x23, 0x14, 0xf9, 0x56, 0xc4, 0xa4, 0x7e, 0x18, 0x9, 0x1a, 0xbe, 0xd3, 0x1, 0xd8, 0xa9, 0x78, 0x44, 0x84}}
+	return a, nil
+}
+
+var _migrations20261017235000Add_trade_flagsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xd1\x4a\xc3\x30\x18\x85\xef\xf3\x14\xff\xa5\xa2\xf3\x05\x7a\x15\x97\x7f\x28\xc4\x54\x6a\x8a\xde\x85\xac\xf9\xd7\x05\xb3\x66\x34\x29\xf3\xf1\x85\x52\xa8\xc2\xb0\x03\x77\x9d\x73\xbe\x93\x93\x93\xd5\x0a\xee\x0e\xbe\xed\x6d\x26\xa8\x8f\x8c\x4b\x8d\x15\x68\xfe\x28\x11\x72\x6f\x1d\x25\xe0\x42\xc0\xba\x94\xf5\x8b\x02\x9f\x4c\xa2\xb0\x33\xe3\x09\x6c\x63\x0c\x64\x3b\x50\xa5\x06\x55\x4b\x09\x02\x37\xbc\x96\x1a\x36\x5c\xbe\x61\xb1\xcc\x3a\xd9\xb4\xbf\x16\x2b\x0e\x39\x78\xea\xff\x09\xda\x05\xdb\x26\xd3\xec\xa9\xf9\x24\xb7\xc8\x62\xeb\x0a\xb9\x46\x78\x56\x02\x3f\x26\x9a\x19\xba\xc9\x6e\xbc\xfb\x82\x52\xc1\x71\xd8\x06\xdf\x3c\x4c\x61\x37\x81\x5c\x4b\xbd\x69\x42\x4c\x64\xb2\x3f\xd0\x3d\x78\x77\x0b\xef\x4f\x58\xe1\x98\xf4\xeb\x0e\x05\x63\x3f\x17\x12\xf1\xd4\x31\x51\x95\xaf\x7f\x64\x9e\xed\x38\x7a\xce\x95\x5c\x54\xcf\x6f\x7b\x89\x74\x9e\xf4\x12\xf5\xfc\x99\x0a\xf6\x3d\x00\x9d\xab\x38\xcf\x89\x02\x00\x00")
+
+func migrations20261017235000Add_trade_flagsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261017235000Add_trade_flagsSql,
+		"migrations/20261017235000-add_trade_flags.sql",
+	)
+}
+
+func migrations20261017235000Add_trade_flagsSql() (*asset, error) {
+	bytes, err := migrations20261017235000Add_trade_flagsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261017235000-add_trade_flags.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8d, 0x18, 0xbe, 0xf1, 0x1b, 0xf2, 0x6b, 0xe4, 0x28, 0xf9, 0x2e, 0x6f, 0x58, 0x82, 0x98, 0x69, 0x6e, 0xb6, 0x13, 0x5c, 0x55, 0x29, 0x9a, 0x44, 0xc3, 0x55, 0x85, 0xec, 0x32, 0x63, 0x32, 0xd9}}
+	return a, nil
+}
+
+var _migrations20261018000000Add_latest_asset_snapshotsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xd1\x6a\xc2\x30\x14\x86\xef\xf3\x14\xff\xa5\x65\xba\x17\xe8\x95\x36\x47\x08\xab\x89\xa4\x11\xe6\x55\x88\x26\xb8\x80\xab\x62\x32\xb6\xc7\x1f\xae\x74\x2d\x1d\x1b\xbb\x3d\xf9\xf8\xce\x7f\xf2\x2f\x16\x78\x78\x8d\xa7\x9b\xcb\x01\xbb\x2b\xab\x34\x2d\x0d\xc1\x2c\x57\x35\xe1\xec\x72\x48\xd9\xba\x94\x42\xb6\xa9\x75\xd7\xf4\x72\xc9\x09\x33\x06\x00\xdd\x34\x7a\xc4\x36\x87\x53\xb8\x41\xd3\x9a\x34\xc9\x8a\x9a\xee\x2d\x61\x16\x7d\x01\xa9\x0c\xe4\xae\xae\xb1\xd5\x62\xb3\xd4\x7b\x3c\xd1\x7e\xfe\x65\xe8\x8d\x36\x7a\x1c\xe2\x29\xb6\xf9\x87\x63\xbc\x75\x2c\x63\x45\xc9\x98\x90\x0d\x69\x03\x21\x8d\xfa\x35\x6a\x9f\x72\x8e\x7e\x68\xa3\x2f\x58\x43\x35\x55\x06\x5c\x34\x46\xc8\xca\x40\xc9\x01\x2d\xbe\x4f\x9b\x23\x7a\xb6\xd6\x6a\x33\x0d\xc3\x94\xe6\xa4\xb1\xda\x8f\xd0\xe3\x2d\xb8\x1c\xbc\x75\x19\x9c\x9a\xaa\x64\xfd\x5f\x0a\xc9\xe9\x79\x6a\xb0\x03\x6e\xa3\xff\xb8\x07\xb8\xbe\x1d\xce\xf1\xf8\x38\x01\x31\x1b\xc8\xfb\xd1\xe3\xbe\xf8\xe5\xbd\x65\x5c\xab\xed\xbf\x76\x94\x1d\xfa\x57\xb5\x25\xfb\x1c\x00\x7f\x41\x0b\x2d\x0f\x02\x00\x00")
+
+func migrations20261018000000Add_latest_asset_snapshotsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261018000000Add_latest_asset_snapshotsSql,
+		"migrations/20261018000000-add_latest_asset_snapshots.sql",
+	)
+}
+
+func migrations20261018000000Add_latest_asset_snapshotsSql() (*asset, error) {
+	bytes, err := migrations20261018000000Add_latest_asset_snapshotsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261018000000-add_latest_asset_snapshots.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x66, 0xd, 0x32, 0x3f, 0x9, 0x93, 0x68, 0x7e, 0x9f, 0xff, 0x2b, 0x99, 0xb9, 0x26, 0x83, 0x1b, 0x52, 0x9, 0x8b, 0x73, 0xbe, 0xf7, 0xff, 0xe4, 0x50, 0xfd, 0xe6, 0x55, 0xc1, 0x33, 0x74, 0x20}}
+	return a, nil
+}
+
+var _migrations20261018010000Add_contract_token_supply_completeSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x93\xcf\x8e\x9b\x30\x10\xc6\xef\x3c\xc5\x77\xa3\x55\x93\x9c\xaa\x1e\xba\x4d\x25\x5a\x88\x7a\x60\x93\x55\x42\xd4\x63\x34\x31\x93\x60\xad\xb1\x91\x3d\xa4\x42\xda\x87\xaf\x1c\x48\x2e\xdb\xed\x75\x8f\x18\xe6\xf7\xfd\xc1\x33\x9f\xe3\x53\xab\xcf\x9e\x84\xb1\xef\x92\xac\xac\x8a\x2d\xaa\xec\x47\x59\x40\x39\x2b\x9e\x94\x1c\xc4\x3d\xb3\x0d\xc8\xf2\x1c\x3f\x37\xe5\xfe\x71\x8d\xd0\x77\x9d\x19\x0e\xca\xb5\x9d\x61\x61\x1c\x9d\x33\x4c\x16\xeb\x4d\x85\xf5\xbe\x2c\x91\x17\xab\x6c\x5f\x56\x58\x65\xe5\xae\x78\x48\x92\xf9\x1c\x55\xc3\x98\x48\x11\xfb\xcc\x35\x82\xc3\x89\x3c\x5a\x1a\xd0\xd0\x85\x71\x64\xb6\x50\x9e\x49\xb8\xc6\x91\x4f\xce\x33\xa4\x61\x9c\xb4\x0f\x02\x6d\xcf\x1c\x84\xeb\x08\x33\x5c\x9f\xd9\xcf\x22\x41\x1a\x1e\x52\xcf\xe8\xad\xd1\xf1\x35\x7a\x2b\xda\xdc\x8f\xf9\x42\xa6\xbf\x02\xe9\x4c\xda\x2e\x92\xfd\x53\x9e\x55\x05\x28\x04\x96\x80\x5d\x51\x41\x87\xc3\x85\x8c\xae\xb1\x1c\xfd\xce\x70\x7d\x24\xd1\xce\x1e\xd8\x7b\xe7\xb1\x44\x1a\x8d\x8c\xb9\xe1\x4e\x11\x3f\xa6\x81\x0e\xd0\xf6\xd6\x44\x9a\xfc\xfe\x55\x6c\x0b\xc8\xd0\x71\x1c\xba\x75\x98\x4e\x1d\x5c\x27\xc2\xd0\x1e\x9d\x09\x20\xcf\xb0\xd4\x72\xe8\x48\x71\x3d\x85\x21\x89\xec\x01\x96\x2f\x1c\xab\x11\xd5\x80\xa0\x0c\x85\xa0\x15\x94\xab\xf9\x5f\x11\xe2\x39\x96\x30\x7c\x92\x0f\x77\xd1\xaf\x29\x5e\x5e\x20\x8b\x51\x6f\x86\x2f\x9f\x3f\x26\xab\xed\xe6\xf1\xf5\x9f\xdd\x41\x26\xe3\x63\x2d\x8b\x57\xfe\x91\xad\xf3\x49\x70\xa1\x43\xe8\xd9\x1f\x48\x29\xd7\x5b\xc1\x12\xb2\xb8\x13\x75\x7d\xfd\xf2\x26\x8a\x6f\xdf\x91\x4e\xe1\xef\xf7\x2c\x77\x7f\xec\xdb\x19\x6e\xa3\xef\x66\xf5\x7f\x3b\x90\x6f\x37\x4f\x6f\x2c\xc1\x43\xf2\x77\x00\xad\x16\x88\x8d\x4c\x03\x00\x00")
+
+func migrations20261018010000Add_contract_token_supply_completeSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20261018010000Add_contract_token_supply_completeSql,
+		"migrations/20261018010000-add_contract_token_supply_complete.sql",
+	)
+}
+
+func migrations20261018010000Add_contract_token_supply_completeSql() (*asset, error) {
+	bytes, err := migrations20261018010000Add_contract_token_supply_completeSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20261018010000-add_contract_token_supply_complete.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x90, 0x82, 0x90, 0x9, 0xb8, 0xcb, 0x6e, 0x8c, 0xba, 0x61, 0x96, 0x10, 0x62, 0x38, 0xfd, 0x9f, 0xb2, 0xe7, 0x69, 0xbf, 0x4c, 0xad, 0x11, 0x73, 0xe8, 0xf, 0x3a, 0xc9, 0x99, 0xd8, 0x3d, 0x2d}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -390,17 +747,34 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"migrations/20190404184050-initial.sql":                         migrations20190404184050InitialSql,
-	"migrations/20190405112544-increase_asset_code_size.sql":        migrations20190405112544Increase_asset_code_sizeSql,
-	"migrations/20190408115724-add_new_asset_fields.sql":            migrations20190408115724Add_new_asset_fieldsSql,
-	"migrations/20190408155841-add_issuers_table.sql":               migrations20190408155841Add_issuers_tableSql,
-	"migrations/20190409152216-add_trades_table.sql":                migrations20190409152216Add_trades_tableSql,
-	"migrations/20190409172610-rename_assets_desc_description.sql":  migrations20190409172610Rename_assets_desc_descriptionSql,
-	"migrations/20190410094830-add_assets_issuer_account_field.sql": migrations20190410094830Add_assets_issuer_account_fieldSql,
-	"migrations/20190411165735-data_seed_and_indices.sql":           migrations20190411165735Data_seed_and_indicesSql,
-	"migrations/20190425110313-add_orderbook_stats.sql":             migrations20190425110313Add_orderbook_statsSql,
-	"migrations/20190426092321-add_aggregated_orderbook_view.sql":   migrations20190426092321Add_aggregated_orderbook_viewSql,
-	"migrations/20220909100700-trades_pk_to_bigint.sql":             migrations20220909100700Trades_pk_to_bigintSql,
+	"migrations/20190404184050-initial.sql":                            migrations20190404184050InitialSql,
+	"migrations/20190405112544-increase_asset_code_size.sql":           migrations20190405112544Increase_asset_code_sizeSql,
+	"migrations/20190408115724-add_new_asset_fields.sql":               migrations20190408115724Add_new_asset_fieldsSql,
+	"migrations/20190408155841-add_issuers_table.sql":                  migrations20190408155841Add_issuers_tableSql,
+	"migrations/20190409152216-add_trades_table.sql":                   migrations20190409152216Add_trades_tableSql,
+	"migrations/20190409172610-rename_assets_desc_description.sql":     migrations20190409172610Rename_assets_desc_descriptionSql,
+	"migrations/20190410094830-add_assets_issuer_account_field.sql":    migrations20190410094830Add_assets_issuer_account_fieldSql,
+	"migrations/20190411165735-data_seed_and_indices.sql":              migrations20190411165735Data_seed_and_indicesSql,
+	"migrations/20190425110313-add_orderbook_stats.sql":                migrations20190425110313Add_orderbook_statsSql,
+	"migrations/20190426092321-add_aggregated_orderbook_view.sql":      migrations20190426092321Add_aggregated_orderbook_viewSql,
+	"migrations/20220909100700-trades_pk_to_bigint.sql":                migrations20220909100700Trades_pk_to_bigintSql,
+	"migrations/20261017100000-add_candles_table.sql":                  migrations20261017100000Add_candles_tableSql,
+	"migrations/20261017110000-add_ingest_state_table.sql":             migrations20261017110000Add_ingest_state_tableSql,
+	"migrations/20261017120000-add_pending_trades_table.sql":           migrations20261017120000Add_pending_trades_tableSql,
+	"migrations/20261017130000-numeric_trade_amounts.sql":              migrations20261017130000Numeric_trade_amountsSql,
+	"migrations/20261017140000-add_liquidity_pools.sql":                migrations20261017140000Add_liquidity_poolsSql,
+	"migrations/20261017150000-add_orderbook_snapshots.sql":            migrations20261017150000Add_orderbook_snapshotsSql,
+	"migrations/20261017160000-add_orderbook_depth.sql":                migrations20261017160000Add_orderbook_depthSql,
+	"migrations/20261017170000-add_asset_prices.sql":                   migrations20261017170000Add_asset_pricesSql,
+	"migrations/20261017180000-add_pagination_indices.sql":             migrations20261017180000Add_pagination_indicesSql,
+	"migrations/20261017190000-add_asset_evaluations.sql":              migrations20261017190000Add_asset_evaluationsSql,
+	"migrations/20261017200000-add_asset_evaluation_toml_findings.sql": migrations20261017200000Add_asset_evaluation_toml_findingsSql,
+	"migrations/20261017210000-add_toml_cache.sql":                     migrations20261017210000Add_toml_cacheSql,
+	"migrations/20261017220000-add_asset_snapshots.sql":                migrations20261017220000Add_asset_snapshotsSql,
+	"migrations/20261017230000-add_contract_tokens.sql":                migrations20261017230000Add_contract_tokensSql,
+	"migrations/20261017235000-add_trade_flags.sql":                    migrations20261017235000Add_trade_flagsSql,
+	"migrations/20261018000000-add_latest_asset_snapshots.sql":         migrations20261018000000Add_latest_asset_snapshotsSql,
+	"migrations/20261018010000-add_contract_token_supply_complete.sql": migrations20261018010000Add_contract_token_supply_completeSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -446,18 +820,35 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"migrations": {nil, map[string]*bintree{
-		"20190404184050-initial.sql":                         {migrations20190404184050InitialSql, map[string]*bintree{}},
-		"20190405112544-increase_asset_code_size.sql":        {migrations20190405112544Increase_asset_code_sizeSql, map[string]*bintree{}},
-		"20190408115724-add_new_asset_fields.sql":            {migrations20190408115724Add_new_asset_fieldsSql, map[string]*bintree{}},
-		"20190408155841-add_issuers_table.sql":               {migrations20190408155841Add_issuers_tableSql, map[string]*bintree{}},
-		"20190409152216-add_trades_table.sql":                {migrations20190409152216Add_trades_tableSql, map[string]*bintree{}},
-		"20190409172610-rename_assets_desc_description.sql":  {migrations20190409172610Rename_assets_desc_descriptionSql, map[string]*bintree{}},
-		"20190410094830-add_assets_issuer_account_field.sql": {migrations20190410094830Add_assets_issuer_account_fieldSql, map[string]*bintree{}},
-		"20190411165735-data_seed_and_indices.sql":           {migrations20190411165735Data_seed_and_indicesSql, map[string]*bintree{}},
-		"20190425110313-add_orderbook_stats.sql":             {migrations20190425110313Add_orderbook_statsSql, map[string]*bintree{}},
-		"20190426092321-add_aggregated_orderbook_view.sql":   {migrations20190426092321Add_aggregated_orderbook_viewSql, map[string]*bintree{}},
-		"20220909100700-trades_pk_to_bigint.sql":             {migrations20220909100700Trades_pk_to_bigintSql, map[string]*bintree{}},
+	"migrations": &bintree{nil, map[string]*bintree{
+		"20190404184050-initial.sql":                            &bintree{migrations20190404184050InitialSql, map[string]*bintree{}},
+		"20190405112544-increase_asset_code_size.sql":           &bintree{migrations20190405112544Increase_asset_code_sizeSql, map[string]*bintree{}},
+		"20190408115724-add_new_asset_fields.sql":               &bintree{migrations20190408115724Add_new_asset_fieldsSql, map[string]*bintree{}},
+		"20190408155841-add_issuers_table.sql":                  &bintree{migrations20190408155841Add_issuers_tableSql, map[string]*bintree{}},
+		"20190409152216-add_trades_table.sql":                   &bintree{migrations20190409152216Add_trades_tableSql, map[string]*bintree{}},
+		"20190409172610-rename_assets_desc_description.sql":     &bintree{migrations20190409172610Rename_assets_desc_descriptionSql, map[string]*bintree{}},
+		"20190410094830-add_assets_issuer_account_field.sql":    &bintree{migrations20190410094830Add_assets_issuer_account_fieldSql, map[string]*bintree{}},
+		"20190411165735-data_seed_and_indices.sql":              &bintree{migrations20190411165735Data_seed_and_indicesSql, map[string]*bintree{}},
+		"20190425110313-add_orderbook_stats.sql":                &bintree{migrations20190425110313Add_orderbook_statsSql, map[string]*bintree{}},
+		"20190426092321-add_aggregated_orderbook_view.sql":      &bintree{migrations20190426092321Add_aggregated_orderbook_viewSql, map[string]*bintree{}},
+		"20220909100700-trades_pk_to_bigint.sql":                &bintree{migrations20220909100700Trades_pk_to_bigintSql, map[string]*bintree{}},
+		"20261017100000-add_candles_table.sql":                  &bintree{migrations20261017100000Add_candles_tableSql, map[string]*bintree{}},
+		"20261017110000-add_ingest_state_table.sql":             &bintree{migrations20261017110000Add_ingest_state_tableSql, map[string]*bintree{}},
+		"20261017120000-add_pending_trades_table.sql":           &bintree{migrations20261017120000Add_pending_trades_tableSql, map[string]*bintree{}},
+		"20261017130000-numeric_trade_amounts.sql":              &bintree{migrations20261017130000Numeric_trade_amountsSql, map[string]*bintree{}},
+		"20261017140000-add_liquidity_pools.sql":                &bintree{migrations20261017140000Add_liquidity_poolsSql, map[string]*bintree{}},
+		"20261017150000-add_orderbook_snapshots.sql":            &bintree{migrations20261017150000Add_orderbook_snapshotsSql, map[string]*bintree{}},
+		"20261017160000-add_orderbook_depth.sql":                &bintree{migrations20261017160000Add_orderbook_depthSql, map[string]*bintree{}},
+		"20261017170000-add_asset_prices.sql":                   &bintree{migrations20261017170000Add_asset_pricesSql, map[string]*bintree{}},
+		"20261017180000-add_pagination_indices.sql":             &bintree{migrations20261017180000Add_pagination_indicesSql, map[string]*bintree{}},
+		"20261017190000-add_asset_evaluations.sql":              &bintree{migrations20261017190000Add_asset_evaluationsSql, map[string]*bintree{}},
+		"20261017200000-add_asset_evaluation_toml_findings.sql": &bintree{migrations20261017200000Add_asset_evaluation_toml_findingsSql, map[string]*bintree{}},
+		"20261017210000-add_toml_cache.sql":                     &bintree{migrations20261017210000Add_toml_cacheSql, map[string]*bintree{}},
+		"20261017220000-add_asset_snapshots.sql":                &bintree{migrations20261017220000Add_asset_snapshotsSql, map[string]*bintree{}},
+		"20261017230000-add_contract_tokens.sql":                &bintree{migrations20261017230000Add_contract_tokensSql, map[string]*bintree{}},
+		"20261017235000-add_trade_flags.sql":                    &bintree{migrations20261017235000Add_trade_flagsSql, map[string]*bintree{}},
+		"20261018000000-add_latest_asset_snapshots.sql":         &bintree{migrations20261018000000Add_latest_asset_snapshotsSql, map[string]*bintree{}},
+		"20261018010000-add_contract_token_supply_complete.sql": &bintree{migrations20261018010000Add_contract_token_supply_completeSql, map[string]*bintree{}},
 	}},
 }}
 
@@ -49,12 +49,51 @@ func (s *TickerSession) GetAllValidAssets(ctx context.Context) (assets []Asset,
 	return
 }
 
+// GetAsset returns the asset with the given code and issuer account
+// ("native" for XLM).
+func (s *TickerSession) GetAsset(ctx context.Context, code string, issuerAccount string) (asset Asset, err error) {
+	err = s.GetRaw(ctx, &asset,
+		"SELECT * FROM assets WHERE code = ? AND issuer_account = ?",
+		code, issuerAccount,
+	)
+	return
+}
+
+// RetrieveAssetsPage returns a page of at most limit valid assets whose ID
+// is greater than afterID, sorted by ID. Assets are optionally filtered by
+// issuer.
+func (s *TickerSession) RetrieveAssetsPage(ctx context.Context,
+	issuerID *int32,
+	afterID int32,
+	limit int,
+) (assets []Asset, err error) {
+	if issuerID != nil {
+		err = s.SelectRaw(ctx, &assets, `
+			SELECT * FROM assets
+			WHERE is_valid = TRUE AND issuer_id = ? AND id > ?
+			ORDER BY id
+			LIMIT ?`,
+			*issuerID, afterID, limit,
+		)
+		return
+	}
+
+	err = s.SelectRaw(ctx, &assets, `
+		SELECT * FROM assets
+		WHERE is_valid = TRUE AND id > ?
+		ORDER BY id
+		LIMIT ?`,
+		afterID, limit,
+	)
+	return
+}
+
 // GetAssetsWithNestedIssuer returns a slice with all assets in the database
 // with is_valid = true, also adding the nested Issuer attribute
 func (s *TickerSession) GetAssetsWithNestedIssuer(ctx context.Context) (assets []Asset, err error) {
 	const q = `
 		SELECT
-			a.code, a.issuer_account, a.type, a.num_accounts, a.auth_required, a.auth_revocable,
+			a.id, a.code, a.issuer_account, a.type, a.num_accounts, a.auth_required, a.auth_revocable,
 			a.amount, a.asset_controlled_by_domain, a.anchor_asset_code, a.anchor_asset_type,
 			a.is_valid, a.validation_error, a.last_valid, a.last_checked, a.display_decimals,
 			a.name, a.description, a.conditions, a.is_asset_anchored, a.fixed_number, a.max_number,
@@ -78,7 +117,7 @@ func (s *TickerSession) GetAssetsWithNestedIssuer(ctx context.Context) (assets [
 		)
 
 		err = rows.Scan(
-			&a.Code, &a.IssuerAccount, &a.Type, &a.NumAccounts, &a.AuthRequired, &a.AuthRevocable,
+			&a.ID, &a.Code, &a.IssuerAccount, &a.Type, &a.NumAccounts, &a.AuthRequired, &a.AuthRevocable,
 			&a.Amount, &a.AssetControlledByDomain, &a.AnchorAssetCode, &a.AnchorAssetType,
 			&a.IsValid, &a.ValidationError, &a.LastValid, &a.LastChecked, &a.DisplayDecimals,
 			&a.Name, &a.Desc, &a.Conditions, &a.IsAssetAnchored, &a.FixedNumber, &a.MaxNumber,
@@ -0,0 +1,58 @@
+package tickerdb
+
+import (
+	"context"
+	"strings"
+)
+
+// assetEvaluationsBatchSize is the number of evaluations upserted per query.
+const assetEvaluationsBatchSize = 500
+
+// BulkUpsertAssetEvaluations inserts a slice of asset evaluations in the
+// database, replacing the previous evaluations of the same assets.
+func (s *TickerSession) BulkUpsertAssetEvaluations(ctx context.Context, evaluations []AssetEvaluation) (err error) {
+	for start := 0; start < len(evaluations); start += assetEvaluationsBatchSize {
+		end := start + assetEvaluationsBatchSize
+		if end > len(evaluations) {
+			end = len(evaluations)
+		}
+
+		err = performUpsertAssetEvaluations(ctx, s, evaluations[start:end])
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// GetAssetEvaluation retrieves the latest evaluation of the asset with the
+// given code and issuer account.
+func (s *TickerSession) GetAssetEvaluation(ctx context.Context, code string, issuerAccount string) (evaluation AssetEvaluation, err error) {
+	err = s.GetRaw(ctx, &evaluation,
+		"SELECT * FROM asset_evaluations WHERE code = ? AND issuer_account = ?",
+		code, issuerAccount,
+	)
+	return
+}
+
+func performUpsertAssetEvaluations(ctx context.Context, s *TickerSession, evaluations []AssetEvaluation) (err error) {
+	var e AssetEvaluation
+	var placeholders []string
+	var dbValues []interface{}
+
+	dbFields := getDBFieldTags(e, true)
+	dbFieldsString := strings.Join(dbFields, ", ")
+
+	for _, evaluation := range evaluations {
+		v := getDBFieldValues(evaluation, true)
+		placeholders = append(placeholders, "("+generatePlaceholders(v)+")")
+		dbValues = append(dbValues, v...)
+	}
+
+	qs := "INSERT INTO asset_evaluations (" + dbFieldsString + ")"
+	qs += " VALUES " + strings.Join(placeholders, ",")
+	qs += " " + createOnConflictFragment("asset_evaluations_code_issuer_key", dbFields) + ";"
+
+	_, err = s.ExecRaw(ctx, qs, dbValues...)
+	return
+}
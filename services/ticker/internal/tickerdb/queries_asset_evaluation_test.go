@@ -0,0 +1,102 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetEvaluations(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	issuer := "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB"
+	now := time.Now().UTC().Truncate(time.Second)
+
+	err = session.BulkUpsertAssetEvaluations(ctx, []AssetEvaluation{
+		{
+			Code:          "BTC",
+			IssuerAccount: issuer,
+			Listed:        true,
+			Rule:          "all",
+			Reason:        "supply of 100 is at least 0",
+			NumAccounts:   20,
+			Amount:        "100.5",
+			TOMLURL:       "https://example.com/.well-known/stellar.toml",
+			EvaluatedAt:   now,
+			HomeDomain:    "example.com",
+			TOMLFindings:  `[{"check":"signing_key","severity":"warning","message":"SIGNING_KEY is missing"}]`,
+		},
+		{
+			Code:          "ETH",
+			IssuerAccount: issuer,
+			Listed:        false,
+			Rule:          "min_holders",
+			Reason:        "2 holders, fewer than 10",
+			NumAccounts:   2,
+			Amount:        "1",
+			EvaluatedAt:   now,
+			TOMLFindings:  "[]",
+		},
+	})
+	require.NoError(t, err)
+
+	btc, err := session.GetAssetEvaluation(ctx, "BTC", issuer)
+	require.NoError(t, err)
+	assert.True(t, btc.Listed)
+	assert.Equal(t, "all", btc.Rule)
+	assert.Equal(t, int32(20), btc.NumAccounts)
+	assert.Equal(t, "100.5", btc.Amount)
+	assert.Equal(t, "https://example.com/.well-known/stellar.toml", btc.TOMLURL)
+	assert.True(t, now.Equal(btc.EvaluatedAt))
+	assert.Equal(t, "example.com", btc.HomeDomain)
+	assert.JSONEq(t, `[{"check":"signing_key","severity":"warning","message":"SIGNING_KEY is missing"}]`, btc.TOMLFindings)
+
+	// Evaluating an asset again replaces its previous evaluation:
+	later := now.Add(time.Hour)
+	err = session.BulkUpsertAssetEvaluations(ctx, []AssetEvaluation{{
+		Code:          "ETH",
+		IssuerAccount: issuer,
+		Listed:        true,
+		Rule:          "allow",
+		Reason:        "ETH:" + issuer + " is on the allow list",
+		NumAccounts:   3,
+		Amount:        "1",
+		EvaluatedAt:   later,
+		TOMLFindings:  "[]",
+	}})
+	require.NoError(t, err)
+
+	eth, err := session.GetAssetEvaluation(ctx, "ETH", issuer)
+	require.NoError(t, err)
+	assert.True(t, eth.Listed)
+	assert.Equal(t, "allow", eth.Rule)
+	assert.Equal(t, int32(3), eth.NumAccounts)
+	assert.True(t, later.Equal(eth.EvaluatedAt))
+
+	var count int
+	err = session.GetRaw(ctx, &count, "SELECT COUNT(*) FROM asset_evaluations")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, err = session.GetAssetEvaluation(ctx, "XYZ", issuer)
+	assert.True(t, session.NoRows(err))
+}
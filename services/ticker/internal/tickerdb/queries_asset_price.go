@@ -0,0 +1,51 @@
+package tickerdb
+
+import (
+	"context"
+	"time"
+)
+
+// InsertOrUpdateAssetPrice inserts an AssetPrice entry on the database (if new),
+// or updates an existing one
+func (s *TickerSession) InsertOrUpdateAssetPrice(ctx context.Context, p *AssetPrice, preserveFields []string) (err error) {
+	return s.performUpsertQuery(ctx, *p, "asset_prices", "asset_prices_asset_currency_key", preserveFields)
+}
+
+// DeleteOldAssetPrices deletes the asset prices that weren't updated since
+// minDate, e.g. because their assets stopped trading.
+func (s *TickerSession) DeleteOldAssetPrices(ctx context.Context, minDate time.Time) error {
+	_, err := s.ExecRaw(ctx, "DELETE FROM asset_prices WHERE updated_at < ?", minDate)
+	return err
+}
+
+// RetrieveAssetPrices retrieves the prices of all assets, in every currency.
+func (s *TickerSession) RetrieveAssetPrices(ctx context.Context) (prices []AssetPrice, err error) {
+	err = s.SelectRaw(ctx, &prices, "SELECT * FROM asset_prices ORDER BY asset_id, currency")
+	return
+}
+
+// RetrieveMarketPrices retrieves the 24h volumes and the most recent price of
+// every market between valid assets that was active within the last 7 days.
+func (s *TickerSession) RetrieveMarketPrices(ctx context.Context) (markets []MarketPrice, err error) {
+	err = s.SelectRaw(ctx, &markets, `
+		SELECT
+			bAsset.id AS base_asset_id,
+			bAsset.code AS base_asset_code,
+			bAsset.issuer_account AS base_asset_issuer,
+			cAsset.id AS counter_asset_id,
+			cAsset.code AS counter_asset_code,
+			cAsset.issuer_account AS counter_asset_issuer,
+			COALESCE(sum(t.base_amount) FILTER (WHERE t.ledger_close_time > now() - interval '1 day'), 0) AS base_volume_24h,
+			COALESCE(sum(t.counter_amount) FILTER (WHERE t.ledger_close_time > now() - interval '1 day'), 0) AS counter_volume_24h,
+			(array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] AS last_price
+		FROM trades AS t
+			JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
+			JOIN assets AS cAsset ON t.counter_asset_id = cAsset.id
+		WHERE bAsset.is_valid = TRUE
+			AND cAsset.is_valid = TRUE
+			AND t.ledger_close_time > now() - interval '7 days'
+		GROUP BY bAsset.id, bAsset.code, bAsset.issuer_account, cAsset.id, cAsset.code, cAsset.issuer_account
+		ORDER BY bAsset.id, cAsset.id`,
+	)
+	return
+}
@@ -0,0 +1,174 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetPrices(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "XLM",
+		IssuerAccount: "native",
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding another asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "USDC",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var usdcAsset Asset
+	err = session.GetRaw(ctx, &usdcAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Only the 24h volume is summed up, and the last price comes from the
+	// most recent trade within the last 7 days:
+	now := time.Now()
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "100.0",
+			CounterAssetID:  usdcAsset.ID,
+			CounterAmount:   "10.0",
+			LedgerCloseTime: now.Add(-time.Hour),
+			Price:           "10.0",
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "300.0",
+			CounterAssetID:  usdcAsset.ID,
+			CounterAmount:   "20.0",
+			LedgerCloseTime: now.Add(-2 * time.Hour),
+			Price:           "15.0",
+		},
+		{
+			HorizonID:       "hrzid3",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "1000.0",
+			CounterAssetID:  usdcAsset.ID,
+			CounterAmount:   "50.0",
+			LedgerCloseTime: now.Add(-72 * time.Hour),
+			Price:           "20.0",
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	markets, err := session.RetrieveMarketPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(markets))
+	assert.Equal(t, xlmAsset.ID, markets[0].BaseAssetID)
+	assert.Equal(t, "native", markets[0].BaseAssetIssuer)
+	assert.Equal(t, usdcAsset.ID, markets[0].CounterAssetID)
+	assert.Equal(t, "USDC", markets[0].CounterAssetCode)
+	assert.Equal(t, issuer.PublicKey, markets[0].CounterAssetIssuer)
+	assert.Equal(t, 400.0, markets[0].BaseVolume24h)
+	assert.Equal(t, 30.0, markets[0].CounterVolume24h)
+	assert.Equal(t, 10.0, markets[0].LastPrice)
+
+	// Updating a price must preserve its asset and currency:
+	err = session.InsertOrUpdateAssetPrice(ctx, &AssetPrice{
+		AssetID:   xlmAsset.ID,
+		Currency:  "USD",
+		Price:     0.1,
+		UpdatedAt: now.Add(-time.Hour),
+	}, []string{"asset_id", "currency"})
+	require.NoError(t, err)
+	err = session.InsertOrUpdateAssetPrice(ctx, &AssetPrice{
+		AssetID:   xlmAsset.ID,
+		Currency:  "USD",
+		Price:     0.075,
+		UpdatedAt: now,
+	}, []string{"asset_id", "currency"})
+	require.NoError(t, err)
+	err = session.InsertOrUpdateAssetPrice(ctx, &AssetPrice{
+		AssetID:   usdcAsset.ID,
+		Currency:  "USD",
+		Price:     1.0,
+		UpdatedAt: now.Add(-time.Hour),
+	}, []string{"asset_id", "currency"})
+	require.NoError(t, err)
+
+	prices, err := session.RetrieveAssetPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(prices))
+	assert.Equal(t, xlmAsset.ID, prices[0].AssetID)
+	assert.Equal(t, "USD", prices[0].Currency)
+	assert.Equal(t, 0.075, prices[0].Price)
+	assert.Equal(t, usdcAsset.ID, prices[1].AssetID)
+
+	// The USD volumes and prices are exposed on the markets:
+	partialMkts, err := session.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(partialMkts))
+	assert.InDelta(t, 30.0, partialMkts[0].BaseVolumeUSD, 1e-9)
+	assert.InDelta(t, 30.0, partialMkts[0].CounterVolumeUSD, 1e-9)
+	assert.Equal(t, 0.075, partialMkts[0].PriceUSD)
+
+	// Stale prices are deleted:
+	err = session.DeleteOldAssetPrices(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	prices, err = session.RetrieveAssetPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(prices))
+	assert.Equal(t, xlmAsset.ID, prices[0].AssetID)
+}
@@ -0,0 +1,208 @@
+package tickerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// InsertAssetSnapshot appends an AssetSnapshot entry to the database for the
+// asset with the given code and issuer account (which sets its AssetID), and
+// makes it the latest snapshot of the asset. Nothing is inserted if the supply
+// breakdown didn't change since the latest snapshot, or if the asset isn't
+// found, in which case inserted is false. Empty amounts are stored as zero.
+func (s *TickerSession) InsertAssetSnapshot(ctx context.Context, code, issuerAccount string, a *AssetSnapshot) (inserted bool, err error) {
+	amount := func(v string) string {
+		if v == "" {
+			return "0"
+		}
+		return v
+	}
+
+	breakdown := []interface{}{
+		a.NumAccounts,
+		a.NumAuthorizedToMaintainLiabilities,
+		a.NumUnauthorized,
+		a.NumClaimableBalances,
+		a.NumLiquidityPools,
+		a.NumContracts,
+		amount(a.Authorized),
+		amount(a.AuthorizedToMaintainLiabilities),
+		amount(a.Unauthorized),
+		amount(a.ClaimableBalances),
+		amount(a.LiquidityPools),
+		amount(a.Contracts),
+		amount(a.ArchivedContracts),
+	}
+	args := []interface{}{code, issuerAccount}
+	args = append(args, breakdown...)
+	args = append(args, a.CreatedAt)
+	args = append(args, breakdown...)
+
+	res, err := s.ExecRaw(ctx, insertAssetSnapshotQuery, args...)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	inserted = n > 0
+	return
+}
+
+// DeleteOldAssetSnapshots deletes the asset snapshots older than minDate,
+// except for the latest snapshot of each asset.
+func (s *TickerSession) DeleteOldAssetSnapshots(ctx context.Context, minDate time.Time) error {
+	_, err := s.ExecRaw(ctx, `
+		DELETE FROM asset_snapshots
+		WHERE created_at < ?
+			AND id NOT IN (SELECT snapshot_id FROM latest_asset_snapshots)`,
+		minDate,
+	)
+	return err
+}
+
+// RetrieveLatestAssetSnapshots retrieves the latest snapshot of each asset,
+// keyed by asset ID.
+func (s *TickerSession) RetrieveLatestAssetSnapshots(ctx context.Context) (snapshots map[int32]AssetSnapshot, err error) {
+	var dbSnapshots []AssetSnapshot
+	err = s.SelectRaw(ctx, &dbSnapshots, `
+		SELECT s.*
+		FROM latest_asset_snapshots AS l
+		JOIN asset_snapshots AS s ON s.id = l.snapshot_id`,
+	)
+	if err != nil {
+		return
+	}
+
+	snapshots = make(map[int32]AssetSnapshot, len(dbSnapshots))
+	for _, snapshot := range dbSnapshots {
+		snapshots[snapshot.AssetID] = snapshot
+	}
+	return
+}
+
+// RetrieveAssetSupplyHistory retrieves the snapshots of an asset taken within
+// [from, to), keeping the last one of each bucket of the given (candle)
+// resolution, oldest first.
+func (s *TickerSession) RetrieveAssetSupplyHistory(ctx context.Context,
+	assetID int32,
+	resolution string,
+	from time.Time,
+	to time.Time,
+) (history []AssetSnapshot, err error) {
+	bucketSize, ok := CandleResolutions[resolution]
+	if !ok {
+		err = fmt.Errorf("invalid supply history resolution: %s", resolution)
+		return
+	}
+
+	if !from.Before(to) {
+		err = errors.New("from must be before to")
+		return
+	}
+
+	err = s.SelectRaw(ctx, &history, assetSupplyHistoryQuery,
+		int64(bucketSize.Seconds()), assetID, from, to,
+	)
+	return
+}
+
+var assetSupplyHistoryQuery = `
+SELECT *
+FROM asset_snapshots
+WHERE id IN (
+	SELECT DISTINCT ON (bucket) id
+	FROM (
+		SELECT
+			id,
+			created_at,
+			floor(extract(epoch FROM created_at) / ?) AS bucket
+		FROM asset_snapshots
+		WHERE asset_id = ? AND created_at >= ? AND created_at < ?
+	) AS s
+	ORDER BY bucket, created_at DESC
+)
+ORDER BY created_at ASC;`
+
+// insertAssetSnapshotQuery inserts a snapshot of the asset with the given code
+// and issuer account unless its latest snapshot has the same supply breakdown,
+// and points the latest snapshot of the asset to it. The placeholders are
+// typed since the values are selected rather than inserted directly.
+var insertAssetSnapshotQuery = `
+WITH asset AS (
+	SELECT id FROM assets WHERE code = ? AND issuer_account = ?
+), snapshot AS (
+	INSERT INTO asset_snapshots (
+		asset_id,
+		num_accounts,
+		num_authorized_to_maintain_liabilities,
+		num_unauthorized,
+		num_claimable_balances,
+		num_liquidity_pools,
+		num_contracts,
+		authorized,
+		authorized_to_maintain_liabilities,
+		unauthorized,
+		claimable_balances,
+		liquidity_pools,
+		contracts,
+		archived_contracts,
+		created_at
+	)
+	SELECT
+		asset.id,
+		?::integer,
+		?::integer,
+		?::integer,
+		?::integer,
+		?::integer,
+		?::integer,
+		?::numeric,
+		?::numeric,
+		?::numeric,
+		?::numeric,
+		?::numeric,
+		?::numeric,
+		?::numeric,
+		?::timestamptz
+	FROM asset
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM latest_asset_snapshots AS l
+		JOIN asset_snapshots AS s ON s.id = l.snapshot_id
+		WHERE l.asset_id = asset.id
+			AND (
+				s.num_accounts,
+				s.num_authorized_to_maintain_liabilities,
+				s.num_unauthorized,
+				s.num_claimable_balances,
+				s.num_liquidity_pools,
+				s.num_contracts,
+				s.authorized,
+				s.authorized_to_maintain_liabilities,
+				s.unauthorized,
+				s.claimable_balances,
+				s.liquidity_pools,
+				s.contracts,
+				s.archived_contracts
+			) = (
+				?::integer,
+				?::integer,
+				?::integer,
+				?::integer,
+				?::integer,
+				?::integer,
+				?::numeric,
+				?::numeric,
+				?::numeric,
+				?::numeric,
+				?::numeric,
+				?::numeric,
+				?::numeric
+			)
+	)
+	RETURNING id, asset_id
+)
+INSERT INTO latest_asset_snapshots (asset_id, snapshot_id)
+SELECT asset_id, id FROM snapshot
+ON CONFLICT (asset_id) DO UPDATE SET snapshot_id = EXCLUDED.snapshot_id;`
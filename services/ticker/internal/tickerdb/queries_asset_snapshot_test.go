@@ -0,0 +1,127 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetSnapshots(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "USDC",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var asset Asset
+	err = session.GetRaw(ctx, &asset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	start := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	for i, authorized := range []string{"100", "150", "200"} {
+		var inserted bool
+		inserted, err = session.InsertAssetSnapshot(ctx, asset.Code, asset.IssuerAccount, &AssetSnapshot{
+			NumAccounts:       int32(10 * (i + 1)),
+			Authorized:        authorized,
+			ClaimableBalances: "5",
+			LiquidityPools:    "20",
+			CreatedAt:         start.Add(time.Duration(i) * 40 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.True(t, inserted)
+	}
+
+	// Unchanged supplies aren't recorded, even with a different scale, and
+	// neither are unknown assets:
+	inserted, err := session.InsertAssetSnapshot(ctx, asset.Code, asset.IssuerAccount, &AssetSnapshot{
+		NumAccounts:       30,
+		Authorized:        "200.0000000",
+		ClaimableBalances: "5.0000000",
+		LiquidityPools:    "20.0000000",
+		CreatedAt:         start.Add(100 * time.Minute),
+	})
+	require.NoError(t, err)
+	assert.False(t, inserted)
+	inserted, err = session.InsertAssetSnapshot(ctx, "EURC", asset.IssuerAccount, &AssetSnapshot{
+		NumAccounts: 30,
+		CreatedAt:   start,
+	})
+	require.NoError(t, err)
+	assert.False(t, inserted)
+
+	// The last snapshot of each hour is kept:
+	history, err := session.RetrieveAssetSupplyHistory(ctx, asset.ID, "1h", start, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "150", history[0].Authorized)
+	assert.Equal(t, int32(20), history[0].NumAccounts)
+	assert.Equal(t, "200", history[1].Authorized)
+	assert.Equal(t, 220.0, history[1].CirculatingSupply())
+	assert.Equal(t, 5.0, history[1].LockedSupply())
+	assert.Equal(t, 225.0, history[1].TotalSupply())
+
+	_, err = session.RetrieveAssetSupplyHistory(ctx, asset.ID, "2h", start, start.Add(time.Hour))
+	assert.Error(t, err)
+	_, err = session.RetrieveAssetSupplyHistory(ctx, asset.ID, "1h", start, start)
+	assert.Error(t, err)
+
+	latest, err := session.RetrieveLatestAssetSnapshots(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "200", latest[asset.ID].Authorized)
+	assert.Equal(t, start.Add(80*time.Minute).Unix(), latest[asset.ID].CreatedAt.Unix())
+
+	// The latest snapshot of each asset is never deleted:
+	err = session.DeleteOldAssetSnapshots(ctx, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	history, err = session.RetrieveAssetSupplyHistory(ctx, asset.ID, "1m", start, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "200", history[0].Authorized)
+	latest, err = session.RetrieveLatestAssetSnapshots(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "200", latest[asset.ID].Authorized)
+}
@@ -229,3 +229,67 @@ func TestGetAssetByCodeAndIssuerAccount(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, found)
 }
+
+func TestRetrieveAssetsPage(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding two seed issuers to be used later:
+	var issuers []Issuer
+	for _, publicKey := range []string{"GISSUER1", "GISSUER2"} {
+		id, err := session.InsertOrUpdateIssuer(ctx, &Issuer{PublicKey: publicKey}, []string{"public_key"})
+		require.NoError(t, err)
+		issuers = append(issuers, Issuer{ID: id, PublicKey: publicKey})
+	}
+
+	// Adding valid assets of both issuers, and an invalid one:
+	for _, a := range []Asset{
+		{Code: "BTC", IssuerAccount: "GISSUER1", IssuerID: issuers[0].ID, IsValid: true},
+		{Code: "ETH", IssuerAccount: "GISSUER1", IssuerID: issuers[0].ID, IsValid: true},
+		{Code: "USD", IssuerAccount: "GISSUER2", IssuerID: issuers[1].ID, IsValid: true},
+		{Code: "SCAM", IssuerAccount: "GISSUER1", IssuerID: issuers[0].ID},
+	} {
+		a := a
+		err = session.InsertOrUpdateAsset(ctx, &a, []string{"code", "issuer_account", "issuer_id"})
+		require.NoError(t, err)
+	}
+
+	dbAsset, err := session.GetAsset(ctx, "ETH", "GISSUER1")
+	require.NoError(t, err)
+	assert.Equal(t, "ETH", dbAsset.Code)
+	assert.Equal(t, issuers[0].ID, dbAsset.IssuerID)
+
+	_, err = session.GetAsset(ctx, "ETH", "GISSUER2")
+	assert.True(t, session.NoRows(err))
+
+	// Pages only include valid assets, sorted by ID:
+	page, err := session.RetrieveAssetsPage(ctx, nil, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(page))
+	assert.Equal(t, "BTC", page[0].Code)
+	assert.Equal(t, "ETH", page[1].Code)
+
+	page, err = session.RetrieveAssetsPage(ctx, nil, page[1].ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(page))
+	assert.Equal(t, "USD", page[0].Code)
+
+	// Assets can be filtered by issuer:
+	page, err = session.RetrieveAssetsPage(ctx, &issuers[1].ID, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(page))
+	assert.Equal(t, "USD", page[0].Code)
+}
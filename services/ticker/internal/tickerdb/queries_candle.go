@@ -0,0 +1,166 @@
+package tickerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CandleResolutions maps each supported candle resolution to the size of its
+// buckets. Candles for every resolution are updated whenever trades are
+// inserted through BulkInsertTrades.
+var CandleResolutions = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// RetrieveCandles retrieves the OHLCV candles of the given resolution whose
+// bucket starts within [from, to). Assets with the same code are aggregated
+// into a single trade pair (e.g. XLM_BTC), and pairName optionally restricts
+// the results to a single pair.
+func (s *TickerSession) RetrieveCandles(ctx context.Context,
+	pairName *string,
+	resolution string,
+	from time.Time,
+	to time.Time,
+) (candles []PairCandle, err error) {
+	if _, ok := CandleResolutions[resolution]; !ok {
+		err = fmt.Errorf("invalid candle resolution: %s", resolution)
+		return
+	}
+
+	if !from.Before(to) {
+		err = errors.New("from must be before to")
+		return
+	}
+
+	var bCode, cCode string
+	sqlTrue := new(string)
+	*sqlTrue = "TRUE"
+	optVars := []optionalVar{
+		{"bAsset.is_valid", sqlTrue},
+		{"cAsset.is_valid", sqlTrue},
+		{"c.resolution", &resolution},
+	}
+
+	// parse base and asset codes and add them as SQL parameters
+	if pairName != nil {
+		bCode, cCode, err = getBaseAndCounterCodes(*pairName)
+		if err != nil {
+			return
+		}
+		optVars = append(optVars, []optionalVar{
+			{"bAsset.code", &bCode},
+			{"cAsset.code", &cCode},
+		}...)
+	}
+
+	where, args := generateWhereClause(optVars)
+	where += " AND c.open_time >= ? AND c.open_time < ?"
+	q := strings.Replace(candleQuery, "__WHERECLAUSE__", where, -1)
+
+	argsInterface := make([]interface{}, 0, len(args)+2)
+	for _, v := range args {
+		argsInterface = append(argsInterface, v)
+	}
+	argsInterface = append(argsInterface, from, to)
+
+	err = s.SelectRaw(ctx, &candles, q, argsInterface...)
+	return
+}
+
+// candleResolutionValues returns the supported resolutions formatted as the
+// rows of a SQL VALUES list (e.g. "('1m', 60), ('5m', 300)"), ordered from
+// the smallest to the largest bucket size.
+func candleResolutionValues() string {
+	names := make([]string, 0, len(CandleResolutions))
+	for name := range CandleResolutions {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return CandleResolutions[names[i]] < CandleResolutions[names[j]]
+	})
+
+	rows := make([]string, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, fmt.Sprintf("('%s', %d)", name, int64(CandleResolutions[name].Seconds())))
+	}
+	return strings.Join(rows, ", ")
+}
+
+// createCandleUpsertFragment generates a statement that merges the trades
+// available under tradesSource into the candles of every supported resolution.
+func createCandleUpsertFragment(tradesSource string) string {
+	q := strings.Replace(candleUpsertQuery, "__TRADES__", tradesSource, -1)
+	return strings.Replace(q, "__RESOLUTIONS__", candleResolutionValues(), -1)
+}
+
+var candleUpsertQuery = `
+INSERT INTO candles (
+	base_asset_id,
+	counter_asset_id,
+	resolution,
+	open_time,
+	open,
+	high,
+	low,
+	close,
+	base_volume,
+	counter_volume,
+	trade_count,
+	first_trade_time,
+	last_trade_time
+)
+SELECT
+	t.base_asset_id,
+	t.counter_asset_id,
+	r.resolution,
+	to_timestamp(floor(extract(epoch FROM t.ledger_close_time) / r.seconds) * r.seconds) AS bucket,
+	(array_agg(t.price ORDER BY t.ledger_close_time ASC))[1],
+	max(t.price),
+	min(t.price),
+	(array_agg(t.price ORDER BY t.ledger_close_time DESC))[1],
+	sum(t.base_amount),
+	sum(t.counter_amount),
+	count(*),
+	min(t.ledger_close_time),
+	max(t.ledger_close_time)
+FROM __TRADES__ AS t
+	CROSS JOIN (VALUES __RESOLUTIONS__) AS r (resolution, seconds)
+GROUP BY t.base_asset_id, t.counter_asset_id, r.resolution, bucket
+ON CONFLICT ON CONSTRAINT candles_pair_resolution_open_time_key DO UPDATE SET
+	open = CASE WHEN EXCLUDED.first_trade_time < candles.first_trade_time THEN EXCLUDED.open ELSE candles.open END,
+	high = GREATEST(candles.high, EXCLUDED.high),
+	low = LEAST(candles.low, EXCLUDED.low),
+	close = CASE WHEN EXCLUDED.last_trade_time >= candles.last_trade_time THEN EXCLUDED.close ELSE candles.close END,
+	base_volume = candles.base_volume + EXCLUDED.base_volume,
+	counter_volume = candles.counter_volume + EXCLUDED.counter_volume,
+	trade_count = candles.trade_count + EXCLUDED.trade_count,
+	first_trade_time = LEAST(candles.first_trade_time, EXCLUDED.first_trade_time),
+	last_trade_time = GREATEST(candles.last_trade_time, EXCLUDED.last_trade_time)`
+
+var candleQuery = `
+SELECT
+	concat(bAsset.code, '_', cAsset.code) AS trade_pair_name,
+	c.resolution,
+	c.open_time,
+	(array_agg(c.open ORDER BY c.first_trade_time ASC))[1] AS open,
+	max(c.high) AS high,
+	min(c.low) AS low,
+	(array_agg(c.close ORDER BY c.last_trade_time DESC))[1] AS close,
+	sum(c.base_volume) AS base_volume,
+	sum(c.counter_volume) AS counter_volume,
+	sum(c.trade_count)::bigint AS trade_count
+FROM candles AS c
+	JOIN assets AS bAsset ON c.base_asset_id = bAsset.id
+	JOIN assets AS cAsset ON c.counter_asset_id = cAsset.id
+__WHERECLAUSE__
+GROUP BY trade_pair_name, c.resolution, c.open_time
+ORDER BY trade_pair_name, c.open_time ASC;`
@@ -0,0 +1,162 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandleResolutionValues(t *testing.T) {
+	assert.Equal(
+		t,
+		"('1m', 60), ('5m', 300), ('15m', 900), ('1h', 3600), ('4h', 14400), ('1d', 86400)",
+		candleResolutionValues(),
+	)
+}
+
+func TestCandlesFromBulkInsertTrades(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "XLM",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding another asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "BTC",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var btcAsset Asset
+	err = session.GetRaw(ctx, &btcAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	hourStart := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+
+	// The first batch of trades creates the candles:
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "10.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "1.0",
+			LedgerCloseTime: hourStart.Add(1 * time.Minute),
+			Price:           "0.1",
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "20.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "4.0",
+			LedgerCloseTime: hourStart.Add(10 * time.Minute),
+			Price:           "0.2",
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	// The second batch contains a duplicate, which must not be counted twice,
+	// and a new trade that updates the close of the existing hourly candle:
+	trades = []Trade{
+		trades[1],
+		{
+			HorizonID:       "hrzid3",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "5.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "0.25",
+			LedgerCloseTime: hourStart.Add(30 * time.Minute),
+			Price:           "0.05",
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	var numMinuteCandles int
+	err = session.GetRaw(ctx, &numMinuteCandles, "SELECT count(*) FROM candles WHERE resolution = '1m'")
+	require.NoError(t, err)
+	assert.Equal(t, 3, numMinuteCandles)
+
+	pairName := "XLM_BTC"
+	candles, err := session.RetrieveCandles(ctx, &pairName, "1h", hourStart.Add(-time.Hour), time.Now())
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+
+	candle := candles[0]
+	assert.Equal(t, "XLM_BTC", candle.TradePairName)
+	assert.Equal(t, "1h", candle.Resolution)
+	assert.True(t, hourStart.Equal(candle.OpenTime))
+	assert.Equal(t, 0.1, candle.Open)
+	assert.Equal(t, 0.2, candle.High)
+	assert.Equal(t, 0.05, candle.Low)
+	assert.Equal(t, 0.05, candle.Close)
+	assert.Equal(t, 35.0, candle.BaseVolume)
+	assert.Equal(t, 5.25, candle.CounterVolume)
+	assert.Equal(t, int64(3), candle.TradeCount)
+
+	// Candles outside of the requested range are not returned:
+	candles, err = session.RetrieveCandles(ctx, &pairName, "1h", hourStart.Add(time.Hour), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, candles)
+
+	// Unknown resolutions are rejected:
+	_, err = session.RetrieveCandles(ctx, &pairName, "2h", hourStart, time.Now())
+	assert.Error(t, err)
+}
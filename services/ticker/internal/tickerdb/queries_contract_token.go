@@ -0,0 +1,270 @@
+package tickerdb
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/support/db"
+)
+
+// ContractAssetType is the type of the assets which are Soroban tokens rather
+// than classic assets (whose types are the ones of Horizon).
+const ContractAssetType = "contract"
+
+// ContractCodePrefix namespaces the codes of the contract tokens on the
+// assets table, so that a token can't pose as a classic asset by choosing its
+// symbol (e.g. the code of a token whose symbol is USDC is "contract:USDC").
+const ContractCodePrefix = "contract:"
+
+// ContractTokenUpdate is the net change of the supply and of the balances
+// (keyed by holder address) of a contract token over a range of ledgers, in
+// raw units (e.g. "-150"). Metadata is set if the token metadata was written
+// within the range.
+type ContractTokenUpdate struct {
+	ContractID string
+	Supply     string
+	Balances   map[string]string
+	Metadata   *ContractTokenMetadata
+	LastLedger int32
+}
+
+// ContractTokenMetadata is the name, symbol and number of decimals of a
+// contract token.
+type ContractTokenMetadata struct {
+	Name     string
+	Symbol   string
+	Decimals int32
+}
+
+// GetContractToken retrieves the contract token with the given contract ID.
+func (s *TickerSession) GetContractToken(ctx context.Context, contractID string) (token ContractToken, err error) {
+	err = s.GetRaw(ctx, &token, "SELECT * FROM contract_tokens WHERE contract_id = ?", contractID)
+	return
+}
+
+// GetAllContractTokens retrieves all the contract tokens, sorted by ID.
+func (s *TickerSession) GetAllContractTokens(ctx context.Context) (tokens []ContractToken, err error) {
+	err = s.SelectRaw(ctx, &tokens, "SELECT * FROM contract_tokens ORDER BY id")
+	return
+}
+
+// ApplyContractTokenUpdates applies the given updates to the supply, balances
+// and holder counts of contract tokens, refreshes their entries on the assets
+// table and advances the paging token of the given ingestion job, all within
+// the same database transaction.
+func (s *TickerSession) ApplyContractTokenUpdates(
+	ctx context.Context,
+	updates []ContractTokenUpdate,
+	job string,
+	pagingToken string,
+) (err error) {
+	txSession := TickerSession{db.Session{DB: s.DB}}
+	if err = txSession.Begin(ctx); err != nil {
+		return
+	}
+
+	for _, update := range updates {
+		if err = txSession.applyContractTokenUpdate(ctx, update); err != nil {
+			txSession.Rollback()
+			return
+		}
+		if err = txSession.updateContractTokenAsset(ctx, update.ContractID); err != nil {
+			txSession.Rollback()
+			return
+		}
+	}
+
+	if err = txSession.updateIngestCursor(ctx, job, pagingToken); err != nil {
+		txSession.Rollback()
+		return
+	}
+
+	return txSession.Commit()
+}
+
+// applyContractTokenUpdate adds the changes of update to the supply and
+// balances of its token, and recounts its holders. The supply of new tokens is
+// only complete if their metadata was written within the update, since the
+// tokens created before the first ingested ledger have events (e.g. mints)
+// that were never seen.
+func (s *TickerSession) applyContractTokenUpdate(ctx context.Context, update ContractTokenUpdate) (err error) {
+	supply := update.Supply
+	if supply == "" {
+		supply = "0"
+	}
+	_, err = s.ExecRaw(ctx, `
+		INSERT INTO contract_tokens (contract_id, supply, last_ledger, updated_at, supply_complete)
+		VALUES (?, ?, ?, now(), ?)
+		ON CONFLICT (contract_id) DO UPDATE SET
+			supply = contract_tokens.supply + EXCLUDED.supply,
+			last_ledger = GREATEST(contract_tokens.last_ledger, EXCLUDED.last_ledger),
+			updated_at = EXCLUDED.updated_at`,
+		update.ContractID, supply, update.LastLedger, update.Metadata != nil,
+	)
+	if err != nil {
+		return
+	}
+
+	if update.Metadata != nil {
+		_, err = s.ExecRaw(ctx,
+			"UPDATE contract_tokens SET name = ?, symbol = ?, decimals = ? WHERE contract_id = ?",
+			update.Metadata.Name, update.Metadata.Symbol, update.Metadata.Decimals, update.ContractID,
+		)
+		if err != nil {
+			return
+		}
+	}
+
+	for holder, delta := range update.Balances {
+		_, err = s.ExecRaw(ctx, `
+			INSERT INTO contract_token_balances (contract_id, holder, balance)
+			VALUES (?, ?, ?)
+			ON CONFLICT (contract_id, holder) DO UPDATE SET
+				balance = contract_token_balances.balance + EXCLUDED.balance`,
+			update.ContractID, holder, delta,
+		)
+		if err != nil {
+			return
+		}
+	}
+
+	// negative supplies or balances reveal events that were never ingested
+	_, err = s.ExecRaw(ctx, `
+		UPDATE contract_tokens SET
+			num_accounts = (
+				SELECT count(*) FROM contract_token_balances
+				WHERE contract_id = ? AND balance > 0 AND holder LIKE 'G%'
+			),
+			num_contracts = (
+				SELECT count(*) FROM contract_token_balances
+				WHERE contract_id = ? AND balance > 0 AND holder LIKE 'C%'
+			),
+			supply_complete = supply_complete AND supply >= 0 AND NOT EXISTS (
+				SELECT 1 FROM contract_token_balances
+				WHERE contract_id = ? AND balance < 0
+			)
+		WHERE contract_id = ?`,
+		update.ContractID, update.ContractID, update.ContractID, update.ContractID,
+	)
+	return
+}
+
+// AssetCode returns the code of the token on the assets table: its symbol,
+// namespaced with ContractCodePrefix, or its contract ID if its metadata is
+// unknown.
+func (t ContractToken) AssetCode() string {
+	code := t.ContractID
+	if t.Symbol != "" {
+		code = ContractCodePrefix + t.Symbol
+	}
+	if len(code) > 64 {
+		code = code[:64]
+	}
+	return code
+}
+
+// Amount returns the supply of the token in units of the token.
+func (t ContractToken) Amount() float64 {
+	return rawAmountToFloat(t.Supply, t.Decimals)
+}
+
+// SetContractTokenListing lists or unlists the entry of a contract token on
+// the assets table, as decided by the asset policy for the given reason.
+func (s *TickerSession) SetContractTokenListing(ctx context.Context, contractID string, listed bool, reason string) (err error) {
+	validationError := ""
+	if !listed {
+		validationError = reason
+	}
+	_, err = s.ExecRaw(ctx, `
+		UPDATE assets SET
+			is_valid = ?,
+			validation_error = ?,
+			last_valid = CASE WHEN ? THEN now() ELSE last_valid END
+		WHERE type = ? AND issuer_account = ?`,
+		listed, validationError, listed, ContractAssetType, contractID,
+	)
+	return
+}
+
+// updateContractTokenAsset inserts or updates the entry of a contract token
+// on the assets table (and the one of its contract on the issuers table), so
+// that it can be listed along with the classic assets once evaluated (see
+// SetContractTokenListing). New entries aren't listed.
+func (s *TickerSession) updateContractTokenAsset(ctx context.Context, contractID string) (err error) {
+	token, err := s.GetContractToken(ctx, contractID)
+	if err != nil {
+		return
+	}
+
+	var contractsAmount string
+	err = s.GetRaw(ctx, &contractsAmount, `
+		SELECT COALESCE(sum(balance), 0)::text FROM contract_token_balances
+		WHERE contract_id = ? AND balance > 0 AND holder LIKE 'C%'`,
+		contractID,
+	)
+	if err != nil {
+		return
+	}
+
+	code := token.AssetCode()
+	amount := token.Amount()
+	contractsFloat := rawAmountToFloat(contractsAmount, token.Decimals)
+	now := time.Now()
+
+	res, err := s.ExecRaw(ctx, `
+		UPDATE assets SET
+			code = ?, name = ?, display_decimals = ?, num_accounts = ?, amount = ?,
+			num_contracts = ?, contracts_amount = ?, last_checked = ?
+		WHERE type = ? AND issuer_account = ?`,
+		code, token.Name, token.Decimals, token.NumAccounts, amount,
+		token.NumContracts, contractsFloat, now,
+		ContractAssetType, contractID,
+	)
+	if err != nil {
+		return
+	}
+	if n, rowsErr := res.RowsAffected(); rowsErr != nil || n > 0 {
+		return rowsErr
+	}
+
+	issuerID, err := s.InsertOrUpdateIssuer(ctx, &Issuer{PublicKey: contractID}, []string{
+		"name", "url", "toml_url", "federation_server", "auth_server",
+		"transfer_server", "web_auth_endpoint", "deposit_server", "org_twitter",
+	})
+	if err != nil {
+		return
+	}
+
+	return s.InsertOrUpdateAsset(ctx, &Asset{
+		Code:            code,
+		IssuerAccount:   contractID,
+		IssuerID:        issuerID,
+		Type:            ContractAssetType,
+		NumAccounts:     token.NumAccounts,
+		Amount:          amount,
+		IsValid:         false,
+		ValidationError: "not evaluated yet",
+		LastChecked:     now,
+		DisplayDecimals: int(token.Decimals),
+		Name:            token.Name,
+		ContractID:      contractID,
+		NumContracts:    token.NumContracts,
+		ContractsAmount: contractsFloat,
+	}, nil)
+}
+
+// rawAmountToFloat converts a raw token amount (e.g. "12345") to units of the
+// token (e.g. 1.2345 with 4 decimals).
+func rawAmountToFloat(raw string, decimals int32) float64 {
+	// numeric values may be returned with a fractional part (e.g. "12.0")
+	raw = strings.SplitN(raw, ".", 2)[0]
+	r, ok := new(big.Rat).SetString(raw)
+	if !ok {
+		return 0
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	f, _ := r.Quo(r, new(big.Rat).SetInt(scale)).Float64()
+	return f
+}
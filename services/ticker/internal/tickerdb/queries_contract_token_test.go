@@ -0,0 +1,148 @@
+package tickerdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyContractTokenUpdates(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	const (
+		token    = "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+		alice    = "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+		bob      = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+		contract = "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+	)
+
+	// A token minted before its metadata is known is added by contract ID, but
+	// its supply is incomplete and it isn't listed until it's evaluated:
+	err = session.ApplyContractTokenUpdates(ctx, []ContractTokenUpdate{{
+		ContractID: token,
+		Supply:     "10000000000",
+		Balances:   map[string]string{alice: "7000000000", contract: "3000000000"},
+		LastLedger: 100,
+	}}, "contract_tokens", "429496729599-0")
+	require.NoError(t, err)
+
+	dbToken, err := session.GetContractToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "10000000000", dbToken.Supply)
+	assert.Equal(t, int32(1), dbToken.NumAccounts)
+	assert.Equal(t, int32(1), dbToken.NumContracts)
+	assert.Equal(t, int32(100), dbToken.LastLedger)
+	assert.False(t, dbToken.SupplyComplete)
+
+	var asset Asset
+	err = session.GetRaw(ctx, &asset, "SELECT * FROM assets WHERE issuer_account = ?", token)
+	require.NoError(t, err)
+	assert.Equal(t, token, asset.Code)
+	assert.Equal(t, ContractAssetType, asset.Type)
+	assert.Equal(t, token, asset.ContractID)
+	assert.Equal(t, 1000.0, asset.Amount)
+	assert.Equal(t, 300.0, asset.ContractsAmount)
+	assert.False(t, asset.IsValid)
+
+	cursor, err := session.GetIngestCursor(ctx, "contract_tokens")
+	require.NoError(t, err)
+	assert.Equal(t, "429496729599-0", cursor)
+
+	// Later updates are added to the supply and balances, and the asset is
+	// renamed (within the namespace of the contract tokens) once the metadata
+	// is known:
+	err = session.ApplyContractTokenUpdates(ctx, []ContractTokenUpdate{{
+		ContractID: token,
+		Supply:     "-1000000000",
+		Balances:   map[string]string{alice: "-7000000000", bob: "6000000000"},
+		Metadata:   &ContractTokenMetadata{Name: "Test Token", Symbol: "TEST", Decimals: 7},
+		LastLedger: 101,
+	}}, "contract_tokens", "433791696895-0")
+	require.NoError(t, err)
+
+	dbToken, err = session.GetContractToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "9000000000", dbToken.Supply)
+	assert.Equal(t, "TEST", dbToken.Symbol)
+	assert.Equal(t, int32(1), dbToken.NumAccounts)
+	assert.False(t, dbToken.SupplyComplete)
+
+	var assets []Asset
+	err = session.SelectRaw(ctx, &assets, "SELECT * FROM assets WHERE issuer_account = ?", token)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	assert.Equal(t, "contract:TEST", assets[0].Code)
+	assert.Equal(t, "Test Token", assets[0].Name)
+	assert.Equal(t, 900.0, assets[0].Amount)
+	assert.Equal(t, asset.ID, assets[0].ID)
+	assert.False(t, assets[0].IsValid)
+
+	err = session.SetContractTokenListing(ctx, token, true, "listed")
+	require.NoError(t, err)
+	err = session.GetRaw(ctx, &asset, "SELECT * FROM assets WHERE issuer_account = ?", token)
+	require.NoError(t, err)
+	assert.True(t, asset.IsValid)
+	assert.Equal(t, "", asset.ValidationError)
+
+	// A token whose metadata is written along with its first events has a
+	// complete supply, until one of its balances goes negative:
+	const newToken = "CBIELTK6YBZJU5UP2WWQEUCYKLPU6AUNZ2BQ4WWFEIE3USCIHMXQDAMA"
+	err = session.ApplyContractTokenUpdates(ctx, []ContractTokenUpdate{{
+		ContractID: newToken,
+		Supply:     "5000000000",
+		Balances:   map[string]string{alice: "5000000000"},
+		Metadata:   &ContractTokenMetadata{Name: "New Token", Symbol: "USDC", Decimals: 7},
+		LastLedger: 102,
+	}}, "contract_tokens", "438086664191-0")
+	require.NoError(t, err)
+	dbToken, err = session.GetContractToken(ctx, newToken)
+	require.NoError(t, err)
+	assert.True(t, dbToken.SupplyComplete)
+	assert.Equal(t, "contract:USDC", dbToken.AssetCode())
+
+	err = session.ApplyContractTokenUpdates(ctx, []ContractTokenUpdate{{
+		ContractID: newToken,
+		Supply:     "0",
+		Balances:   map[string]string{bob: "-1000000000"},
+		LastLedger: 103,
+	}}, "contract_tokens", "442381631487-0")
+	require.NoError(t, err)
+	dbToken, err = session.GetContractToken(ctx, newToken)
+	require.NoError(t, err)
+	assert.False(t, dbToken.SupplyComplete)
+}
+
+func TestContractTokenAssetCode(t *testing.T) {
+	const contractID = "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+	assert.Equal(t, contractID, ContractToken{ContractID: contractID}.AssetCode())
+	assert.Equal(t, "contract:USDC", ContractToken{ContractID: contractID, Symbol: "USDC"}.AssetCode())
+	long := ContractToken{ContractID: contractID, Symbol: strings.Repeat("A", 100)}.AssetCode()
+	assert.Equal(t, "contract:"+strings.Repeat("A", 55), long)
+}
+
+func TestRawAmountToFloat(t *testing.T) {
+	assert.Equal(t, 1.2345, rawAmountToFloat("12345", 4))
+	assert.Equal(t, 100.0, rawAmountToFloat("1000000000", 7))
+	assert.Equal(t, -5.0, rawAmountToFloat("-5", 0))
+	assert.Equal(t, 12.0, rawAmountToFloat("12.0", 0))
+	assert.Equal(t, 0.0, rawAmountToFloat("", 7))
+}
@@ -0,0 +1,79 @@
+package tickerdb
+
+import (
+	"context"
+
+	"github.com/stellar/go/support/db"
+)
+
+// GetIngestCursor returns the paging token of the last trade processed by the
+// given ingestion job, or an empty string if the job never persisted one.
+func (s *TickerSession) GetIngestCursor(ctx context.Context, job string) (cursor string, err error) {
+	var state IngestState
+	err = s.GetRaw(ctx, &state, "SELECT * FROM ingest_state WHERE job = ?", job)
+	if s.NoRows(err) {
+		return "", nil
+	}
+	if err != nil {
+		return
+	}
+
+	cursor = state.PagingToken
+	return
+}
+
+// BulkInsertTradesAndUpdateCursor inserts a slice of trades (see BulkInsertTrades)
+// and of pending trades (see BulkInsertPendingTrades), and advances the paging
+// token of the given ingestion job within the same database transaction, so
+// that either all or none of them are persisted.
+func (s *TickerSession) BulkInsertTradesAndUpdateCursor(
+	ctx context.Context,
+	trades []Trade,
+	pendingTrades []PendingTrade,
+	job string,
+	pagingToken string,
+) (err error) {
+	txSession := TickerSession{db.Session{DB: s.DB}}
+	if err = txSession.Begin(ctx); err != nil {
+		return
+	}
+
+	if err = txSession.BulkInsertTrades(ctx, trades); err != nil {
+		txSession.Rollback()
+		return
+	}
+
+	if err = txSession.BulkInsertPendingTrades(ctx, pendingTrades); err != nil {
+		txSession.Rollback()
+		return
+	}
+
+	if err = txSession.updateIngestCursor(ctx, job, pagingToken); err != nil {
+		txSession.Rollback()
+		return
+	}
+
+	return txSession.Commit()
+}
+
+// updateIngestCursor sets the paging token of the given ingestion job. Paging
+// tokens are in the "<operation id>-<order>" format, and the cursor is only
+// ever moved forward, so that concurrent runs of the same job don't rewind it.
+func (s *TickerSession) updateIngestCursor(ctx context.Context, job string, pagingToken string) error {
+	_, err := s.ExecRaw(ctx, ingestCursorUpsertQuery, job, pagingToken)
+	return err
+}
+
+var ingestCursorUpsertQuery = `
+INSERT INTO ingest_state (job, paging_token, updated_at)
+VALUES (?, ?, now())
+ON CONFLICT (job) DO UPDATE SET
+	paging_token = EXCLUDED.paging_token,
+	updated_at = EXCLUDED.updated_at
+WHERE (
+	split_part(EXCLUDED.paging_token, '-', 1)::bigint,
+	COALESCE(NULLIF(split_part(EXCLUDED.paging_token, '-', 2), ''), '0')::integer
+) > (
+	split_part(ingest_state.paging_token, '-', 1)::bigint,
+	COALESCE(NULLIF(split_part(ingest_state.paging_token, '-', 2), ''), '0')::integer
+);`
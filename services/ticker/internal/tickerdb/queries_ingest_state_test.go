@@ -0,0 +1,147 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkInsertTradesAndUpdateCursor(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "XLM",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding another asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "BTC",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var btcAsset Asset
+	err = session.GetRaw(ctx, &btcAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Jobs without a cursor return an empty one:
+	cursor, err := session.GetIngestCursor(ctx, "horizon_trades")
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	trades := []Trade{
+		{
+			HorizonID:       "429496729601-0",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "100.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "10.0",
+			LedgerCloseTime: time.Now(),
+			Price:           "0.1",
+		},
+		{
+			HorizonID:       "429496729601-1",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "50.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "5.0",
+			LedgerCloseTime: time.Now(),
+			Price:           "0.1",
+		},
+	}
+	err = session.BulkInsertTradesAndUpdateCursor(ctx, trades, nil, "horizon_trades", "429496729601-1")
+	require.NoError(t, err)
+
+	var numTrades int
+	err = session.GetRaw(ctx, &numTrades, "SELECT count(*) FROM trades")
+	require.NoError(t, err)
+	assert.Equal(t, 2, numTrades)
+
+	cursor, err = session.GetIngestCursor(ctx, "horizon_trades")
+	require.NoError(t, err)
+	assert.Equal(t, "429496729601-1", cursor)
+
+	// Cursors are never moved backwards, even if the trades are stored:
+	err = session.BulkInsertTradesAndUpdateCursor(ctx, trades[:1], nil, "horizon_trades", "429496729601-0")
+	require.NoError(t, err)
+	cursor, err = session.GetIngestCursor(ctx, "horizon_trades")
+	require.NoError(t, err)
+	assert.Equal(t, "429496729601-1", cursor)
+
+	// The cursor can be advanced without any trade, and the order of the
+	// paging tokens is numeric rather than lexicographic:
+	err = session.BulkInsertTradesAndUpdateCursor(ctx, nil, nil, "horizon_trades", "429496729601-10")
+	require.NoError(t, err)
+	cursor, err = session.GetIngestCursor(ctx, "horizon_trades")
+	require.NoError(t, err)
+	assert.Equal(t, "429496729601-10", cursor)
+
+	// Each job has its own cursor:
+	cursor, err = session.GetIngestCursor(ctx, "ledger_trades")
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	// If the trades can't be inserted, the cursor isn't updated either:
+	invalidTrade := trades[0]
+	invalidTrade.HorizonID = "429496729602-0"
+	invalidTrade.BaseAssetID = -1
+	err = session.BulkInsertTradesAndUpdateCursor(ctx, []Trade{invalidTrade}, nil, "horizon_trades", "429496729602-0")
+	assert.Error(t, err)
+	cursor, err = session.GetIngestCursor(ctx, "horizon_trades")
+	require.NoError(t, err)
+	assert.Equal(t, "429496729601-10", cursor)
+}
@@ -38,3 +38,19 @@ func (s *TickerSession) GetAllIssuers(ctx context.Context) (issuers []Issuer, er
 	err = s.SelectRaw(ctx, &issuers, "SELECT * FROM issuers")
 	return
 }
+
+// GetIssuerByPublicKey returns the issuer with the given public key.
+func (s *TickerSession) GetIssuerByPublicKey(ctx context.Context, publicKey string) (issuer Issuer, err error) {
+	err = s.GetRaw(ctx, &issuer, "SELECT * FROM issuers WHERE public_key = ?", publicKey)
+	return
+}
+
+// RetrieveIssuersPage returns a page of at most limit issuers whose ID is
+// greater than afterID, sorted by ID.
+func (s *TickerSession) RetrieveIssuersPage(ctx context.Context, afterID int32, limit int) (issuers []Issuer, err error) {
+	err = s.SelectRaw(ctx, &issuers,
+		"SELECT * FROM issuers WHERE id > ? ORDER BY id LIMIT ?",
+		afterID, limit,
+	)
+	return
+}
@@ -92,3 +92,47 @@ func TestInsertOrUpdateIssuer(t *testing.T) {
 	assert.Equal(t, dbIssuer.PublicKey, dbIssuer3.PublicKey)
 	assert.Equal(t, name3, dbIssuer3.Name)
 }
+
+func TestRetrieveIssuersPage(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	var ids []int32
+	for _, publicKey := range []string{"GISSUER1", "GISSUER2", "GISSUER3"} {
+		id, err := session.InsertOrUpdateIssuer(ctx, &Issuer{PublicKey: publicKey}, []string{"public_key"})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	issuer, err := session.GetIssuerByPublicKey(ctx, "GISSUER2")
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], issuer.ID)
+
+	_, err = session.GetIssuerByPublicKey(ctx, "GUNKNOWN")
+	assert.True(t, session.NoRows(err))
+
+	// Pages are sorted by ID, starting after the given one:
+	page, err := session.RetrieveIssuersPage(ctx, ids[0], 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(page))
+	assert.Equal(t, "GISSUER2", page[0].PublicKey)
+	assert.Equal(t, "GISSUER3", page[1].PublicKey)
+
+	page, err = session.RetrieveIssuersPage(ctx, ids[0], 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(page))
+	assert.Equal(t, "GISSUER2", page[0].PublicKey)
+}
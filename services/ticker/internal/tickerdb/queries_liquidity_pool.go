@@ -0,0 +1,68 @@
+package tickerdb
+
+import (
+	"context"
+)
+
+// InsertOrUpdateLiquidityPool inserts a LiquidityPool entry on the database (if new),
+// or updates an existing one
+func (s *TickerSession) InsertOrUpdateLiquidityPool(ctx context.Context, p *LiquidityPool, preserveFields []string) (err error) {
+	return s.performUpsertQuery(ctx, *p, "liquidity_pools", "liquidity_pools_pool_id_key", preserveFields)
+}
+
+// UpdateLiquidityPoolFeeIncome sets the fee income of every liquidity pool to
+// the fees charged on its trades within the last 24 hours. Fees are charged on
+// the amount sent to the pool, and are approximated from the counter amount of
+// each trade, so that they are in units of the counter asset.
+func (s *TickerSession) UpdateLiquidityPoolFeeIncome(ctx context.Context) error {
+	_, err := s.ExecRaw(ctx, `
+		UPDATE liquidity_pools AS lp SET fee_income_24h = COALESCE((
+			SELECT sum(t.counter_amount * t.liquidity_pool_fee_bp) / 10000
+			FROM trades AS t
+			WHERE t.liquidity_pool_id = lp.pool_id
+				AND t.ledger_close_time > now() - interval '1 day'
+		), 0)`,
+	)
+	return err
+}
+
+// RetrieveLiquidityPools retrieves the liquidity pools between valid assets,
+// optionally filtered by their base and counter assets.
+func (s *TickerSession) RetrieveLiquidityPools(ctx context.Context,
+	baseAssetCode *string,
+	baseAssetIssuer *string,
+	counterAssetCode *string,
+	counterAssetIssuer *string,
+) (pools []MarketLiquidityPool, err error) {
+	sqlTrue := new(string)
+	*sqlTrue = "TRUE"
+
+	where, args := generateWhereClause([]optionalVar{
+		{"bAsset.is_valid", sqlTrue},
+		{"cAsset.is_valid", sqlTrue},
+		{"bAsset.code", baseAssetCode},
+		{"bAsset.issuer_account", baseAssetIssuer},
+		{"cAsset.code", counterAssetCode},
+		{"cAsset.issuer_account", counterAssetIssuer},
+	})
+
+	argsInterface := make([]interface{}, len(args))
+	for i, v := range args {
+		argsInterface[i] = v
+	}
+	err = s.SelectRaw(ctx, &pools, `
+		SELECT
+			lp.*,
+			bAsset.code AS base_asset_code,
+			bAsset.issuer_account AS base_asset_issuer,
+			cAsset.code AS counter_asset_code,
+			cAsset.issuer_account AS counter_asset_issuer
+		FROM liquidity_pools AS lp
+			JOIN assets AS bAsset ON lp.base_asset_id = bAsset.id
+			JOIN assets AS cAsset ON lp.counter_asset_id = cAsset.id
+		`+where+`
+		ORDER BY lp.base_asset_id, lp.counter_asset_id, lp.pool_id`,
+		argsInterface...,
+	)
+	return
+}
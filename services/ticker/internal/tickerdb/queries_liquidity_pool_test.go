@@ -0,0 +1,187 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiquidityPools(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "XLM",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding another asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "BTC",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var btcAsset Asset
+	err = session.GetRaw(ctx, &btcAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	now := time.Now()
+	err = session.InsertOrUpdateLiquidityPool(ctx, &LiquidityPool{
+		PoolID:          "abcdef",
+		BaseAssetID:     xlmAsset.ID,
+		CounterAssetID:  btcAsset.ID,
+		FeeBP:           30,
+		TotalTrustlines: 2,
+		TotalShares:     50.0,
+		BaseReserve:     1000.0,
+		CounterReserve:  10.0,
+		Price:           100.0,
+		TVL:             20.0,
+		UpdatedAt:       now,
+	}, []string{"pool_id", "fee_income_24h"})
+	require.NoError(t, err)
+
+	// Only trades with the pool within the last 24 hours count towards its
+	// fee income:
+	trades := []Trade{
+		{
+			HorizonID:          "hrzid1",
+			BaseAssetID:        xlmAsset.ID,
+			BaseAmount:         "100.0",
+			CounterAssetID:     btcAsset.ID,
+			CounterAmount:      "1.0",
+			LedgerCloseTime:    now.Add(-time.Hour),
+			Price:              "100.0",
+			TradeType:          TradeTypeLiquidityPool,
+			LiquidityPoolID:    "abcdef",
+			LiquidityPoolFeeBP: 30,
+		},
+		{
+			HorizonID:          "hrzid2",
+			BaseAssetID:        xlmAsset.ID,
+			BaseAmount:         "200.0",
+			CounterAssetID:     btcAsset.ID,
+			CounterAmount:      "2.0",
+			LedgerCloseTime:    now.Add(-48 * time.Hour),
+			Price:              "100.0",
+			TradeType:          TradeTypeLiquidityPool,
+			LiquidityPoolID:    "abcdef",
+			LiquidityPoolFeeBP: 30,
+		},
+		{
+			HorizonID:       "hrzid3",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "300.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "3.0",
+			LedgerCloseTime: now.Add(-time.Hour),
+			Price:           "100.0",
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	err = session.UpdateLiquidityPoolFeeIncome(ctx)
+	require.NoError(t, err)
+
+	// Updating the pool must preserve its fee income:
+	err = session.InsertOrUpdateLiquidityPool(ctx, &LiquidityPool{
+		PoolID:          "abcdef",
+		BaseAssetID:     xlmAsset.ID,
+		CounterAssetID:  btcAsset.ID,
+		FeeBP:           30,
+		TotalTrustlines: 3,
+		TotalShares:     60.0,
+		BaseReserve:     1200.0,
+		CounterReserve:  12.0,
+		Price:           100.0,
+		TVL:             24.0,
+		UpdatedAt:       now,
+	}, []string{"pool_id", "fee_income_24h"})
+	require.NoError(t, err)
+
+	pools, err := session.RetrieveLiquidityPools(ctx, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pools))
+	pool := pools[0]
+	assert.Equal(t, "abcdef", pool.PoolID)
+	assert.Equal(t, "XLM", pool.BaseAssetCode)
+	assert.Equal(t, issuer.PublicKey, pool.BaseAssetIssuer)
+	assert.Equal(t, "BTC", pool.CounterAssetCode)
+	assert.Equal(t, issuer.PublicKey, pool.CounterAssetIssuer)
+	assert.Equal(t, int64(3), pool.TotalTrustlines)
+	assert.Equal(t, 1200.0, pool.BaseReserve)
+	assert.Equal(t, 12.0, pool.CounterReserve)
+	assert.Equal(t, 24.0, pool.TVL)
+	assert.Equal(t, 0.003, pool.FeeIncome24h)
+
+	// The trades keep track of their venue:
+	var dbTrades []Trade
+	err = session.SelectRaw(ctx, &dbTrades, "SELECT * FROM trades ORDER BY horizon_id")
+	require.NoError(t, err)
+	require.Equal(t, 3, len(dbTrades))
+	assert.Equal(t, TradeTypeLiquidityPool, dbTrades[0].TradeType)
+	assert.Equal(t, "abcdef", dbTrades[0].LiquidityPoolID)
+	assert.Equal(t, int32(30), dbTrades[0].LiquidityPoolFeeBP)
+	assert.Equal(t, TradeTypeOrderbook, dbTrades[2].TradeType)
+	assert.Equal(t, "", dbTrades[2].LiquidityPoolID)
+
+	// Filtering by an unknown asset returns no pools:
+	ethCode := "ETH"
+	pools, err = session.RetrieveLiquidityPools(ctx, nil, nil, &ethCode, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pools))
+}
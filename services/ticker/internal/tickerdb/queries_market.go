@@ -4,20 +4,110 @@ import (
 	"context"
 	"fmt"
 	"strings"
+
+	"github.com/stellar/go/services/ticker/internal/utils"
 )
 
+// MarketFilter restricts the markets returned by RetrieveMarketData and
+// RetrieveMarketWindows to the ones between the given assets, or with the
+// given trade pair name (e.g. "XLM_BTC", case-insensitive). Empty fields match
+// every market.
+type MarketFilter struct {
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	TradePairName      string
+}
+
+// withMarketFilter replaces each __MARKETFILTER__ placeholder of q with the
+// conditions of f, returning the args of every placeholder in order.
+func withMarketFilter(q string, f MarketFilter) (string, []interface{}) {
+	var clause string
+	var fArgs []interface{}
+	for _, c := range []struct{ expr, val string }{
+		{"bAsset.code", f.BaseAssetCode},
+		{"bAsset.issuer_account", f.BaseAssetIssuer},
+		{"cAsset.code", f.CounterAssetCode},
+		{"cAsset.issuer_account", f.CounterAssetIssuer},
+		{"upper(" + tradePairNameField + ")", strings.ToUpper(f.TradePairName)},
+	} {
+		if c.val != "" {
+			clause += " AND " + c.expr + " = ?"
+			fArgs = append(fArgs, c.val)
+		}
+	}
+
+	var args []interface{}
+	for i := 0; i < strings.Count(q, "__MARKETFILTER__"); i++ {
+		args = append(args, fArgs...)
+	}
+	return strings.Replace(q, "__MARKETFILTER__", clause, -1), args
+}
+
 // RetrieveMarketData retrieves the 24h- and 7d aggregated market data for all
-// markets that were active during this period.
-func (s *TickerSession) RetrieveMarketData(ctx context.Context) (markets []Market, err error) {
-	err = s.SelectRaw(ctx, &markets, marketQuery)
+// markets matching filter that were active during this period. Each market is
+// identified by its canonical ID, unless aggregateByCode is set, in which case
+// the markets whose assets share the same codes are merged into a single trade
+// pair. The price stats (VWAP, TWAP and median price) only include the trades
+// worth at least minTradeSize in USD.
+func (s *TickerSession) RetrieveMarketData(ctx context.Context, filter MarketFilter, aggregateByCode bool, minTradeSize float64) (markets []Market, err error) {
+	q := strings.Replace(marketQuery, "__MARKETID__", marketIDSelector(aggregateByCode), -1)
+	q = withMinTradeSize(q, minTradeSize)
+	q, args := withMarketFilter(q, filter)
+	err = s.SelectRaw(ctx, &markets, q, args...)
+	return
+}
+
+// RetrieveMarketWindows retrieves the aggregated market data of all markets
+// that were active during each of the given trailing windows (e.g. "1h" or
+// "30d", as parsed by utils.ParseWindow). A single query is built with one
+// subquery per window, and each returned row is tagged with its window name.
+// Only the markets matching filter are included. Markets are aggregated by
+// asset code only if aggregateByCode is set, and the price stats only include
+// the trades worth at least minTradeSize in USD.
+func (s *TickerSession) RetrieveMarketWindows(ctx context.Context, windows []string, filter MarketFilter, aggregateByCode bool, minTradeSize float64) (mktWindows []MarketWindow, err error) {
+	if len(windows) == 0 {
+		return
+	}
+
+	subqueries := make([]string, 0, len(windows))
+	args := make([]interface{}, 0, len(windows))
+	for _, w := range windows {
+		d, pErr := utils.ParseWindow(w)
+		if pErr != nil {
+			err = pErr
+			return
+		}
+
+		subquery := strings.Replace(
+			marketWindowQuery,
+			"__NUMSECONDS__",
+			fmt.Sprintf("%d", int64(d.Seconds())),
+			-1,
+		)
+		subquery = strings.Replace(subquery, "__MARKETID__", marketIDSelector(aggregateByCode), -1)
+		subquery, filterArgs := withMarketFilter(subquery, filter)
+		subqueries = append(subqueries, subquery)
+		args = append(args, w)
+		args = append(args, filterArgs...)
+	}
+
+	q := withMinTradeSize(strings.Join(subqueries, "UNION ALL")+";", minTradeSize)
+	err = s.SelectRaw(ctx, &mktWindows, q, args...)
 	return
 }
 
 // RetrievePartialAggMarkets retrieves the aggregated market data for all
-// markets (or for a specific one if PairName != nil) for a given period.
+// markets (or for a specific trade pair if PairName != nil) for a given period.
+// Markets whose assets share the same codes are merged into a single trade
+// pair only if aggregateByCode is set. The price stats only include the trades
+// worth at least minTradeSize in USD.
 func (s *TickerSession) RetrievePartialAggMarkets(ctx context.Context,
 	pairName *string,
 	numHoursAgo int,
+	aggregateByCode bool,
+	minTradeSize float64,
 ) (partialMkts []PartialMarket, err error) {
 	var bCode, cCode string
 	sqlTrue := new(string)
@@ -46,10 +136,17 @@ func (s *TickerSession) RetrievePartialAggMarkets(ctx context.Context,
 	)
 	q := strings.Replace(aggMarketQuery, "__WHERECLAUSE__", where, -1)
 	q = strings.Replace(q, "__NUMHOURS__", fmt.Sprintf("%d", numHoursAgo), -1)
+	q = strings.Replace(q, "__MARKETID__", marketIDSelector(aggregateByCode), -1)
+	q = withMinTradeSize(q, minTradeSize)
+	q, _ = withMarketFilter(q, MarketFilter{})
 
-	argsInterface := make([]interface{}, len(args))
-	for i, v := range args {
-		argsInterface[i] = v
+	// the where clause is used by both the market and the price stats
+	// subqueries, so its args are repeated:
+	argsInterface := make([]interface{}, 0, 2*len(args))
+	for i := 0; i < 2; i++ {
+		for _, v := range args {
+			argsInterface = append(argsInterface, v)
+		}
 	}
 
 	err = s.SelectRaw(ctx, &partialMkts, q, argsInterface...)
@@ -58,13 +155,15 @@ func (s *TickerSession) RetrievePartialAggMarkets(ctx context.Context,
 
 // RetrievePartialMarkets retrieves data in the PartialMarket format from the database.
 // It optionally filters the data according to the provided base and counter asset params
-// provided, as well as the numHoursAgo time offset.
+// provided, as well as the numHoursAgo time offset. The price stats only include the
+// trades worth at least minTradeSize in USD.
 func (s *TickerSession) RetrievePartialMarkets(ctx context.Context,
 	baseAssetCode *string,
 	baseAssetIssuer *string,
 	counterAssetCode *string,
 	counterAssetIssuer *string,
 	numHoursAgo int,
+	minTradeSize float64,
 ) (partialMkts []PartialMarket, err error) {
 	sqlTrue := new(string)
 	*sqlTrue = "TRUE"
@@ -84,10 +183,16 @@ func (s *TickerSession) RetrievePartialMarkets(ctx context.Context,
 
 	q := strings.Replace(partialMarketQuery, "__WHERECLAUSE__", where, -1)
 	q = strings.Replace(q, "__NUMHOURS__", fmt.Sprintf("%d", numHoursAgo), -1)
+	q = strings.Replace(q, "__MARKETID__", marketIDField, -1)
+	q = withMinTradeSize(q, minTradeSize)
 
-	argsInterface := make([]interface{}, len(args))
-	for i, v := range args {
-		argsInterface[i] = v
+	// the where clause is used by both the price stats subquery and the
+	// market query, so its args are repeated:
+	argsInterface := make([]interface{}, 0, 2*len(args))
+	for i := 0; i < 2; i++ {
+		for _, v := range args {
+			argsInterface = append(argsInterface, v)
+		}
 	}
 	err = s.SelectRaw(ctx, &partialMkts, q, argsInterface...)
 	return
@@ -99,6 +204,7 @@ func (s *TickerSession) RetrievePartialMarkets(ctx context.Context,
 func (s *TickerSession) RetrievePartialMarketsByIssuer(ctx context.Context,
 	baseAssetIssuer string,
 	numHoursAgo int,
+	minTradeSize float64,
 ) (partialMkts []PartialMarket, err error) {
 	sqlTrue := new(string)
 	*sqlTrue = "TRUE"
@@ -115,10 +221,16 @@ func (s *TickerSession) RetrievePartialMarketsByIssuer(ctx context.Context,
 
 	q := strings.Replace(partialMarketQuery, "__WHERECLAUSE__", where, -1)
 	q = strings.Replace(q, "__NUMHOURS__", fmt.Sprintf("%d", numHoursAgo), -1)
+	q = strings.Replace(q, "__MARKETID__", marketIDField, -1)
+	q = withMinTradeSize(q, minTradeSize)
 
-	argsInterface := make([]interface{}, len(args))
-	for i, v := range args {
-		argsInterface[i] = v
+	// the where clause is used by both the price stats subquery and the
+	// market query, so its args are repeated:
+	argsInterface := make([]interface{}, 0, 2*len(args))
+	for i := 0; i < 2; i++ {
+		for _, v := range args {
+			argsInterface = append(argsInterface, v)
+		}
 	}
 	err = s.SelectRaw(ctx, &partialMkts, q, argsInterface...)
 	return
@@ -144,6 +256,8 @@ func (s *TickerSession) Retrieve7DRelevantMarkets(ctx context.Context) (partialM
 var marketQuery = `
 SELECT
 	t2.trade_pair_name,
+	t2.market_id,
+	t2.market_ids,
 	COALESCE(base_volume_24h, 0.0) as base_volume_24h,
 	COALESCE(counter_volume_24h, 0.0) as counter_volume_24h,
 	COALESCE(trade_count_24h, 0) as trade_count_24h,
@@ -160,6 +274,19 @@ SELECT
 	COALESCE(price_change_7d, 0.0) as price_change_7d,
 	COALESCE(open_price_7d, 0.0) as open_price_7d,
 
+	COALESCE(clean_base_volume_24h, 0.0) as clean_base_volume_24h,
+	COALESCE(clean_counter_volume_24h, 0.0) as clean_counter_volume_24h,
+	COALESCE(flagged_trade_count_24h, 0) as flagged_trade_count_24h,
+	COALESCE(clean_base_volume_7d, 0.0) as clean_base_volume_7d,
+	COALESCE(clean_counter_volume_7d, 0.0) as clean_counter_volume_7d,
+
+	COALESCE(ps24.vwap, 0.0) as vwap_24h,
+	COALESCE(ps24.twap, 0.0) as twap_24h,
+	COALESCE(ps24.median_price, 0.0) as median_price_24h,
+	COALESCE(ps7.vwap, 0.0) as vwap_7d,
+	COALESCE(ps7.twap, 0.0) as twap_7d,
+	COALESCE(ps7.median_price, 0.0) as median_price_7d,
+
 	COALESCE(last_price, last_price_7d, 0.0) as last_price,
 	COALESCE(last_close_time_24h, last_close_time_7d) as close_time,
 
@@ -168,7 +295,38 @@ SELECT
 	COALESCE(os.highest_bid, 0.0) as highest_bid,
 	COALESCE(os.num_asks, 0) as num_asks,
 	COALESCE(os.ask_volume, 0.0) as ask_volume,
-	COALESCE(os.lowest_ask, 0.0) as lowest_ask
+	COALESCE(os.lowest_ask, 0.0) as lowest_ask,
+	COALESCE(os.bid_depth_2pct, 0.0) as bid_depth_2pct,
+	COALESCE(os.bid_depth_5pct, 0.0) as bid_depth_5pct,
+	COALESCE(os.bid_depth_10pct, 0.0) as bid_depth_10pct,
+	COALESCE(os.ask_depth_2pct, 0.0) as ask_depth_2pct,
+	COALESCE(os.ask_depth_5pct, 0.0) as ask_depth_5pct,
+	COALESCE(os.ask_depth_10pct, 0.0) as ask_depth_10pct,
+	COALESCE(os.slippage_amount, 0.0) as slippage_amount,
+	COALESCE(os.buy_slippage, 0.0) as buy_slippage,
+	COALESCE(os.sell_slippage, 0.0) as sell_slippage,
+
+	COALESCE(orderbook_base_volume_24h, 0.0) as orderbook_base_volume_24h,
+	COALESCE(orderbook_counter_volume_24h, 0.0) as orderbook_counter_volume_24h,
+	COALESCE(pool_base_volume_24h, 0.0) as pool_base_volume_24h,
+	COALESCE(pool_counter_volume_24h, 0.0) as pool_counter_volume_24h,
+	COALESCE(alp.base_reserve, 0.0) as pool_base_reserve,
+	COALESCE(alp.counter_reserve, 0.0) as pool_counter_reserve,
+	COALESCE(alp.tvl, 0.0) as pool_tvl,
+	COALESCE(alp.fee_income_24h, 0.0) as pool_fee_income_24h,
+
+	COALESCE(base_volume_24h_usd, 0.0) as base_volume_24h_usd,
+	COALESCE(counter_volume_24h_usd, 0.0) as counter_volume_24h_usd,
+	COALESCE(price_usd, 0.0) as price_usd,
+
+	COALESCE(base_volume_24h, 0)::text as base_volume_24h_exact,
+	COALESCE(counter_volume_24h, 0)::text as counter_volume_24h_exact,
+	COALESCE(open_price_24h, last_price_7d, 0)::text as open_price_24h_exact,
+	COALESCE(lowest_price_24h, last_price_7d, 0)::text as lowest_price_24h_exact,
+	COALESCE(highest_price_24h, last_price_7d, 0)::text as highest_price_24h_exact,
+	COALESCE(base_volume_7d, 0)::text as base_volume_7d_exact,
+	COALESCE(counter_volume_7d, 0)::text as counter_volume_7d_exact,
+	COALESCE(last_price, last_price_7d, 0)::text as last_price_exact
 FROM (
 	SELECT
 			-- All valid trades for 24h period
@@ -177,6 +335,7 @@ FROM (
 				'_',
 				COALESCE(NULLIF(cAsset.anchor_asset_code, ''), cAsset.code)
 			) as trade_pair_name,
+			__MARKETID__ AS market_id,
 			sum(t.base_amount) AS base_volume_24h,
 			sum(t.counter_amount) AS counter_volume_24h,
 			count(t.base_amount) AS trade_count_24h,
@@ -185,14 +344,25 @@ FROM (
 			(array_agg(t.price ORDER BY t.ledger_close_time ASC))[1] AS open_price_24h,
 			(array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] AS last_price,
 			((array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] - (array_agg(t.price ORDER BY t.ledger_close_time ASC))[1]) AS price_change_24h,
+			sum(t.base_amount) FILTER (WHERE t.trade_type <> 'liquidity_pool') AS orderbook_base_volume_24h,
+			sum(t.counter_amount) FILTER (WHERE t.trade_type <> 'liquidity_pool') AS orderbook_counter_volume_24h,
+			sum(t.base_amount) FILTER (WHERE t.trade_type = 'liquidity_pool') AS pool_base_volume_24h,
+			sum(t.counter_amount) FILTER (WHERE t.trade_type = 'liquidity_pool') AS pool_counter_volume_24h,
+			sum(t.base_amount * bp.price) AS base_volume_24h_usd,
+			sum(t.counter_amount * cp.price) AS counter_volume_24h_usd,
+			sum(t.base_amount) FILTER (WHERE ` + cleanTradeFilter + `) AS clean_base_volume_24h,
+			sum(t.counter_amount) FILTER (WHERE ` + cleanTradeFilter + `) AS clean_counter_volume_24h,
+			count(t.base_amount) FILTER (WHERE ` + flaggedTradeFilter + `) AS flagged_trade_count_24h,
 			max(t.ledger_close_time) AS last_close_time_24h
 		FROM trades AS t
 			JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
 			JOIN assets AS cAsset on t.counter_asset_id = cAsset.id
+			LEFT JOIN asset_prices AS bp ON bp.asset_id = bAsset.id AND bp.currency = 'USD'
+			LEFT JOIN asset_prices AS cp ON cp.asset_id = cAsset.id AND cp.currency = 'USD'
 		WHERE bAsset.is_valid = TRUE
 			AND cAsset.is_valid = TRUE
-			AND t.ledger_close_time > now() - interval '1 day'
-		GROUP BY trade_pair_name
+			AND t.ledger_close_time > now() - interval '1 day'__MARKETFILTER__
+		GROUP BY trade_pair_name, market_id
 	) t1 RIGHT JOIN (
 	SELECT
 			-- All valid trades for 7d period
@@ -201,6 +371,8 @@ FROM (
 				'_',
 				COALESCE(NULLIF(cAsset.anchor_asset_code, ''), cAsset.code)
 			) as trade_pair_name,
+			__MARKETID__ AS market_id,
+			string_agg(DISTINCT ` + marketIDField + `, ',' ORDER BY ` + marketIDField + `) AS market_ids,
 			sum(t.base_amount) AS base_volume_7d,
 			sum(t.counter_amount) AS counter_volume_7d,
 			count(t.base_amount) AS trade_count_7d,
@@ -209,23 +381,81 @@ FROM (
 			(array_agg(t.price ORDER BY t.ledger_close_time ASC))[1] AS open_price_7d,
 			(array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] AS last_price_7d,
 			((array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] - (array_agg(t.price ORDER BY t.ledger_close_time ASC))[1]) AS price_change_7d,
-			max(t.ledger_close_time) AS last_close_time_7d
+			max(t.ledger_close_time) AS last_close_time_7d,
+			sum(t.base_amount) FILTER (WHERE ` + cleanTradeFilter + `) AS clean_base_volume_7d,
+			sum(t.counter_amount) FILTER (WHERE ` + cleanTradeFilter + `) AS clean_counter_volume_7d,
+			(array_agg(bp.price ORDER BY t.ledger_close_time DESC) FILTER (WHERE bp.price IS NOT NULL))[1] AS price_usd
 		FROM trades AS t
 			LEFT JOIN orderbook_stats AS os
 				ON t.base_asset_id = os.base_asset_id AND t.counter_asset_id = os.counter_asset_id
 			JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
 			JOIN assets AS cAsset on t.counter_asset_id = cAsset.id
+			LEFT JOIN asset_prices AS bp ON bp.asset_id = bAsset.id AND bp.currency = 'USD'
 		WHERE bAsset.is_valid = TRUE
 			AND cAsset.is_valid = TRUE
-			AND t.ledger_close_time > now() - interval '7 days'
-		GROUP BY trade_pair_name
-	) t2 ON t1.trade_pair_name = t2.trade_pair_name
-	LEFT JOIN aggregated_orderbook AS os ON t2.trade_pair_name = os.trade_pair_name;
+			AND t.ledger_close_time > now() - interval '7 days'__MARKETFILTER__
+		GROUP BY trade_pair_name, market_id
+	) t2 ON t1.trade_pair_name = t2.trade_pair_name AND t1.market_id = t2.market_id
+	LEFT JOIN (` + aggregatedOrderbookQuery + `) AS os
+		ON t2.trade_pair_name = os.trade_pair_name AND t2.market_id = os.market_id
+	LEFT JOIN (` + aggregatedLiquidityPoolsQuery + `) AS alp
+		ON t2.trade_pair_name = alp.trade_pair_name AND t2.market_id = alp.market_id
+	LEFT JOIN (` + priceStatsQuery(validTradesSince("1 day")) + `) AS ps24
+		ON t2.trade_pair_name = ps24.trade_pair_name AND t2.market_id = ps24.market_id
+	LEFT JOIN (` + priceStatsQuery(validTradesSince("7 days")) + `) AS ps7
+		ON t2.trade_pair_name = ps7.trade_pair_name AND t2.market_id = ps7.market_id;
+`
+
+var marketWindowQuery = `
+SELECT
+	w.*,
+	COALESCE(ps.vwap, 0) AS vwap,
+	COALESCE(ps.twap, 0) AS twap,
+	COALESCE(ps.median_price, 0) AS median_price
+FROM (
+	SELECT
+		concat(
+			COALESCE(NULLIF(bAsset.anchor_asset_code, ''), bAsset.code),
+			'_',
+			COALESCE(NULLIF(cAsset.anchor_asset_code, ''), cAsset.code)
+		) as trade_pair_name,
+		__MARKETID__ AS market_id,
+		string_agg(DISTINCT ` + marketIDField + `, ',' ORDER BY ` + marketIDField + `) AS market_ids,
+		?::text AS window_name,
+		sum(t.base_amount) AS base_volume,
+		sum(t.counter_amount) AS counter_volume,
+		count(t.base_amount) AS trade_count,
+		max(t.price) AS highest_price,
+		min(t.price) AS lowest_price,
+		(array_agg(t.price ORDER BY t.ledger_close_time ASC))[1] AS open_price,
+		(array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] AS last_price,
+		((array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] - (array_agg(t.price ORDER BY t.ledger_close_time ASC))[1]) AS price_change,
+		max(t.ledger_close_time) AS close_time,
+		COALESCE(sum(t.base_amount) FILTER (WHERE ` + cleanTradeFilter + `), 0) AS clean_base_volume,
+		COALESCE(sum(t.counter_amount) FILTER (WHERE ` + cleanTradeFilter + `), 0) AS clean_counter_volume,
+		count(t.base_amount) FILTER (WHERE ` + flaggedTradeFilter + `) AS flagged_trade_count,
+		sum(t.base_amount)::text AS base_volume_exact,
+		sum(t.counter_amount)::text AS counter_volume_exact,
+		max(t.price)::text AS highest_price_exact,
+		min(t.price)::text AS lowest_price_exact,
+		((array_agg(t.price ORDER BY t.ledger_close_time ASC))[1])::text AS open_price_exact,
+		((array_agg(t.price ORDER BY t.ledger_close_time DESC))[1])::text AS last_price_exact
+	FROM trades AS t
+		JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
+		JOIN assets AS cAsset on t.counter_asset_id = cAsset.id
+	WHERE bAsset.is_valid = TRUE
+		AND cAsset.is_valid = TRUE
+		AND t.ledger_close_time > now() - interval '__NUMSECONDS__ seconds'__MARKETFILTER__
+	GROUP BY trade_pair_name, market_id
+) AS w LEFT JOIN (` + priceStatsQuery(validTradesSince("__NUMSECONDS__ seconds")) + `) AS ps
+	ON w.trade_pair_name = ps.trade_pair_name AND w.market_id = ps.market_id
 `
 
 var partialMarketQuery = `
 SELECT
 	concat(bAsset.code, ':', bAsset.issuer_account, ' / ', cAsset.code, ':', cAsset.issuer_account) as trade_pair_name,
+	` + marketIDField + ` AS market_id,
+	` + marketIDField + ` AS market_ids,
 	bAsset.id AS base_asset_id,
 	bAsset.code AS base_asset_code,
 	bAsset.issuer_account as base_asset_issuer,
@@ -245,16 +475,55 @@ SELECT
 	(now() - interval '__NUMHOURS__ hours') AS interval_start,
 	min(t.ledger_close_time) AS first_ledger_close_time,
 	max(t.ledger_close_time) AS last_ledger_close_time,
+	sum(t.base_amount)::text AS base_volume_exact,
+	sum(t.counter_amount)::text AS counter_volume_exact,
+	max(t.price)::text AS highest_price_exact,
+	min(t.price)::text AS lowest_price_exact,
+	((array_agg(t.price ORDER BY t.ledger_close_time ASC))[1])::text AS open_price_exact,
+	((array_agg(t.price ORDER BY t.ledger_close_time DESC))[1])::text AS last_price_exact,
+	COALESCE(sum(t.base_amount) FILTER (WHERE t.trade_type <> 'liquidity_pool'), 0) AS orderbook_base_volume,
+	COALESCE(sum(t.counter_amount) FILTER (WHERE t.trade_type <> 'liquidity_pool'), 0) AS orderbook_counter_volume,
+	COALESCE(sum(t.base_amount) FILTER (WHERE t.trade_type = 'liquidity_pool'), 0) AS pool_base_volume,
+	COALESCE(sum(t.counter_amount) FILTER (WHERE t.trade_type = 'liquidity_pool'), 0) AS pool_counter_volume,
+	COALESCE(sum(t.base_amount * bp.price), 0) AS base_volume_usd,
+	COALESCE(sum(t.counter_amount * cp.price), 0) AS counter_volume_usd,
+	COALESCE(max(bp.price), 0) AS price_usd,
+	COALESCE(sum(t.base_amount) FILTER (WHERE ` + cleanTradeFilter + `), 0) AS clean_base_volume,
+	COALESCE(sum(t.counter_amount) FILTER (WHERE ` + cleanTradeFilter + `), 0) AS clean_counter_volume,
+	count(t.base_amount) FILTER (WHERE ` + flaggedTradeFilter + `) AS flagged_trade_count,
+	(SELECT COALESCE(sum(lp.base_reserve), 0) FROM liquidity_pools AS lp
+		WHERE lp.base_asset_id = bAsset.id AND lp.counter_asset_id = cAsset.id) AS pool_base_reserve,
+	(SELECT COALESCE(sum(lp.counter_reserve), 0) FROM liquidity_pools AS lp
+		WHERE lp.base_asset_id = bAsset.id AND lp.counter_asset_id = cAsset.id) AS pool_counter_reserve,
+	(SELECT COALESCE(sum(lp.tvl), 0) FROM liquidity_pools AS lp
+		WHERE lp.base_asset_id = bAsset.id AND lp.counter_asset_id = cAsset.id) AS pool_tvl,
+	(SELECT COALESCE(sum(lp.fee_income_24h), 0) FROM liquidity_pools AS lp
+		WHERE lp.base_asset_id = bAsset.id AND lp.counter_asset_id = cAsset.id) AS pool_fee_income_24h,
 	COALESCE((array_agg(os.num_bids))[1], 0) AS num_bids,
 	COALESCE((array_agg(os.bid_volume))[1], 0.0) AS bid_volume,
 	COALESCE((array_agg(os.highest_bid))[1], 0.0) AS highest_bid,
 	COALESCE((array_agg(os.num_asks))[1], 0) AS num_asks,
 	COALESCE((array_agg(os.ask_volume))[1], 0.0) AS ask_volume,
-	COALESCE((array_agg(os.lowest_ask))[1], 0.0) AS lowest_ask
+	COALESCE((array_agg(os.lowest_ask))[1], 0.0) AS lowest_ask,
+	COALESCE((array_agg(os.bid_depth_2pct))[1], 0.0) AS bid_depth_2pct,
+	COALESCE((array_agg(os.bid_depth_5pct))[1], 0.0) AS bid_depth_5pct,
+	COALESCE((array_agg(os.bid_depth_10pct))[1], 0.0) AS bid_depth_10pct,
+	COALESCE((array_agg(os.ask_depth_2pct))[1], 0.0) AS ask_depth_2pct,
+	COALESCE((array_agg(os.ask_depth_5pct))[1], 0.0) AS ask_depth_5pct,
+	COALESCE((array_agg(os.ask_depth_10pct))[1], 0.0) AS ask_depth_10pct,
+	COALESCE((array_agg(os.slippage_amount))[1], 0.0) AS slippage_amount,
+	COALESCE((array_agg(os.buy_slippage))[1], 0.0) AS buy_slippage,
+	COALESCE((array_agg(os.sell_slippage))[1], 0.0) AS sell_slippage,
+	COALESCE((array_agg(ps.vwap))[1], 0.0) AS vwap,
+	COALESCE((array_agg(ps.twap))[1], 0.0) AS twap,
+	COALESCE((array_agg(ps.median_price))[1], 0.0) AS median_price
 FROM trades AS t
 	LEFT JOIN orderbook_stats AS os ON t.base_asset_id = os.base_asset_id AND t.counter_asset_id = os.counter_asset_id
 	JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
 	JOIN assets AS cAsset on t.counter_asset_id = cAsset.id
+	LEFT JOIN asset_prices AS bp ON bp.asset_id = bAsset.id AND bp.currency = 'USD'
+	LEFT JOIN asset_prices AS cp ON cp.asset_id = cAsset.id AND cp.currency = 'USD'
+	LEFT JOIN (` + priceStatsQuery("__WHERECLAUSE__") + `) AS ps ON ps.market_id = ` + marketIDField + `
 __WHERECLAUSE__
 GROUP BY bAsset.id, bAsset.code, bAsset.issuer_account, bAsset.type, cAsset.id, cAsset.code, cAsset.issuer_account, cAsset.type;
 `
@@ -262,6 +531,8 @@ GROUP BY bAsset.id, bAsset.code, bAsset.issuer_account, bAsset.type, cAsset.id,
 var aggMarketQuery = `
 SELECT
 	t1.trade_pair_name,
+	t1.market_id,
+	t1.market_ids,
 	t1.base_volume,
 	t1.counter_volume,
 	t1.trade_count,
@@ -273,6 +544,26 @@ SELECT
 	t1.interval_start,
 	t1.first_ledger_close_time,
 	t1.last_ledger_close_time,
+	t1.base_volume_exact,
+	t1.counter_volume_exact,
+	t1.highest_price_exact,
+	t1.lowest_price_exact,
+	t1.open_price_exact,
+	t1.last_price_exact,
+	t1.orderbook_base_volume,
+	t1.orderbook_counter_volume,
+	t1.pool_base_volume,
+	t1.pool_counter_volume,
+	t1.base_volume_usd,
+	t1.counter_volume_usd,
+	t1.price_usd,
+	t1.clean_base_volume,
+	t1.clean_counter_volume,
+	t1.flagged_trade_count,
+	COALESCE(alp.base_reserve, 0.0) AS pool_base_reserve,
+	COALESCE(alp.counter_reserve, 0.0) AS pool_counter_reserve,
+	COALESCE(alp.tvl, 0.0) AS pool_tvl,
+	COALESCE(alp.fee_income_24h, 0.0) AS pool_fee_income_24h,
 	COALESCE(aob.base_asset_code, '') as base_asset_code,
 	COALESCE(aob.counter_asset_code, '') as counter_asset_code,
 	COALESCE(aob.num_bids, 0) AS num_bids,
@@ -280,7 +571,19 @@ SELECT
 	COALESCE(aob.highest_bid, 0.0) AS highest_bid,
 	COALESCE(aob.num_asks, 0) AS num_asks,
 	COALESCE(aob.ask_volume, 0.0) AS ask_volume,
-	COALESCE(aob.lowest_ask, 0.0) AS lowest_ask
+	COALESCE(aob.lowest_ask, 0.0) AS lowest_ask,
+	COALESCE(aob.bid_depth_2pct, 0.0) AS bid_depth_2pct,
+	COALESCE(aob.bid_depth_5pct, 0.0) AS bid_depth_5pct,
+	COALESCE(aob.bid_depth_10pct, 0.0) AS bid_depth_10pct,
+	COALESCE(aob.ask_depth_2pct, 0.0) AS ask_depth_2pct,
+	COALESCE(aob.ask_depth_5pct, 0.0) AS ask_depth_5pct,
+	COALESCE(aob.ask_depth_10pct, 0.0) AS ask_depth_10pct,
+	COALESCE(aob.slippage_amount, 0.0) AS slippage_amount,
+	COALESCE(aob.buy_slippage, 0.0) AS buy_slippage,
+	COALESCE(aob.sell_slippage, 0.0) AS sell_slippage,
+	COALESCE(ps.vwap, 0.0) AS vwap,
+	COALESCE(ps.twap, 0.0) AS twap,
+	COALESCE(ps.median_price, 0.0) AS median_price
 FROM (
 	SELECT
 		concat(
@@ -288,6 +591,8 @@ FROM (
 			'_',
 			COALESCE(NULLIF(cAsset.anchor_asset_code, ''), cAsset.code)
 		) as trade_pair_name,
+		__MARKETID__ AS market_id,
+		string_agg(DISTINCT ` + marketIDField + `, ',' ORDER BY ` + marketIDField + `) AS market_ids,
 		sum(t.base_amount) AS base_volume,
 		sum(t.counter_amount) AS counter_volume,
 		count(t.base_amount) AS trade_count,
@@ -298,11 +603,91 @@ FROM (
 		((array_agg(t.price ORDER BY t.ledger_close_time DESC))[1] - (array_agg(t.price ORDER BY t.ledger_close_time ASC))[1]) AS price_change,
 		(now() - interval '__NUMHOURS__ hours') AS interval_start,
 		min(t.ledger_close_time) AS first_ledger_close_time,
-		max(t.ledger_close_time) AS last_ledger_close_time
+		max(t.ledger_close_time) AS last_ledger_close_time,
+		sum(t.base_amount)::text AS base_volume_exact,
+		sum(t.counter_amount)::text AS counter_volume_exact,
+		max(t.price)::text AS highest_price_exact,
+		min(t.price)::text AS lowest_price_exact,
+		((array_agg(t.price ORDER BY t.ledger_close_time ASC))[1])::text AS open_price_exact,
+		((array_agg(t.price ORDER BY t.ledger_close_time DESC))[1])::text AS last_price_exact,
+		COALESCE(sum(t.base_amount) FILTER (WHERE t.trade_type <> 'liquidity_pool'), 0) AS orderbook_base_volume,
+		COALESCE(sum(t.counter_amount) FILTER (WHERE t.trade_type <> 'liquidity_pool'), 0) AS orderbook_counter_volume,
+		COALESCE(sum(t.base_amount) FILTER (WHERE t.trade_type = 'liquidity_pool'), 0) AS pool_base_volume,
+		COALESCE(sum(t.counter_amount) FILTER (WHERE t.trade_type = 'liquidity_pool'), 0) AS pool_counter_volume,
+		COALESCE(sum(t.base_amount * bp.price), 0) AS base_volume_usd,
+		COALESCE(sum(t.counter_amount * cp.price), 0) AS counter_volume_usd,
+		COALESCE((array_agg(bp.price ORDER BY t.ledger_close_time DESC) FILTER (WHERE bp.price IS NOT NULL))[1], 0) AS price_usd,
+		COALESCE(sum(t.base_amount) FILTER (WHERE ` + cleanTradeFilter + `), 0) AS clean_base_volume,
+		COALESCE(sum(t.counter_amount) FILTER (WHERE ` + cleanTradeFilter + `), 0) AS clean_counter_volume,
+		count(t.base_amount) FILTER (WHERE ` + flaggedTradeFilter + `) AS flagged_trade_count
 	FROM trades AS t
 		LEFT JOIN orderbook_stats AS os ON t.base_asset_id = os.base_asset_id AND t.counter_asset_id = os.counter_asset_id
 		JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
 		JOIN assets AS cAsset on t.counter_asset_id = cAsset.id
+		LEFT JOIN asset_prices AS bp ON bp.asset_id = bAsset.id AND bp.currency = 'USD'
+		LEFT JOIN asset_prices AS cp ON cp.asset_id = cAsset.id AND cp.currency = 'USD'
 	__WHERECLAUSE__
-	GROUP BY trade_pair_name
-) t1 LEFT JOIN aggregated_orderbook AS aob ON t1.trade_pair_name = aob.trade_pair_name;`
+	GROUP BY trade_pair_name, market_id
+) t1 LEFT JOIN (` + aggregatedOrderbookQuery + `) AS aob
+		ON t1.trade_pair_name = aob.trade_pair_name AND t1.market_id = aob.market_id
+	LEFT JOIN (` + aggregatedLiquidityPoolsQuery + `) AS alp
+		ON t1.trade_pair_name = alp.trade_pair_name AND t1.market_id = alp.market_id
+	LEFT JOIN (` + priceStatsQuery("__WHERECLAUSE__") + `) AS ps
+		ON t1.trade_pair_name = ps.trade_pair_name AND t1.market_id = ps.market_id;`
+
+// aggregatedLiquidityPoolsQuery sums the liquidity of the pools between valid
+// assets, aggregated by trade pair name and market ID (see marketIDSelector).
+var aggregatedLiquidityPoolsQuery = `
+	SELECT
+		concat(
+			COALESCE(NULLIF(bAsset.anchor_asset_code, ''), bAsset.code),
+			'_',
+			COALESCE(NULLIF(cAsset.anchor_asset_code, ''), cAsset.code)
+		) as trade_pair_name,
+		__MARKETID__ AS market_id,
+		sum(lp.base_reserve) AS base_reserve,
+		sum(lp.counter_reserve) AS counter_reserve,
+		sum(lp.tvl) AS tvl,
+		sum(lp.fee_income_24h) AS fee_income_24h
+	FROM liquidity_pools AS lp
+		JOIN assets AS bAsset ON lp.base_asset_id = bAsset.id
+		JOIN assets AS cAsset ON lp.counter_asset_id = cAsset.id
+	WHERE bAsset.is_valid = TRUE AND cAsset.is_valid = TRUE__MARKETFILTER__
+	GROUP BY trade_pair_name, market_id
+`
+
+// aggregatedOrderbookQuery aggregates the orderbook stats of valid assets by
+// trade pair name and market ID (see marketIDSelector), like the
+// aggregated_orderbook view: depths are summed across the markets of a trade
+// pair, while the slippage is the lowest one among them.
+var aggregatedOrderbookQuery = `
+	SELECT
+		concat(
+			COALESCE(NULLIF(bAsset.anchor_asset_code, ''), bAsset.code),
+			'_',
+			COALESCE(NULLIF(cAsset.anchor_asset_code, ''), cAsset.code)
+		) as trade_pair_name,
+		__MARKETID__ AS market_id,
+		min(bAsset.code) AS base_asset_code,
+		min(cAsset.code) AS counter_asset_code,
+		COALESCE(sum(os.num_bids), 0) AS num_bids,
+		COALESCE(sum(os.bid_volume), 0.0) AS bid_volume,
+		COALESCE(max(os.highest_bid), 0.0) AS highest_bid,
+		COALESCE(sum(os.num_asks), 0) AS num_asks,
+		COALESCE(sum(os.ask_volume), 0.0) AS ask_volume,
+		COALESCE(min(os.lowest_ask), 0.0) AS lowest_ask,
+		COALESCE(sum(os.bid_depth_2pct), 0.0) AS bid_depth_2pct,
+		COALESCE(sum(os.bid_depth_5pct), 0.0) AS bid_depth_5pct,
+		COALESCE(sum(os.bid_depth_10pct), 0.0) AS bid_depth_10pct,
+		COALESCE(sum(os.ask_depth_2pct), 0.0) AS ask_depth_2pct,
+		COALESCE(sum(os.ask_depth_5pct), 0.0) AS ask_depth_5pct,
+		COALESCE(sum(os.ask_depth_10pct), 0.0) AS ask_depth_10pct,
+		COALESCE(max(os.slippage_amount), 0.0) AS slippage_amount,
+		COALESCE(min(NULLIF(os.buy_slippage, 0)), 0.0) AS buy_slippage,
+		COALESCE(min(NULLIF(os.sell_slippage, 0)), 0.0) AS sell_slippage
+	FROM orderbook_stats AS os
+		JOIN assets AS bAsset ON os.base_asset_id = bAsset.id
+		JOIN assets AS cAsset ON os.counter_asset_id = cAsset.id
+	WHERE bAsset.is_valid = TRUE AND cAsset.is_valid = TRUE__MARKETFILTER__
+	GROUP BY trade_pair_name, market_id
+`
@@ -109,46 +109,46 @@ func TestRetrieveMarketData(t *testing.T) {
 		{ // XLM_BTC trade
 			HorizonID:       "hrzid1",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      100.0,
+			BaseAmount:      "100.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   10.0,
-			Price:           0.1,
+			CounterAmount:   "10.0",
+			Price:           "0.1",
 			LedgerCloseTime: now,
 		},
 		{ // XLM_ETH trade
 			HorizonID:       "hrzid3",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      24.0,
+			BaseAmount:      "24.0",
 			CounterAssetID:  ethAsset.ID,
-			CounterAmount:   26.0,
-			Price:           0.92,
+			CounterAmount:   "26.0",
+			Price:           "0.92",
 			LedgerCloseTime: oneHourAgo,
 		},
 		{ // XLM_ETH trade
 			HorizonID:       "hrzid2",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      50.0,
+			BaseAmount:      "50.0",
 			CounterAssetID:  ethAsset.ID,
-			CounterAmount:   50.0,
-			Price:           1.0,
+			CounterAmount:   "50.0",
+			Price:           "1.0",
 			LedgerCloseTime: now,
 		},
 		{ // XLM_BTC trade
 			HorizonID:       "hrzid4",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      50.0,
+			BaseAmount:      "50.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   6.0,
-			Price:           0.12,
+			CounterAmount:   "6.0",
+			Price:           "0.12",
 			LedgerCloseTime: threeDaysAgo,
 		},
 		{ // XLM_ETH trade
 			HorizonID:       "hrzid5",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      24.0,
+			BaseAmount:      "24.0",
 			CounterAssetID:  ethAsset.ID,
-			CounterAmount:   28.0,
-			Price:           1.10,
+			CounterAmount:   "28.0",
+			Price:           "1.10",
 			LedgerCloseTime: oneMonthAgo,
 		},
 	}
@@ -214,7 +214,7 @@ func TestRetrieveMarketData(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEqual(t, obBTCETH1.ID, obBTCETH2.ID)
 
-	markets, err := session.RetrieveMarketData(ctx)
+	markets, err := session.RetrieveMarketData(ctx, MarketFilter{}, true, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 2, len(markets))
 
@@ -419,37 +419,37 @@ func TestRetrievePartialMarkets(t *testing.T) {
 		{ // BTC_ETH  trade (ETH is from issuer 1)
 			HorizonID:       "hrzid1",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      100.0,
+			BaseAmount:      "100.0",
 			CounterAssetID:  ethAsset1.ID,
-			CounterAmount:   10.0,
-			Price:           0.1,
+			CounterAmount:   "10.0",
+			Price:           "0.1",
 			LedgerCloseTime: tenMinutesAgo,
 		},
 		{ // BTC_ETH trade (ETH is from issuer 2)
 			HorizonID:       "hrzid3",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      24.0,
+			BaseAmount:      "24.0",
 			CounterAssetID:  ethAsset2.ID,
-			CounterAmount:   26.0,
-			Price:           0.92,
+			CounterAmount:   "26.0",
+			Price:           "0.92",
 			LedgerCloseTime: now,
 		},
 		{ // BTC_ETH  trade (ETH is from issuer 1)
 			HorizonID:       "hrzid2",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      50.0,
+			BaseAmount:      "50.0",
 			CounterAssetID:  ethAsset1.ID,
-			CounterAmount:   50.0,
-			Price:           1.0,
+			CounterAmount:   "50.0",
+			Price:           "1.0",
 			LedgerCloseTime: oneHourAgo,
 		},
 		{ // BTC_ETH  trade (ETH is from issuer 1)
 			HorizonID:       "hrzid4",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      50.0,
+			BaseAmount:      "50.0",
 			CounterAssetID:  ethAsset1.ID,
-			CounterAmount:   6.0,
-			Price:           0.12,
+			CounterAmount:   "6.0",
+			Price:           "0.12",
 			LedgerCloseTime: threeDaysAgo,
 		},
 	}
@@ -516,7 +516,7 @@ func TestRetrievePartialMarkets(t *testing.T) {
 	assert.NotEqual(t, obBTCETH1.ID, obBTCETH2.ID)
 
 	partialMkts, err := session.RetrievePartialMarkets(ctx,
-		nil, nil, nil, nil, 12,
+		nil, nil, nil, nil, 12, 0,
 	)
 	require.NoError(t, err)
 	assert.Equal(t, 2, len(partialMkts))
@@ -576,7 +576,7 @@ func TestRetrievePartialMarkets(t *testing.T) {
 	assert.Equal(t, 0.2, btceth2Mkt.LowestAsk)
 
 	// Now let's use the same data, but aggregating by asset pair
-	partialAggMkts, err := session.RetrievePartialAggMarkets(ctx, nil, 12)
+	partialAggMkts, err := session.RetrievePartialAggMarkets(ctx, nil, 12, true, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(partialAggMkts))
 
@@ -602,7 +602,7 @@ func TestRetrievePartialMarkets(t *testing.T) {
 	// Validate the pair name parsing:
 	pairName := new(string)
 	*pairName = "BTC_ETH"
-	partialAggMkts, err = session.RetrievePartialAggMarkets(ctx, pairName, 12)
+	partialAggMkts, err = session.RetrievePartialAggMarkets(ctx, pairName, 12, true, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(partialAggMkts))
 	assert.Equal(t, int32(3), partialAggMkts[0].TradeCount)
@@ -691,26 +691,26 @@ func Test24hStatsFallback(t *testing.T) {
 		{
 			HorizonID:       "hrzid1",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      1.0,
+			BaseAmount:      "1.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   1.0,
-			Price:           0.5, // close price & lowest price
+			CounterAmount:   "1.0",
+			Price:           "0.5", // close price & lowest price
 			LedgerCloseTime: twoDaysAgo,
 		},
 		{ // BTC_ETH trade (ETH is from issuer 2)
 			HorizonID:       "hrzid2",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      1.0,
+			BaseAmount:      "1.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   1.0,
-			Price:           1.0, // open price & highest price
+			CounterAmount:   "1.0",
+			Price:           "1.0", // open price & highest price
 			LedgerCloseTime: threeDaysAgo,
 		},
 	}
 	err = session.BulkInsertTrades(ctx, trades)
 	require.NoError(t, err)
 
-	markets, err := session.RetrieveMarketData(ctx)
+	markets, err := session.RetrieveMarketData(ctx, MarketFilter{}, true, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(markets))
 	mkt := markets[0]
@@ -798,36 +798,336 @@ func TestPreferAnchorAssetCode(t *testing.T) {
 		{
 			HorizonID:       "hrzid1",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      1.0,
+			BaseAmount:      "1.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   1.0,
-			Price:           0.5, // close price & lowest price
+			CounterAmount:   "1.0",
+			Price:           "0.5", // close price & lowest price
 			LedgerCloseTime: twoDaysAgo,
 		},
 		{ // BTC_ETH trade (ETH is from issuer 2)
 			HorizonID:       "hrzid2",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      1.0,
+			BaseAmount:      "1.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   1.0,
-			Price:           1.0, // open price & highest price
+			CounterAmount:   "1.0",
+			Price:           "1.0", // open price & highest price
 			LedgerCloseTime: threeDaysAgo,
 		},
 	}
 	err = session.BulkInsertTrades(ctx, trades)
 	require.NoError(t, err)
 
-	markets, err := session.RetrieveMarketData(ctx)
+	markets, err := session.RetrieveMarketData(ctx, MarketFilter{}, true, 0)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(markets))
 	for _, mkt := range markets {
 		require.Equal(t, "XLM_EUR", mkt.TradePair)
 	}
 
-	partialAggMkts, err := session.RetrievePartialAggMarkets(ctx, nil, 168)
+	partialAggMkts, err := session.RetrievePartialAggMarkets(ctx, nil, 168, true, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(partialAggMkts))
 	for _, aggMkt := range partialAggMkts {
 		require.Equal(t, "XLM_EUR", aggMkt.TradePairName)
 	}
 }
+
+func TestRetrieveMarketWindows(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:     "XLM",
+		IssuerID: issuer.ID,
+		IsValid:  true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding another asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:     "BTC",
+		IssuerID: issuer.ID,
+		IsValid:  true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var btcAsset Asset
+	err = session.GetRaw(ctx, &btcAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// A few times to be used:
+	now := time.Now()
+	tenMinutesAgo := now.Add(-10 * time.Minute)
+	twentyDaysAgo := now.AddDate(0, 0, -20)
+
+	// Now let's create the trades:
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "10.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "5.0",
+			Price:           "0.5", // close price & lowest price
+			LedgerCloseTime: tenMinutesAgo,
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "20.0",
+			CounterAssetID:  btcAsset.ID,
+			CounterAmount:   "20.0",
+			Price:           "1.0", // open price & highest price
+			LedgerCloseTime: twentyDaysAgo,
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	mktWindows, err := session.RetrieveMarketWindows(ctx, []string{"1h", "7d", "30d"}, MarketFilter{}, true, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(mktWindows))
+
+	windows := make(map[string]MarketWindow)
+	for _, w := range mktWindows {
+		assert.Equal(t, "XLM_BTC", w.TradePair)
+		windows[w.WindowName] = w
+	}
+
+	assert.Equal(t, 10.0, windows["1h"].BaseVolume)
+	assert.Equal(t, int64(1), windows["1h"].TradeCount)
+	assert.Equal(t, 10.0, windows["7d"].BaseVolume)
+	assert.Equal(t, int64(1), windows["7d"].TradeCount)
+
+	assert.Equal(t, 30.0, windows["30d"].BaseVolume)
+	assert.Equal(t, 25.0, windows["30d"].CounterVolume)
+	assert.Equal(t, int64(2), windows["30d"].TradeCount)
+	assert.Equal(t, 1.0, windows["30d"].OpenPrice)
+	assert.Equal(t, 0.5, windows["30d"].LowestPrice)
+	assert.Equal(t, 1.0, windows["30d"].HighestPrice)
+	assert.Equal(t, -0.5, windows["30d"].PriceChange)
+	assert.Equal(t, 0.5, windows["30d"].LastPrice)
+
+	// Invalid windows are rejected:
+	_, err = session.RetrieveMarketWindows(ctx, []string{"1y"}, MarketFilter{}, true, 0)
+	assert.Error(t, err)
+}
+
+func TestRetrieveMarketsByIssuer(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding two seed issuers of the same asset code:
+	issuers := make([]Issuer, 2)
+	for i, publicKey := range []string{
+		"GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		"GAEUSDZBW3QJY3Y6V4VZHD4DMG3RZL3VGSGUCNUFZ5FBS7HLQ7KNNWA2",
+	} {
+		tbl := session.GetTable("issuers")
+		_, err = tbl.Insert(Issuer{
+			PublicKey: publicKey,
+			Name:      "FOO BAR",
+		}).IgnoreCols("id").Exec(ctx)
+		require.NoError(t, err)
+		err = session.GetRaw(ctx, &issuers[i], `
+			SELECT *
+			FROM issuers
+			ORDER BY id DESC
+			LIMIT 1`,
+		)
+		require.NoError(t, err)
+	}
+
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "XLM",
+		IssuerAccount: "native",
+		IssuerID:      issuers[0].ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	usdAssets := make([]Asset, 2)
+	for i, issuer := range issuers {
+		err = session.InsertOrUpdateAsset(ctx, &Asset{
+			Code:          "USD",
+			IssuerAccount: issuer.PublicKey,
+			IssuerID:      issuer.ID,
+			IsValid:       true,
+		}, []string{"code", "issuer_id"})
+		require.NoError(t, err)
+		err = session.GetRaw(ctx, &usdAssets[i], `
+			SELECT *
+			FROM assets
+			ORDER BY id DESC
+			LIMIT 1`,
+		)
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "10.0",
+			CounterAssetID:  usdAssets[0].ID,
+			CounterAmount:   "1.0",
+			Price:           "10.0",
+			LedgerCloseTime: now.Add(-time.Hour),
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     xlmAsset.ID,
+			BaseAmount:      "20.0",
+			CounterAssetID:  usdAssets[1].ID,
+			CounterAmount:   "1.0",
+			Price:           "20.0",
+			LedgerCloseTime: now.Add(-time.Hour),
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	id1 := MarketID("XLM", "native", "USD", issuers[0].PublicKey)
+	id2 := MarketID("XLM", "native", "USD", issuers[1].PublicKey)
+
+	// By default, each issuer's market is kept apart:
+	markets, err := session.RetrieveMarketData(ctx, MarketFilter{}, false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(markets))
+	marketsByID := make(map[string]Market)
+	for _, m := range markets {
+		assert.Equal(t, "XLM_USD", m.TradePair)
+		assert.Equal(t, m.MarketID, m.MarketIDs)
+		marketsByID[m.MarketID] = m
+	}
+	assert.Equal(t, 10.0, marketsByID[id1].BaseVolume24h)
+	assert.Equal(t, 10.0, marketsByID[id1].LastPrice)
+	assert.Equal(t, 20.0, marketsByID[id2].BaseVolume24h)
+	assert.Equal(t, 20.0, marketsByID[id2].LastPrice)
+
+	mktWindows, err := session.RetrieveMarketWindows(ctx, []string{"1d"}, MarketFilter{}, false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(mktWindows))
+	assert.NotEqual(t, mktWindows[0].MarketID, mktWindows[1].MarketID)
+
+	partialMkts, err := session.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(partialMkts))
+	assert.ElementsMatch(t, []string{id1, id2}, []string{partialMkts[0].MarketID, partialMkts[1].MarketID})
+
+	pairName := "XLM_USD"
+	partialAggMkts, err := session.RetrievePartialAggMarkets(ctx, &pairName, 24, false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(partialAggMkts))
+
+	// Aggregating by code merges them into a single trade pair:
+	markets, err = session.RetrieveMarketData(ctx, MarketFilter{}, true, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(markets))
+	assert.Equal(t, "XLM_USD", markets[0].TradePair)
+	assert.Equal(t, "", markets[0].MarketID)
+	assert.ElementsMatch(t, []string{id1, id2}, SplitMarketIDs(markets[0].MarketIDs))
+	assert.Equal(t, 30.0, markets[0].BaseVolume24h)
+
+	mktWindows, err = session.RetrieveMarketWindows(ctx, []string{"1d"}, MarketFilter{}, true, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(mktWindows))
+	assert.Equal(t, "", mktWindows[0].MarketID)
+
+	partialAggMkts, err = session.RetrievePartialAggMarkets(ctx, &pairName, 24, true, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(partialAggMkts))
+	assert.Equal(t, 30.0, partialAggMkts[0].BaseVolume)
+	assert.ElementsMatch(t, []string{id1, id2}, SplitMarketIDs(partialAggMkts[0].MarketIDs))
+
+	// Filtering only retrieves the requested market:
+	filter := MarketFilter{
+		BaseAssetCode:      "XLM",
+		BaseAssetIssuer:    "native",
+		CounterAssetCode:   "USD",
+		CounterAssetIssuer: issuers[1].PublicKey,
+	}
+	markets, err = session.RetrieveMarketData(ctx, filter, false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(markets))
+	assert.Equal(t, id2, markets[0].MarketID)
+	assert.Equal(t, 20.0, markets[0].BaseVolume24h)
+
+	mktWindows, err = session.RetrieveMarketWindows(ctx, []string{"1d"}, filter, false, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(mktWindows))
+	assert.Equal(t, id2, mktWindows[0].MarketID)
+
+	markets, err = session.RetrieveMarketData(ctx, MarketFilter{TradePairName: "xlm_usd"}, true, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(markets))
+	assert.Equal(t, 30.0, markets[0].BaseVolume24h)
+
+	markets, err = session.RetrieveMarketData(ctx, MarketFilter{TradePairName: "XLM_BTC"}, true, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(markets))
+}
@@ -2,6 +2,10 @@ package tickerdb
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // InsertOrUpdateOrderbookStats inserts an OrdebookStats entry on the database (if new),
@@ -9,3 +13,101 @@ import (
 func (s *TickerSession) InsertOrUpdateOrderbookStats(ctx context.Context, o *OrderbookStats, preserveFields []string) (err error) {
 	return s.performUpsertQuery(ctx, *o, "orderbook_stats", "orderbook_stats_base_counter_asset_key", preserveFields)
 }
+
+// InsertOrderbookSnapshot appends an OrderbookSnapshot entry to the database.
+func (s *TickerSession) InsertOrderbookSnapshot(ctx context.Context, o *OrderbookSnapshot) (err error) {
+	_, err = s.GetTable("orderbook_snapshots").Insert(*o).IgnoreCols("id").Exec(ctx)
+	return
+}
+
+// DeleteOldOrderbookSnapshots deletes orderbook snapshots in the database
+// older than minDate.
+func (s *TickerSession) DeleteOldOrderbookSnapshots(ctx context.Context, minDate time.Time) error {
+	_, err := s.ExecRaw(ctx, "DELETE FROM orderbook_snapshots WHERE created_at < ?", minDate)
+	return err
+}
+
+// RetrieveOrderbookHistory retrieves the orderbook snapshots taken within
+// [from, to), grouped into buckets of the given (candle) resolution. Assets
+// with the same code are aggregated into a single trade pair (e.g. XLM_BTC),
+// and the base and counter assets optionally restrict the results.
+func (s *TickerSession) RetrieveOrderbookHistory(ctx context.Context,
+	baseAssetCode *string,
+	baseAssetIssuer *string,
+	counterAssetCode *string,
+	counterAssetIssuer *string,
+	resolution string,
+	from time.Time,
+	to time.Time,
+) (history []OrderbookHistoryEntry, err error) {
+	bucketSize, ok := CandleResolutions[resolution]
+	if !ok {
+		err = fmt.Errorf("invalid orderbook history resolution: %s", resolution)
+		return
+	}
+
+	if !from.Before(to) {
+		err = errors.New("from must be before to")
+		return
+	}
+
+	sqlTrue := new(string)
+	*sqlTrue = "TRUE"
+	where, args := generateWhereClause([]optionalVar{
+		{"bAsset.is_valid", sqlTrue},
+		{"cAsset.is_valid", sqlTrue},
+		{"bAsset.code", baseAssetCode},
+		{"bAsset.issuer_account", baseAssetIssuer},
+		{"cAsset.code", counterAssetCode},
+		{"cAsset.issuer_account", counterAssetIssuer},
+	})
+	q := strings.Replace(orderbookHistoryQuery, "__WHERECLAUSE__", where, -1)
+
+	seconds := int64(bucketSize.Seconds())
+	argsInterface := make([]interface{}, 0, len(args)+5)
+	argsInterface = append(argsInterface, resolution, seconds, seconds, from, to)
+	for _, v := range args {
+		argsInterface = append(argsInterface, v)
+	}
+
+	err = s.SelectRaw(ctx, &history, q, argsInterface...)
+	return
+}
+
+var orderbookHistoryQuery = `
+SELECT
+	concat(bAsset.code, '_', cAsset.code) AS trade_pair_name,
+	?::text AS resolution,
+	os.start_time,
+	sum(os.snapshot_count)::bigint AS snapshot_count,
+	round(sum(os.num_bids))::bigint AS num_bids,
+	sum(os.bid_volume) AS bid_volume,
+	max(os.highest_bid) AS highest_bid,
+	round(sum(os.num_asks))::bigint AS num_asks,
+	sum(os.ask_volume) AS ask_volume,
+	COALESCE(min(NULLIF(os.lowest_ask, 0)), 0) AS lowest_ask,
+	avg(os.spread) AS spread,
+	avg(os.spread_mid_point) AS spread_mid_point
+FROM (
+	SELECT
+		base_asset_id,
+		counter_asset_id,
+		to_timestamp(floor(extract(epoch FROM created_at) / ?) * ?) AS start_time,
+		count(*) AS snapshot_count,
+		avg(num_bids) AS num_bids,
+		avg(bid_volume) AS bid_volume,
+		max(highest_bid) AS highest_bid,
+		avg(num_asks) AS num_asks,
+		avg(ask_volume) AS ask_volume,
+		min(NULLIF(lowest_ask, 0)) AS lowest_ask,
+		avg(spread) AS spread,
+		avg(spread_mid_point) AS spread_mid_point
+	FROM orderbook_snapshots
+	WHERE created_at >= ? AND created_at < ?
+	GROUP BY base_asset_id, counter_asset_id, start_time
+) AS os
+	JOIN assets AS bAsset ON os.base_asset_id = bAsset.id
+	JOIN assets AS cAsset ON os.counter_asset_id = cAsset.id
+__WHERECLAUSE__
+GROUP BY trade_pair_name, os.start_time
+ORDER BY trade_pair_name, os.start_time ASC;`
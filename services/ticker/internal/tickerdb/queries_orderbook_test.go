@@ -106,6 +106,11 @@ func TestInsertOrUpdateOrderbokStats(t *testing.T) {
 		Spread:         0.93,
 		SpreadMidPoint: 0.35,
 		UpdatedAt:      obTime,
+		BidDepth2Pct:   0.05,
+		AskDepth10Pct:  25.0,
+		SlippageAmount: 1000.0,
+		BuySlippage:    1.5,
+		SellSlippage:   2.5,
 	}
 	err = session.InsertOrUpdateOrderbookStats(ctx,
 		&orderbookStats,
@@ -132,6 +137,11 @@ func TestInsertOrUpdateOrderbokStats(t *testing.T) {
 	assert.Equal(t, 0.1, dbOS.LowestAsk)
 	assert.Equal(t, 0.93, dbOS.Spread)
 	assert.Equal(t, 0.35, dbOS.SpreadMidPoint)
+	assert.Equal(t, 0.05, dbOS.BidDepth2Pct)
+	assert.Equal(t, 25.0, dbOS.AskDepth10Pct)
+	assert.Equal(t, 1000.0, dbOS.SlippageAmount)
+	assert.Equal(t, 1.5, dbOS.BuySlippage)
+	assert.Equal(t, 2.5, dbOS.SellSlippage)
 	assert.WithinDuration(t, obTime.Local(), dbOS.UpdatedAt.Local(), 10*time.Millisecond)
 
 	// Making sure we're upserting:
@@ -178,3 +188,164 @@ func TestInsertOrUpdateOrderbokStats(t *testing.T) {
 	assert.Equal(t, 0.7, dbOS2.SpreadMidPoint)
 	assert.WithinDuration(t, obTime2.Local(), dbOS2.UpdatedAt.Local(), 10*time.Millisecond)
 }
+
+func TestOrderbookSnapshots(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "XLM",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding another asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "BTC",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var btcAsset Asset
+	err = session.GetRaw(ctx, &btcAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	hourStart := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+	snapshots := []OrderbookSnapshot{
+		{
+			NumBids:        10,
+			BidVolume:      100.0,
+			HighestBid:     2.0,
+			NumAsks:        20,
+			AskVolume:      50.0,
+			LowestAsk:      2.2,
+			Spread:         0.1,
+			SpreadMidPoint: 2.1,
+			CreatedAt:      hourStart.Add(10 * time.Minute),
+		},
+		{
+			NumBids:        20,
+			BidVolume:      200.0,
+			HighestBid:     2.1,
+			NumAsks:        30,
+			AskVolume:      150.0,
+			LowestAsk:      2.3,
+			Spread:         0.3,
+			SpreadMidPoint: 2.2,
+			CreatedAt:      hourStart.Add(40 * time.Minute),
+		},
+		{
+			NumBids:        5,
+			BidVolume:      10.0,
+			HighestBid:     1.9,
+			NumAsks:        6,
+			AskVolume:      20.0,
+			LowestAsk:      2.0,
+			Spread:         0.05,
+			SpreadMidPoint: 1.95,
+			CreatedAt:      hourStart.Add(70 * time.Minute),
+		},
+		{
+			NumBids:        1,
+			BidVolume:      1.0,
+			HighestBid:     1.0,
+			NumAsks:        1,
+			AskVolume:      1.0,
+			LowestAsk:      1.0,
+			Spread:         0.0,
+			SpreadMidPoint: 1.0,
+			CreatedAt:      hourStart.Add(-48 * time.Hour),
+		},
+	}
+	for _, snapshot := range snapshots {
+		snapshot.BaseAssetID = xlmAsset.ID
+		snapshot.CounterAssetID = btcAsset.ID
+		err = session.InsertOrderbookSnapshot(ctx, &snapshot)
+		require.NoError(t, err)
+	}
+
+	// Snapshots are averaged within each bucket:
+	history, err := session.RetrieveOrderbookHistory(ctx, nil, nil, nil, nil, "1h", hourStart, hourStart.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history))
+
+	assert.Equal(t, "XLM_BTC", history[0].TradePairName)
+	assert.Equal(t, "1h", history[0].Resolution)
+	assert.True(t, hourStart.Equal(history[0].StartTime))
+	assert.Equal(t, int64(2), history[0].SnapshotCount)
+	assert.Equal(t, int64(15), history[0].NumBids)
+	assert.Equal(t, 150.0, history[0].BidVolume)
+	assert.Equal(t, 2.1, history[0].HighestBid)
+	assert.Equal(t, int64(25), history[0].NumAsks)
+	assert.Equal(t, 100.0, history[0].AskVolume)
+	assert.Equal(t, 2.2, history[0].LowestAsk)
+	assert.InDelta(t, 0.2, history[0].Spread, 1e-9)
+	assert.InDelta(t, 2.15, history[0].SpreadMidPoint, 1e-9)
+
+	assert.True(t, hourStart.Add(time.Hour).Equal(history[1].StartTime))
+	assert.Equal(t, int64(1), history[1].SnapshotCount)
+	assert.Equal(t, int64(5), history[1].NumBids)
+
+	// Filtering by an unknown asset returns no history:
+	ethCode := "ETH"
+	history, err = session.RetrieveOrderbookHistory(ctx, &ethCode, nil, nil, nil, "1h", hourStart, hourStart.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(history))
+
+	_, err = session.RetrieveOrderbookHistory(ctx, nil, nil, nil, nil, "2h", hourStart, hourStart.Add(2*time.Hour))
+	assert.Error(t, err)
+
+	// Old snapshots are deleted:
+	err = session.DeleteOldOrderbookSnapshots(ctx, hourStart.Add(-24*time.Hour))
+	require.NoError(t, err)
+	var count int
+	err = session.GetRaw(ctx, &count, "SELECT count(*) FROM orderbook_snapshots")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
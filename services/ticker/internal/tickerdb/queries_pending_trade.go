@@ -0,0 +1,108 @@
+package tickerdb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stellar/go/support/db"
+)
+
+// BulkInsertPendingTrades inserts a slice of pending trades in the database.
+// Trades that are already pending (i.e. horizon_id already exists) are ignored.
+func (s *TickerSession) BulkInsertPendingTrades(ctx context.Context, trades []PendingTrade) (err error) {
+	if len(trades) == 0 {
+		return
+	}
+
+	if len(trades) <= 50 {
+		return performInsertPendingTrades(ctx, s, trades)
+	}
+
+	for start := 0; start < len(trades); start += 50 {
+		end := start + 50
+		if end > len(trades) {
+			end = len(trades)
+		}
+
+		err = performInsertPendingTrades(ctx, s, trades[start:end])
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// GetResolvablePendingTrades returns the pending trades whose base and
+// counter assets can now be found in the assets table.
+func (s *TickerSession) GetResolvablePendingTrades(ctx context.Context) (trades []ResolvablePendingTrade, err error) {
+	err = s.SelectRaw(ctx, &trades, resolvablePendingTradesQuery)
+	return
+}
+
+// ResolvePendingTrades inserts a slice of trades (see BulkInsertTrades) and
+// deletes the pending trades with the given Horizon IDs within the same
+// database transaction.
+func (s *TickerSession) ResolvePendingTrades(ctx context.Context, trades []Trade, horizonIDs []string) (err error) {
+	txSession := TickerSession{db.Session{DB: s.DB}}
+	if err = txSession.Begin(ctx); err != nil {
+		return
+	}
+
+	if err = txSession.BulkInsertTrades(ctx, trades); err != nil {
+		txSession.Rollback()
+		return
+	}
+
+	_, err = txSession.ExecRaw(
+		ctx,
+		"DELETE FROM pending_trades WHERE horizon_id = ANY(?::text[])",
+		pq.Array(horizonIDs),
+	)
+	if err != nil {
+		txSession.Rollback()
+		return
+	}
+
+	return txSession.Commit()
+}
+
+// DeleteOldPendingTrades deletes pending trades in the database older than minDate.
+func (s *TickerSession) DeleteOldPendingTrades(ctx context.Context, minDate time.Time) error {
+	_, err := s.ExecRaw(ctx, "DELETE FROM pending_trades WHERE ledger_close_time < ?", minDate)
+	return err
+}
+
+func performInsertPendingTrades(ctx context.Context, s *TickerSession, trades []PendingTrade) (err error) {
+	var t PendingTrade
+	var placeholders []string
+	var dbValues []interface{}
+
+	dbFields := getDBFieldTags(t, true)
+	dbFieldsString := strings.Join(dbFields, ", ")
+
+	for _, trade := range trades {
+		v := getDBFieldValues(trade, true)
+		placeholders = append(placeholders, "("+generatePlaceholders(v)+")")
+		dbValues = append(dbValues, v...)
+	}
+
+	qs := "INSERT INTO pending_trades (" + dbFieldsString + ")"
+	qs += " VALUES " + strings.Join(placeholders, ",")
+	qs += " ON CONFLICT (horizon_id) DO NOTHING;"
+
+	_, err = s.ExecRaw(ctx, qs, dbValues...)
+	return
+}
+
+var resolvablePendingTradesQuery = `
+SELECT
+	p.*,
+	b.id AS base_asset_id,
+	c.id AS counter_asset_id
+FROM pending_trades AS p
+JOIN assets AS b ON b.code = p.base_asset_code AND b.issuer_account = p.base_asset_issuer
+JOIN assets AS c ON c.code = p.counter_asset_code AND c.issuer_account = p.counter_asset_issuer
+ORDER BY p.ledger_close_time ASC;`
@@ -0,0 +1,146 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePendingTrades(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "XLM",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var xlmAsset Asset
+	err = session.GetRaw(ctx, &xlmAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// A trade against BTC arrives before the BTC asset is known:
+	now := time.Now()
+	pendingTrade := PendingTrade{
+		HorizonID:          "hrzid1",
+		LedgerCloseTime:    now,
+		BaseAssetCode:      "XLM",
+		BaseAssetIssuer:    issuer.PublicKey,
+		CounterAssetCode:   "BTC",
+		CounterAssetIssuer: issuer.PublicKey,
+		Trade:              `{"id": "hrzid1"}`,
+		CreatedAt:          now,
+	}
+	err = session.BulkInsertPendingTrades(ctx, []PendingTrade{pendingTrade})
+	require.NoError(t, err)
+
+	// Trades that are already pending are ignored:
+	err = session.BulkInsertPendingTrades(ctx, []PendingTrade{pendingTrade})
+	require.NoError(t, err)
+
+	var numPending int
+	err = session.GetRaw(ctx, &numPending, "SELECT count(*) FROM pending_trades")
+	require.NoError(t, err)
+	assert.Equal(t, 1, numPending)
+
+	resolvable, err := session.GetResolvablePendingTrades(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, resolvable)
+
+	// Once BTC is added, the trade can be resolved:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "BTC",
+		IssuerAccount: issuer.PublicKey,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var btcAsset Asset
+	err = session.GetRaw(ctx, &btcAsset, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	resolvable, err = session.GetResolvablePendingTrades(ctx)
+	require.NoError(t, err)
+	require.Len(t, resolvable, 1)
+	assert.Equal(t, "hrzid1", resolvable[0].HorizonID)
+	assert.Equal(t, `{"id": "hrzid1"}`, resolvable[0].Trade)
+	assert.Equal(t, xlmAsset.ID, resolvable[0].BaseAssetID)
+	assert.Equal(t, btcAsset.ID, resolvable[0].CounterAssetID)
+
+	err = session.ResolvePendingTrades(ctx, []Trade{{
+		HorizonID:       "hrzid1",
+		BaseAssetID:     xlmAsset.ID,
+		BaseAmount:      "100.0",
+		CounterAssetID:  btcAsset.ID,
+		CounterAmount:   "10.0",
+		LedgerCloseTime: now,
+		Price:           "0.1",
+	}}, []string{"hrzid1"})
+	require.NoError(t, err)
+
+	lastTrade, err := session.GetLastTrade(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "hrzid1", lastTrade.HorizonID)
+
+	err = session.GetRaw(ctx, &numPending, "SELECT count(*) FROM pending_trades")
+	require.NoError(t, err)
+	assert.Equal(t, 0, numPending)
+
+	// Old pending trades are cleaned up:
+	pendingTrade.HorizonID = "hrzid2"
+	pendingTrade.LedgerCloseTime = now.AddDate(0, 0, -10)
+	err = session.BulkInsertPendingTrades(ctx, []PendingTrade{pendingTrade})
+	require.NoError(t, err)
+	err = session.DeleteOldPendingTrades(ctx, now.AddDate(0, 0, -7))
+	require.NoError(t, err)
+	err = session.GetRaw(ctx, &numPending, "SELECT count(*) FROM pending_trades")
+	require.NoError(t, err)
+	assert.Equal(t, 0, numPending)
+}
@@ -0,0 +1,74 @@
+package tickerdb
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// priceStatsQuery returns a subquery computing the price stats of the trades
+// matching where (a WHERE clause on trades t and assets bAsset and cAsset) by
+// trade pair name and market ID (see marketIDSelector):
+//
+//   - vwap: the volume-weighted average price, i.e. the counter volume
+//     divided by the base volume;
+//   - twap: the time-weighted average price, where each price is weighted by
+//     the time until the next trade of its market (or until now), markets
+//     being grouped the same way as the stats, so that the trades of the
+//     markets aggregated by code follow each other;
+//   - median_price: the median trade price.
+//
+// Trades flagged as anomalous are left out, as well as the ones worth less
+// than the minimum trade size in USD (see withMinTradeSize), so that dust
+// trades don't move the prices. Trades whose USD value is unknown are kept.
+func priceStatsQuery(where string) string {
+	return strings.Replace(priceStatsQueryTemplate, "__PRICESTATSWHERE__", where, -1)
+}
+
+// withMinTradeSize sets the minimum value in USD of the trades included in the
+// price stats of q. Invalid sizes (e.g. negative ones) disable the filter.
+func withMinTradeSize(q string, minTradeSize float64) string {
+	if !(minTradeSize > 0) || math.IsInf(minTradeSize, 0) {
+		minTradeSize = 0
+	}
+	return strings.Replace(q, "__MINTRADESIZE__", strconv.FormatFloat(minTradeSize, 'f', -1, 64), -1)
+}
+
+// validTradesSince returns the WHERE clause matching the trades between valid
+// assets which closed within the given interval (e.g. "1 day"), and the
+// markets matching the filter of the query (see withMarketFilter).
+func validTradesSince(interval string) string {
+	return "WHERE bAsset.is_valid = TRUE AND cAsset.is_valid = TRUE AND t.ledger_close_time > now() - interval '" + interval + "'__MARKETFILTER__"
+}
+
+var priceStatsQueryTemplate = `
+	SELECT
+		pt.trade_pair_name,
+		pt.market_id,
+		sum(pt.counter_amount) / NULLIF(sum(pt.base_amount), 0) AS vwap,
+		COALESCE(sum(pt.price * pt.duration) / NULLIF(sum(pt.duration), 0), avg(pt.price)) AS twap,
+		percentile_cont(0.5) WITHIN GROUP (ORDER BY pt.price) AS median_price
+	FROM (
+		SELECT
+			` + tradePairNameField + ` AS trade_pair_name,
+			__MARKETID__ AS market_id,
+			t.base_amount,
+			t.counter_amount,
+			t.price,
+			EXTRACT(EPOCH FROM COALESCE(
+				lead(t.ledger_close_time) OVER (
+					PARTITION BY ` + tradePairNameField + `, __MARKETID__
+					ORDER BY t.ledger_close_time, t.id
+				),
+				now()
+			) - t.ledger_close_time) AS duration
+		FROM trades AS t
+			JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
+			JOIN assets AS cAsset on t.counter_asset_id = cAsset.id
+			LEFT JOIN asset_prices AS bp ON bp.asset_id = bAsset.id AND bp.currency = 'USD'
+		__PRICESTATSWHERE__
+			AND ` + cleanTradeFilter + `
+			AND (bp.price IS NULL OR t.base_amount * bp.price >= __MINTRADESIZE__)
+	) AS pt
+	GROUP BY pt.trade_pair_name, pt.market_id
+`
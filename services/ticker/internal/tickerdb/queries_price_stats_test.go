@@ -0,0 +1,204 @@
+package tickerdb
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMinTradeSize(t *testing.T) {
+	q := "t.base_amount * bp.price >= __MINTRADESIZE__"
+	assert.Equal(t, "t.base_amount * bp.price >= 0", withMinTradeSize(q, 0))
+	assert.Equal(t, "t.base_amount * bp.price >= 12.5", withMinTradeSize(q, 12.5))
+	assert.Equal(t, "t.base_amount * bp.price >= 0", withMinTradeSize(q, -1))
+	assert.Equal(t, "t.base_amount * bp.price >= 0", withMinTradeSize(q, math.NaN()))
+	assert.Equal(t, "t.base_amount * bp.price >= 0", withMinTradeSize(q, math.Inf(1)))
+}
+
+func TestRetrievePriceStats(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer and two valid assets:
+	issuerID, err := session.InsertOrUpdateIssuer(ctx, &Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}, []string{"public_key"})
+	require.NoError(t, err)
+
+	var assets []Asset
+	for _, code := range []string{"XLM", "BTC"} {
+		err = session.InsertOrUpdateAsset(ctx, &Asset{
+			Code:          code,
+			IssuerAccount: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+			IssuerID:      issuerID,
+			IsValid:       true,
+		}, []string{"code", "issuer_id"})
+		require.NoError(t, err)
+
+		var asset Asset
+		err = session.GetRaw(ctx, &asset, "SELECT * FROM assets ORDER BY id DESC LIMIT 1")
+		require.NoError(t, err)
+		assets = append(assets, asset)
+	}
+
+	now := time.Now()
+	err = session.InsertOrUpdateAssetPrice(ctx, &AssetPrice{
+		AssetID:   assets[0].ID,
+		Currency:  "USD",
+		Price:     0.1,
+		UpdatedAt: now,
+	}, []string{"asset_id", "currency"})
+	require.NoError(t, err)
+
+	// A $1 dust trade sits between two larger ones:
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     assets[0].ID,
+			BaseAmount:      "1000.0",
+			CounterAssetID:  assets[1].ID,
+			CounterAmount:   "100.0",
+			Price:           "0.1",
+			LedgerCloseTime: now.Add(-3 * time.Hour),
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     assets[0].ID,
+			BaseAmount:      "10.0",
+			CounterAssetID:  assets[1].ID,
+			CounterAmount:   "2.0",
+			Price:           "0.2",
+			LedgerCloseTime: now.Add(-2 * time.Hour),
+		},
+		{
+			HorizonID:       "hrzid3",
+			BaseAssetID:     assets[0].ID,
+			BaseAmount:      "500.0",
+			CounterAssetID:  assets[1].ID,
+			CounterAmount:   "75.0",
+			Price:           "0.15",
+			LedgerCloseTime: now.Add(-time.Hour),
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	// Without a minimum trade size, every trade is included. Each price is
+	// weighted by the hour until the next trade (or until now):
+	markets, err := session.RetrieveMarketData(ctx, MarketFilter{}, false, 0)
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+	assert.InDelta(t, 177.0/1510.0, markets[0].VWAP24h, 1e-9)
+	assert.InDelta(t, 0.15, markets[0].TWAP24h, 1e-3)
+	assert.InDelta(t, 0.15, markets[0].MedianPrice24h, 1e-9)
+	assert.InDelta(t, 177.0/1510.0, markets[0].VWAP7d, 1e-9)
+
+	// The dust trade is left out with a $10 minimum, so that the first price
+	// lasts until the third trade:
+	markets, err = session.RetrieveMarketData(ctx, MarketFilter{}, false, 10)
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+	assert.InDelta(t, 175.0/1500.0, markets[0].VWAP24h, 1e-9)
+	assert.InDelta(t, (0.1*2+0.15)/3, markets[0].TWAP24h, 1e-3)
+	assert.InDelta(t, 0.125, markets[0].MedianPrice24h, 1e-9)
+	assert.InDelta(t, 0.125, markets[0].MedianPrice7d, 1e-9)
+
+	mktWindows, err := session.RetrieveMarketWindows(ctx, []string{"150m"}, MarketFilter{}, false, 10)
+	require.NoError(t, err)
+	require.Len(t, mktWindows, 1)
+	assert.InDelta(t, 0.15, mktWindows[0].VWAP, 1e-9)
+	assert.InDelta(t, 0.15, mktWindows[0].TWAP, 1e-9)
+	assert.InDelta(t, 0.15, mktWindows[0].MedianPrice, 1e-9)
+
+	partialMkts, err := session.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, 10)
+	require.NoError(t, err)
+	require.Len(t, partialMkts, 1)
+	assert.InDelta(t, 175.0/1500.0, partialMkts[0].VWAP, 1e-9)
+	assert.InDelta(t, 0.125, partialMkts[0].MedianPrice, 1e-9)
+
+	pairName := "XLM_BTC"
+	partialAggMkts, err := session.RetrievePartialAggMarkets(ctx, &pairName, 24, true, 10)
+	require.NoError(t, err)
+	require.Len(t, partialAggMkts, 1)
+	assert.InDelta(t, 175.0/1500.0, partialAggMkts[0].VWAP, 1e-9)
+	assert.InDelta(t, 0.125, partialAggMkts[0].MedianPrice, 1e-9)
+
+	// Flagged trades are left out as well:
+	unchecked, err := session.RetrieveUncheckedTrades(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unchecked, 3)
+	err = session.UpdateTradeFlags(ctx, map[int64]TradeFlags{
+		unchecked[2].ID: {Outlier: true},
+	})
+	require.NoError(t, err)
+
+	partialMkts, err = session.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, 10)
+	require.NoError(t, err)
+	require.Len(t, partialMkts, 1)
+	assert.InDelta(t, 0.1, partialMkts[0].VWAP, 1e-9)
+	assert.InDelta(t, 0.1, partialMkts[0].TWAP, 1e-9)
+	assert.InDelta(t, 0.1, partialMkts[0].MedianPrice, 1e-9)
+
+	// A BTC issued by another account trades in a separate market, which is
+	// merged with the first one when the markets are aggregated by code:
+	otherIssuerID, err := session.InsertOrUpdateIssuer(ctx, &Issuer{
+		PublicKey: "GAEPJZ2BIAXCEXEF2BNTUCJGYGQHAK5B7XK6FPLZ6ECJQT7KQWAIQB5Y",
+		Name:      "BAZ",
+	}, []string{"public_key"})
+	require.NoError(t, err)
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "BTC",
+		IssuerAccount: "GAEPJZ2BIAXCEXEF2BNTUCJGYGQHAK5B7XK6FPLZ6ECJQT7KQWAIQB5Y",
+		IssuerID:      otherIssuerID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var otherBTC Asset
+	err = session.GetRaw(ctx, &otherBTC, "SELECT * FROM assets ORDER BY id DESC LIMIT 1")
+	require.NoError(t, err)
+
+	err = session.BulkInsertTrades(ctx, []Trade{
+		{
+			HorizonID:       "hrzid4",
+			BaseAssetID:     assets[0].ID,
+			BaseAmount:      "1000.0",
+			CounterAssetID:  otherBTC.ID,
+			CounterAmount:   "300.0",
+			Price:           "0.3",
+			LedgerCloseTime: now.Add(-90 * time.Minute),
+		},
+	})
+	require.NoError(t, err)
+
+	partialMkts, err = session.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, 10)
+	require.NoError(t, err)
+	require.Len(t, partialMkts, 2)
+
+	// Once aggregated, the first price only lasts until the trade of the
+	// other market:
+	partialAggMkts, err = session.RetrievePartialAggMarkets(ctx, &pairName, 24, true, 10)
+	require.NoError(t, err)
+	require.Len(t, partialAggMkts, 1)
+	assert.InDelta(t, 0.2, partialAggMkts[0].TWAP, 1e-3)
+	assert.InDelta(t, 0.2, partialAggMkts[0].MedianPrice, 1e-9)
+}
@@ -0,0 +1,52 @@
+package tickerdb
+
+import (
+	"context"
+
+	"github.com/stellar/go/support/db"
+)
+
+// GetTOMLCacheEntry retrieves the cached stellar.toml file of the given URL.
+func (s *TickerSession) GetTOMLCacheEntry(ctx context.Context, url string) (entry TOMLCacheEntry, err error) {
+	err = s.GetRaw(ctx, &entry, "SELECT * FROM toml_cache WHERE url = ?", url)
+	return
+}
+
+// SaveTOMLCacheEntry inserts or updates the cached stellar.toml file of
+// entry.URL. If changed is set, the body of the entry is also added to the
+// history of the URL, within the same database transaction.
+func (s *TickerSession) SaveTOMLCacheEntry(ctx context.Context, entry TOMLCacheEntry, changed bool) (err error) {
+	txSession := TickerSession{db.Session{DB: s.DB}}
+	if err = txSession.Begin(ctx); err != nil {
+		return
+	}
+
+	err = txSession.performUpsertQuery(ctx, entry, "toml_cache", "toml_cache_url_key", nil)
+	if err != nil {
+		txSession.Rollback()
+		return
+	}
+
+	if changed {
+		_, err = txSession.ExecRaw(ctx,
+			"INSERT INTO toml_history (url, body, etag, fetched_at) VALUES (?, ?, ?, ?)",
+			entry.URL, entry.Body, entry.ETag, entry.FetchedAt,
+		)
+		if err != nil {
+			txSession.Rollback()
+			return
+		}
+	}
+
+	return txSession.Commit()
+}
+
+// RetrieveTOMLHistory retrieves the last versions of the stellar.toml file of
+// the given URL, newest first.
+func (s *TickerSession) RetrieveTOMLHistory(ctx context.Context, url string, limit int) (versions []TOMLVersion, err error) {
+	err = s.SelectRaw(ctx, &versions,
+		"SELECT * FROM toml_history WHERE url = ? ORDER BY fetched_at DESC, id DESC LIMIT ?",
+		url, limit,
+	)
+	return
+}
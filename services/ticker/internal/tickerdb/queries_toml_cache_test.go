@@ -0,0 +1,86 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOMLCache(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	url := "https://example.com/.well-known/stellar.toml"
+	_, err = session.GetTOMLCacheEntry(ctx, url)
+	assert.True(t, session.NoRows(err))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	entry := TOMLCacheEntry{
+		URL:        url,
+		Body:       `SIGNING_KEY = "A"`,
+		ETag:       `"v1"`,
+		StatusCode: 200,
+		FetchedAt:  now,
+		CheckedAt:  now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+	require.NoError(t, session.SaveTOMLCacheEntry(ctx, entry, true))
+
+	// revalidating the file (e.g. after a 304 response) doesn't add a version:
+	entry.StatusCode = 304
+	entry.CheckedAt = now.Add(time.Hour)
+	entry.ExpiresAt = now.Add(2 * time.Hour)
+	require.NoError(t, session.SaveTOMLCacheEntry(ctx, entry, false))
+
+	cached, err := session.GetTOMLCacheEntry(ctx, url)
+	require.NoError(t, err)
+	assert.Equal(t, `SIGNING_KEY = "A"`, cached.Body)
+	assert.Equal(t, `"v1"`, cached.ETag)
+	assert.Equal(t, int32(304), cached.StatusCode)
+	assert.True(t, now.Add(2*time.Hour).Equal(cached.ExpiresAt))
+
+	entry.Body = `SIGNING_KEY = "B"`
+	entry.ETag = `"v2"`
+	entry.StatusCode = 200
+	entry.FetchedAt = now.Add(3 * time.Hour)
+	require.NoError(t, session.SaveTOMLCacheEntry(ctx, entry, true))
+
+	entry.LastError = "http request failed"
+	entry.CheckedAt = now.Add(4 * time.Hour)
+	require.NoError(t, session.SaveTOMLCacheEntry(ctx, entry, false))
+
+	cached, err = session.GetTOMLCacheEntry(ctx, url)
+	require.NoError(t, err)
+	assert.Equal(t, `SIGNING_KEY = "B"`, cached.Body)
+	assert.Equal(t, "http request failed", cached.LastError)
+
+	versions, err := session.RetrieveTOMLHistory(ctx, url, 10)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, `SIGNING_KEY = "B"`, versions[0].Body)
+	assert.Equal(t, `"v2"`, versions[0].ETag)
+	assert.Equal(t, `SIGNING_KEY = "A"`, versions[1].Body)
+	assert.True(t, now.Equal(versions[1].FetchedAt))
+
+	versions, err = session.RetrieveTOMLHistory(ctx, url, 1)
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+}
@@ -2,6 +2,7 @@ package tickerdb
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"strings"
 	"time"
@@ -9,8 +10,13 @@ import (
 
 // BulkInsertTrades inserts a slice of trades in the database. Trades
 // that are already in the database (i.e. horizon_id already exists)
-// are ignored.
+// are ignored. The candles of every resolution are updated with the
+// newly inserted trades within the same statement.
 func (s *TickerSession) BulkInsertTrades(ctx context.Context, trades []Trade) (err error) {
+	if len(trades) == 0 {
+		return
+	}
+
 	if len(trades) <= 50 {
 		return performInsertTrades(ctx, s, trades)
 	}
@@ -32,6 +38,72 @@ func (s *TickerSession) GetLastTrade(ctx context.Context) (trade Trade, err erro
 	return
 }
 
+// RetrieveTrades returns the most recent trades (at most limit) between the
+// given base and counter assets that closed within [since, until), newest
+// first.
+func (s *TickerSession) RetrieveTrades(
+	ctx context.Context,
+	baseAssetID int32,
+	counterAssetID int32,
+	since time.Time,
+	until time.Time,
+	limit int,
+) (trades []Trade, err error) {
+	err = s.SelectRaw(ctx, &trades, `
+		SELECT * FROM trades
+		WHERE base_asset_id = ? AND counter_asset_id = ?
+			AND ledger_close_time >= ? AND ledger_close_time < ?
+		ORDER BY ledger_close_time DESC, id DESC
+		LIMIT ?`,
+		baseAssetID, counterAssetID, since, until, limit,
+	)
+	return
+}
+
+// RetrieveTradesPage returns a page of at most limit trades between valid
+// assets that closed within [since, until), newest first, starting after the
+// given cursor (if any). Trades are optionally filtered by the codes and
+// issuers of their base and counter assets.
+func (s *TickerSession) RetrieveTradesPage(ctx context.Context,
+	baseAssetCode *string,
+	baseAssetIssuer *string,
+	counterAssetCode *string,
+	counterAssetIssuer *string,
+	since time.Time,
+	until time.Time,
+	after *TradeCursor,
+	limit int,
+) (trades []TradeWithAssets, err error) {
+	sqlTrue := new(string)
+	*sqlTrue = "TRUE"
+
+	where, args := generateWhereClause([]optionalVar{
+		{"bAsset.is_valid", sqlTrue},
+		{"cAsset.is_valid", sqlTrue},
+		{"bAsset.code", baseAssetCode},
+		{"bAsset.issuer_account", baseAssetIssuer},
+		{"cAsset.code", counterAssetCode},
+		{"cAsset.issuer_account", counterAssetIssuer},
+	})
+
+	argsInterface := make([]interface{}, len(args))
+	for i, v := range args {
+		argsInterface[i] = v
+	}
+
+	where += " AND t.ledger_close_time >= ? AND t.ledger_close_time < ?"
+	argsInterface = append(argsInterface, since, until)
+	if after != nil {
+		where += " AND (t.ledger_close_time, t.id) < (?, ?)"
+		argsInterface = append(argsInterface, after.LedgerCloseTime, after.ID)
+	}
+	argsInterface = append(argsInterface, limit)
+
+	q := strings.Replace(tradesPageQuery, "__WHERECLAUSE__", where, -1)
+	err = s.SelectRaw(ctx, &trades, q, argsInterface...)
+	return
+}
+
 // DeleteOldTrades deletes trades in the database older than minDate.
 func (s *TickerSession) DeleteOldTrades(ctx context.Context, minDate time.Time) error {
 	_, err := s.ExecRaw(ctx, "DELETE FROM trades WHERE ledger_close_time < ?", minDate)
@@ -71,7 +143,13 @@ func performInsertTrades(ctx context.Context, s *TickerSession, trades []Trade)
 	dbFieldsString := strings.Join(dbFields, ", ")
 
 	for i, trade := range trades {
-		v := getDBFieldValues(trade, true)
+		// amounts and prices are NUMERIC, which has no zero value: inserting
+		// an empty one as 0 would drag the stats of its market down.
+		if trade.BaseAmount == "" || trade.CounterAmount == "" || trade.Price == "" {
+			return fmt.Errorf("trade %s has an empty amount or price", trade.HorizonID)
+		}
+
+		v := getDBFieldValues(withDefaultType(trade), true)
 		placeholders += "(" + generatePlaceholders(v) + ")"
 		dbValues = append(dbValues, v...)
 
@@ -80,10 +158,36 @@ func performInsertTrades(ctx context.Context, s *TickerSession, trades []Trade)
 		}
 	}
 
-	qs := "INSERT INTO trades (" + dbFieldsString + ")"
+	qs := "WITH inserted_trades AS ("
+	qs += "INSERT INTO trades (" + dbFieldsString + ")"
 	qs += " VALUES " + placeholders
-	qs += " ON CONFLICT ON CONSTRAINT trades_horizon_id_key DO NOTHING;"
+	qs += " ON CONFLICT ON CONSTRAINT trades_horizon_id_key DO NOTHING"
+	qs += " RETURNING *)"
+	qs += createCandleUpsertFragment("inserted_trades") + ";"
 
 	_, err = s.ExecRaw(ctx, qs, dbValues...)
 	return
 }
+
+// withDefaultType returns trade as an orderbook trade if it has no type.
+func withDefaultType(trade Trade) Trade {
+	if trade.TradeType == "" {
+		trade.TradeType = TradeTypeOrderbook
+	}
+	return trade
+}
+
+var tradesPageQuery = `
+SELECT
+	t.*,
+	bAsset.code AS base_asset_code,
+	bAsset.issuer_account AS base_asset_issuer,
+	cAsset.code AS counter_asset_code,
+	cAsset.issuer_account AS counter_asset_issuer
+FROM trades AS t
+	JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
+	JOIN assets AS cAsset ON t.counter_asset_id = cAsset.id
+__WHERECLAUSE__
+ORDER BY t.ledger_close_time DESC, t.id DESC
+LIMIT ?
+`
@@ -0,0 +1,88 @@
+package tickerdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go/support/db"
+)
+
+// TradeFlags are the anomalies detected on a trade.
+type TradeFlags struct {
+	SelfTrade bool
+	WashTrade bool
+	Outlier   bool
+}
+
+// Any returns whether any anomaly was detected, i.e. whether the trade is
+// excluded from the clean volumes.
+func (f TradeFlags) Any() bool {
+	return f.SelfTrade || f.WashTrade || f.Outlier
+}
+
+// flaggedTradeFilter is the SQL condition matching the trades (aliased as t)
+// flagged as anomalous, and cleanTradeFilter the one matching the others.
+// Trades which weren't checked yet are considered clean.
+const (
+	flaggedTradeFilter = "(t.is_self_trade OR t.is_wash_trade OR t.is_outlier)"
+	cleanTradeFilter   = "NOT " + flaggedTradeFilter
+)
+
+// RetrieveUncheckedTrades returns the oldest trades (at most limit) which
+// weren't checked for anomalies yet.
+func (s *TickerSession) RetrieveUncheckedTrades(ctx context.Context, limit int) (trades []Trade, err error) {
+	err = s.SelectRaw(ctx, &trades, `
+		SELECT * FROM trades
+		WHERE NOT flags_checked
+		ORDER BY ledger_close_time ASC, id ASC
+		LIMIT ?`,
+		limit,
+	)
+	return
+}
+
+// RetrieveCheckedTrades returns the most recent trades (at most limit)
+// between the given base and counter assets that were already checked for
+// anomalies and closed within [since, until), newest first.
+func (s *TickerSession) RetrieveCheckedTrades(
+	ctx context.Context,
+	baseAssetID int32,
+	counterAssetID int32,
+	since time.Time,
+	until time.Time,
+	limit int,
+) (trades []Trade, err error) {
+	err = s.SelectRaw(ctx, &trades, `
+		SELECT * FROM trades
+		WHERE base_asset_id = ? AND counter_asset_id = ? AND flags_checked
+			AND ledger_close_time >= ? AND ledger_close_time < ?
+		ORDER BY ledger_close_time DESC, id DESC
+		LIMIT ?`,
+		baseAssetID, counterAssetID, since, until, limit,
+	)
+	return
+}
+
+// UpdateTradeFlags sets the anomaly flags of the given trades (keyed by trade
+// ID) and marks them as checked, within the same database transaction.
+func (s *TickerSession) UpdateTradeFlags(ctx context.Context, flags map[int64]TradeFlags) (err error) {
+	txSession := TickerSession{db.Session{DB: s.DB}}
+	if err = txSession.Begin(ctx); err != nil {
+		return
+	}
+
+	for id, f := range flags {
+		_, err = txSession.ExecRaw(ctx, `
+			UPDATE trades SET
+				is_self_trade = ?, is_wash_trade = ?, is_outlier = ?, flags_checked = TRUE
+			WHERE id = ?`,
+			f.SelfTrade, f.WashTrade, f.Outlier, id,
+		)
+		if err != nil {
+			txSession.Rollback()
+			return
+		}
+	}
+
+	return txSession.Commit()
+}
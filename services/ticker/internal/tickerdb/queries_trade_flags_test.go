@@ -0,0 +1,130 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTradeFlags(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer and two valid assets:
+	issuerID, err := session.InsertOrUpdateIssuer(ctx, &Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}, []string{"public_key"})
+	require.NoError(t, err)
+
+	var assets []Asset
+	for _, code := range []string{"XLM", "BTC"} {
+		err = session.InsertOrUpdateAsset(ctx, &Asset{
+			Code:          code,
+			IssuerAccount: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+			IssuerID:      issuerID,
+			IsValid:       true,
+		}, []string{"code", "issuer_id"})
+		require.NoError(t, err)
+
+		var asset Asset
+		err = session.GetRaw(ctx, &asset, "SELECT * FROM assets ORDER BY id DESC LIMIT 1")
+		require.NoError(t, err)
+		assets = append(assets, asset)
+	}
+
+	now := time.Now()
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     assets[0].ID,
+			BaseAccount:     "GALICE",
+			BaseAmount:      "100.0",
+			CounterAssetID:  assets[1].ID,
+			CounterAccount:  "GALICE",
+			CounterAmount:   "10.0",
+			Price:           "0.1",
+			LedgerCloseTime: now.Add(-2 * time.Hour),
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     assets[0].ID,
+			BaseAccount:     "GALICE",
+			BaseAmount:      "50.0",
+			CounterAssetID:  assets[1].ID,
+			CounterAccount:  "GBOB",
+			CounterAmount:   "5.0",
+			Price:           "0.1",
+			LedgerCloseTime: now.Add(-time.Hour),
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	// New trades are unchecked, and counted in the clean volumes:
+	unchecked, err := session.RetrieveUncheckedTrades(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unchecked, 2)
+	assert.Equal(t, "hrzid1", unchecked[0].HorizonID)
+	assert.Equal(t, "hrzid2", unchecked[1].HorizonID)
+	assert.False(t, unchecked[0].FlagsChecked)
+
+	mkts, err := session.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, 0)
+	require.NoError(t, err)
+	require.Len(t, mkts, 1)
+	assert.Equal(t, 150.0, mkts[0].CleanBaseVolume)
+	assert.Equal(t, int32(0), mkts[0].FlaggedTradeCount)
+
+	err = session.UpdateTradeFlags(ctx, map[int64]TradeFlags{
+		unchecked[0].ID: {SelfTrade: true},
+		unchecked[1].ID: {},
+	})
+	require.NoError(t, err)
+
+	unchecked, err = session.RetrieveUncheckedTrades(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, unchecked)
+
+	checked, err := session.RetrieveCheckedTrades(ctx, assets[0].ID, assets[1].ID, now.Add(-24*time.Hour), now, 10)
+	require.NoError(t, err)
+	require.Len(t, checked, 2)
+	assert.Equal(t, "hrzid2", checked[0].HorizonID)
+	assert.False(t, checked[0].IsSelfTrade)
+	assert.True(t, checked[1].IsSelfTrade)
+	assert.True(t, checked[1].FlagsChecked)
+
+	// Flagged trades are excluded from the clean volumes only:
+	mkts, err = session.RetrievePartialMarkets(ctx, nil, nil, nil, nil, 24, 0)
+	require.NoError(t, err)
+	require.Len(t, mkts, 1)
+	assert.Equal(t, 150.0, mkts[0].BaseVolume)
+	assert.Equal(t, 50.0, mkts[0].CleanBaseVolume)
+	assert.Equal(t, 5.0, mkts[0].CleanCounterVolume)
+	assert.Equal(t, int32(1), mkts[0].FlaggedTradeCount)
+
+	markets, err := session.RetrieveMarketData(ctx, MarketFilter{}, false, 0)
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+	assert.Equal(t, 150.0, markets[0].BaseVolume24h)
+	assert.Equal(t, 50.0, markets[0].CleanBaseVolume24h)
+	assert.Equal(t, 50.0, markets[0].CleanBaseVolume7d)
+	assert.Equal(t, int64(1), markets[0].FlaggedTradeCount24h)
+}
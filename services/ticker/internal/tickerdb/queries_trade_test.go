@@ -84,12 +84,18 @@ func TestBulkInsertTrades(t *testing.T) {
 			HorizonID:       "hrzid1",
 			BaseAssetID:     asset1.ID,
 			CounterAssetID:  asset2.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: time.Now(),
 		},
 		{
 			HorizonID:       "hrzid2",
 			BaseAssetID:     asset2.ID,
 			CounterAssetID:  asset1.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: time.Now(),
 		},
 	}
@@ -118,6 +124,18 @@ func TestBulkInsertTrades(t *testing.T) {
 	assert.Equal(t, 2, rowsCount2)
 }
 
+func TestBulkInsertTradesWithEmptyAmounts(t *testing.T) {
+	// Trades without amounts or price are rejected before reaching the
+	// database, instead of being stored as zero:
+	var session TickerSession
+	err := session.BulkInsertTrades(context.Background(), []Trade{{
+		HorizonID:     "hrzid1",
+		BaseAmount:    "10.0",
+		CounterAmount: "5.0",
+	}})
+	assert.EqualError(t, err, "trade hrzid1 has an empty amount or price")
+}
+
 func TestGetLastTrade(t *testing.T) {
 	db := OpenTestDBConnection(t)
 	defer db.Close()
@@ -197,18 +215,27 @@ func TestGetLastTrade(t *testing.T) {
 			HorizonID:       "hrzid2",
 			BaseAssetID:     asset2.ID,
 			CounterAssetID:  asset1.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: oneYearBefore,
 		},
 		{
 			HorizonID:       "hrzid1",
 			BaseAssetID:     asset1.ID,
 			CounterAssetID:  asset2.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: now,
 		},
 		{
 			HorizonID:       "hrzid2",
 			BaseAssetID:     asset2.ID,
 			CounterAssetID:  asset1.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: oneYearBefore,
 		},
 	}
@@ -222,6 +249,138 @@ func TestGetLastTrade(t *testing.T) {
 	assert.WithinDuration(t, now.Local(), lastTrade.LedgerCloseTime.Local(), 10*time.Millisecond)
 }
 
+func TestRetrieveTrades(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding a seed asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:     "XLM",
+		IssuerID: issuer.ID,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var asset1 Asset
+	err = session.GetRaw(ctx, &asset1, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding another asset to be used later:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:     "BTC",
+		IssuerID: issuer.ID,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	var asset2 Asset
+	err = session.GetRaw(ctx, &asset2, `
+		SELECT *
+		FROM assets
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	now := time.Now()
+	oneHourAgo := now.Add(-1 * time.Hour)
+	twoHoursAgo := now.Add(-2 * time.Hour)
+	oneDayAgo := now.Add(-24 * time.Hour)
+
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     asset1.ID,
+			CounterAssetID:  asset2.ID,
+			BaseAmount:      "10",
+			CounterAmount:   "1",
+			Price:           "10",
+			LedgerCloseTime: twoHoursAgo,
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     asset1.ID,
+			CounterAssetID:  asset2.ID,
+			BaseAmount:      "20",
+			CounterAmount:   "2",
+			Price:           "10",
+			LedgerCloseTime: oneHourAgo,
+		},
+		{
+			HorizonID:       "hrzid3",
+			BaseAssetID:     asset1.ID,
+			CounterAssetID:  asset2.ID,
+			BaseAmount:      "30",
+			CounterAmount:   "3",
+			Price:           "10",
+			LedgerCloseTime: oneDayAgo,
+		},
+		{
+			// Trade of the inverse market, which must be ignored:
+			HorizonID:       "hrzid4",
+			BaseAssetID:     asset2.ID,
+			CounterAssetID:  asset1.ID,
+			BaseAmount:      "1",
+			CounterAmount:   "10",
+			Price:           "0.1",
+			LedgerCloseTime: oneHourAgo,
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	// Trades are returned newest first:
+	dbTrades, err := session.RetrieveTrades(ctx, asset1.ID, asset2.ID, oneDayAgo.Add(time.Minute), now, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dbTrades))
+	assert.Equal(t, "hrzid2", dbTrades[0].HorizonID)
+	assert.Equal(t, "hrzid1", dbTrades[1].HorizonID)
+
+	// The limit applies to the most recent trades:
+	dbTrades, err = session.RetrieveTrades(ctx, asset1.ID, asset2.ID, oneDayAgo.Add(-time.Minute), now, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dbTrades))
+	assert.Equal(t, "hrzid2", dbTrades[0].HorizonID)
+	assert.Equal(t, "hrzid1", dbTrades[1].HorizonID)
+
+	// The upper bound is exclusive:
+	dbTrades, err = session.RetrieveTrades(ctx, asset1.ID, asset2.ID, oneDayAgo.Add(-time.Minute), oneHourAgo, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dbTrades))
+	assert.Equal(t, "hrzid1", dbTrades[0].HorizonID)
+	assert.Equal(t, "hrzid3", dbTrades[1].HorizonID)
+}
+
 func TestDeleteOldTrades(t *testing.T) {
 	db := OpenTestDBConnection(t)
 	defer db.Close()
@@ -300,24 +459,36 @@ func TestDeleteOldTrades(t *testing.T) {
 			HorizonID:       "hrzid1",
 			BaseAssetID:     asset1.ID,
 			CounterAssetID:  asset2.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: now,
 		},
 		{
 			HorizonID:       "hrzid2",
 			BaseAssetID:     asset2.ID,
 			CounterAssetID:  asset1.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: oneDayAgo,
 		},
 		{
 			HorizonID:       "hrzid3",
 			BaseAssetID:     asset2.ID,
 			CounterAssetID:  asset1.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: oneMonthAgo,
 		},
 		{
 			HorizonID:       "hrzid4",
 			BaseAssetID:     asset2.ID,
 			CounterAssetID:  asset1.ID,
+			BaseAmount:      "10.0",
+			CounterAmount:   "5.0",
+			Price:           "0.5",
 			LedgerCloseTime: oneYearAgo,
 		},
 	}
@@ -350,3 +521,136 @@ func TestDeleteOldTrades(t *testing.T) {
 	assert.WithinDuration(t, now.Local(), trade1.LedgerCloseTime.Local(), 10*time.Millisecond)
 	assert.WithinDuration(t, oneDayAgo.Local(), trade2.LedgerCloseTime.Local(), 10*time.Millisecond)
 }
+
+func TestRetrieveTradesPage(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB",
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding seed assets to be used later:
+	var assets []Asset
+	for _, code := range []string{"XLM", "BTC", "ETH"} {
+		issuerAccount := issuer.PublicKey
+		if code == "XLM" {
+			issuerAccount = "native"
+		}
+		err = session.InsertOrUpdateAsset(ctx, &Asset{
+			Code:          code,
+			IssuerAccount: issuerAccount,
+			IssuerID:      issuer.ID,
+			IsValid:       true,
+		}, []string{"code", "issuer_id"})
+		require.NoError(t, err)
+		var a Asset
+		err = session.GetRaw(ctx, &a, `
+			SELECT *
+			FROM assets
+			ORDER BY id DESC
+			LIMIT 1`,
+		)
+		require.NoError(t, err)
+		assets = append(assets, a)
+	}
+	xlm, btc, eth := assets[0], assets[1], assets[2]
+
+	now := time.Now()
+	trades := []Trade{
+		{
+			HorizonID:       "hrzid1",
+			BaseAssetID:     xlm.ID,
+			CounterAssetID:  btc.ID,
+			BaseAmount:      "10",
+			CounterAmount:   "1",
+			Price:           "10",
+			LedgerCloseTime: now.Add(-3 * time.Hour),
+		},
+		{
+			HorizonID:       "hrzid2",
+			BaseAssetID:     xlm.ID,
+			CounterAssetID:  btc.ID,
+			BaseAmount:      "20",
+			CounterAmount:   "2",
+			Price:           "10",
+			LedgerCloseTime: now.Add(-2 * time.Hour),
+		},
+		{
+			// Trades closed at the same time are sorted by ID:
+			HorizonID:       "hrzid3",
+			BaseAssetID:     xlm.ID,
+			CounterAssetID:  btc.ID,
+			BaseAmount:      "30",
+			CounterAmount:   "3",
+			Price:           "10",
+			LedgerCloseTime: now.Add(-2 * time.Hour),
+		},
+		{
+			// Trade of another market, which must be filtered out:
+			HorizonID:       "hrzid4",
+			BaseAssetID:     xlm.ID,
+			CounterAssetID:  eth.ID,
+			BaseAmount:      "1",
+			CounterAmount:   "10",
+			Price:           "0.1",
+			LedgerCloseTime: now.Add(-time.Hour),
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	xlmCode, btcCode := "XLM", "BTC"
+	dbTrades, err := session.RetrieveTradesPage(ctx, &xlmCode, nil, &btcCode, nil, now.Add(-24*time.Hour), now, nil, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dbTrades))
+	assert.Equal(t, "hrzid3", dbTrades[0].HorizonID)
+	assert.Equal(t, "hrzid2", dbTrades[1].HorizonID)
+	assert.Equal(t, "XLM", dbTrades[0].BaseAssetCode)
+	assert.Equal(t, "native", dbTrades[0].BaseAssetIssuer)
+	assert.Equal(t, "BTC", dbTrades[0].CounterAssetCode)
+	assert.Equal(t, issuer.PublicKey, dbTrades[0].CounterAssetIssuer)
+
+	// The next page starts after the cursor of the last trade:
+	cursor := TradeCursor{LedgerCloseTime: dbTrades[1].LedgerCloseTime, ID: dbTrades[1].ID}
+	dbTrades, err = session.RetrieveTradesPage(ctx, &xlmCode, nil, &btcCode, &issuer.PublicKey, now.Add(-24*time.Hour), now, &cursor, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(dbTrades))
+	assert.Equal(t, "hrzid1", dbTrades[0].HorizonID)
+
+	// Trades are filtered by close time and by issuer:
+	dbTrades, err = session.RetrieveTradesPage(ctx, nil, nil, nil, nil, now.Add(-90*time.Minute), now, nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(dbTrades))
+	assert.Equal(t, "hrzid4", dbTrades[0].HorizonID)
+
+	other := "GOTHER"
+	dbTrades, err = session.RetrieveTradesPage(ctx, &xlmCode, nil, &btcCode, &other, now.Add(-24*time.Hour), now, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, dbTrades)
+}
@@ -131,37 +131,37 @@ func SetupTickerTestSession(t *testing.T, migrationsDir string) (session tickerd
 		{ // BTC_ETH  trade (ETH is from issuer 1)
 			HorizonID:       "hrzid1",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      100.0,
+			BaseAmount:      "100.0",
 			CounterAssetID:  ethAsset1.ID,
-			CounterAmount:   10.0,
-			Price:           0.1,
+			CounterAmount:   "10.0",
+			Price:           "0.1",
 			LedgerCloseTime: tenMinutesAgo,
 		},
 		{ // BTC_ETH trade (ETH is from issuer 2)
 			HorizonID:       "hrzid3",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      24.0,
+			BaseAmount:      "24.0",
 			CounterAssetID:  ethAsset2.ID,
-			CounterAmount:   26.0,
-			Price:           0.92,
+			CounterAmount:   "26.0",
+			Price:           "0.92",
 			LedgerCloseTime: now,
 		},
 		{ // BTC_ETH  trade (ETH is from issuer 1)
 			HorizonID:       "hrzid2",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      50.0,
+			BaseAmount:      "50.0",
 			CounterAssetID:  ethAsset1.ID,
-			CounterAmount:   50.0,
-			Price:           1.0,
+			CounterAmount:   "50.0",
+			Price:           "1.0",
 			LedgerCloseTime: oneHourAgo,
 		},
 		{ // BTC_ETH  trade (ETH is from issuer 1)
 			HorizonID:       "hrzid4",
 			BaseAssetID:     btcAsset.ID,
-			BaseAmount:      50.0,
+			BaseAmount:      "50.0",
 			CounterAssetID:  ethAsset1.ID,
-			CounterAmount:   6.0,
-			Price:           0.12,
+			CounterAmount:   "6.0",
+			Price:           "0.12",
 			LedgerCloseTime: threeDaysAgo,
 		},
 	}
@@ -306,19 +306,19 @@ func SetupTickerTestSession(t *testing.T, migrationsDir string) (session tickerd
 		{
 			HorizonID:       "hrzid5",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      10.0,
+			BaseAmount:      "10.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   10.0,
-			Price:           0.5, // close price & lowest price
+			CounterAmount:   "10.0",
+			Price:           "0.5", // close price & lowest price
 			LedgerCloseTime: tenMinutesAgo,
 		},
 		{
 			HorizonID:       "hrzid6",
 			BaseAssetID:     xlmAsset.ID,
-			BaseAmount:      10.0,
+			BaseAmount:      "10.0",
 			CounterAssetID:  btcAsset.ID,
-			CounterAmount:   10.0,
-			Price:           1.0, // open price & highest price
+			CounterAmount:   "10.0",
+			Price:           "1.0", // open price & highest price
 			LedgerCloseTime: now,
 		},
 	}
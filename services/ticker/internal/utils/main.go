@@ -2,10 +2,14 @@ package utils
 
 import (
 	"fmt"
+	"math/big"
 	"math/rand"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/stellar/go/services/ticker/internal/metrics"
 	hlog "github.com/stellar/go/support/log"
 )
 
@@ -81,6 +85,49 @@ func CalcSpread(bidMax float64, askMin float64) (spread float64, midPoint float6
 	return
 }
 
+// CalcPoolPrice calculates the price implied by the reserves of a liquidity
+// pool, in units of the base asset (like trade prices)
+func CalcPoolPrice(baseReserve float64, counterReserve float64) float64 {
+	if baseReserve == 0 || counterReserve == 0 {
+		return 0
+	}
+	return baseReserve / counterReserve
+}
+
+// RatString returns the decimal representation of r, rounded to the given
+// number of decimal places and without trailing zeros (e.g. "0.25" or "3").
+func RatString(r *big.Rat, decimals int) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	return strings.TrimSuffix(strings.TrimRight(r.FloatString(decimals), "0"), ".")
+}
+
+// ParseWindow parses a trailing time window such as "1h", "24h", "7d" or "30d".
+// On top of the units supported by time.ParseDuration, it accepts a "d" suffix
+// for whole days. Windows must be at least one second long.
+func ParseWindow(window string) (time.Duration, error) {
+	var d time.Duration
+	if strings.HasSuffix(window, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q", window)
+		}
+		d = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		d, err = time.ParseDuration(window)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q", window)
+		}
+	}
+
+	if d < time.Second {
+		return 0, fmt.Errorf("window %q must be at least 1s long", window)
+	}
+	return d, nil
+}
+
 // Retry retries running a function that returns an error numRetries times, multiplying
 // the sleep time by a factor of 2 each time it retries.
 func Retry(numRetries int, delay time.Duration, logger *hlog.Entry, f func() error) error {
@@ -90,10 +137,12 @@ func Retry(numRetries int, delay time.Duration, logger *hlog.Entry, f func() err
 			delay = delay + jitter/2
 
 			logger.Infof("Backing off for %.3f seconds before retrying", delay.Seconds())
+			metrics.HorizonRequestRetries.Inc()
 
 			time.Sleep(delay)
 			return Retry(numRetries, 2*delay, logger, f)
 		}
+		metrics.HorizonRequestFailures.Inc()
 		return err
 	}
 
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -16,3 +18,36 @@ func TestSliceDiff(t *testing.T) {
 	assert.NotContains(t, diff, "b")
 	assert.Equal(t, 1, len(diff))
 }
+
+func TestParseWindow(t *testing.T) {
+	d, err := ParseWindow("1h")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, d)
+
+	d, err = ParseWindow("90m")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	d, err = ParseWindow("30d")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	for _, w := range []string{"", "d", "1.5d", "-1d", "0h", "10ms", "1y"} {
+		_, err = ParseWindow(w)
+		assert.Error(t, err, w)
+	}
+}
+
+func TestCalcPoolPrice(t *testing.T) {
+	assert.Equal(t, 100.0, CalcPoolPrice(1000, 10))
+	assert.Equal(t, 0.0, CalcPoolPrice(0, 10))
+	assert.Equal(t, 0.0, CalcPoolPrice(1000, 0))
+}
+
+func TestRatString(t *testing.T) {
+	assert.Equal(t, "3", RatString(big.NewRat(6, 2), 7))
+	assert.Equal(t, "0.25", RatString(big.NewRat(1, 4), 7))
+	assert.Equal(t, "0.3333333", RatString(big.NewRat(1, 3), 7))
+	assert.Equal(t, "0.6666667", RatString(big.NewRat(2, 3), 7))
+	assert.Equal(t, "0", RatString(big.NewRat(1, 1000), 2))
+}